@@ -0,0 +1,227 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+type Column struct {
+	Name string
+	// GoType is the type of the field corresponding to the column.
+	GoType string
+}
+
+type ForeignKey struct {
+	ReferencedTable string
+	ReferencedKey   []string
+	ReferencingKey  []string
+}
+
+type Table struct {
+	Name        string
+	Columns     []Column
+	PrimaryKey  []string
+	UniqueKeys  [][]string
+	ForeignKeys []ForeignKey
+}
+
+type Config struct {
+	// Package is the name of the generated package.
+	Package string
+	// Imports are import paths of packages used in types of columns.
+	Imports []string
+	// Tags are keys of struct tags whose values are column names.
+	Tags []string
+	// KeyType is the type returned by the helper methods for keys.
+	KeyType string
+}
+
+type field struct {
+	Name   string
+	Column string
+	Type   string
+	Tag    string
+}
+
+type keyMethod struct {
+	Name    string
+	Doc     string
+	Columns []string
+	Fields  []string
+}
+
+type structData struct {
+	Name    string
+	Table   string
+	Fields  []field
+	Methods []keyMethod
+}
+
+type packageData struct {
+	Package    string
+	StdImports []string
+	Imports    []string
+	KeyType    string
+	Structs    []structData
+}
+
+var packageTemplate = template.Must(template.New("package").Parse(`// Code generated by gotaface. DO NOT EDIT.
+
+package {{.Package}}
+{{if or .StdImports .Imports}}
+import (
+{{- range .StdImports}}
+	"{{.}}"
+{{- end}}
+{{- if and .StdImports .Imports}}
+{{end}}
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+{{- $keyType := .KeyType}}
+{{- range .Structs}}
+{{- $struct := .Name}}
+// {{.Name}} represents a row of table {{.Table}}.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}{{if .Tag}} ` + "`{{.Tag}}`" + `{{end}}
+{{- end}}
+}
+
+// TableName returns the name of the table.
+func ({{.Name}}) TableName() string {
+	return {{printf "%q" .Table}}
+}
+{{range .Methods}}
+// {{.Name}} returns values of {{.Doc}}.
+func (row {{$struct}}) {{.Name}}() {{$keyType}} {
+	return {{$keyType}}{ {{- range $i, $f := .Fields}}{{if $i}}, {{end}}row.{{$f}}{{end -}} }
+}
+
+// {{.Name}}Columns returns names of columns returned by {{.Name}}.
+func ({{$struct}}) {{.Name}}Columns() []string {
+	return []string{ {{- range $i, $c := .Columns}}{{if $i}}, {{end}}{{printf "%q" $c}}{{end -}} }
+}
+{{end}}
+{{- end}}`))
+
+// Generate returns formatted source code of a Go package in which a struct type and helper methods are defined for each table.
+// The helper methods return values of the primary key, unique keys, and foreign keys.
+func Generate(config Config, tables []Table) ([]byte, error) {
+	imports := slices.Clone(config.Imports)
+	slices.Sort(imports)
+	// standard packages are grouped separately from the others in the same way as goimports
+	stdImports, otherImports := []string{}, []string{}
+	for _, path := range slices.Compact(imports) {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			otherImports = append(otherImports, path)
+		} else {
+			stdImports = append(stdImports, path)
+		}
+	}
+	data := packageData{
+		Package:    config.Package,
+		StdImports: stdImports,
+		Imports:    otherImports,
+		KeyType:    config.KeyType,
+	}
+
+	structNames := map[string]bool{}
+	for _, table := range tables {
+		data.Structs = append(data.Structs, newStructData(config, table, structNames))
+	}
+
+	var buf bytes.Buffer
+	if err := packageTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf(`fail to generate code: %w`, err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf(`fail to format generated code: %w`, err)
+	}
+	return src, nil
+}
+
+func newStructData(config Config, table Table, structNames map[string]bool) structData {
+	s := structData{Name: uniqueName(GoName(table.Name), structNames), Table: table.Name}
+
+	// fields and methods share the names of a struct, and TableName is reserved before the fields.
+	memberNames := map[string]bool{"TableName": true}
+	fieldOf := map[string]string{}
+	for _, column := range table.Columns {
+		name := uniqueName(GoName(column.Name), memberNames)
+		fieldOf[column.Name] = name
+		tags := lo.Map(config.Tags, func(tag string, _ int) string { return fmt.Sprintf(`%s:%q`, tag, column.Name) })
+		s.Fields = append(s.Fields, field{Name: name, Column: column.Name, Type: column.GoType, Tag: strings.Join(tags, " ")})
+	}
+	fields := func(columns []string) []string {
+		return lo.Map(columns, func(column string, _ int) string { return fieldOf[column] })
+	}
+
+	addMethod := func(name, doc string, columns []string) {
+		if len(columns) == 0 || lo.SomeBy(columns, func(column string) bool { return fieldOf[column] == "" }) {
+			return
+		}
+		// each key method is paired with the method named with the suffix Columns, and both names must be unused.
+		unique := name
+		for i := 2; memberNames[unique] || memberNames[unique+"Columns"]; i++ {
+			unique = fmt.Sprintf("%s%d", name, i)
+		}
+		name = unique
+		memberNames[name], memberNames[name+"Columns"] = true, true
+		s.Methods = append(s.Methods, keyMethod{Name: name, Doc: doc, Columns: columns, Fields: fields(columns)})
+	}
+	byColumns := func(columns []string) string {
+		return strings.Join(lo.Map(columns, func(column string, _ int) string { return GoName(column) }), "")
+	}
+
+	addMethod("PrimaryKey", "the primary key", table.PrimaryKey)
+	for _, key := range table.UniqueKeys {
+		addMethod("UniqueKeyBy"+byColumns(key), "the unique key ("+strings.Join(key, ", ")+")", key)
+	}
+	for _, key := range table.ForeignKeys {
+		doc := fmt.Sprintf("the foreign key (%s) referencing %s (%s)", strings.Join(key.ReferencingKey, ", "), key.ReferencedTable, strings.Join(key.ReferencedKey, ", "))
+		addMethod("ForeignKeyTo"+GoName(key.ReferencedTable)+"By"+byColumns(key.ReferencingKey), doc, key.ReferencingKey)
+	}
+
+	return s
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+var initialisms = []string{"API", "HTML", "HTTP", "ID", "IP", "JSON", "SQL", "URI", "URL", "UUID", "XML"}
+
+// GoName converts an identifier in a database into an exported Go identifier.
+// Words separated by non-alphanumeric characters are joined in camel case, and common initialisms are capitalized.
+func GoName(identifier string) string {
+	words := strings.FieldsFunc(identifier, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	name := ""
+	for _, word := range words {
+		if upper := strings.ToUpper(word); slices.Contains(initialisms, upper) {
+			name += upper
+			continue
+		}
+		runes := []rune(word)
+		name += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
@@ -0,0 +1,187 @@
+package codegen_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/codegen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoName(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want string
+	}{
+		{in: "user", want: "User"},
+		{in: "user_id", want: "UserID"},
+		{in: "UserName", want: "UserName"},
+		{in: "api-url", want: "APIURL"},
+		{in: "1st", want: "X1st"},
+		{in: "_", want: "X"},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			assert.Equal(t, testcase.want, codegen.GoName(testcase.in))
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	config := codegen.Config{
+		Package: "model",
+		Imports: []string{"time", "database/sql", "example.com/x", "time"},
+		Tags:    []string{"db", "json"},
+		KeyType: "[]any",
+	}
+	tables := []codegen.Table{
+		{
+			Name:       "parent",
+			Columns:    []codegen.Column{{Name: "id", GoType: "int64"}, {Name: "code", GoType: "sql.NullString"}},
+			PrimaryKey: []string{"id"},
+			UniqueKeys: [][]string{{"code"}},
+		},
+		{
+			Name: "child_item",
+			Columns: []codegen.Column{
+				{Name: "id", GoType: "int64"},
+				{Name: "parent_id", GoType: "int64"},
+				{Name: "created_at", GoType: "time.Time"},
+				{Name: "value", GoType: "x.Value"},
+			},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []codegen.ForeignKey{
+				{ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"parent_id"}},
+			},
+		},
+	}
+	want := "// Code generated by gotaface. DO NOT EDIT.\n" + `
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"example.com/x"
+)
+
+// Parent represents a row of table parent.
+type Parent struct {
+	ID   int64          ` + "`db:\"id\" json:\"id\"`" + `
+	Code sql.NullString ` + "`db:\"code\" json:\"code\"`" + `
+}
+
+// TableName returns the name of the table.
+func (Parent) TableName() string {
+	return "parent"
+}
+
+// PrimaryKey returns values of the primary key.
+func (row Parent) PrimaryKey() []any {
+	return []any{row.ID}
+}
+
+// PrimaryKeyColumns returns names of columns returned by PrimaryKey.
+func (Parent) PrimaryKeyColumns() []string {
+	return []string{"id"}
+}
+
+// UniqueKeyByCode returns values of the unique key (code).
+func (row Parent) UniqueKeyByCode() []any {
+	return []any{row.Code}
+}
+
+// UniqueKeyByCodeColumns returns names of columns returned by UniqueKeyByCode.
+func (Parent) UniqueKeyByCodeColumns() []string {
+	return []string{"code"}
+}
+
+// ChildItem represents a row of table child_item.
+type ChildItem struct {
+	ID        int64     ` + "`db:\"id\" json:\"id\"`" + `
+	ParentID  int64     ` + "`db:\"parent_id\" json:\"parent_id\"`" + `
+	CreatedAt time.Time ` + "`db:\"created_at\" json:\"created_at\"`" + `
+	Value     x.Value   ` + "`db:\"value\" json:\"value\"`" + `
+}
+
+// TableName returns the name of the table.
+func (ChildItem) TableName() string {
+	return "child_item"
+}
+
+// PrimaryKey returns values of the primary key.
+func (row ChildItem) PrimaryKey() []any {
+	return []any{row.ID}
+}
+
+// PrimaryKeyColumns returns names of columns returned by PrimaryKey.
+func (ChildItem) PrimaryKeyColumns() []string {
+	return []string{"id"}
+}
+
+// ForeignKeyToParentByParentID returns values of the foreign key (parent_id) referencing parent (id).
+func (row ChildItem) ForeignKeyToParentByParentID() []any {
+	return []any{row.ParentID}
+}
+
+// ForeignKeyToParentByParentIDColumns returns names of columns returned by ForeignKeyToParentByParentID.
+func (ChildItem) ForeignKeyToParentByParentIDColumns() []string {
+	return []string{"parent_id"}
+}
+`
+
+	got, err := codegen.Generate(config, tables)
+	assert.Nil(t, err)
+	assert.Equal(t, want, string(got))
+}
+
+func TestGenerate_UniqueNames(t *testing.T) {
+	tables := []codegen.Table{
+		{Name: "a_b", Columns: []codegen.Column{{Name: "x-y", GoType: "int"}, {Name: "x_y", GoType: "int"}}},
+		{Name: "a-b"},
+	}
+
+	got, err := codegen.Generate(codegen.Config{Package: "model", KeyType: "[]any"}, tables)
+	assert.Nil(t, err)
+	assert.Contains(t, string(got), "type AB struct {\n\tXY  int\n\tXY2 int\n}")
+	assert.Contains(t, string(got), "type AB2 struct {\n}")
+}
+
+func TestGenerate_MemberNames(t *testing.T) {
+	tables := []codegen.Table{
+		{
+			Name: "t",
+			Columns: []codegen.Column{
+				{Name: "table_name", GoType: "string"},
+				{Name: "primary_key", GoType: "int64"},
+				{Name: "primary_key_columns", GoType: "string"},
+				{Name: "unique_key_by_code", GoType: "string"},
+				{Name: "code", GoType: "string"},
+				{Name: "parent", GoType: "int64"},
+			},
+			PrimaryKey: []string{"primary_key"},
+			UniqueKeys: [][]string{{"code"}},
+			ForeignKeys: []codegen.ForeignKey{
+				{ReferencedTable: "t", ReferencedKey: []string{"primary_key"}, ReferencingKey: []string{"parent"}},
+			},
+		},
+	}
+
+	got, err := codegen.Generate(codegen.Config{Package: "model", KeyType: "[]any"}, tables)
+	assert.Nil(t, err)
+	assert.Contains(t, string(got), "\tTableName2 ")
+	assert.Contains(t, string(got), ") PrimaryKey2() []any {")
+	assert.Contains(t, string(got), ") UniqueKeyByCode2() []any {")
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "model.go", got, 0)
+	assert.Nil(t, err)
+	_, err = (&types.Config{Importer: importer.Default()}).Check("model", fset, []*ast.File{file}, nil)
+	assert.Nil(t, err)
+}
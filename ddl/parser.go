@@ -0,0 +1,168 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parser reads tokens of a statement from the beginning.
+type Parser struct {
+	src    string
+	tokens []Token
+	pos    int
+}
+
+func NewParser(src string, tokens []Token) *Parser {
+	return &Parser{src: src, tokens: tokens}
+}
+
+// EOF reports whether all tokens have been read.
+func (p *Parser) EOF() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// Peek returns the next token without reading it.
+func (p *Parser) Peek() (Token, bool) {
+	if p.EOF() {
+		return Token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// Next reads the next token.
+func (p *Parser) Next() (Token, error) {
+	token, ok := p.Peek()
+	if !ok {
+		return Token{}, fmt.Errorf(`unexpected end of statement`)
+	}
+	p.pos++
+	return token, nil
+}
+
+// PeekKeyword reports whether the next tokens are the given words, which are compared case-insensitively.
+func (p *Parser) PeekKeyword(keywords ...string) bool {
+	for i, keyword := range keywords {
+		if p.pos+i >= len(p.tokens) {
+			return false
+		}
+		token := p.tokens[p.pos+i]
+		if token.Kind != TokenKindWord || !strings.EqualFold(token.Value, keyword) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keyword reads the next tokens if they are the given words.
+func (p *Parser) Keyword(keywords ...string) bool {
+	if !p.PeekKeyword(keywords...) {
+		return false
+	}
+	p.pos += len(keywords)
+	return true
+}
+
+// ExpectKeyword reads the next tokens which must be the given words.
+func (p *Parser) ExpectKeyword(keywords ...string) error {
+	if !p.Keyword(keywords...) {
+		return p.unexpected(strings.Join(keywords, " "))
+	}
+	return nil
+}
+
+// PeekSymbol reports whether the next token is the given symbol.
+func (p *Parser) PeekSymbol(symbol string) bool {
+	token, ok := p.Peek()
+	return ok && token.Kind == TokenKindSymbol && token.Value == symbol
+}
+
+// Symbol reads the next token if it is the given symbol.
+func (p *Parser) Symbol(symbol string) bool {
+	if !p.PeekSymbol(symbol) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// ExpectSymbol reads the next token which must be the given symbol.
+func (p *Parser) ExpectSymbol(symbol string) error {
+	if !p.Symbol(symbol) {
+		return p.unexpected(symbol)
+	}
+	return nil
+}
+
+// Identifier reads the next token which must be a word or a quoted identifier.
+func (p *Parser) Identifier() (Token, error) {
+	token, ok := p.Peek()
+	if !ok || (token.Kind != TokenKindWord && token.Kind != TokenKindQuotedIdentifier) {
+		return Token{}, p.unexpected("identifier")
+	}
+	p.pos++
+	return token, nil
+}
+
+// Skip reads the next token, or a whole group if the next token opens parentheses.
+func (p *Parser) Skip() error {
+	depth := 0
+	for {
+		token, err := p.Next()
+		if err != nil {
+			return err
+		}
+		if token.Kind == TokenKindSymbol {
+			switch token.Value {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+		}
+		if depth <= 0 {
+			return nil
+		}
+	}
+}
+
+// SkipUntil skips tokens and groups in parentheses until the stop function returns true or a comma or a closing parenthesis appears.
+// The stop function can be nil.
+func (p *Parser) SkipUntil(stop func() bool) error {
+	for !p.EOF() && !p.PeekSymbol(",") && !p.PeekSymbol(")") && (stop == nil || !stop()) {
+		if err := p.Skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Text returns the source text from the beginning of the begin-th token to the end of the (end-1)-th token.
+func (p *Parser) Text(begin, end int) string {
+	if begin >= end {
+		return ""
+	}
+	return p.src[p.tokens[begin].Begin:p.tokens[end-1].End]
+}
+
+// Tokens returns tokens from the begin-th token to the (end-1)-th token.
+func (p *Parser) Tokens(begin, end int) []Token {
+	return p.tokens[begin:end]
+}
+
+// Pos returns the index of the next token.
+func (p *Parser) Pos() int {
+	return p.pos
+}
+
+func (p *Parser) unexpected(expected string) error {
+	token, ok := p.Peek()
+	if !ok {
+		return fmt.Errorf(`%s is expected but statement ends`, expected)
+	}
+	text := token.Value
+	if 0 <= token.Begin && token.Begin <= token.End && token.End <= len(p.src) {
+		// the source may not cover the tokens if the parser is created for a part of the tokens
+		text = p.src[token.Begin:token.End]
+	}
+	return fmt.Errorf(`%s is expected but %q appears at %d`, expected, text, token.Begin)
+}
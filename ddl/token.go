@@ -0,0 +1,162 @@
+package ddl
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type TokenKind int
+
+const (
+	TokenKindWord TokenKind = iota
+	TokenKindQuotedIdentifier
+	TokenKindString
+	TokenKindNumber
+	TokenKindSymbol
+)
+
+type Token struct {
+	Kind TokenKind
+	// Value is the text of the token, in which quoted identifiers and strings are unquoted.
+	Value string
+	// Begin and End are byte offsets of the token in the source.
+	Begin int
+	End   int
+}
+
+// Tokenize splits the source into tokens skipping whitespaces and comments.
+// Identifiers are quoted by double quotes or backquotes and strings are quoted by single quotes, in which a quote is escaped by doubling it.
+// Dollar-quoted strings such as $tag$...$tag$ are also recognized as strings.
+func Tokenize(src string) ([]Token, error) {
+	tokens := []Token{}
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(src[i:], "--"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src[i:])
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf(`comment is not closed at %d`, i)
+			}
+			i += 2 + end + 2
+		case r == '"' || r == '`' || r == '\'':
+			kind := TokenKindQuotedIdentifier
+			if r == '\'' {
+				kind = TokenKindString
+			}
+			quote := string(r)
+			value := strings.Builder{}
+			j := i + 1
+			for {
+				end := strings.Index(src[j:], quote)
+				if end < 0 {
+					return nil, fmt.Errorf(`quotation is not closed at %d`, i)
+				}
+				value.WriteString(src[j : j+end])
+				j += end + 1
+				if !strings.HasPrefix(src[j:], quote) {
+					break
+				}
+				value.WriteString(quote)
+				j++
+			}
+			tokens = append(tokens, Token{Kind: kind, Value: value.String(), Begin: i, End: j})
+			i = j
+		case r == '$' && dollarTag(src[i:]) != "":
+			tag := dollarTag(src[i:])
+			end := strings.Index(src[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf(`quotation is not closed at %d`, i)
+			}
+			j := i + len(tag) + end + len(tag)
+			tokens = append(tokens, Token{Kind: TokenKindString, Value: src[i+len(tag) : j-len(tag)], Begin: i, End: j})
+			i = j
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, Token{Kind: TokenKindNumber, Value: src[i:j], Begin: i, End: j})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for _, r := range src[i:] {
+				if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$') {
+					break
+				}
+				j += len(string(r))
+			}
+			tokens = append(tokens, Token{Kind: TokenKindWord, Value: src[i:j], Begin: i, End: j})
+			i = j
+		default:
+			tokens = append(tokens, Token{Kind: TokenKindSymbol, Value: src[i : i+size], Begin: i, End: i + size})
+			i += size
+		}
+	}
+
+	return tokens, nil
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+func dollarTag(src string) string {
+	for i := 1; i < len(src); i++ {
+		switch b := src[i]; {
+		case b == '$':
+			return src[:i+1]
+		case b == '_' || isDigit(b) || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z'):
+			if i == 1 && isDigit(b) {
+				return ""
+			}
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// Split splits tokens into statements separated by semicolons, in which empty statements are removed.
+func Split(tokens []Token) [][]Token {
+	stmts := [][]Token{}
+	stmt := []Token{}
+	for _, token := range tokens {
+		if token.Kind == TokenKindSymbol && token.Value == ";" {
+			if len(stmt) > 0 {
+				stmts = append(stmts, stmt)
+			}
+			stmt = []Token{}
+			continue
+		}
+		stmt = append(stmt, token)
+	}
+	if len(stmt) > 0 {
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+// Identifiers returns the distinct values of words and quoted identifiers in the order they appear, which may include keywords.
+func Identifiers(tokens []Token) []string {
+	identifiers := []string{}
+	for _, token := range tokens {
+		if token.Kind != TokenKindWord && token.Kind != TokenKindQuotedIdentifier {
+			continue
+		}
+		if !slices.Contains(identifiers, token.Value) {
+			identifiers = append(identifiers, token.Value)
+		}
+	}
+	return identifiers
+}
@@ -0,0 +1,84 @@
+package ddl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/ddl"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	type token struct {
+		kind  ddl.TokenKind
+		value string
+	}
+	testcases := []struct {
+		name string
+		src  string
+		want []token
+	}{
+		{
+			name: "words_and_symbols",
+			src:  `CREATE TABLE t1 (c VARCHAR(10));`,
+			want: []token{
+				{ddl.TokenKindWord, "CREATE"}, {ddl.TokenKindWord, "TABLE"}, {ddl.TokenKindWord, "t1"},
+				{ddl.TokenKindSymbol, "("}, {ddl.TokenKindWord, "c"}, {ddl.TokenKindWord, "VARCHAR"},
+				{ddl.TokenKindSymbol, "("}, {ddl.TokenKindNumber, "10"}, {ddl.TokenKindSymbol, ")"},
+				{ddl.TokenKindSymbol, ")"}, {ddl.TokenKindSymbol, ";"},
+			},
+		},
+		{
+			name: "comments",
+			src:  "-- line comment\na /* block\ncomment */ b -- end",
+			want: []token{{ddl.TokenKindWord, "a"}, {ddl.TokenKindWord, "b"}},
+		},
+		{
+			name: "quotes",
+			src:  "\"a\"\"b\" `c` 'it''s' $$x;y$$ $tag$z$tag$",
+			want: []token{
+				{ddl.TokenKindQuotedIdentifier, `a"b`}, {ddl.TokenKindQuotedIdentifier, "c"},
+				{ddl.TokenKindString, "it's"}, {ddl.TokenKindString, "x;y"}, {ddl.TokenKindString, "z"},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			got, err := ddl.Tokenize(testcase.src)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, lo.Map(got, func(it ddl.Token, _ int) token { return token{it.Kind, it.Value} }))
+			for _, token := range got {
+				assert.Less(t, token.Begin, token.End)
+			}
+		})
+	}
+}
+
+func TestTokenize_Error(t *testing.T) {
+	for number, src := range []string{`"a`, `'a`, `/* a`, `$$ a`} {
+		t.Run(fmt.Sprintf("%03d", number), func(t *testing.T) {
+			_, err := ddl.Tokenize(src)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tokens, err := ddl.Tokenize(`;a b; ;c;`)
+	assert.Nil(t, err)
+
+	got := lo.Map(ddl.Split(tokens), func(stmt []ddl.Token, _ int) []string {
+		return lo.Map(stmt, func(it ddl.Token, _ int) string { return it.Value })
+	})
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, got)
+}
+
+func TestIdentifiers(t *testing.T) {
+	tokens, err := ddl.Tokenize(`SELECT a.x, "b c" FROM a JOIN "b c" ON 'a' = 1`)
+	assert.Nil(t, err)
+
+	got := ddl.Identifiers(tokens)
+	assert.Equal(t, []string{"SELECT", "a", "x", "b c", "FROM", "JOIN", "ON"}, got)
+}
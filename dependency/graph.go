@@ -0,0 +1,211 @@
+package dependency
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+type EdgeKind string
+
+const (
+	EdgeKindForeignKey EdgeKind = "foreign_key"
+	EdgeKindInterleave EdgeKind = "interleave"
+)
+
+// Edge represents that rows in table From reference rows in table To.
+// Rows in To must be inserted before rows in From, and rows in From must be deleted before rows in To.
+type Edge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+	// Name is the name of the foreign key if available.
+	Name string `json:"name"`
+	// Index is the position of the foreign key in the referencing table.
+	Index int `json:"index"`
+	// Nullable is true if all the referencing columns are nullable, i.e., the reference can be nulled.
+	Nullable bool `json:"nullable"`
+}
+
+type Graph struct {
+	Tables []string `json:"tables"`
+	Edges  []Edge   `json:"edges"`
+}
+
+// Cycle is a strongly connected component of tables that reference each other.
+type Cycle struct {
+	// Tables are tables in the component in insert order.
+	Tables []string `json:"tables"`
+	// BreakEdges are foreign keys which must be deferred or nulled to insert rows in Tables in insert order.
+	BreakEdges []Edge `json:"break_edges"`
+}
+
+type Order struct {
+	InsertOrder []string `json:"insert_order"`
+	DeleteOrder []string `json:"delete_order"`
+	Cycles      []Cycle  `json:"cycles"`
+}
+
+// Resolve determines orders to insert and delete rows in tables of the graph.
+// Edges referencing tables not in the graph are ignored.
+// The result is deterministic for the order of graph.Tables.
+// It fails if tables are in a cycle which cannot be broken, i.e., a cycle consisting of interleave edges.
+func Resolve(graph Graph) (Order, error) {
+	index := map[string]int{}
+	for i, table := range graph.Tables {
+		if _, found := index[table]; found {
+			return Order{}, fmt.Errorf(`table %q is duplicated`, table)
+		}
+		index[table] = i
+	}
+	edges := lo.Filter(graph.Edges, func(edge Edge, _ int) bool {
+		_, fromFound := index[edge.From]
+		_, toFound := index[edge.To]
+		return fromFound && toFound
+	})
+
+	components := stronglyConnectedComponents(len(graph.Tables), lo.Map(edges, func(edge Edge, _ int) [2]int {
+		return [2]int{index[edge.From], index[edge.To]}
+	}))
+
+	order := Order{}
+	for _, component := range components {
+		members := lo.Map(component, func(i int, _ int) string { return graph.Tables[i] })
+		componentEdges := lo.Filter(edges, func(edge Edge, _ int) bool {
+			return slices.Contains(members, edge.From) && slices.Contains(members, edge.To)
+		})
+		if len(members) == 1 && len(componentEdges) == 0 {
+			order.InsertOrder = append(order.InsertOrder, members[0])
+			continue
+		}
+
+		cycle, err := breakCycle(members, componentEdges)
+		if err != nil {
+			return Order{}, fmt.Errorf(`fail to break cycle of %v: %w`, members, err)
+		}
+		order.InsertOrder = append(order.InsertOrder, cycle.Tables...)
+		order.Cycles = append(order.Cycles, cycle)
+	}
+	order.DeleteOrder = lo.Reverse(slices.Clone(order.InsertOrder))
+
+	return order, nil
+}
+
+// stronglyConnectedComponents returns strongly connected components in topological order, in which each component follows components it depends on.
+// Each arc [from, to] means from depends on to.
+func stronglyConnectedComponents(n int, arcs [][2]int) [][]int {
+	adjacency := make([][]int, n)
+	for _, arc := range arcs {
+		adjacency[arc[0]] = append(adjacency[arc[0]], arc[1])
+	}
+	for _, next := range adjacency {
+		slices.Sort(next)
+	}
+
+	// Tarjan's algorithm emits a component after all components reachable from it, i.e., the components it depends on.
+	counter := 0
+	indices := make([]int, n)
+	lowLinks := make([]int, n)
+	visited := make([]bool, n)
+	onStack := make([]bool, n)
+	stack := []int{}
+	components := [][]int{}
+	var visit func(v int)
+	visit = func(v int) {
+		visited[v] = true
+		indices[v], lowLinks[v] = counter, counter
+		counter++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range adjacency[v] {
+			if !visited[w] {
+				visit(w)
+				lowLinks[v] = min(lowLinks[v], lowLinks[w])
+			} else if onStack[w] {
+				lowLinks[v] = min(lowLinks[v], indices[w])
+			}
+		}
+		if lowLinks[v] == indices[v] {
+			component := []int{}
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			slices.Sort(component)
+			components = append(components, component)
+		}
+	}
+	for v := 0; v < n; v++ {
+		if !visited[v] {
+			visit(v)
+		}
+	}
+
+	return components
+}
+
+// breakCycle orders tables in a strongly connected component and determines foreign keys to be broken.
+// It greedily places a table whose references to unplaced tables are fewest, preferring nullable ones, and never breaks interleave edges.
+func breakCycle(tables []string, edges []Edge) (Cycle, error) {
+	placed := map[string]bool{}
+	cycle := Cycle{}
+	for len(cycle.Tables) < len(tables) {
+		type candidate struct {
+			table       string
+			unmet       int
+			notNullable int
+		}
+		candidates := []candidate{}
+		for _, table := range tables {
+			if placed[table] {
+				continue
+			}
+			c := candidate{table: table}
+			feasible := true
+			for _, edge := range edges {
+				if edge.From != table || placed[edge.To] {
+					continue
+				}
+				if edge.Kind == EdgeKindInterleave {
+					feasible = false
+					break
+				}
+				c.unmet++
+				if !edge.Nullable {
+					c.notNullable++
+				}
+			}
+			if feasible {
+				candidates = append(candidates, c)
+			}
+		}
+		if len(candidates) == 0 {
+			return Cycle{}, fmt.Errorf(`interleave edges are cyclic`)
+		}
+
+		best := lo.MinBy(candidates, func(a, b candidate) bool {
+			if a.unmet == 0 || b.unmet == 0 {
+				return a.unmet < b.unmet
+			}
+			if a.notNullable != b.notNullable {
+				return a.notNullable < b.notNullable
+			}
+			return a.unmet < b.unmet
+		})
+		for _, edge := range edges {
+			if edge.From == best.table && !placed[edge.To] {
+				cycle.BreakEdges = append(cycle.BreakEdges, edge)
+			}
+		}
+		placed[best.table] = true
+		cycle.Tables = append(cycle.Tables, best.table)
+	}
+
+	return cycle, nil
+}
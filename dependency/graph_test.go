@@ -0,0 +1,185 @@
+package dependency_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/dependency"
+	"github.com/stretchr/testify/assert"
+)
+
+func fk(from, to string, nullable bool) dependency.Edge {
+	return dependency.Edge{From: from, To: to, Kind: dependency.EdgeKindForeignKey, Nullable: nullable}
+}
+
+func interleave(from, to string) dependency.Edge {
+	return dependency.Edge{From: from, To: to, Kind: dependency.EdgeKindInterleave}
+}
+
+func TestResolve(t *testing.T) {
+	testcases := []struct {
+		name  string
+		graph dependency.Graph
+		want  dependency.Order
+	}{
+		{
+			name: "no_edges",
+			graph: dependency.Graph{
+				Tables: []string{"A", "B"},
+			},
+			want: dependency.Order{
+				InsertOrder: []string{"A", "B"},
+				DeleteOrder: []string{"B", "A"},
+			},
+		},
+		{
+			name: "interleave",
+			graph: dependency.Graph{
+				Tables: []string{"B_4", "B_3", "B_2", "B_1"},
+				Edges: []dependency.Edge{
+					interleave("B_2", "B_1"),
+					interleave("B_3", "B_2"),
+					interleave("B_4", "B_2"),
+				},
+			},
+			want: dependency.Order{
+				InsertOrder: []string{"B_1", "B_2", "B_4", "B_3"},
+				DeleteOrder: []string{"B_3", "B_4", "B_2", "B_1"},
+			},
+		},
+		{
+			name: "foreign_keys",
+			graph: dependency.Graph{
+				Tables: []string{"C_5", "C_4", "C_3", "C_2", "C_1"},
+				Edges: []dependency.Edge{
+					fk("C_2", "C_1", false),
+					fk("C_3", "C_2", false),
+					fk("C_4", "C_2", false),
+					fk("C_5", "C_3", false),
+					fk("C_5", "C_4", false),
+				},
+			},
+			want: dependency.Order{
+				InsertOrder: []string{"C_1", "C_2", "C_4", "C_3", "C_5"},
+				DeleteOrder: []string{"C_5", "C_3", "C_4", "C_2", "C_1"},
+			},
+		},
+		{
+			name: "foreign_loop_1",
+			graph: dependency.Graph{
+				Tables: []string{"D_1"},
+				Edges:  []dependency.Edge{fk("D_1", "D_1", false)},
+			},
+			want: dependency.Order{
+				InsertOrder: []string{"D_1"},
+				DeleteOrder: []string{"D_1"},
+				Cycles: []dependency.Cycle{
+					{Tables: []string{"D_1"}, BreakEdges: []dependency.Edge{fk("D_1", "D_1", false)}},
+				},
+			},
+		},
+		{
+			name: "foreign_loop_2",
+			graph: dependency.Graph{
+				Tables: []string{"E_1", "E_2"},
+				Edges: []dependency.Edge{
+					fk("E_1", "E_2", false),
+					fk("E_2", "E_1", false),
+				},
+			},
+			want: dependency.Order{
+				InsertOrder: []string{"E_1", "E_2"},
+				DeleteOrder: []string{"E_2", "E_1"},
+				Cycles: []dependency.Cycle{
+					{Tables: []string{"E_1", "E_2"}, BreakEdges: []dependency.Edge{fk("E_1", "E_2", false)}},
+				},
+			},
+		},
+		{
+			name: "foreign_loop_3_prefers_nullable",
+			graph: dependency.Graph{
+				Tables: []string{"F_1", "F_2", "F_3", "X"},
+				Edges: []dependency.Edge{
+					fk("F_1", "F_3", false),
+					fk("F_2", "F_1", false),
+					fk("F_3", "F_2", true),
+					fk("X", "F_3", false),
+				},
+			},
+			want: dependency.Order{
+				InsertOrder: []string{"F_3", "F_1", "F_2", "X"},
+				DeleteOrder: []string{"X", "F_2", "F_1", "F_3"},
+				Cycles: []dependency.Cycle{
+					{Tables: []string{"F_3", "F_1", "F_2"}, BreakEdges: []dependency.Edge{fk("F_3", "F_2", true)}},
+				},
+			},
+		},
+		{
+			name: "interleave_is_not_broken",
+			graph: dependency.Graph{
+				Tables: []string{"P", "Q"},
+				Edges: []dependency.Edge{
+					interleave("Q", "P"),
+					fk("P", "Q", false),
+				},
+			},
+			want: dependency.Order{
+				InsertOrder: []string{"P", "Q"},
+				DeleteOrder: []string{"Q", "P"},
+				Cycles: []dependency.Cycle{
+					{Tables: []string{"P", "Q"}, BreakEdges: []dependency.Edge{fk("P", "Q", false)}},
+				},
+			},
+		},
+		{
+			name: "external_references_are_ignored",
+			graph: dependency.Graph{
+				Tables: []string{"A"},
+				Edges:  []dependency.Edge{fk("A", "External", false)},
+			},
+			want: dependency.Order{
+				InsertOrder: []string{"A"},
+				DeleteOrder: []string{"A"},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			got, err := dependency.Resolve(testcase.graph)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestResolve_Error(t *testing.T) {
+	testcases := []struct {
+		name  string
+		graph dependency.Graph
+	}{
+		{
+			name: "duplicated_tables",
+			graph: dependency.Graph{
+				Tables: []string{"A", "A"},
+			},
+		},
+		{
+			name: "interleave_loop",
+			graph: dependency.Graph{
+				Tables: []string{"P", "Q"},
+				Edges: []dependency.Edge{
+					interleave("Q", "P"),
+					interleave("P", "Q"),
+				},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			_, err := dependency.Resolve(testcase.graph)
+			assert.NotNil(t, err)
+		})
+	}
+}
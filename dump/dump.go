@@ -0,0 +1,216 @@
+package dump
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+)
+
+// Writer writes rows of a table.
+// Values of the rows are nil, bool, int64, float64, string, []byte, time.Time, json.Number, json.RawMessage, or []any of them.
+type Writer interface {
+	// WriteHeader starts rows of a table with the columns, which must be called before WriteRow for each table.
+	WriteHeader(columns []string) error
+	// WriteRow writes the values of a row in order of the columns.
+	WriteRow(values []any) error
+	Flush() error
+}
+
+// NewWriter returns a writer of rows in the format.
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	default:
+		return nil, fmt.Errorf(`fail to create writer: unsupported format %q`, format)
+	case FormatJSONL:
+		return NewJSONLWriter(w), nil
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	}
+}
+
+type jsonlWriter struct {
+	w       io.Writer
+	columns []string
+}
+
+// NewJSONLWriter returns a writer that writes each row as a JSON object in a line, whose keys are in order of the columns.
+// Bytes are encoded in base64, timestamps in RFC 3339, and NaN and infinities of floats as strings.
+func NewJSONLWriter(w io.Writer) Writer {
+	return &jsonlWriter{w: w}
+}
+
+func (w *jsonlWriter) WriteHeader(columns []string) error {
+	w.columns = columns
+	return nil
+}
+
+func (w *jsonlWriter) WriteRow(values []any) error {
+	if len(values) != len(w.columns) {
+		return fmt.Errorf(`fail to write row: %d values for %d columns`, len(values), len(w.columns))
+	}
+	var line bytes.Buffer
+	line.WriteString("{")
+	for i, column := range w.columns {
+		if i > 0 {
+			line.WriteString(",")
+		}
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(jsonValue(values[i]))
+		if err != nil {
+			return fmt.Errorf(`fail to encode value of %s: %w`, column, err)
+		}
+		line.Write(key)
+		line.WriteString(":")
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+	if _, err := w.w.Write(line.Bytes()); err != nil {
+		return fmt.Errorf(`fail to write row: %w`, err)
+	}
+	return nil
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+func jsonValue(value any) any {
+	switch value := value.(type) {
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return formatFloat(value)
+		}
+		return value
+	case []any:
+		values := make([]any, len(value))
+		for i, elem := range value {
+			values[i] = jsonValue(elem)
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter returns a writer that writes a header line of the columns and a line for each row in CSV.
+// NULL is written as an empty field, bytes are encoded in base64, timestamps in RFC 3339, and arrays in JSON.
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) WriteHeader(columns []string) error {
+	if err := w.w.Write(columns); err != nil {
+		return fmt.Errorf(`fail to write header: %w`, err)
+	}
+	return nil
+}
+
+func (w *csvWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		field, err := FormatValue(value)
+		if err != nil {
+			return fmt.Errorf(`fail to format value: %w`, err)
+		}
+		record[i] = field
+	}
+	if err := w.w.Write(record); err != nil {
+		return fmt.Errorf(`fail to write row: %w`, err)
+	}
+	return nil
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		return fmt.Errorf(`fail to flush rows: %w`, err)
+	}
+	return nil
+}
+
+// FormatValue returns the text representation of the value, in which NULL is an empty string.
+func FormatValue(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case float64:
+		return formatFloat(value), nil
+	case string:
+		return value, nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(value), nil
+	case time.Time:
+		return value.Format(time.RFC3339Nano), nil
+	case json.Number:
+		return value.String(), nil
+	case json.RawMessage:
+		return string(value), nil
+	default:
+		b, err := json.Marshal(jsonValue(value))
+		if err != nil {
+			return "", fmt.Errorf(`fail to encode value %v: %w`, value, err)
+		}
+		return string(b), nil
+	}
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// Query is a SELECT statement of rows to be dumped from a table, whose identifiers are quoted in advance.
+type Query struct {
+	Table   string
+	Columns []string
+	// Where is a condition filtering rows, which is omitted if empty.
+	Where string
+	// OrderBy are columns ordering rows, which is omitted if empty.
+	OrderBy []string
+	// Limit is the maximum number of rows, which is omitted if not positive.
+	Limit int64
+}
+
+func (q Query) String() string {
+	stmt := fmt.Sprintf(`SELECT %s FROM %s`, strings.Join(q.Columns, ", "), q.Table)
+	if q.Where != "" {
+		stmt += ` WHERE ` + q.Where
+	}
+	if len(q.OrderBy) > 0 {
+		stmt += ` ORDER BY ` + strings.Join(q.OrderBy, ", ")
+	}
+	if q.Limit > 0 {
+		stmt += ` LIMIT ` + strconv.FormatInt(q.Limit, 10)
+	}
+	return stmt
+}
@@ -0,0 +1,87 @@
+package dump_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/dump"
+	"github.com/stretchr/testify/assert"
+)
+
+var columns = []string{"id", "name", "data", "price", "doc", "tags", "at", "score"}
+
+var rows = [][]any{
+	{
+		int64(1),
+		"a,b",
+		[]byte{1, 2},
+		json.Number("12.50"),
+		json.RawMessage(`{"k":[1,2]}`),
+		[]any{"x", nil},
+		time.Date(2024, 1, 2, 3, 4, 5, 600000000, time.UTC),
+		math.Inf(1),
+	},
+	{int64(2), nil, nil, nil, nil, nil, nil, 0.5},
+}
+
+func TestWriter(t *testing.T) {
+	testcases := []struct {
+		format dump.Format
+		want   string
+	}{
+		{
+			format: dump.FormatJSONL,
+			want: `{"id":1,"name":"a,b","data":"AQI=","price":12.50,"doc":{"k":[1,2]},"tags":["x",null],"at":"2024-01-02T03:04:05.6Z","score":"Infinity"}
+{"id":2,"name":null,"data":null,"price":null,"doc":null,"tags":null,"at":null,"score":0.5}
+`,
+		},
+		{
+			format: dump.FormatCSV,
+			want: `id,name,data,price,doc,tags,at,score
+1,"a,b",AQI=,12.50,"{""k"":[1,2]}","[""x"",null]",2024-01-02T03:04:05.6Z,Infinity
+2,,,,,,,0.5
+`,
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.format), func(t *testing.T) {
+			var got bytes.Buffer
+			writer, err := dump.NewWriter(testcase.format, &got)
+			assert.Nil(t, err)
+
+			assert.Nil(t, writer.WriteHeader(columns))
+			for _, row := range rows {
+				assert.Nil(t, writer.WriteRow(row))
+			}
+			assert.Nil(t, writer.Flush())
+			assert.Equal(t, testcase.want, got.String())
+		})
+	}
+}
+
+func TestQuery_String(t *testing.T) {
+	testcases := []struct {
+		in   dump.Query
+		want string
+	}{
+		{
+			in:   dump.Query{Table: `"t"`, Columns: []string{`"a"`, `"b"`}},
+			want: `SELECT "a", "b" FROM "t"`,
+		},
+		{
+			in:   dump.Query{Table: `"t"`, Columns: []string{`"a"`, `"b"`}, Where: `"a" > 0`, OrderBy: []string{`"b"`, `"a"`}, Limit: 10},
+			want: `SELECT "a", "b" FROM "t" WHERE "a" > 0 ORDER BY "b", "a" LIMIT 10`,
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.want), func(t *testing.T) {
+			assert.Equal(t, testcase.want, testcase.in.String())
+		})
+	}
+}
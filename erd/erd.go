@@ -0,0 +1,249 @@
+package erd
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+type Format string
+
+const (
+	FormatMermaid  Format = "mermaid"
+	FormatPlantUML Format = "plantuml"
+	FormatDOT      Format = "dot"
+)
+
+// Generate returns an ER diagram of the tables in the format.
+func Generate(format Format, tables []schema.Table) (string, error) {
+	switch format {
+	default:
+		return "", fmt.Errorf(`fail to generate ER diagram: unsupported format %q`, format)
+	case FormatMermaid:
+		return Mermaid(tables), nil
+	case FormatPlantUML:
+		return PlantUML(tables), nil
+	case FormatDOT:
+		return DOT(tables), nil
+	}
+}
+
+// relationship is an edge from a referencing table to a referenced table.
+type relationship struct {
+	From string
+	To   string
+	// Interleave is true if the relationship represents interleaving of From in To.
+	Interleave bool
+	// Optional is true if a row of From may have no corresponding row of To.
+	Optional bool
+	// OneToOne is true if at most one row of From corresponds to a row of To.
+	OneToOne bool
+	Label    string
+}
+
+// relationships returns the interleaving and the foreign keys of the tables in order.
+// The label of a relationship shows the mapping from the referencing columns to the referenced columns.
+func relationships(tables []schema.Table) []relationship {
+	edges := []relationship{}
+	for _, table := range tables {
+		if table.Parent != "" {
+			edge := relationship{From: table.Name, To: table.Parent, Interleave: true, Label: "interleave"}
+			if foreignKey, found := schema.ParentForeignKey(table, tables); found {
+				edge.OneToOne = len(foreignKey.ReferencingKey) == len(table.PrimaryKey)
+				edge.Label = "interleave: " + columnMapping(foreignKey)
+			}
+			edges = append(edges, edge)
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			edges = append(edges, relationship{
+				From: table.Name,
+				To:   foreignKey.ReferencedTable,
+				Optional: lo.SomeBy(table.Columns, func(column schema.Column) bool {
+					return column.Nullable && lo.Contains(foreignKey.ReferencingKey, column.Name)
+				}),
+				OneToOne: isUnique(table, foreignKey.ReferencingKey),
+				Label:    columnMapping(foreignKey),
+			})
+		}
+	}
+	return edges
+}
+
+func columnMapping(foreignKey schema.ForeignKey) string {
+	pairs := []string{}
+	for i, referencing := range foreignKey.ReferencingKey {
+		if i < len(foreignKey.ReferencedKey) {
+			pairs = append(pairs, referencing+" -> "+foreignKey.ReferencedKey[i])
+		}
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// isUnique returns true if the columns include the primary key or a unique key of the table.
+func isUnique(table schema.Table, columns []string) bool {
+	keys := append([][]string{table.PrimaryKey}, lo.Map(table.UniqueKeys, func(key schema.UniqueKey, _ int) []string { return key.Key })...)
+	for _, index := range table.Indexes {
+		if index.Unique && index.Predicate == "" {
+			keys = append(keys, lo.Map(index.Key, func(key schema.IndexKey, _ int) string { return key.Name }))
+		}
+	}
+	return lo.SomeBy(keys, func(key []string) bool { return len(key) > 0 && lo.Every(columns, key) })
+}
+
+// markers returns PK, FK, and UK if the column is included in the primary key, a foreign key, and a unique key of the table respectively.
+func markers(table schema.Table, column string) []string {
+	markers := []string{}
+	if lo.Contains(table.PrimaryKey, column) {
+		markers = append(markers, "PK")
+	}
+	if lo.SomeBy(table.ForeignKeys, func(key schema.ForeignKey) bool { return lo.Contains(key.ReferencingKey, column) }) {
+		markers = append(markers, "FK")
+	}
+	unique := lo.SomeBy(table.UniqueKeys, func(key schema.UniqueKey) bool { return lo.Contains(key.Key, column) }) ||
+		lo.SomeBy(table.Indexes, func(index schema.Index) bool {
+			return index.Unique && lo.SomeBy(index.Key, func(key schema.IndexKey) bool { return key.Name == column })
+		})
+	if unique {
+		markers = append(markers, "UK")
+	}
+	return markers
+}
+
+var mermaidInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_\-\[\]()]`)
+
+// Mermaid returns an ER diagram of the tables in the erDiagram syntax of Mermaid.
+// Foreign keys are drawn as non-identifying relationships and interleaving is drawn as identifying relationships.
+// Characters that cannot appear in attributes of Mermaid are replaced with underscores.
+func Mermaid(tables []schema.Table) string {
+	word := func(s string) string {
+		s = mermaidInvalidChars.ReplaceAllString(s, "_")
+		if s == "" || strings.ContainsAny(s[:1], "0123456789-[]()") {
+			s = "_" + s
+		}
+		return s
+	}
+	entity := func(s string) string {
+		return `"` + strings.ReplaceAll(s, `"`, `'`) + `"`
+	}
+
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	for _, table := range tables {
+		fmt.Fprintf(&b, "    %s {\n", entity(table.Name))
+		for _, column := range table.Columns {
+			fmt.Fprintf(&b, "        %s %s", word(column.NativeType), word(column.Name))
+			if markers := markers(table, column.Name); len(markers) > 0 {
+				fmt.Fprintf(&b, " %s", strings.Join(markers, ", "))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("    }\n")
+	}
+	for _, edge := range relationships(tables) {
+		from, line, to := "}o", "..", "||"
+		if edge.OneToOne {
+			from = "|o"
+		}
+		if edge.Interleave {
+			line = "--"
+		}
+		if edge.Optional {
+			to = "o|"
+		}
+		fmt.Fprintf(&b, "    %s %s%s%s %s : %s\n", entity(edge.From), from, line, to, entity(edge.To), entity(edge.Label))
+	}
+	return b.String()
+}
+
+var plantUMLInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// PlantUML returns an ER diagram of the tables in the information engineering notation of PlantUML.
+// Foreign keys are drawn as dotted lines and interleaving is drawn as solid lines.
+// Columns in the primary key are separated from the other columns, and NOT NULL columns are marked with *.
+func PlantUML(tables []schema.Table) string {
+	alias := func(s string) string {
+		return "t_" + plantUMLInvalidChars.ReplaceAllString(s, "_")
+	}
+
+	var b strings.Builder
+	b.WriteString("@startuml\n")
+	for _, table := range tables {
+		fmt.Fprintf(&b, "entity %q as %s {\n", table.Name, alias(table.Name))
+		writeColumn := func(column schema.Column) {
+			b.WriteString("  ")
+			if !column.Nullable {
+				b.WriteString("* ")
+			}
+			fmt.Fprintf(&b, "%s : %s", column.Name, column.NativeType)
+			for _, marker := range markers(table, column.Name) {
+				fmt.Fprintf(&b, " <<%s>>", marker)
+			}
+			b.WriteString("\n")
+		}
+		inPrimaryKey := func(column schema.Column, _ int) bool { return lo.Contains(table.PrimaryKey, column.Name) }
+		for _, column := range lo.Filter(table.Columns, inPrimaryKey) {
+			writeColumn(column)
+		}
+		b.WriteString("  --\n")
+		for _, column := range lo.Reject(table.Columns, inPrimaryKey) {
+			writeColumn(column)
+		}
+		b.WriteString("}\n")
+	}
+	for _, edge := range relationships(tables) {
+		from, line, to := "}o", "..", "||"
+		if edge.OneToOne {
+			from = "|o"
+		}
+		if edge.Interleave {
+			line = "--"
+		}
+		if edge.Optional {
+			to = "o|"
+		}
+		fmt.Fprintf(&b, "%s %s%s%s %s : %s\n", alias(edge.From), from, line, to, alias(edge.To), edge.Label)
+	}
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+// DOT returns an ER diagram of the tables in the DOT language of Graphviz, in which each table is a node with an HTML-like label.
+// Foreign keys are drawn as normal edges and interleaving is drawn as bold edges with diamond arrowheads.
+func DOT(tables []schema.Table) string {
+	id := func(s string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph {\n")
+	b.WriteString("    node [shape=plaintext];\n")
+	for _, table := range tables {
+		fmt.Fprintf(&b, "    %s [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">", id(table.Name))
+		fmt.Fprintf(&b, "<tr><td colspan=\"3\"><b>%s</b></td></tr>", html.EscapeString(table.Name))
+		for _, column := range table.Columns {
+			nativeType := column.NativeType
+			if !column.Nullable {
+				nativeType += " NOT NULL"
+			}
+			fmt.Fprintf(&b, "<tr><td align=\"left\">%s</td><td align=\"left\">%s</td><td>%s</td></tr>",
+				html.EscapeString(column.Name), html.EscapeString(nativeType), strings.Join(markers(table, column.Name), ", "))
+		}
+		b.WriteString("</table>>];\n")
+	}
+	for _, edge := range relationships(tables) {
+		attributes := []string{"label=" + id(edge.Label)}
+		if edge.Interleave {
+			attributes = append(attributes, "style=bold", "arrowhead=diamond")
+		}
+		if edge.Optional {
+			attributes = append(attributes, "arrowhead=odot")
+		}
+		fmt.Fprintf(&b, "    %s -> %s [%s];\n", id(edge.From), id(edge.To), strings.Join(attributes, ", "))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,122 @@
+package erd_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/erd"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var tables = []schema.Table{
+	{
+		Name: "users",
+		Columns: []schema.Column{
+			{Name: "id", NativeType: "INT64"},
+			{Name: "email", NativeType: "STRING(100)", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes:    []schema.Index{{Name: "users_email_key", Unique: true, Key: []schema.IndexKey{{Name: "email"}}}},
+	},
+	{
+		Name: "posts",
+		Columns: []schema.Column{
+			{Name: "user_id", NativeType: "INT64"},
+			{Name: "post_id", NativeType: "INT64"},
+			{Name: "editor_id", NativeType: "INT64", Nullable: true},
+		},
+		PrimaryKey:  []string{"user_id", "post_id"},
+		Parent:      "users",
+		ForeignKeys: []schema.ForeignKey{{Name: "fk_editor", ReferencedTable: "users", ReferencedKey: []string{"id"}, ReferencingKey: []string{"editor_id"}}},
+	},
+	{
+		Name: "profiles",
+		Columns: []schema.Column{
+			{Name: "user id", NativeType: "bigint"},
+			{Name: "bio", NativeType: "character varying", Nullable: true},
+		},
+		PrimaryKey:  []string{"user id"},
+		ForeignKeys: []schema.ForeignKey{{ReferencedTable: "users", ReferencedKey: []string{"id"}, ReferencingKey: []string{"user id"}}},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	testcases := []struct {
+		format erd.Format
+		want   string
+	}{
+		{
+			format: erd.FormatMermaid,
+			want: `erDiagram
+    "users" {
+        INT64 id PK
+        STRING(100) email UK
+    }
+    "posts" {
+        INT64 user_id PK
+        INT64 post_id PK
+        INT64 editor_id FK
+    }
+    "profiles" {
+        bigint user_id PK, FK
+        character_varying bio
+    }
+    "posts" }o--|| "users" : "interleave: user_id -> id"
+    "posts" }o..o| "users" : "editor_id -> id"
+    "profiles" |o..|| "users" : "user id -> id"
+`,
+		},
+		{
+			format: erd.FormatPlantUML,
+			want: `@startuml
+entity "users" as t_users {
+  * id : INT64 <<PK>>
+  --
+  email : STRING(100) <<UK>>
+}
+entity "posts" as t_posts {
+  * user_id : INT64 <<PK>>
+  * post_id : INT64 <<PK>>
+  --
+  editor_id : INT64 <<FK>>
+}
+entity "profiles" as t_profiles {
+  * user id : bigint <<PK>> <<FK>>
+  --
+  bio : character varying
+}
+t_posts }o--|| t_users : interleave: user_id -> id
+t_posts }o..o| t_users : editor_id -> id
+t_profiles |o..|| t_users : user id -> id
+@enduml
+`,
+		},
+		{
+			format: erd.FormatDOT,
+			want: `digraph {
+    node [shape=plaintext];
+    "users" [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td colspan="3"><b>users</b></td></tr><tr><td align="left">id</td><td align="left">INT64 NOT NULL</td><td>PK</td></tr><tr><td align="left">email</td><td align="left">STRING(100)</td><td>UK</td></tr></table>>];
+    "posts" [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td colspan="3"><b>posts</b></td></tr><tr><td align="left">user_id</td><td align="left">INT64 NOT NULL</td><td>PK</td></tr><tr><td align="left">post_id</td><td align="left">INT64 NOT NULL</td><td>PK</td></tr><tr><td align="left">editor_id</td><td align="left">INT64</td><td>FK</td></tr></table>>];
+    "profiles" [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td colspan="3"><b>profiles</b></td></tr><tr><td align="left">user id</td><td align="left">bigint NOT NULL</td><td>PK, FK</td></tr><tr><td align="left">bio</td><td align="left">character varying</td><td></td></tr></table>>];
+    "posts" -> "users" [label="interleave: user_id -> id", style=bold, arrowhead=diamond];
+    "posts" -> "users" [label="editor_id -> id", arrowhead=odot];
+    "profiles" -> "users" [label="user id -> id"];
+}
+`,
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.format), func(t *testing.T) {
+			got, err := erd.Generate(testcase.format, tables)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestGenerate_Unsupported(t *testing.T) {
+	_, err := erd.Generate("svg", tables)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,254 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Row maps names of columns to their values.
+type Row map[string]any
+
+// Fixture maps names of tables to rows to be inserted into them in order.
+type Fixture map[string][]Row
+
+// Tables returns the names of the tables in the fixture in lexicographic order.
+func (f Fixture) Tables() []string {
+	tables := lo.Keys(f)
+	slices.Sort(tables)
+	return tables
+}
+
+// Parse parses the fixture in the format, which is an object whose keys are names of tables and values are arrays of rows.
+// Numbers in JSON are parsed as json.Number to preserve their precision.
+func Parse(format Format, data []byte) (Fixture, error) {
+	fixture := Fixture{}
+	switch format {
+	default:
+		return nil, fmt.Errorf(`fail to parse fixture: unsupported format %q`, format)
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fixture); err != nil {
+			return nil, fmt.Errorf(`fail to parse fixture in JSON: %w`, err)
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf(`fail to parse fixture in YAML: %w`, err)
+		}
+	}
+	return fixture, nil
+}
+
+// ReadFile reads the fixture from the file whose format is determined by the extension, which is one of .json, .yaml, and .yml.
+func ReadFile(path string) (Fixture, error) {
+	var format Format
+	switch filepath.Ext(path) {
+	default:
+		return nil, fmt.Errorf(`fail to read fixture: unsupported extension of %q`, path)
+	case ".json":
+		format = FormatJSON
+	case ".yaml", ".yml":
+		format = FormatYAML
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to read fixture: %w`, err)
+	}
+	return Parse(format, data)
+}
+
+// Marshal encodes the fixture in the format, in which columns of each row are sorted by name.
+func Marshal(format Format, fixture Fixture) ([]byte, error) {
+	switch format {
+	default:
+		return nil, fmt.Errorf(`fail to marshal fixture: unsupported format %q`, format)
+	case FormatJSON:
+		b, err := json.MarshalIndent(fixture, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf(`fail to marshal fixture in JSON: %w`, err)
+		}
+		return append(b, '\n'), nil
+	case FormatYAML:
+		b, err := yaml.Marshal(fixture)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to marshal fixture in YAML: %w`, err)
+		}
+		return b, nil
+	}
+}
+
+// RowError describes a row in a fixture that fails to be inserted.
+type RowError struct {
+	Table string
+	// Index is the position of the row in the rows of Table in the fixture.
+	Index int
+	// Constraint describes the violated constraint such as its name or kind if available.
+	Constraint string
+	Err        error
+}
+
+func (e *RowError) Error() string {
+	if e.Constraint != "" {
+		return fmt.Sprintf(`row %d of %s violates %s: %v`, e.Index, e.Table, e.Constraint, e.Err)
+	}
+	return fmt.Sprintf(`fail to insert row %d of %s: %v`, e.Index, e.Table, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Columns returns columns of the table included in the row in order of the columns.
+// It fails if the row includes a column that is not in the columns.
+func Columns(columns []string, row Row) ([]string, error) {
+	for column := range row {
+		if !slices.Contains(columns, column) {
+			return nil, fmt.Errorf(`column %q is not found`, column)
+		}
+	}
+	return lo.Filter(columns, func(column string, _ int) bool {
+		_, found := row[column]
+		return found
+	}), nil
+}
+
+// ConvertValue converts the value parsed from a fixture into a value of the logical type.
+// Integers are converted into int64, floats into float64, decimals into string, bytes from base64 into []byte, dates into string in form 2006-01-02, timestamps into time.Time, JSON into string of the encoded value, and arrays into []any.
+// Values of the other types are converted into int64 or float64 if they are numbers, or they are not converted.
+func ConvertValue(t schema.Type, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch t.Kind {
+	case schema.TypeKindInteger:
+		switch value := value.(type) {
+		case json.Number:
+			return value.Int64()
+		case int:
+			return int64(value), nil
+		case int64:
+			return value, nil
+		case uint64:
+			if value > uint64(1<<63-1) {
+				return nil, fmt.Errorf(`fail to convert %v into integer: out of range`, value)
+			}
+			return int64(value), nil
+		case string:
+			return strconv.ParseInt(value, 10, 64)
+		}
+	case schema.TypeKindFloat:
+		switch value := value.(type) {
+		case json.Number:
+			return value.Float64()
+		case int:
+			return float64(value), nil
+		case int64:
+			return float64(value), nil
+		case float64:
+			return value, nil
+		case string:
+			return strconv.ParseFloat(value, 64)
+		}
+	case schema.TypeKindDecimal:
+		switch value := value.(type) {
+		case json.Number:
+			return value.String(), nil
+		case int, int64, uint64:
+			return fmt.Sprint(value), nil
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64), nil
+		case string:
+			return value, nil
+		}
+	case schema.TypeKindString:
+		switch value := value.(type) {
+		case string:
+			return value, nil
+		case json.Number, int, int64, uint64, float64, bool:
+			return fmt.Sprint(value), nil
+		}
+	case schema.TypeKindBytes:
+		switch value := value.(type) {
+		case string:
+			return base64.StdEncoding.DecodeString(value)
+		case []byte:
+			return value, nil
+		}
+	case schema.TypeKindBool:
+		switch value := value.(type) {
+		case bool:
+			return value, nil
+		case string:
+			return strconv.ParseBool(value)
+		case json.Number:
+			return value.String() != "0", nil
+		case int:
+			return value != 0, nil
+		}
+	case schema.TypeKindDate:
+		switch value := value.(type) {
+		case string:
+			return value, nil
+		case time.Time:
+			return value.Format(time.DateOnly), nil
+		}
+	case schema.TypeKindTimestamp:
+		switch value := value.(type) {
+		case time.Time:
+			return value, nil
+		case string:
+			return time.Parse(time.RFC3339Nano, value)
+		}
+	case schema.TypeKindJSON:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to encode %v into JSON: %w`, value, err)
+		}
+		return string(b), nil
+	case schema.TypeKindArray:
+		elems, ok := value.([]any)
+		if !ok {
+			break
+		}
+		elemType := schema.Type{Kind: schema.TypeKindOther}
+		if t.Elem != nil {
+			elemType = *t.Elem
+		}
+		values := []any{}
+		for _, elem := range elems {
+			converted, err := ConvertValue(elemType, elem)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, converted)
+		}
+		return values, nil
+	default:
+		if number, ok := value.(json.Number); ok {
+			if i, err := number.Int64(); err == nil {
+				return i, nil
+			}
+			return number.Float64()
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf(`fail to convert %v of %T into %s`, value, value, t)
+}
@@ -0,0 +1,165 @@
+package fixture_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/fixture"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		format fixture.Format
+		in     string
+		want   fixture.Fixture
+	}{
+		{
+			format: fixture.FormatJSON,
+			in:     `{"B": [{"ID": 1, "Price": 12.50}], "A": [{"ID": 2, "Name": "a"}, {"ID": 3, "Name": null}]}`,
+			want: fixture.Fixture{
+				"A": {{"ID": json.Number("2"), "Name": "a"}, {"ID": json.Number("3"), "Name": nil}},
+				"B": {{"ID": json.Number("1"), "Price": json.Number("12.50")}},
+			},
+		},
+		{
+			format: fixture.FormatYAML,
+			in: `B:
+  - ID: 1
+    Price: 12.5
+A:
+  - {ID: 2, Name: a}
+  - {ID: 3, Name: null}
+`,
+			want: fixture.Fixture{
+				"A": {{"ID": 2, "Name": "a"}, {"ID": 3, "Name": nil}},
+				"B": {{"ID": 1, "Price": 12.5}},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.format), func(t *testing.T) {
+			got, err := fixture.Parse(testcase.format, []byte(testcase.in))
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+			assert.Equal(t, []string{"A", "B"}, got.Tables())
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	in := fixture.Fixture{
+		"B": {{"ID": int64(1), "Price": "12.50"}},
+		"A": {{"Name": "a", "ID": int64(2)}, {"ID": int64(3), "Name": nil}},
+	}
+	testcases := []struct {
+		format fixture.Format
+		want   string
+	}{
+		{
+			format: fixture.FormatJSON,
+			want: `{
+  "A": [
+    {
+      "ID": 2,
+      "Name": "a"
+    },
+    {
+      "ID": 3,
+      "Name": null
+    }
+  ],
+  "B": [
+    {
+      "ID": 1,
+      "Price": "12.50"
+    }
+  ]
+}
+`,
+		},
+		{
+			format: fixture.FormatYAML,
+			want: `A:
+    - ID: 2
+      Name: a
+    - ID: 3
+      Name: null
+B:
+    - ID: 1
+      Price: "12.50"
+`,
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.format), func(t *testing.T) {
+			got, err := fixture.Marshal(testcase.format, in)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, string(got))
+
+			parsed, err := fixture.Parse(testcase.format, got)
+			assert.Nil(t, err)
+			assert.Equal(t, in.Tables(), parsed.Tables())
+		})
+	}
+}
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		in      any
+		t       schema.Type
+		want    any
+		wantErr bool
+	}{
+		{in: json.Number("1"), t: schema.Type{Kind: schema.TypeKindInteger}, want: int64(1)},
+		{in: 2, t: schema.Type{Kind: schema.TypeKindInteger}, want: int64(2)},
+		{in: json.Number("1.5"), t: schema.Type{Kind: schema.TypeKindInteger}, wantErr: true},
+		{in: json.Number("0.5"), t: schema.Type{Kind: schema.TypeKindFloat}, want: 0.5},
+		{in: json.Number("12.50"), t: schema.Type{Kind: schema.TypeKindDecimal}, want: "12.50"},
+		{in: 12.5, t: schema.Type{Kind: schema.TypeKindDecimal}, want: "12.5"},
+		{in: "abc", t: schema.Type{Kind: schema.TypeKindString}, want: "abc"},
+		{in: "AQI=", t: schema.Type{Kind: schema.TypeKindBytes}, want: []byte{1, 2}},
+		{in: true, t: schema.Type{Kind: schema.TypeKindBool}, want: true},
+		{in: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), t: schema.Type{Kind: schema.TypeKindDate}, want: "2024-01-02"},
+		{in: "2024-01-02T03:04:05Z", t: schema.Type{Kind: schema.TypeKindTimestamp}, want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{in: map[string]any{"k": []any{json.Number("1")}}, t: schema.Type{Kind: schema.TypeKindJSON}, want: `{"k":[1]}`},
+		{in: []any{json.Number("1"), nil}, t: schema.Type{Kind: schema.TypeKindArray, Elem: &schema.Type{Kind: schema.TypeKindInteger}}, want: []any{int64(1), nil}},
+		{in: json.Number("1.5"), t: schema.Type{Kind: schema.TypeKindOther}, want: 1.5},
+		{in: true, t: schema.Type{Kind: schema.TypeKindDate}, wantErr: true},
+		{in: nil, t: schema.Type{Kind: schema.TypeKindInteger}, want: nil},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.t), func(t *testing.T) {
+			got, err := fixture.ConvertValue(testcase.t, testcase.in)
+			if testcase.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	got, err := fixture.Columns([]string{"A", "B", "C"}, fixture.Row{"C": 1, "A": 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"A", "C"}, got)
+
+	_, err = fixture.Columns([]string{"A", "B", "C"}, fixture.Row{"D": 1})
+	assert.NotNil(t, err)
+}
+
+func TestRowError(t *testing.T) {
+	cause := errors.New("cause")
+	var err error = &fixture.RowError{Table: "T", Index: 1, Constraint: "UNIQUE constraint", Err: cause}
+	assert.Equal(t, "row 1 of T violates UNIQUE constraint: cause", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
@@ -0,0 +1,410 @@
+package generate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/fixture"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+const (
+	// maxAttempts is the maximum number of attempts to generate a row that does not duplicate the unique keys of the other rows.
+	maxAttempts = 100
+	// maxLength is the maximum length of generated strings and bytes.
+	maxLength = 20
+	// maxDigits is the maximum number of digits of each of the integer part and the fractional part of generated decimals.
+	maxDigits = 6
+	// maxInteger is the maximum of generated integers, which fit in 16-bit integers.
+	maxInteger = 1<<15 - 1
+)
+
+var (
+	minTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxTime = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type generator struct {
+	seed      int64
+	rows      int
+	tableRows map[string]int
+}
+
+// NewGenerator returns a generator that generates 10 rows for each table from random numbers of the seed.
+// Generated rows are the same for the same seed and the same tables.
+func NewGenerator(seed int64) generator {
+	return generator{seed: seed, rows: 10, tableRows: map[string]int{}}
+}
+
+// WithRows returns a generator that generates the number of rows for each table.
+func (g generator) WithRows(rows int) generator {
+	g.rows = rows
+	return g
+}
+
+// WithTableRows returns a generator that generates the number of rows for the table, which is preferred to WithRows.
+func (g generator) WithTableRows(table string, rows int) generator {
+	g.tableRows = lo.Assign(g.tableRows, map[string]int{table: rows})
+	return g
+}
+
+// Generate generates rows of the tables as a fixture, in which values are represented as in fixtures parsed from JSON.
+// Values of non-nullable columns are not NULL, values of primary keys, unique keys, and unique indexes are unique,
+// and values of foreign keys and the primary key prefixes of interleaved tables reference rows generated for the referenced tables.
+// Values of strings and bytes are not longer than their lengths and values of decimals fit in their precisions and scales.
+// Values are generated also for auto-incremented columns so that they can be referenced, while generated columns are omitted and CHECK constraints are not considered.
+// It fails if a table references a table not in the tables by a non-nullable foreign key.
+func (g generator) Generate(tables []schema.Table) (fixture.Fixture, error) {
+	order, err := dependency.Resolve(newGraph(tables))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to resolve insert order: %w`, err)
+	}
+
+	s := state{
+		random:     rand.New(rand.NewSource(g.seed)),
+		tables:     lo.SliceToMap(tables, func(table schema.Table) (string, schema.Table) { return table.Name, table }),
+		generated:  map[string]bool{},
+		fixture:    fixture.Fixture{},
+		uniqueKeys: map[string][][]string{},
+		seen:       map[string][]map[string]bool{},
+	}
+	for _, name := range order.InsertOrder {
+		rows := g.rows
+		if n, found := g.tableRows[name]; found {
+			rows = n
+		}
+		if err := s.generateTable(s.tables[name], rows); err != nil {
+			return nil, err
+		}
+		s.generated[name] = true
+	}
+	if err := s.resolvePendings(); err != nil {
+		return nil, err
+	}
+	return s.fixture, nil
+}
+
+// newGraph returns a dependency graph of the tables implied by their foreign keys and interleaving.
+func newGraph(tables []schema.Table) dependency.Graph {
+	graph := dependency.Graph{}
+	for _, table := range tables {
+		graph.Tables = append(graph.Tables, table.Name)
+		if table.Parent != "" {
+			graph.Edges = append(graph.Edges, dependency.Edge{From: table.Name, To: table.Parent, Kind: dependency.EdgeKindInterleave})
+		}
+		for index, foreignKey := range table.ForeignKeys {
+			graph.Edges = append(graph.Edges, dependency.Edge{
+				From:     table.Name,
+				To:       foreignKey.ReferencedTable,
+				Kind:     dependency.EdgeKindForeignKey,
+				Name:     foreignKey.Name,
+				Index:    index,
+				Nullable: nullable(table, foreignKey.ReferencingKey),
+			})
+		}
+	}
+	return graph
+}
+
+// nullable returns true if all the columns are nullable and not in the primary key.
+func nullable(table schema.Table, columns []string) bool {
+	return lo.EveryBy(columns, func(name string) bool {
+		column, found := lo.Find(table.Columns, func(column schema.Column) bool { return column.Name == name })
+		return found && column.Nullable && !slices.Contains(table.PrimaryKey, name)
+	})
+}
+
+// pending is a reference of a row to a table whose rows are not generated yet.
+type pending struct {
+	table      string
+	index      int
+	foreignKey schema.ForeignKey
+}
+
+type state struct {
+	random    *rand.Rand
+	tables    map[string]schema.Table
+	generated map[string]bool
+	fixture   fixture.Fixture
+	pendings  []pending
+	// uniqueKeys are the columns of the primary key, unique keys, and unique indexes of each table.
+	uniqueKeys map[string][][]string
+	// seen are the sets of the tuples of the unique keys of each table in the generated rows.
+	seen map[string][]map[string]bool
+}
+
+// references returns the foreign keys of the table including the reference to the parent of the interleaved table.
+func (s *state) references(table schema.Table) []schema.ForeignKey {
+	foreignKeys := slices.Clone(table.ForeignKeys)
+	if parent, found := schema.ParentForeignKey(table, lo.Values(s.tables)); found {
+		foreignKeys = append([]schema.ForeignKey{parent}, foreignKeys...)
+	}
+	return foreignKeys
+}
+
+func (s *state) generateTable(table schema.Table, rows int) error {
+	if _, found := schema.ParentForeignKey(table, lo.Values(s.tables)); table.Parent != "" && !found {
+		return fmt.Errorf(`fail to generate rows of %s: parent table %s is not found`, table.Name, table.Parent)
+	}
+	uniqueKeys := [][]string{}
+	if len(table.PrimaryKey) > 0 {
+		uniqueKeys = append(uniqueKeys, table.PrimaryKey)
+	}
+	for _, uniqueKey := range table.UniqueKeys {
+		uniqueKeys = append(uniqueKeys, uniqueKey.Key)
+	}
+	for _, index := range table.Indexes {
+		// unique indexes on expressions cannot be checked since expressions are not evaluated
+		if index.Unique && index.Predicate == "" && !lo.SomeBy(index.Key, func(key schema.IndexKey) bool { return key.Expression != "" }) {
+			uniqueKeys = append(uniqueKeys, lo.Map(index.Key, func(key schema.IndexKey, _ int) string { return key.Name }))
+		}
+	}
+	seen := make([]map[string]bool, len(uniqueKeys))
+	for i := range seen {
+		seen[i] = map[string]bool{}
+	}
+	s.uniqueKeys[table.Name] = uniqueKeys
+	s.seen[table.Name] = seen
+
+	s.fixture[table.Name] = []fixture.Row{}
+	for index := 0; index < rows; index++ {
+		var row fixture.Row
+		var pendings []pending
+		var pendingColumns []string
+		unique := false
+		for attempt := 0; attempt < maxAttempts && !unique; attempt++ {
+			var err error
+			row, pendings, err = s.generateRow(table, index)
+			if err != nil {
+				return err
+			}
+			pendingColumns = lo.FlatMap(pendings, func(p pending, _ int) []string { return p.foreignKey.ReferencingKey })
+			unique = lo.EveryBy(lo.Range(len(uniqueKeys)), func(i int) bool {
+				if lo.Some(uniqueKeys[i], pendingColumns) {
+					return true
+				}
+				return !seen[i][tuple(row, uniqueKeys[i])]
+			})
+		}
+		if !unique {
+			return fmt.Errorf(`fail to generate row %d of %s with unique keys in %d attempts`, index, table.Name, maxAttempts)
+		}
+		for i, key := range uniqueKeys {
+			// unique keys including pending columns are checked when the references are resolved
+			if !lo.Some(key, pendingColumns) {
+				seen[i][tuple(row, key)] = true
+			}
+		}
+		s.fixture[table.Name] = append(s.fixture[table.Name], row)
+		s.pendings = append(s.pendings, pendings...)
+	}
+	return nil
+}
+
+func (s *state) generateRow(table schema.Table, index int) (fixture.Row, []pending, error) {
+	row := fixture.Row{}
+	pendings := []pending{}
+	for _, foreignKey := range s.references(table) {
+		if lo.EveryBy(foreignKey.ReferencingKey, func(column string) bool { _, found := row[column]; return found }) {
+			continue
+		}
+		referenced := referenceableRows(s.fixture[foreignKey.ReferencedTable], foreignKey)
+		switch {
+		case len(referenced) > 0 && (s.generated[foreignKey.ReferencedTable] || foreignKey.ReferencedTable == table.Name):
+			referencedRow := referenced[s.random.Intn(len(referenced))]
+			for i, column := range foreignKey.ReferencingKey {
+				if _, found := row[column]; !found {
+					row[column] = referencedRow[foreignKey.ReferencedKey[i]]
+				}
+			}
+		case nullable(table, foreignKey.ReferencingKey):
+			for _, column := range foreignKey.ReferencingKey {
+				if _, found := row[column]; !found {
+					row[column] = nil
+				}
+			}
+		case s.generated[foreignKey.ReferencedTable] && foreignKey.ReferencedTable != table.Name:
+			return nil, nil, fmt.Errorf(`fail to generate row %d of %s: no rows referenced by %s are generated in %s`, index, table.Name, foreignKeyLabel(foreignKey), foreignKey.ReferencedTable)
+		case s.tables[foreignKey.ReferencedTable].Name == "":
+			return nil, nil, fmt.Errorf(`fail to generate row %d of %s: table %s referenced by %s is not found`, index, table.Name, foreignKey.ReferencedTable, foreignKeyLabel(foreignKey))
+		default:
+			// The referenced rows are generated later in the cycle of references.
+			pendings = append(pendings, pending{table: table.Name, index: index, foreignKey: foreignKey})
+		}
+	}
+
+	pendingColumns := lo.FlatMap(pendings, func(p pending, _ int) []string { return p.foreignKey.ReferencingKey })
+	for _, column := range table.Columns {
+		if _, found := row[column.Name]; found || column.Generated != "" || slices.Contains(pendingColumns, column.Name) {
+			continue
+		}
+		if column.Nullable && !slices.Contains(table.PrimaryKey, column.Name) && s.random.Intn(4) == 0 {
+			row[column.Name] = nil
+			continue
+		}
+		row[column.Name] = s.value(column.Type, column.NativeType)
+	}
+	return row, pendings, nil
+}
+
+// resolvePendings assigns references to rows of tables generated after the referencing tables.
+// The referenced rows are chosen so that the values of the unique keys including the referencing columns are unique.
+func (s *state) resolvePendings() error {
+	type rowID struct {
+		table string
+		index int
+	}
+	unresolved := map[rowID][]string{}
+	for _, p := range s.pendings {
+		id := rowID{table: p.table, index: p.index}
+		unresolved[id] = append(unresolved[id], p.foreignKey.ReferencingKey...)
+	}
+	for _, p := range s.pendings {
+		id := rowID{table: p.table, index: p.index}
+		unresolved[id] = lo.Without(unresolved[id], p.foreignKey.ReferencingKey...)
+		referenced := referenceableRows(s.fixture[p.foreignKey.ReferencedTable], p.foreignKey)
+		if len(referenced) == 0 {
+			return fmt.Errorf(`fail to generate row %d of %s: no rows referenced by %s are generated in %s`, p.index, p.table, foreignKeyLabel(p.foreignKey), p.foreignKey.ReferencedTable)
+		}
+		// unique keys are checked when all of their pending columns are resolved
+		uniqueKeys := s.uniqueKeys[p.table]
+		checked := lo.Filter(lo.Range(len(uniqueKeys)), func(i int, _ int) bool {
+			return lo.Some(uniqueKeys[i], p.foreignKey.ReferencingKey) && !lo.Some(uniqueKeys[i], unresolved[id])
+		})
+		row := s.fixture[p.table][p.index]
+		unique := false
+		for _, r := range s.random.Perm(len(referenced)) {
+			for i, column := range p.foreignKey.ReferencingKey {
+				row[column] = referenced[r][p.foreignKey.ReferencedKey[i]]
+			}
+			if unique = lo.EveryBy(checked, func(i int) bool { return !s.seen[p.table][i][tuple(row, uniqueKeys[i])] }); unique {
+				break
+			}
+		}
+		if !unique {
+			return fmt.Errorf(`fail to generate row %d of %s: no rows referenced by %s in %s are left for unique keys`, p.index, p.table, foreignKeyLabel(p.foreignKey), p.foreignKey.ReferencedTable)
+		}
+		for _, i := range checked {
+			s.seen[p.table][i][tuple(row, uniqueKeys[i])] = true
+		}
+	}
+	return nil
+}
+
+// referenceableRows returns the rows whose values of the referenced columns are not NULL, which can be referenced by the foreign key.
+func referenceableRows(rows []fixture.Row, foreignKey schema.ForeignKey) []fixture.Row {
+	return lo.Filter(rows, func(row fixture.Row, _ int) bool {
+		return lo.EveryBy(foreignKey.ReferencedKey, func(column string) bool { return row[column] != nil })
+	})
+}
+
+// value returns a random value of the type represented as in fixtures parsed from JSON.
+func (s *state) value(t schema.Type, nativeType string) any {
+	switch t.Kind {
+	case schema.TypeKindInteger:
+		return int64(s.random.Intn(maxInteger) + 1)
+	case schema.TypeKindFloat:
+		return float64(s.random.Intn(1_000_000)) / 1000
+	case schema.TypeKindDecimal:
+		integerDigits, fractionDigits := int64(maxDigits), int64(0)
+		if t.Precision > 0 {
+			integerDigits, fractionDigits = min(t.Precision-t.Scale, maxDigits), min(t.Scale, maxDigits)
+		}
+		decimal := s.digits(integerDigits)
+		if fractionDigits > 0 {
+			decimal += "." + s.digits(fractionDigits)
+		}
+		return decimal
+	case schema.TypeKindString:
+		return s.string(t.Length)
+	case schema.TypeKindBytes:
+		length := int64(maxLength)
+		if t.Length > 0 {
+			length = min(t.Length, maxLength)
+		}
+		b := make([]byte, s.random.Int63n(length)+1)
+		s.random.Read(b)
+		return base64.StdEncoding.EncodeToString(b)
+	case schema.TypeKindBool:
+		return s.random.Intn(2) == 0
+	case schema.TypeKindDate:
+		return s.time().Format(time.DateOnly)
+	case schema.TypeKindTimestamp:
+		return s.time().Format(time.RFC3339)
+	case schema.TypeKindJSON:
+		return map[string]any{"id": int64(s.random.Intn(maxInteger) + 1), "value": s.string(0)}
+	case schema.TypeKindArray:
+		if t.Elem == nil || t.Elem.Kind == schema.TypeKindOther {
+			// Values of unknown element types may not be accepted.
+			return []any{}
+		}
+		return lo.Map(lo.Range(s.random.Intn(4)), func(int, int) any { return s.value(*t.Elem, "") })
+	default:
+		if strings.EqualFold(nativeType, "uuid") {
+			b := make([]byte, 16)
+			s.random.Read(b)
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+		}
+		return s.string(0)
+	}
+}
+
+// digits returns a random number of the digits without leading zeros, which is 0 if the digits is not positive.
+func (s *state) digits(digits int64) string {
+	if digits <= 0 {
+		return "0"
+	}
+	n := s.random.Int63n(pow10(digits))
+	return strconv.FormatInt(n, 10)
+}
+
+// string returns a random alphanumeric string not longer than the length, which is not limited if it is 0.
+func (s *state) string(length int64) string {
+	const letters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	n := int64(maxLength)
+	if length > 0 {
+		n = min(length, maxLength)
+	}
+	b := make([]byte, s.random.Int63n(n)+1)
+	for i := range b {
+		b[i] = letters[s.random.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// time returns a random time in seconds between 2000-01-01 and 2030-01-01 in UTC.
+func (s *state) time() time.Time {
+	return minTime.Add(time.Duration(s.random.Int63n(int64(maxTime.Sub(minTime)/time.Second))) * time.Second)
+}
+
+func pow10(n int64) int64 {
+	p := int64(1)
+	for i := int64(0); i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+// tuple returns a string that identifies the values of the columns in the row.
+func tuple(row fixture.Row, columns []string) string {
+	b, _ := json.Marshal(lo.Map(columns, func(column string, _ int) any { return row[column] }))
+	return string(b)
+}
+
+func foreignKeyLabel(foreignKey schema.ForeignKey) string {
+	if foreignKey.Name != "" {
+		return "foreign key " + foreignKey.Name
+	}
+	if foreignKey.ReferencedKey == nil {
+		return "interleaving"
+	}
+	return fmt.Sprintf("foreign key (%s)", strings.Join(foreignKey.ReferencingKey, ", "))
+}
@@ -0,0 +1,310 @@
+package generate_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/fixture"
+	"github.com/Jumpaku/gotaface/generate"
+	"github.com/Jumpaku/gotaface/schema"
+	spanner_fixture "github.com/Jumpaku/gotaface/spanner/fixture"
+	spanner_schema "github.com/Jumpaku/gotaface/spanner/schema"
+	spanner_testdata "github.com/Jumpaku/gotaface/spanner/schema/testdata"
+	sqlite3_fixture "github.com/Jumpaku/gotaface/sqlite3/fixture"
+	sqlite3_schema "github.com/Jumpaku/gotaface/sqlite3/schema"
+	sqlite3_testdata "github.com/Jumpaku/gotaface/sqlite3/schema/testdata"
+	"github.com/Jumpaku/gotaface/sqlite3/test"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	typeInteger = schema.Type{Kind: schema.TypeKindInteger}
+	typeBool    = schema.Type{Kind: schema.TypeKindBool}
+)
+
+func TestGenerate_Deterministic(t *testing.T) {
+	tables := []schema.Table{{
+		Name: "A",
+		Columns: []schema.Column{
+			{Name: "ID", Type: typeInteger},
+			{Name: "Name", Type: schema.Type{Kind: schema.TypeKindString, Length: 10}, Nullable: true},
+		},
+		PrimaryKey: []string{"ID"},
+	}}
+
+	got1, err := generate.NewGenerator(1).Generate(tables)
+	assert.Nil(t, err)
+	got2, err := generate.NewGenerator(1).Generate(tables)
+	assert.Nil(t, err)
+	got3, err := generate.NewGenerator(2).Generate(tables)
+	assert.Nil(t, err)
+
+	assert.Equal(t, got1, got2)
+	assert.NotEqual(t, got1, got3)
+}
+
+func TestGenerate_Constraints(t *testing.T) {
+	tables := []schema.Table{
+		{
+			Name: "Child",
+			Columns: []schema.Column{
+				{Name: "ParentID", Type: typeInteger},
+				{Name: "ChildID", Type: typeInteger},
+				{Name: "Code", Type: schema.Type{Kind: schema.TypeKindString, Length: 3}},
+				{Name: "Data", Type: schema.Type{Kind: schema.TypeKindBytes, Length: 4}, Nullable: true},
+				{Name: "Price", Type: schema.Type{Kind: schema.TypeKindDecimal, Precision: 5, Scale: 2}},
+				{Name: "OtherID", Type: typeInteger, Nullable: true},
+				{Name: "Upper", Type: schema.Type{Kind: schema.TypeKindString}, Generated: "UPPER(Code)"},
+			},
+			PrimaryKey:  []string{"ParentID", "ChildID"},
+			Parent:      "Parent",
+			ForeignKeys: []schema.ForeignKey{{Name: "FK_Other", ReferencedTable: "Other", ReferencedKey: []string{"ID"}, ReferencingKey: []string{"OtherID"}}},
+			UniqueKeys:  []schema.UniqueKey{{Name: "UQ_Code", Key: []string{"Code"}}},
+		},
+		{
+			Name:       "Parent",
+			Columns:    []schema.Column{{Name: "ParentID", Type: typeInteger}},
+			PrimaryKey: []string{"ParentID"},
+		},
+		{
+			Name:       "Other",
+			Columns:    []schema.Column{{Name: "ID", Type: typeInteger}},
+			PrimaryKey: []string{"ID"},
+		},
+	}
+
+	got, err := generate.NewGenerator(1).WithRows(20).WithTableRows("Parent", 3).Generate(tables)
+	assert.Nil(t, err)
+
+	assert.Len(t, got["Parent"], 3)
+	assert.Len(t, got["Other"], 20)
+	assert.Len(t, got["Child"], 20)
+	parentIDs := lo.Map(got["Parent"], func(row fixture.Row, _ int) any { return row["ParentID"] })
+	otherIDs := lo.Map(got["Other"], func(row fixture.Row, _ int) any { return row["ID"] })
+	keys := map[string]bool{}
+	codes := map[string]bool{}
+	for _, row := range got["Child"] {
+		assert.Contains(t, parentIDs, row["ParentID"])
+		if row["OtherID"] != nil {
+			assert.Contains(t, otherIDs, row["OtherID"])
+		}
+		keys[fmt.Sprint(row["ParentID"], row["ChildID"])] = true
+
+		code := row["Code"].(string)
+		assert.LessOrEqual(t, len(code), 3)
+		codes[code] = true
+
+		if row["Data"] != nil {
+			data, err := base64.StdEncoding.DecodeString(row["Data"].(string))
+			assert.Nil(t, err)
+			assert.LessOrEqual(t, len(data), 4)
+		}
+
+		integer, _, _ := strings.Cut(row["Price"].(string), ".")
+		assert.LessOrEqual(t, len(integer), 3)
+
+		assert.NotContains(t, row, "Upper")
+	}
+	assert.Len(t, keys, 20)
+	assert.Len(t, codes, 20)
+}
+
+func TestGenerate_Cycle(t *testing.T) {
+	tables := []schema.Table{
+		{
+			Name:        "X",
+			Columns:     []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "YID", Type: typeInteger}},
+			PrimaryKey:  []string{"ID"},
+			ForeignKeys: []schema.ForeignKey{{ReferencedTable: "Y", ReferencedKey: []string{"ID"}, ReferencingKey: []string{"YID"}}},
+		},
+		{
+			Name:        "Y",
+			Columns:     []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "XID", Type: typeInteger}},
+			PrimaryKey:  []string{"ID"},
+			ForeignKeys: []schema.ForeignKey{{ReferencedTable: "X", ReferencedKey: []string{"ID"}, ReferencingKey: []string{"XID"}}},
+		},
+	}
+
+	got, err := generate.NewGenerator(1).Generate(tables)
+	assert.Nil(t, err)
+
+	xIDs := lo.Map(got["X"], func(row fixture.Row, _ int) any { return row["ID"] })
+	yIDs := lo.Map(got["Y"], func(row fixture.Row, _ int) any { return row["ID"] })
+	for _, row := range got["X"] {
+		assert.Contains(t, yIDs, row["YID"])
+	}
+	for _, row := range got["Y"] {
+		assert.Contains(t, xIDs, row["XID"])
+	}
+}
+
+func TestGenerate_CycleUniqueKeys(t *testing.T) {
+	tables := []schema.Table{
+		{
+			Name:        "X",
+			Columns:     []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "YID", Type: typeInteger}},
+			PrimaryKey:  []string{"ID"},
+			ForeignKeys: []schema.ForeignKey{{ReferencedTable: "Y", ReferencedKey: []string{"ID"}, ReferencingKey: []string{"YID"}}},
+			UniqueKeys:  []schema.UniqueKey{{Name: "UQ_X_YID", Key: []string{"YID"}}},
+		},
+		{
+			Name:        "Y",
+			Columns:     []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "XID", Type: typeInteger}},
+			PrimaryKey:  []string{"ID"},
+			ForeignKeys: []schema.ForeignKey{{ReferencedTable: "X", ReferencedKey: []string{"ID"}, ReferencingKey: []string{"XID"}}},
+			Indexes:     []schema.Index{{Name: "UQ_Y_XID", Unique: true, Key: []schema.IndexKey{{Name: "XID"}}}},
+		},
+	}
+
+	got, err := generate.NewGenerator(1).Generate(tables)
+	assert.Nil(t, err)
+
+	yIDs := lo.Map(got["X"], func(row fixture.Row, _ int) any { return row["YID"] })
+	xIDs := lo.Map(got["Y"], func(row fixture.Row, _ int) any { return row["XID"] })
+	assert.ElementsMatch(t, lo.Map(got["Y"], func(row fixture.Row, _ int) any { return row["ID"] }), yIDs)
+	assert.ElementsMatch(t, lo.Map(got["X"], func(row fixture.Row, _ int) any { return row["ID"] }), xIDs)
+}
+
+func TestGenerate_NullReferenced(t *testing.T) {
+	tables := []schema.Table{
+		{
+			Name:        "A",
+			Columns:     []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "BCode", Type: typeInteger}},
+			PrimaryKey:  []string{"ID"},
+			ForeignKeys: []schema.ForeignKey{{ReferencedTable: "B", ReferencedKey: []string{"Code"}, ReferencingKey: []string{"BCode"}}},
+		},
+		{
+			Name:       "B",
+			Columns:    []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "Code", Type: typeInteger, Nullable: true}},
+			PrimaryKey: []string{"ID"},
+			UniqueKeys: []schema.UniqueKey{{Name: "UQ_B_Code", Key: []string{"Code"}}},
+		},
+	}
+
+	got, err := generate.NewGenerator(1).WithRows(40).Generate(tables)
+	assert.Nil(t, err)
+
+	codes := lo.Map(got["B"], func(row fixture.Row, _ int) any { return row["Code"] })
+	assert.Contains(t, codes, nil)
+	for _, row := range got["A"] {
+		assert.NotNil(t, row["BCode"])
+		assert.Contains(t, codes, row["BCode"])
+	}
+}
+
+func TestGenerate_Error(t *testing.T) {
+	testcases := []struct {
+		name   string
+		tables []schema.Table
+	}{
+		{
+			name: "referenced_table_not_found",
+			tables: []schema.Table{{
+				Name:        "A",
+				Columns:     []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "BID", Type: typeInteger}},
+				ForeignKeys: []schema.ForeignKey{{ReferencedTable: "B", ReferencedKey: []string{"ID"}, ReferencingKey: []string{"BID"}}},
+			}},
+		},
+		{
+			name: "parent_not_found",
+			tables: []schema.Table{{
+				Name:       "A",
+				Columns:    []schema.Column{{Name: "ID", Type: typeInteger}},
+				PrimaryKey: []string{"ID"},
+				Parent:     "B",
+			}},
+		},
+		{
+			name: "unique_keys_exhausted",
+			tables: []schema.Table{{
+				Name:       "A",
+				Columns:    []schema.Column{{Name: "Flag", Type: typeBool}},
+				PrimaryKey: []string{"Flag"},
+			}},
+		},
+		{
+			name: "cycle_unique_keys_exhausted",
+			tables: []schema.Table{
+				{
+					Name:        "X",
+					Columns:     []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "YID", Type: typeInteger}},
+					PrimaryKey:  []string{"ID"},
+					ForeignKeys: []schema.ForeignKey{{ReferencedTable: "Y", ReferencedKey: []string{"ID"}, ReferencingKey: []string{"YID"}}},
+					UniqueKeys:  []schema.UniqueKey{{Name: "UQ_X_YID", Key: []string{"YID"}}},
+				},
+				{
+					Name:        "Y",
+					Columns:     []schema.Column{{Name: "ID", Type: typeInteger}, {Name: "XID", Type: typeInteger}},
+					PrimaryKey:  []string{"ID"},
+					ForeignKeys: []schema.ForeignKey{{ReferencedTable: "X", ReferencedKey: []string{"ID"}, ReferencingKey: []string{"XID"}}},
+				},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			_, err := generate.NewGenerator(1).WithTableRows("Y", 3).Generate(testcase.tables)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestGenerate_SpannerAllTypes(t *testing.T) {
+	fetcher, err := spanner_schema.NewDDLFetcher(spanner_testdata.DDL00AllTypesSQL)
+	assert.Nil(t, err)
+	table, err := fetcher.Fetch(context.Background(), "A")
+	assert.Nil(t, err)
+
+	got, err := generate.NewGenerator(1).WithRows(50).Generate([]schema.Table{spanner_schema.ConvertTable(table)})
+	assert.Nil(t, err)
+	assert.Len(t, got["A"], 50)
+
+	for index, row := range got["A"] {
+		for _, column := range table.Columns {
+			value, found := row[column.Name]
+			assert.True(t, found, "%s of row %d", column.Name, index)
+			if !column.Nullable {
+				assert.NotNil(t, value, "%s of row %d", column.Name, index)
+			}
+			_, err := spanner_fixture.ConvertValue(column, value)
+			assert.Nil(t, err, "%s of row %d", column.Name, index)
+		}
+		for _, column := range []string{"Col_15", "Col_16"} {
+			if s, ok := row[column].(string); ok {
+				assert.LessOrEqual(t, len(s), 50)
+			}
+		}
+		for _, column := range []string{"Col_03", "Col_04"} {
+			if s, ok := row[column].(string); ok {
+				b, err := base64.StdEncoding.DecodeString(s)
+				assert.Nil(t, err)
+				assert.LessOrEqual(t, len(b), 50)
+			}
+		}
+	}
+}
+
+func TestGenerate_SQLite3AllTypes(t *testing.T) {
+	db, teardown := test.Setup(t, "generate_all_types.sqlite")
+	defer teardown()
+	test.InitDDLs(t, db, []string{sqlite3_testdata.DDL00AllTypesSQL})
+
+	table, err := sqlite3_schema.NewFetcher(db).Fetch(context.Background(), "A")
+	assert.Nil(t, err)
+
+	got, err := generate.NewGenerator(1).WithRows(50).Generate([]schema.Table{sqlite3_schema.ConvertTable(table)})
+	assert.Nil(t, err)
+
+	err = sqlite3_fixture.NewLoader(db).Load(context.Background(), []sqlite3_schema.SchemaTable{table}, got)
+	assert.Nil(t, err)
+
+	var count int
+	assert.Nil(t, db.Get(&count, `SELECT COUNT(*) FROM A`))
+	assert.Equal(t, 50, count)
+}
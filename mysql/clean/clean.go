@@ -0,0 +1,77 @@
+package clean
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/mysql/dependency"
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+)
+
+type cleaner struct {
+	db   *sqlx.DB
+	keep []string
+}
+
+// NewCleaner returns a cleaner that deletes all rows of tables in a transaction.
+func NewCleaner(db *sqlx.DB) cleaner {
+	return cleaner{db: db}
+}
+
+// WithKeep returns a cleaner that keeps rows of the tables, such as reference tables holding master data.
+func (c cleaner) WithKeep(tables ...string) cleaner {
+	c.keep = append(slices.Clone(c.keep), tables...)
+	return c
+}
+
+// Clean deletes all rows of the tables except the kept tables in delete order resolved from their foreign keys.
+// Foreign key checks are disabled while deleting rows if the tables reference each other.
+// Rows are deleted by DELETE instead of TRUNCATE, which commits implicitly and fails on tables referenced by foreign keys.
+func (c cleaner) Clean(ctx context.Context, tables []schema.SchemaTable) error {
+	targets := lo.Reject(tables, func(table schema.SchemaTable, _ int) bool { return lo.Contains(c.keep, table.Name) })
+	order, err := gf_dependency.Resolve(dependency.NewGraph(targets))
+	if err != nil {
+		return fmt.Errorf(`fail to resolve delete order: %w`, err)
+	}
+
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(`fail to begin transaction: %w`, err)
+	}
+	defer tx.Rollback()
+
+	disabled := len(order.Cycles) > 0
+	if disabled {
+		// FOREIGN_KEY_CHECKS is a session variable, which must be restored before the connection returns to the pool.
+		if _, err := tx.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 0`); err != nil {
+			return fmt.Errorf(`fail to disable foreign key checks: %w`, err)
+		}
+		defer tx.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 1`)
+	}
+
+	for _, name := range order.DeleteOrder {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", quoteIdentifier(name))); err != nil {
+			return fmt.Errorf(`fail to delete rows of %s: %w`, name, err)
+		}
+	}
+
+	if disabled {
+		if _, err := tx.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 1`); err != nil {
+			return fmt.Errorf(`fail to enable foreign key checks: %w`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`fail to commit deletion: %w`, err)
+	}
+	return nil
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
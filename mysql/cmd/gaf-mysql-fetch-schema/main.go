@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"io"
+	"log"
+	"os"
+	"text/template"
+
+	"github.com/Jumpaku/gotaface/erd"
+	"github.com/Jumpaku/gotaface/mysql/codegen"
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+//go:generate go run "github.com/Jumpaku/cyamli/cmd/cyamli@latest" golang -schema-path=cli.yaml -out-path=cli.gen.go
+var cli = NewCLI()
+
+func main() {
+	cli.FUNC = fetch
+	if err := Run(cli, os.Args); err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+}
+
+func fetch(subcommand []string, input CLI_Input, inputErr error) (err error) {
+	if inputErr != nil {
+		fmt.Fprintln(os.Stderr, cli.DESC_Simple())
+		return fmt.Errorf("fail to resolve command line arguments: %w", inputErr)
+	}
+	if input.Opt_Help {
+		fmt.Fprintln(os.Stderr, cli.DESC_Detail())
+		return nil
+	}
+	ctx := context.Background()
+	dbx, err := sqlx.Open("mysql", input.Arg_DataSource)
+	if err != nil {
+		return fmt.Errorf("fail to open MySQL database: %w", err)
+	}
+	defer dbx.Close()
+
+	fetcher := schema.NewFetcher(dbx)
+	if input.Opt_ReferencedBy {
+		fetcher = fetcher.WithReferencedBy()
+	}
+
+	targetTables := input.Arg_TargetTables
+	if len(targetTables) == 0 {
+		targetTables, err = fetcher.ListTables(ctx)
+		if err != nil {
+			return fmt.Errorf("fail to list tables in MySQL database: %w", err)
+		}
+	}
+
+	schemas := []schema.SchemaTable{}
+	for _, targetTable := range targetTables {
+		result, err := fetcher.Fetch(ctx, targetTable)
+		if err != nil {
+			return fmt.Errorf("fail to fetch schema of %q in MySQL database: %w", targetTable, err)
+		}
+		schemas = append(schemas, result)
+	}
+
+	var out io.Writer = os.Stdout
+	if input.Opt_Output != "" {
+		f, err := os.Create(input.Opt_Output)
+		if err != nil {
+			return fmt.Errorf("fail to open output file %q: %w", input.Opt_Output, err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	switch input.Opt_Format {
+	default:
+		return fmt.Errorf("invalid option value for format, which must be one of json, go, sql, mermaid, plantuml, dot, txt.tpl")
+	case "json":
+		encoder := json.NewEncoder(out)
+		for _, schema := range schemas {
+			if err := encoder.Encode(schema); err != nil {
+				return fmt.Errorf("fail to encode schema of %q into JSON: %w", schema.Name, err)
+			}
+		}
+	case "go":
+		src, err := codegen.Generate(input.Opt_GoPackage, schemas)
+		if err != nil {
+			return fmt.Errorf("fail to generate Go source code: %w", err)
+		}
+		if _, err := out.Write(src); err != nil {
+			return fmt.Errorf("fail to write Go source code: %w", err)
+		}
+	case "sql":
+		for _, stmt := range schema.GenerateDDL(schemas) {
+			if _, err := fmt.Fprintf(out, "%s;\n\n", stmt); err != nil {
+				return fmt.Errorf("fail to write DDL: %w", err)
+			}
+		}
+	case "mermaid", "plantuml", "dot":
+		tables := lo.Map(schemas, func(table schema.SchemaTable, _ int) gf_schema.Table { return schema.ConvertTable(table) })
+		diagram, err := erd.Generate(erd.Format(input.Opt_Format), tables)
+		if err != nil {
+			return fmt.Errorf("fail to generate ER diagram: %w", err)
+		}
+		if _, err := io.WriteString(out, diagram); err != nil {
+			return fmt.Errorf("fail to write ER diagram: %w", err)
+		}
+	case "txt.tpl":
+		var in io.Reader = os.Stdin
+		if input.Opt_InputTxtTpl != "" {
+			f, err := os.Open(input.Opt_InputTxtTpl)
+			if err != nil {
+				return fmt.Errorf("fail to open output file %q: %w", input.Opt_InputTxtTpl, err)
+			}
+			defer f.Close()
+
+			in = f
+		}
+
+		inBytes, err := io.ReadAll(in)
+		if err != nil {
+			return fmt.Errorf("fail to read from stdin: %w", err)
+		}
+		executor, err := template.New("txt.tpl").Parse(string(inBytes))
+		if err != nil {
+			return fmt.Errorf("fail to parse text template: %w", err)
+		}
+		for _, schema := range schemas {
+			if err := executor.Execute(out, schema); err != nil {
+				return fmt.Errorf("fail to process template with schema of %q: %w", schema.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package codegen
+
+import (
+	"strings"
+
+	gf_codegen "github.com/Jumpaku/gotaface/codegen"
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/samber/lo"
+)
+
+// Generate returns source code of a Go package that defines a struct for each table, whose fields are tagged with db and json.
+func Generate(packageName string, tables []schema.SchemaTable) ([]byte, error) {
+	imports := []string{}
+	codegenTables := lo.Map(tables, func(table schema.SchemaTable, _ int) gf_codegen.Table {
+		return gf_codegen.Table{
+			Name: table.Name,
+			Columns: lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) gf_codegen.Column {
+				goType, importPath := GoType(column)
+				if importPath != "" {
+					imports = append(imports, importPath)
+				}
+				return gf_codegen.Column{Name: column.Name, GoType: goType}
+			}),
+			PrimaryKey: table.PrimaryKey,
+			UniqueKeys: lo.Map(table.UniqueKeys, func(key schema.SchemaUniqueKey, _ int) []string { return key.Key }),
+			ForeignKeys: lo.Map(table.ForeignKeys, func(key schema.SchemaForeignKey, _ int) gf_codegen.ForeignKey {
+				return gf_codegen.ForeignKey{ReferencedTable: key.ReferencedTable, ReferencedKey: key.ReferencedKey, ReferencingKey: key.ReferencingKey}
+			}),
+		}
+	})
+
+	return gf_codegen.Generate(gf_codegen.Config{
+		Package: packageName,
+		Imports: imports,
+		Tags:    []string{"db", "json"},
+		KeyType: "[]any",
+	}, codegenTables)
+}
+
+// GoType returns the Go type of the field for the column and the import path of the package that the type requires.
+// The type is determined by the column type, in which tinyint(1) is regarded as boolean.
+// Nullable columns are mapped to sql.Null* types, or to pointers if there are no corresponding sql.Null* types.
+// Columns of date and time types are mapped to time.Time, which requires parseTime=true in the data source name.
+func GoType(column schema.SchemaColumn) (goType string, importPath string) {
+	nullable := func(notNullType, nullType, nullImportPath string) (string, string) {
+		if column.Nullable {
+			return nullType, nullImportPath
+		}
+		return notNullType, ""
+	}
+
+	columnType := strings.ToLower(column.Type)
+	if columnType == "tinyint(1)" {
+		return nullable("bool", "sql.NullBool", "database/sql")
+	}
+	typeName, _, _ := strings.Cut(columnType, "(")
+	typeName, _, _ = strings.Cut(typeName, " ")
+	if strings.HasSuffix(columnType, " unsigned") || strings.HasSuffix(columnType, " unsigned zerofill") {
+		switch typeName {
+		case "tinyint":
+			return nullable("uint8", "sql.NullByte", "database/sql")
+		case "smallint":
+			return nullable("uint16", "sql.NullInt32", "database/sql")
+		case "mediumint", "int", "integer":
+			return nullable("uint32", "sql.NullInt64", "database/sql")
+		case "bigint":
+			return nullable("uint64", "*uint64", "")
+		}
+	}
+
+	switch typeName {
+	case "tinyint":
+		return nullable("int8", "sql.NullInt16", "database/sql")
+	case "smallint", "year":
+		return nullable("int16", "sql.NullInt16", "database/sql")
+	case "mediumint", "int", "integer":
+		return nullable("int32", "sql.NullInt32", "database/sql")
+	case "bigint":
+		return nullable("int64", "sql.NullInt64", "database/sql")
+	case "float":
+		return nullable("float32", "*float32", "")
+	case "double", "real":
+		return nullable("float64", "sql.NullFloat64", "database/sql")
+	case "decimal", "numeric", "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum", "set", "time":
+		return nullable("string", "sql.NullString", "database/sql")
+	case "date", "datetime", "timestamp":
+		if column.Nullable {
+			return "sql.NullTime", "database/sql"
+		}
+		return "time.Time", "time"
+	case "json":
+		return "json.RawMessage", "encoding/json"
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob", "bit":
+		return "[]byte", ""
+	default:
+		return "any", ""
+	}
+}
@@ -0,0 +1,72 @@
+package codegen_test
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/mysql/codegen"
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoType(t *testing.T) {
+	testcases := []struct {
+		column         schema.SchemaColumn
+		wantGoType     string
+		wantImportPath string
+	}{
+		{column: schema.SchemaColumn{Type: "int"}, wantGoType: "int32", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "int", Nullable: true}, wantGoType: "sql.NullInt32", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "bigint"}, wantGoType: "int64", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "bigint unsigned"}, wantGoType: "uint64", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "bigint unsigned", Nullable: true}, wantGoType: "*uint64", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "tinyint(1)", Nullable: true}, wantGoType: "sql.NullBool", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "tinyint"}, wantGoType: "int8", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "tinyint unsigned", Nullable: true}, wantGoType: "sql.NullByte", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "year"}, wantGoType: "int16", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "float", Nullable: true}, wantGoType: "*float32", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "double", Nullable: true}, wantGoType: "sql.NullFloat64", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "decimal(10,2)"}, wantGoType: "string", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "varchar(50)", Nullable: true}, wantGoType: "sql.NullString", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "enum('a','b')"}, wantGoType: "string", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "datetime"}, wantGoType: "time.Time", wantImportPath: "time"},
+		{column: schema.SchemaColumn{Type: "timestamp", Nullable: true}, wantGoType: "sql.NullTime", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "json", Nullable: true}, wantGoType: "json.RawMessage", wantImportPath: "encoding/json"},
+		{column: schema.SchemaColumn{Type: "varbinary(50)"}, wantGoType: "[]byte", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "bit(50)", Nullable: true}, wantGoType: "[]byte", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "geometry"}, wantGoType: "any", wantImportPath: ""},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.column.Type), func(t *testing.T) {
+			gotGoType, gotImportPath := codegen.GoType(testcase.column)
+			assert.Equal(t, testcase.wantGoType, gotGoType)
+			assert.Equal(t, testcase.wantImportPath, gotImportPath)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tables := []schema.SchemaTable{
+		{
+			Name:       "t",
+			Columns:    []schema.SchemaColumn{{Name: "id", Type: "int"}, {Name: "at", Type: "datetime", Nullable: true}, {Name: "doc", Type: "json"}},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	got, err := codegen.Generate("model", tables)
+	assert.Nil(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "model.go", got, parser.ImportsOnly)
+	assert.Nil(t, err)
+	assert.Equal(t, "model", file.Name.Name)
+	imports := []string{}
+	for _, spec := range file.Imports {
+		imports = append(imports, spec.Path.Value)
+	}
+	assert.Equal(t, []string{`"database/sql"`, `"encoding/json"`}, imports)
+	assert.Contains(t, string(got), "func (row T) PrimaryKey() []any {")
+}
@@ -0,0 +1,153 @@
+package dump
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jumpaku/gotaface/dump"
+	gf_mysql "github.com/Jumpaku/gotaface/mysql"
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+type dumper struct {
+	queryer           gf_mysql.Queryer
+	where             string
+	limit             int64
+	orderByPrimaryKey bool
+}
+
+// NewDumper returns a dumper that dumps all rows of tables in arbitrary order.
+func NewDumper(queryer gf_mysql.Queryer) dumper {
+	return dumper{queryer: queryer}
+}
+
+// WithWhere returns a dumper that dumps only rows satisfying the condition.
+func (d dumper) WithWhere(condition string) dumper {
+	d.where = condition
+	return d
+}
+
+// WithLimit returns a dumper that dumps at most limit rows of each table.
+func (d dumper) WithLimit(limit int64) dumper {
+	d.limit = limit
+	return d
+}
+
+// WithOrderByPrimaryKey returns a dumper that dumps rows in order of the primary key, which makes outputs stable.
+func (d dumper) WithOrderByPrimaryKey() dumper {
+	d.orderByPrimaryKey = true
+	return d
+}
+
+// Dump writes rows of the table, whose values are typed according to the column types of the columns.
+func (d dumper) Dump(ctx context.Context, table schema.SchemaTable, writer dump.Writer) error {
+	columns := lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) string { return column.Name })
+	query := dump.Query{
+		Table:   quoteIdentifier(table.Name),
+		Columns: lo.Map(columns, func(column string, _ int) string { return quoteIdentifier(column) }),
+		Where:   d.where,
+		Limit:   d.limit,
+	}
+	if d.orderByPrimaryKey {
+		query.OrderBy = lo.Map(table.PrimaryKey, func(column string, _ int) string { return quoteIdentifier(column) })
+	}
+
+	itr, err := d.queryer.QueryxContext(ctx, query.String())
+	if err != nil {
+		return fmt.Errorf(`fail to query rows of %s: %w`, table.Name, err)
+	}
+	defer itr.Close()
+
+	if err := writer.WriteHeader(columns); err != nil {
+		return fmt.Errorf(`fail to write header of %s: %w`, table.Name, err)
+	}
+	for itr.Next() {
+		values, err := itr.SliceScan()
+		if err != nil {
+			return fmt.Errorf(`fail to scan row of %s: %w`, table.Name, err)
+		}
+		for i, column := range table.Columns {
+			values[i] = ConvertValue(column, values[i])
+		}
+		if err := writer.WriteRow(values); err != nil {
+			return fmt.Errorf(`fail to write row of %s: %w`, table.Name, err)
+		}
+	}
+	if err := itr.Err(); err != nil {
+		return fmt.Errorf(`fail to iterate rows of %s: %w`, table.Name, err)
+	}
+	return nil
+}
+
+// ConvertValue converts the value scanned from the column into a value accepted by dump.Writer.
+// Values are scanned as text in the text protocol of MySQL, which are parsed according to the column type.
+// Integers out of the range of int64 such as large bigint unsigned are converted into json.Number.
+func ConvertValue(column schema.SchemaColumn, value any) any {
+	if value == nil {
+		return nil
+	}
+	if v, ok := value.(time.Time); ok {
+		return v
+	}
+	s, ok := text(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	switch schema.ConvertType(column.Type).Kind {
+	case gf_schema.TypeKindInteger:
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+		if isNumber(s) {
+			return json.Number(s)
+		}
+	case gf_schema.TypeKindFloat:
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case gf_schema.TypeKindDecimal:
+		if isNumber(s) {
+			return json.Number(s)
+		}
+	case gf_schema.TypeKindBytes:
+		return []byte(s)
+	case gf_schema.TypeKindBool:
+		return s != "0"
+	case gf_schema.TypeKindJSON:
+		if json.Valid([]byte(s)) {
+			return json.RawMessage(s)
+		}
+	}
+	return s
+}
+
+// isNumber returns true if the string is a number literal in JSON.
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil && json.Valid([]byte(s))
+}
+
+func text(value any) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case []byte:
+		return string(value), true
+	case int64:
+		return strconv.FormatInt(value, 10), true
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
@@ -0,0 +1,37 @@
+package dump_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/mysql/dump"
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		columnType string
+		in         any
+		want       any
+	}{
+		{columnType: "int", in: []byte("-1"), want: int64(-1)},
+		{columnType: "bigint unsigned", in: []byte("18446744073709551615"), want: json.Number("18446744073709551615")},
+		{columnType: "double", in: []byte("0.5"), want: 0.5},
+		{columnType: "decimal(10,2)", in: []byte("12.50"), want: json.Number("12.50")},
+		{columnType: "varchar(10)", in: []byte("abc"), want: "abc"},
+		{columnType: "varbinary(10)", in: []byte{1, 2}, want: []byte{1, 2}},
+		{columnType: "tinyint(1)", in: []byte("1"), want: true},
+		{columnType: "datetime", in: []byte("2024-01-02 03:04:05"), want: "2024-01-02 03:04:05"},
+		{columnType: "json", in: []byte(`{"k": 1}`), want: json.RawMessage(`{"k": 1}`)},
+		{columnType: "int", in: nil, want: nil},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.columnType), func(t *testing.T) {
+			got := dump.ConvertValue(schema.SchemaColumn{Name: "C", Type: testcase.columnType}, testcase.in)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/fixture"
+	"github.com/Jumpaku/gotaface/mysql/dependency"
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+)
+
+type loader struct {
+	db *sqlx.DB
+}
+
+// NewLoader returns a loader that inserts rows of fixtures in a transaction.
+func NewLoader(db *sqlx.DB) loader {
+	return loader{db: db}
+}
+
+// Load inserts rows of the fixture into the tables in insert order resolved from their foreign keys, in which all the rows are inserted or none of them are.
+// Foreign key checks are disabled while inserting rows if the tables reference each other, and the foreign keys breaking the cycles are checked for each row before commit.
+// The tables must include all the tables in the fixture.
+func (l loader) Load(ctx context.Context, tables []schema.SchemaTable, f fixture.Fixture) error {
+	tableMap := lo.SliceToMap(tables, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+	targets := []schema.SchemaTable{}
+	for _, name := range f.Tables() {
+		table, found := tableMap[name]
+		if !found {
+			return fmt.Errorf(`fail to load fixture: table %q is not found`, name)
+		}
+		targets = append(targets, table)
+	}
+	order, err := gf_dependency.Resolve(dependency.NewGraph(targets))
+	if err != nil {
+		return fmt.Errorf(`fail to resolve insert order: %w`, err)
+	}
+
+	tx, err := l.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(`fail to begin transaction: %w`, err)
+	}
+	defer tx.Rollback()
+
+	deferred := len(order.Cycles) > 0
+	if deferred {
+		// FOREIGN_KEY_CHECKS is a session variable, which must be restored before the connection returns to the pool.
+		if _, err := tx.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 0`); err != nil {
+			return fmt.Errorf(`fail to disable foreign key checks: %w`, err)
+		}
+		defer tx.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 1`)
+	}
+
+	for _, name := range order.InsertOrder {
+		table := tableMap[name]
+		for index, row := range f[name] {
+			if err := insertRow(ctx, tx, table, row); err != nil {
+				return &fixture.RowError{Table: name, Index: index, Constraint: constraint(err), Err: err}
+			}
+		}
+	}
+
+	if deferred {
+		for _, cycle := range order.Cycles {
+			for _, edge := range cycle.BreakEdges {
+				if err := checkForeignKey(ctx, tx, tableMap[edge.From], edge.Index, f[edge.From]); err != nil {
+					return err
+				}
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 1`); err != nil {
+			return fmt.Errorf(`fail to enable foreign key checks: %w`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`fail to commit fixture: %w`, err)
+	}
+	return nil
+}
+
+func insertRow(ctx context.Context, tx *sqlx.Tx, table schema.SchemaTable, row fixture.Row) error {
+	columns, values, err := rowValues(table, row)
+	if err != nil {
+		return err
+	}
+	stmt := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`,
+		quoteIdentifier(table.Name),
+		strings.Join(lo.Map(columns, func(column string, _ int) string { return quoteIdentifier(column) }), ", "),
+		strings.Join(lo.Map(columns, func(string, int) string { return "?" }), ", "))
+	if _, err := tx.ExecContext(ctx, stmt, values...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkForeignKey returns an error on the first row whose referencing key of the foreign key is not found in the referenced table.
+// Rows whose referencing keys are not specified or include NULL are not checked.
+func checkForeignKey(ctx context.Context, tx *sqlx.Tx, table schema.SchemaTable, foreignKeyIndex int, rows []fixture.Row) error {
+	foreignKey := table.ForeignKeys[foreignKeyIndex]
+	stmt := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`,
+		quoteIdentifier(foreignKey.ReferencedTable),
+		strings.Join(lo.Map(foreignKey.ReferencedKey, func(column string, _ int) string { return quoteIdentifier(column) + " = ?" }), " AND "))
+	for index, row := range rows {
+		columns, values, err := rowValues(table, row)
+		if err != nil {
+			return &fixture.RowError{Table: table.Name, Index: index, Err: err}
+		}
+		valueMap := lo.SliceToMap(lo.Zip2(columns, values), func(t lo.Tuple2[string, any]) (string, any) { return t.A, t.B })
+		key := []any{}
+		for _, column := range foreignKey.ReferencingKey {
+			if value := valueMap[column]; value != nil {
+				key = append(key, value)
+			}
+		}
+		if len(key) < len(foreignKey.ReferencingKey) {
+			continue
+		}
+		var count int64
+		if err := tx.GetContext(ctx, &count, stmt, key...); err != nil {
+			return fmt.Errorf(`fail to check foreign key %s: %w`, foreignKey.Name, err)
+		}
+		if count == 0 {
+			return &fixture.RowError{
+				Table:      table.Name,
+				Index:      index,
+				Constraint: foreignKey.Name,
+				Err:        fmt.Errorf(`referenced row in %s is not found`, foreignKey.ReferencedTable),
+			}
+		}
+	}
+	return nil
+}
+
+func rowValues(table schema.SchemaTable, row fixture.Row) (columns []string, values []any, err error) {
+	columns, err = fixture.Columns(lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) string { return column.Name }), row)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, column := range table.Columns {
+		if !lo.Contains(columns, column.Name) {
+			continue
+		}
+		value, err := ConvertValue(column, row[column.Name])
+		if err != nil {
+			return nil, nil, fmt.Errorf(`fail to convert value of %s: %w`, column.Name, err)
+		}
+		values = append(values, value)
+	}
+	return columns, values, nil
+}
+
+// constraint returns the kind of the constraint violated by the error if available.
+func constraint(err error) string {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return ""
+	}
+	switch mysqlErr.Number {
+	case 1062:
+		return "UNIQUE constraint"
+	case 1048:
+		return "NOT NULL constraint"
+	case 1216, 1452:
+		return "FOREIGN KEY constraint"
+	case 3819:
+		return "CHECK constraint"
+	default:
+		return ""
+	}
+}
+
+// ConvertValue converts the value parsed from a fixture into a value to be inserted into the column according to the column type.
+func ConvertValue(column schema.SchemaColumn, value any) (any, error) {
+	return fixture.ConvertValue(schema.ConvertType(column.Type), value)
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
@@ -0,0 +1,39 @@
+package fixture_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/mysql/fixture"
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		columnType string
+		in         any
+		want       any
+	}{
+		{columnType: "bigint", in: json.Number("1"), want: int64(1)},
+		{columnType: "tinyint(1)", in: true, want: true},
+		{columnType: "double", in: json.Number("0.5"), want: float64(0.5)},
+		{columnType: "decimal(10,2)", in: json.Number("12.50"), want: "12.50"},
+		{columnType: "varchar(255)", in: "abc", want: "abc"},
+		{columnType: "varbinary(16)", in: "AQI=", want: []byte{1, 2}},
+		{columnType: "date", in: "2024-01-02", want: "2024-01-02"},
+		{columnType: "datetime(6)", in: "2024-01-02T03:04:05Z", want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{columnType: "json", in: []any{json.Number("1"), "x"}, want: `[1,"x"]`},
+		{columnType: "text", in: nil, want: nil},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.columnType), func(t *testing.T) {
+			got, err := fixture.ConvertValue(schema.SchemaColumn{Name: "C", Type: testcase.columnType}, testcase.in)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
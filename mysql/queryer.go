@@ -0,0 +1,5 @@
+package mysql
+
+import "github.com/jmoiron/sqlx"
+
+type Queryer sqlx.QueryerContext
@@ -0,0 +1,25 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func ScanRowsStruct[RowStruct any](itr *sqlx.Rows) ([]RowStruct, error) {
+	defer itr.Close()
+
+	rowsStruct := []RowStruct{}
+	for itr.Next() {
+		var row RowStruct
+		if err := itr.StructScan(&row); err != nil {
+			return nil, fmt.Errorf(`fail to scan row: %w`, err)
+		}
+
+		rowsStruct = append(rowsStruct, row)
+	}
+	if err := itr.Err(); err != nil {
+		return nil, fmt.Errorf(`fail to iterate rows: %w`, err)
+	}
+	return rowsStruct, nil
+}
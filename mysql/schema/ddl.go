@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// GenerateDDL returns statements to create the given tables.
+// Indexes are created by CREATE INDEX statements following the CREATE TABLE statement of each table.
+// Foreign keys are added by ALTER TABLE statements following all CREATE TABLE statements so that tables referencing each other can be created.
+func GenerateDDL(tables []SchemaTable) []string {
+	stmts := []string{}
+	for _, table := range tables {
+		stmts = append(stmts, CreateTableDDL(table))
+		for _, index := range table.Indexes {
+			stmts = append(stmts, CreateIndexDDL(table.Name, index))
+		}
+	}
+	for _, table := range tables {
+		for _, foreignKey := range table.ForeignKeys {
+			stmts = append(stmts, AddForeignKeyDDL(table.Name, foreignKey))
+		}
+	}
+	return stmts
+}
+
+// CreateTableDDL returns a CREATE TABLE statement with columns, primary key, unique keys, and CHECK constraints of the table.
+// Unique keys having the same names as indexes are not included since they are created as the indexes.
+func CreateTableDDL(table SchemaTable) string {
+	definitions := []string{}
+	for _, column := range table.Columns {
+		definitions = append(definitions, ColumnDefinition(column))
+	}
+	if len(table.PrimaryKey) > 0 {
+		definitions = append(definitions, fmt.Sprintf(`PRIMARY KEY (%s)`, quoteIdentifiers(table.PrimaryKey)))
+	}
+	for _, uniqueKey := range table.UniqueKeys {
+		if HasIndex(table, uniqueKey.Name) {
+			continue
+		}
+		definition := fmt.Sprintf(`UNIQUE (%s)`, quoteIdentifiers(uniqueKey.Key))
+		if uniqueKey.Name != "" {
+			definition = fmt.Sprintf(`CONSTRAINT %s %s`, quoteIdentifier(uniqueKey.Name), definition)
+		}
+		definitions = append(definitions, definition)
+	}
+	for _, check := range table.Checks {
+		definitions = append(definitions, CheckDefinition(check))
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", quoteIdentifier(table.Name), strings.Join(definitions, ",\n    "))
+}
+
+// ColumnDefinition returns the definition of the column with its type, nullability, default value, generation expression, and auto-increment attribute.
+// Default values other than literals and CURRENT_TIMESTAMP are enclosed in parentheses as expressions.
+func ColumnDefinition(column SchemaColumn) string {
+	definition := quoteIdentifier(column.Name) + " " + column.Type
+	if column.Generated != "" {
+		definition += fmt.Sprintf(" GENERATED ALWAYS AS (%s) %s", column.Generated, lo.Ternary(column.Stored, "STORED", "VIRTUAL"))
+	}
+	definition += lo.Ternary(column.Nullable, " NULL", " NOT NULL")
+	if column.Default != "" {
+		definition += " DEFAULT " + lo.Ternary(isLiteralDefault(column.Default), column.Default, "("+column.Default+")")
+	}
+	if column.AutoIncrement {
+		definition += " AUTO_INCREMENT"
+	}
+	return definition
+}
+
+func isLiteralDefault(value string) bool {
+	if strings.HasPrefix(value, "'") || strings.HasPrefix(strings.ToUpper(value), "CURRENT_TIMESTAMP") {
+		return true
+	}
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+// CheckDefinition returns a CHECK constraint definition used in CREATE TABLE statements.
+func CheckDefinition(check SchemaCheck) string {
+	definition := fmt.Sprintf(`CHECK (%s)`, check.Expression)
+	if check.Name != "" {
+		definition = fmt.Sprintf(`CONSTRAINT %s %s`, quoteIdentifier(check.Name), definition)
+	}
+	return definition
+}
+
+// CreateIndexDDL returns a CREATE INDEX statement for the index of the table.
+func CreateIndexDDL(table string, index SchemaIndex) string {
+	return fmt.Sprintf(`CREATE %sINDEX %s ON %s (%s)`,
+		lo.Ternary(index.Unique, "UNIQUE ", ""),
+		quoteIdentifier(index.Name),
+		quoteIdentifier(table),
+		quoteIndexKey(index.Key),
+	)
+}
+
+// HasIndex reports whether the table has the index of the name, which is compared case-insensitively.
+func HasIndex(table SchemaTable, name string) bool {
+	return lo.ContainsBy(table.Indexes, func(index SchemaIndex) bool { return strings.EqualFold(index.Name, name) })
+}
+
+// AddForeignKeyDDL returns an ALTER TABLE statement adding the foreign key to the table.
+func AddForeignKeyDDL(table string, foreignKey SchemaForeignKey) string {
+	stmt := fmt.Sprintf(`ALTER TABLE %s ADD %sFOREIGN KEY (%s) REFERENCES %s (%s)`,
+		quoteIdentifier(table),
+		lo.Ternary(foreignKey.Name == "", "", "CONSTRAINT "+quoteIdentifier(foreignKey.Name)+" "),
+		quoteIdentifiers(foreignKey.ReferencingKey),
+		quoteIdentifier(foreignKey.ReferencedTable),
+		quoteIdentifiers(foreignKey.ReferencedKey),
+	)
+	if foreignKey.OnDelete != "" {
+		stmt += " ON DELETE " + foreignKey.OnDelete
+	}
+	if foreignKey.OnUpdate != "" {
+		stmt += " ON UPDATE " + foreignKey.OnUpdate
+	}
+	return stmt
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+func quoteIndexKey(key []SchemaIndexKey) string {
+	return strings.Join(lo.Map(key, func(column SchemaIndexKey, _ int) string {
+		if column.Expression != "" {
+			return "(" + column.Expression + ")" + lo.Ternary(column.Desc, " DESC", "")
+		}
+		return quoteIdentifier(column.Name) + lo.Ternary(column.Desc, " DESC", "")
+	}), ", ")
+}
+
+func quoteIdentifiers(identifiers []string) string {
+	return strings.Join(lo.Map(identifiers, func(identifier string, _ int) string { return quoteIdentifier(identifier) }), ", ")
+}
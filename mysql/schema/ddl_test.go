@@ -0,0 +1,58 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/Jumpaku/gotaface/mysql/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDDL(t *testing.T) {
+	testcases := []struct {
+		ddl    string
+		tables []string
+	}{
+		{ddl: "ddl_00_all_types", tables: []string{"A"}},
+		{ddl: "ddl_02_foreign_keys", tables: []string{"C_1", "C_2", "C_3", "C_4", "C_5"}},
+		{ddl: "ddl_03_foreign_loop_1", tables: []string{"D_1"}},
+		{ddl: "ddl_04_foreign_loop_2", tables: []string{"E_1", "E_2"}},
+		{ddl: "ddl_05_foreign_loop_3", tables: []string{"F_1", "F_2", "F_3"}},
+		{ddl: "ddl_06_unique_keys_index", tables: []string{"G"}},
+		{ddl: "ddl_07_unique_keys_constraint", tables: []string{"H"}},
+		{ddl: "ddl_08_unique_keys_column", tables: []string{"I"}},
+		{ddl: "ddl_10_indexes", tables: []string{"L"}},
+		{ddl: "ddl_11_column_defaults", tables: []string{"M"}},
+		{ddl: "ddl_12_checks", tables: []string{"N"}},
+		{ddl: "ddl_13_foreign_key_actions", tables: []string{"O_1", "O_2"}},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now().Unix()
+
+			db, teardown := test.Setup(t, *test.DataSource, fmt.Sprintf("test_ddl_%03d_%d", number, now))
+			defer teardown()
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+			want := []schema.SchemaTable{}
+			for _, table := range testcase.tables {
+				got, err := schema.NewFetcher(db).Fetch(ctx, table)
+				assert.Nil(t, err)
+				want = append(want, got)
+			}
+
+			generatedDB, generatedTeardown := test.Setup(t, *test.DataSource, fmt.Sprintf("test_ddl_%03d_%d_generated", number, now))
+			defer generatedTeardown()
+			test.InitDDLs(t, generatedDB, schema.GenerateDDL(want))
+			for _, want := range want {
+				got, err := schema.NewFetcher(generatedDB).Fetch(ctx, want.Name)
+				assert.Nil(t, err)
+				assertEqualSchemaTable(t, want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Jumpaku/go-assert"
+	gf_mysql "github.com/Jumpaku/gotaface/mysql"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+)
+
+type SchemaColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	// Default is the default value, which is a quoted literal, a number, or an expression, and is empty if the column has no default value.
+	Default string `json:"default"`
+	// Generated is the generation expression, which is empty if the column is not a generated column.
+	Generated string `json:"generated"`
+	// Stored is true if the column is a stored generated column.
+	Stored        bool `json:"stored"`
+	AutoIncrement bool `json:"auto_increment"`
+}
+type SchemaForeignKey struct {
+	Name            string   `json:"name"`
+	ReferencedTable string   `json:"referenced_table"`
+	ReferencedKey   []string `json:"referenced_key"`
+	ReferencingKey  []string `json:"referencing_key"`
+	// OnDelete and OnUpdate are the referential actions CASCADE, SET NULL, SET DEFAULT, or RESTRICT, which are empty for NO ACTION.
+	OnDelete string `json:"on_delete"`
+	OnUpdate string `json:"on_update"`
+}
+
+// SchemaReference is a foreign key of a table referencing the table that has the reference.
+type SchemaReference struct {
+	Name             string   `json:"name"`
+	ReferencingTable string   `json:"referencing_table"`
+	ReferencingKey   []string `json:"referencing_key"`
+	ReferencedKey    []string `json:"referenced_key"`
+}
+type SchemaUniqueKey struct {
+	Name string   `json:"name"`
+	Key  []string `json:"key"`
+}
+type SchemaIndexKey struct {
+	// Name is the column of the key part, which is empty if the key part is an expression.
+	Name string `json:"name"`
+	Desc bool   `json:"desc"`
+	// Expression is the expression of the functional key part, which is empty if the key part is a column.
+	Expression string `json:"expression"`
+}
+type SchemaIndex struct {
+	Name   string           `json:"name"`
+	Unique bool             `json:"unique"`
+	Key    []SchemaIndexKey `json:"key"`
+}
+type SchemaCheck struct {
+	// Name is the name of the CHECK constraint, which is generated by MySQL such as table_chk_1 if the constraint is not named.
+	Name string `json:"name"`
+	// Expression is the condition of the CHECK constraint as shown by information_schema, in which identifiers are quoted.
+	Expression string `json:"expression"`
+}
+type SchemaTable struct {
+	Name        string             `json:"name"`
+	Columns     []SchemaColumn     `json:"columns"`
+	PrimaryKey  []string           `json:"primary_key"`
+	ForeignKeys []SchemaForeignKey `json:"foreign_key"`
+	UniqueKeys  []SchemaUniqueKey  `json:"unique_key"`
+	// Indexes are the B-tree and hash indexes other than the primary key, which include the indexes also fetched as unique keys.
+	Indexes []SchemaIndex `json:"index"`
+	Checks  []SchemaCheck `json:"check"`
+	// ReferencedBy are the foreign keys referencing the table, which are fetched only by fetchers configured with WithReferencedBy.
+	ReferencedBy []SchemaReference `json:"referenced_by"`
+}
+
+type fetcher struct {
+	queryer      sqlx.QueryerContext
+	referencedBy bool
+}
+
+// NewFetcher returns a fetcher of tables in the current database of the queryer, which is a database selected by the data source name.
+func NewFetcher(queryer gf_mysql.Queryer) fetcher {
+	return fetcher{queryer: queryer}
+}
+
+// WithReferencedBy returns a copy of the fetcher that also fetches foreign keys referencing the table into ReferencedBy.
+func (fetcher fetcher) WithReferencedBy() fetcher {
+	fetcher.referencedBy = true
+	return fetcher
+}
+
+var _ schema.Fetcher[SchemaTable] = fetcher{}
+var _ schema.Lister = fetcher{}
+
+func (fetcher fetcher) Fetch(ctx context.Context, table string) (SchemaTable, error) {
+	wrapError := func(err error) (SchemaTable, error) {
+		assert.Params(err != nil, "wrapped error must be not nil")
+		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: %w`, table, err)
+	}
+
+	isView, err := queryIsView(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+	if isView {
+		return wrapError(fmt.Errorf(`%s is a view`, table))
+	}
+
+	schemaTable := SchemaTable{Name: table}
+
+	schemaTable.Columns, err = queryColumns(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.PrimaryKey, err = queryPrimaryKey(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.ForeignKeys, err = queryForeignKeys(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.UniqueKeys, err = queryUniqueKeys(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.Indexes, err = queryIndexes(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.Checks, err = queryChecks(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if fetcher.referencedBy {
+		schemaTable.ReferencedBy, err = queryReferencedBy(ctx, fetcher.queryer, table)
+		if err != nil {
+			return wrapError(err)
+		}
+	}
+
+	return schemaTable, nil
+}
+
+func (fetcher fetcher) ListTables(ctx context.Context) ([]string, error) {
+	sql := `-- query user table names
+SELECT
+	TABLE_NAME AS Name
+FROM information_schema.TABLES
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'
+ORDER BY TABLE_NAME`
+	rows, err := fetcher.queryer.QueryxContext(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	type table struct {
+		Name string `db:"Name"`
+	}
+	tables, err := gf_mysql.ScanRowsStruct[table](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	return lo.Map(tables, func(it table, i int) string { return it.Name }), nil
+}
+
+func queryColumns(ctx context.Context, tx gf_mysql.Queryer, table string) ([]SchemaColumn, error) {
+	sql := `-- query column information
+SELECT
+	COLUMN_NAME AS Name,
+	COLUMN_TYPE AS Type,
+	IS_NULLABLE = 'YES' AS Nullable,
+	COLUMN_DEFAULT AS DefaultValue,
+	GENERATION_EXPRESSION AS Generated,
+	EXTRA AS Extra
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
+ORDER BY ORDINAL_POSITION`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get columns of %s: %w`, table, err)
+	}
+	type column struct {
+		Name         string  `db:"Name"`
+		Type         string  `db:"Type"`
+		Nullable     bool    `db:"Nullable"`
+		DefaultValue *string `db:"DefaultValue"`
+		Generated    string  `db:"Generated"`
+		Extra        string  `db:"Extra"`
+	}
+	columns, err := gf_mysql.ScanRowsStruct[column](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get columns of %s: %w`, table, err)
+	}
+
+	return lo.Map(columns, func(column column, index int) SchemaColumn {
+		extra := strings.ToUpper(column.Extra)
+		return SchemaColumn{
+			Name:          column.Name,
+			Type:          column.Type,
+			Nullable:      column.Nullable,
+			Default:       defaultExpression(column.DefaultValue, strings.Contains(extra, "DEFAULT_GENERATED")),
+			Generated:     column.Generated,
+			Stored:        strings.Contains(extra, "STORED GENERATED"),
+			AutoIncrement: strings.Contains(extra, "AUTO_INCREMENT"),
+		}
+	}), nil
+}
+
+// defaultExpression returns the default value as an SQL expression.
+// Literal default values other than numbers are quoted since information_schema shows them without quotes.
+func defaultExpression(defaultValue *string, expression bool) string {
+	switch {
+	case defaultValue == nil:
+		return ""
+	case expression:
+		return *defaultValue
+	}
+	if _, err := strconv.ParseFloat(*defaultValue, 64); err == nil {
+		return *defaultValue
+	}
+	return "'" + strings.ReplaceAll(*defaultValue, "'", "''") + "'"
+}
+
+func queryPrimaryKey(ctx context.Context, tx gf_mysql.Queryer, table string) ([]string, error) {
+	sql := `-- query primary key information
+SELECT
+	COLUMN_NAME AS Name
+FROM information_schema.KEY_COLUMN_USAGE
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY'
+ORDER BY ORDINAL_POSITION`
+	type key struct {
+		Name string `db:"Name"`
+	}
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
+	}
+	primaryKey, err := gf_mysql.ScanRowsStruct[key](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
+	}
+	return lo.Map(primaryKey, func(it key, i int) string { return it.Name }), nil
+}
+
+func queryForeignKeys(ctx context.Context, tx gf_mysql.Queryer, table string) ([]SchemaForeignKey, error) {
+	sql := `-- query foreign key information
+SELECT
+	kcu.CONSTRAINT_NAME AS Name,
+	kcu.REFERENCED_TABLE_NAME AS ReferencedTable,
+	kcu.COLUMN_NAME AS ReferencingKey,
+	kcu.REFERENCED_COLUMN_NAME AS ReferencedKey,
+	IF(rc.DELETE_RULE = 'NO ACTION', '', rc.DELETE_RULE) AS OnDelete,
+	IF(rc.UPDATE_RULE = 'NO ACTION', '', rc.UPDATE_RULE) AS OnUpdate
+FROM information_schema.KEY_COLUMN_USAGE AS kcu
+	JOIN information_schema.REFERENTIAL_CONSTRAINTS AS rc
+		ON rc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA
+			AND rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+			AND rc.TABLE_NAME = kcu.TABLE_NAME
+WHERE kcu.TABLE_SCHEMA = DATABASE() AND kcu.TABLE_NAME = ?
+ORDER BY kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys of %s: %w`, table, err)
+	}
+	type fkRow struct {
+		Name            string `db:"Name"`
+		ReferencedTable string `db:"ReferencedTable"`
+		ReferencingKey  string `db:"ReferencingKey"`
+		ReferencedKey   string `db:"ReferencedKey"`
+		OnDelete        string `db:"OnDelete"`
+		OnUpdate        string `db:"OnUpdate"`
+	}
+	fkRows, err := gf_mysql.ScanRowsStruct[fkRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys of %s: %w`, table, err)
+	}
+
+	group := lo.GroupBy(fkRows, func(fkRow fkRow) string { return fkRow.Name })
+	groupNames := lo.Keys(group)
+	slices.Sort(groupNames)
+
+	var foreignKeys []SchemaForeignKey
+	for _, name := range groupNames {
+		g := group[name]
+		foreignKeys = append(foreignKeys, SchemaForeignKey{
+			Name:            name,
+			ReferencedTable: g[0].ReferencedTable,
+			ReferencedKey:   lo.Map(g, func(fkRow fkRow, _ int) string { return fkRow.ReferencedKey }),
+			ReferencingKey:  lo.Map(g, func(fkRow fkRow, _ int) string { return fkRow.ReferencingKey }),
+			OnDelete:        g[0].OnDelete,
+			OnUpdate:        g[0].OnUpdate,
+		})
+	}
+
+	return foreignKeys, nil
+}
+
+func queryReferencedBy(ctx context.Context, tx gf_mysql.Queryer, table string) ([]SchemaReference, error) {
+	sql := `-- query foreign keys referencing the table
+SELECT
+	CONSTRAINT_NAME AS Name,
+	TABLE_NAME AS ReferencingTable,
+	COLUMN_NAME AS ReferencingKey,
+	REFERENCED_COLUMN_NAME AS ReferencedKey
+FROM information_schema.KEY_COLUMN_USAGE
+WHERE REFERENCED_TABLE_SCHEMA = DATABASE() AND TABLE_SCHEMA = DATABASE() AND REFERENCED_TABLE_NAME = ?
+ORDER BY TABLE_NAME, CONSTRAINT_NAME, ORDINAL_POSITION`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys referencing %s: %w`, table, err)
+	}
+	type referenceRow struct {
+		Name             string `db:"Name"`
+		ReferencingTable string `db:"ReferencingTable"`
+		ReferencingKey   string `db:"ReferencingKey"`
+		ReferencedKey    string `db:"ReferencedKey"`
+	}
+	referenceRows, err := gf_mysql.ScanRowsStruct[referenceRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys referencing %s: %w`, table, err)
+	}
+
+	return lo.Map(lo.PartitionBy(referenceRows, func(row referenceRow) lo.Tuple2[string, string] {
+		return lo.T2(row.ReferencingTable, row.Name)
+	}), func(g []referenceRow, _ int) SchemaReference {
+		return SchemaReference{
+			Name:             g[0].Name,
+			ReferencingTable: g[0].ReferencingTable,
+			ReferencingKey:   lo.Map(g, func(row referenceRow, _ int) string { return row.ReferencingKey }),
+			ReferencedKey:    lo.Map(g, func(row referenceRow, _ int) string { return row.ReferencedKey }),
+		}
+	}), nil
+}
+
+func queryUniqueKeys(ctx context.Context, tx gf_mysql.Queryer, table string) ([]SchemaUniqueKey, error) {
+	sql := `-- query unique key information
+SELECT
+	INDEX_NAME AS Name,
+	COLUMN_NAME AS ColumnName
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND NON_UNIQUE = 0 AND INDEX_NAME <> 'PRIMARY'
+ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get unique keys of %s: %w`, table, err)
+	}
+	type ukRow struct {
+		Name string `db:"Name"`
+		// ColumnName is NULL if the key part is an expression.
+		ColumnName *string `db:"ColumnName"`
+	}
+	ukRows, err := gf_mysql.ScanRowsStruct[ukRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get unique keys of %s: %w`, table, err)
+	}
+	group := lo.GroupBy(ukRows, func(ukRow ukRow) string { return ukRow.Name })
+	groupNames := lo.Keys(group)
+	slices.Sort(groupNames)
+
+	var uniqueKeys []SchemaUniqueKey
+	for _, name := range groupNames {
+		g := group[name]
+		if lo.SomeBy(g, func(ukRow ukRow) bool { return ukRow.ColumnName == nil }) {
+			// unique indexes on expressions are not keys consisting of columns
+			continue
+		}
+		uniqueKeys = append(uniqueKeys, SchemaUniqueKey{
+			Name: name,
+			Key:  lo.Map(g, func(ukRow ukRow, _ int) string { return *ukRow.ColumnName }),
+		})
+	}
+
+	return uniqueKeys, nil
+}
+
+func queryIndexes(ctx context.Context, tx gf_mysql.Queryer, table string) ([]SchemaIndex, error) {
+	sql := `-- query index information
+SELECT
+	INDEX_NAME AS Name,
+	NON_UNIQUE = 0 AS IsUnique,
+	COLUMN_NAME AS ColumnName,
+	IFNULL(EXPRESSION, '') AS Expression,
+	IFNULL(COLLATION, '') = 'D' AS IsDesc
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME <> 'PRIMARY' AND INDEX_TYPE IN ('BTREE', 'HASH')
+ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+	}
+	type indexRow struct {
+		Name     string `db:"Name"`
+		IsUnique bool   `db:"IsUnique"`
+		// ColumnName is NULL if the key part is an expression.
+		ColumnName *string `db:"ColumnName"`
+		Expression string  `db:"Expression"`
+		IsDesc     bool    `db:"IsDesc"`
+	}
+	indexRows, err := gf_mysql.ScanRowsStruct[indexRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+	}
+	group := lo.GroupBy(indexRows, func(indexRow indexRow) string { return indexRow.Name })
+	groupNames := lo.Keys(group)
+	slices.Sort(groupNames)
+
+	var indexes []SchemaIndex
+	for _, name := range groupNames {
+		g := group[name]
+		indexes = append(indexes, SchemaIndex{
+			Name:   name,
+			Unique: g[0].IsUnique,
+			Key: lo.Map(g, func(indexRow indexRow, _ int) SchemaIndexKey {
+				return SchemaIndexKey{Name: lo.FromPtr(indexRow.ColumnName), Desc: indexRow.IsDesc, Expression: indexRow.Expression}
+			}),
+		})
+	}
+
+	return indexes, nil
+}
+
+func queryChecks(ctx context.Context, tx gf_mysql.Queryer, table string) ([]SchemaCheck, error) {
+	sql := `-- query check constraint information
+SELECT
+	cc.CONSTRAINT_NAME AS Name,
+	cc.CHECK_CLAUSE AS Expression
+FROM information_schema.CHECK_CONSTRAINTS AS cc
+	JOIN information_schema.TABLE_CONSTRAINTS AS tc
+	ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE = 'CHECK'
+ORDER BY cc.CONSTRAINT_NAME`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get checks of %s: %w`, table, err)
+	}
+	type checkRow struct {
+		Name       string `db:"Name"`
+		Expression string `db:"Expression"`
+	}
+	checkRows, err := gf_mysql.ScanRowsStruct[checkRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get checks of %s: %w`, table, err)
+	}
+	return lo.Map(checkRows, func(checkRow checkRow, _ int) SchemaCheck {
+		return SchemaCheck{Name: checkRow.Name, Expression: checkRow.Expression}
+	}), nil
+}
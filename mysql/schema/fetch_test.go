@@ -0,0 +1,627 @@
+package schema_test
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/Jumpaku/gotaface/mysql/schema/testdata"
+	"github.com/Jumpaku/gotaface/mysql/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var ddls = map[string]string{
+	"ddl_00_all_types":              testdata.DDL00AllTypesSQL,
+	"ddl_02_foreign_keys":           testdata.DDL02ForeignKeysSQL,
+	"ddl_03_foreign_loop_1":         testdata.DDL03ForeignLoop1SQL,
+	"ddl_04_foreign_loop_2":         testdata.DDL04ForeignLoop2SQL,
+	"ddl_05_foreign_loop_3":         testdata.DDL05ForeignLoop3SQL,
+	"ddl_06_unique_keys_index":      testdata.DDL06UniqueKeysIndexSQL,
+	"ddl_07_unique_keys_constraint": testdata.DDL07UniqueKeysConstraintSQL,
+	"ddl_08_unique_keys_column":     testdata.DDL08UniqueKeysColumnSQL,
+	"ddl_10_indexes":                testdata.DDL10IndexesSQL,
+	"ddl_11_column_defaults":        testdata.DDL11ColumnDefaultsSQL,
+	"ddl_12_checks":                 testdata.DDL12ChecksSQL,
+	"ddl_13_foreign_key_actions":    testdata.DDL13ForeignKeyActionsSQL,
+	"ddl_14_views":                  testdata.DDL14ViewsSQL,
+}
+
+var fetcherTestcases = []struct {
+	ddl   string
+	table string
+	want  schema.SchemaTable
+}{
+	{
+		ddl:   "ddl_00_all_types",
+		table: "A",
+		want: schema.SchemaTable{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int", Nullable: false},
+				{Name: "Col_01", Type: "bigint", Nullable: true},
+				{Name: "Col_02", Type: "bigint", Nullable: false},
+				{Name: "Col_03", Type: "binary(50)", Nullable: true},
+				{Name: "Col_04", Type: "binary(50)", Nullable: false},
+				{Name: "Col_05", Type: "bit(50)", Nullable: true},
+				{Name: "Col_06", Type: "bit(50)", Nullable: false},
+				{Name: "Col_07", Type: "blob", Nullable: true},
+				{Name: "Col_08", Type: "blob", Nullable: false},
+				{Name: "Col_09", Type: "tinyint(1)", Nullable: true},
+				{Name: "Col_10", Type: "tinyint(1)", Nullable: false},
+				{Name: "Col_11", Type: "char(50)", Nullable: true},
+				{Name: "Col_12", Type: "char(50)", Nullable: false},
+				{Name: "Col_13", Type: "date", Nullable: true},
+				{Name: "Col_14", Type: "date", Nullable: false},
+				{Name: "Col_15", Type: "datetime", Nullable: true},
+				{Name: "Col_16", Type: "datetime", Nullable: false},
+				{Name: "Col_17", Type: "decimal(10,2)", Nullable: true},
+				{Name: "Col_18", Type: "decimal(10,2)", Nullable: false},
+				{Name: "Col_19", Type: "double", Nullable: true},
+				{Name: "Col_20", Type: "double", Nullable: false},
+				{Name: "Col_21", Type: "enum('a','b')", Nullable: true},
+				{Name: "Col_22", Type: "enum('a','b')", Nullable: false},
+				{Name: "Col_23", Type: "float", Nullable: true},
+				{Name: "Col_24", Type: "float", Nullable: false},
+				{Name: "Col_25", Type: "int", Nullable: true},
+				{Name: "Col_26", Type: "int", Nullable: false},
+				{Name: "Col_27", Type: "json", Nullable: true},
+				{Name: "Col_28", Type: "json", Nullable: false},
+				{Name: "Col_29", Type: "mediumint", Nullable: true},
+				{Name: "Col_30", Type: "mediumint", Nullable: false},
+				{Name: "Col_31", Type: "set('a','b')", Nullable: true},
+				{Name: "Col_32", Type: "set('a','b')", Nullable: false},
+				{Name: "Col_33", Type: "smallint", Nullable: true},
+				{Name: "Col_34", Type: "smallint", Nullable: false},
+				{Name: "Col_35", Type: "text", Nullable: true},
+				{Name: "Col_36", Type: "text", Nullable: false},
+				{Name: "Col_37", Type: "time", Nullable: true},
+				{Name: "Col_38", Type: "time", Nullable: false},
+				{Name: "Col_39", Type: "timestamp", Nullable: true},
+				{Name: "Col_40", Type: "timestamp", Nullable: false},
+				{Name: "Col_41", Type: "tinyint", Nullable: true},
+				{Name: "Col_42", Type: "tinyint", Nullable: false},
+				{Name: "Col_43", Type: "varbinary(50)", Nullable: true},
+				{Name: "Col_44", Type: "varbinary(50)", Nullable: false},
+				{Name: "Col_45", Type: "varchar(50)", Nullable: true},
+				{Name: "Col_46", Type: "varchar(50)", Nullable: false},
+				{Name: "Col_47", Type: "year", Nullable: true},
+				{Name: "Col_48", Type: "year", Nullable: false},
+				{Name: "Col_49", Type: "bigint unsigned", Nullable: true},
+				{Name: "Col_50", Type: "bigint unsigned", Nullable: false},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_1",
+		want: schema.SchemaTable{
+			Name: "C_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "int"},
+				{Name: "PK_12", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+		},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_2",
+		want: schema.SchemaTable{
+			Name: "C_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "int"},
+				{Name: "PK_22", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_2_1",
+					ReferencedTable: "C_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_3",
+		want: schema.SchemaTable{
+			Name: "C_3",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_31", Type: "int"},
+				{Name: "PK_32", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_31", "PK_32"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_3_2",
+					ReferencedTable: "C_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_31", "PK_32"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_4",
+		want: schema.SchemaTable{
+			Name: "C_4",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_41", Type: "int"},
+				{Name: "PK_42", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_41", "PK_42"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_4_2",
+					ReferencedTable: "C_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_41", "PK_42"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_5",
+		want: schema.SchemaTable{
+			Name: "C_5",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_51", Type: "int"},
+				{Name: "PK_52", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_51", "PK_52"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_5_4",
+					ReferencedTable: "C_4",
+					ReferencedKey:   []string{"PK_41", "PK_42"},
+					ReferencingKey:  []string{"PK_51", "PK_52"},
+				},
+				{
+					Name:            "FK_C_5_3",
+					ReferencedTable: "C_3",
+					ReferencedKey:   []string{"PK_31", "PK_32"},
+					ReferencingKey:  []string{"PK_51", "PK_52"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_03_foreign_loop_1",
+		table: "D_1",
+		want: schema.SchemaTable{
+			Name: "D_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "int"},
+				{Name: "PK_12", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_D_1_1",
+					ReferencedTable: "D_1",
+					ReferencedKey:   []string{"PK_12"},
+					ReferencingKey:  []string{"PK_11"},
+				},
+			},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "PK_12", Key: []string{"PK_12"}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_04_foreign_loop_2",
+		table: "E_1",
+		want: schema.SchemaTable{
+			Name: "E_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "int"},
+				{Name: "PK_12", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_E_1_2",
+					ReferencedTable: "E_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_11", "PK_12"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_04_foreign_loop_2",
+		table: "E_2",
+		want: schema.SchemaTable{
+			Name: "E_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "int"},
+				{Name: "PK_22", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_E_2_1",
+					ReferencedTable: "E_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_1",
+		want: schema.SchemaTable{
+			Name: "F_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "int"},
+				{Name: "PK_12", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_1_3",
+					ReferencedTable: "F_3",
+					ReferencedKey:   []string{"PK_31", "PK_32"},
+					ReferencingKey:  []string{"PK_11", "PK_12"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_2",
+		want: schema.SchemaTable{
+			Name: "F_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "int"},
+				{Name: "PK_22", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_2_1",
+					ReferencedTable: "F_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_3",
+		want: schema.SchemaTable{
+			Name: "F_3",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_31", Type: "int"},
+				{Name: "PK_32", Type: "int"},
+			},
+			PrimaryKey: []string{"PK_31", "PK_32"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_3_2",
+					ReferencedTable: "F_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_31", "PK_32"},
+				},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_06_unique_keys_index",
+		table: "G",
+		want: schema.SchemaTable{
+			Name: "G",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int"},
+				{Name: "C1", Type: "int"},
+				{Name: "C2", Type: "int"},
+				{Name: "C3", Type: "int"},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "UQ_G_C1", Key: []string{"C1"}},
+				{Name: "UQ_G_C1_C2", Key: []string{"C1", "C2"}},
+				{Name: "UQ_G_C1_C2_C3", Key: []string{"C1", "C2", "C3"}},
+				{Name: "UQ_G_C1_C3", Key: []string{"C1", "C3"}},
+				{Name: "UQ_G_C1_C3_C2", Key: []string{"C1", "C3", "C2"}},
+				{Name: "UQ_G_C2", Key: []string{"C2"}},
+				{Name: "UQ_G_C2_C1", Key: []string{"C2", "C1"}},
+				{Name: "UQ_G_C2_C1_C3", Key: []string{"C2", "C1", "C3"}},
+				{Name: "UQ_G_C2_C3", Key: []string{"C2", "C3"}},
+				{Name: "UQ_G_C2_C3_C1", Key: []string{"C2", "C3", "C1"}},
+				{Name: "UQ_G_C3", Key: []string{"C3"}},
+				{Name: "UQ_G_C3_C1", Key: []string{"C3", "C1"}},
+				{Name: "UQ_G_C3_C1_C2", Key: []string{"C3", "C1", "C2"}},
+				{Name: "UQ_G_C3_C2", Key: []string{"C3", "C2"}},
+				{Name: "UQ_G_C3_C2_C1", Key: []string{"C3", "C2", "C1"}},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "UQ_G_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "UQ_G_C1_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C2"}}},
+				{Name: "UQ_G_C1_C2_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C2"}, {Name: "C3"}}},
+				{Name: "UQ_G_C1_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C3"}}},
+				{Name: "UQ_G_C1_C3_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C3"}, {Name: "C2"}}},
+				{Name: "UQ_G_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}}},
+				{Name: "UQ_G_C2_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C1"}}},
+				{Name: "UQ_G_C2_C1_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C1"}, {Name: "C3"}}},
+				{Name: "UQ_G_C2_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C3"}}},
+				{Name: "UQ_G_C2_C3_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C3"}, {Name: "C1"}}},
+				{Name: "UQ_G_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}}},
+				{Name: "UQ_G_C3_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C1"}}},
+				{Name: "UQ_G_C3_C1_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C1"}, {Name: "C2"}}},
+				{Name: "UQ_G_C3_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C2"}}},
+				{Name: "UQ_G_C3_C2_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C2"}, {Name: "C1"}}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_07_unique_keys_constraint",
+		table: "H",
+		want: schema.SchemaTable{
+			Name: "H",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int"},
+				{Name: "C1", Type: "int"},
+				{Name: "C2", Type: "int"},
+				{Name: "C3", Type: "int"},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "UQ_H_C1", Key: []string{"C1"}},
+				{Name: "UQ_H_C1_C2", Key: []string{"C1", "C2"}},
+				{Name: "UQ_H_C1_C2_C3", Key: []string{"C1", "C2", "C3"}},
+				{Name: "UQ_H_C1_C3", Key: []string{"C1", "C3"}},
+				{Name: "UQ_H_C1_C3_C2", Key: []string{"C1", "C3", "C2"}},
+				{Name: "UQ_H_C2", Key: []string{"C2"}},
+				{Name: "UQ_H_C2_C1", Key: []string{"C2", "C1"}},
+				{Name: "UQ_H_C2_C1_C3", Key: []string{"C2", "C1", "C3"}},
+				{Name: "UQ_H_C2_C3", Key: []string{"C2", "C3"}},
+				{Name: "UQ_H_C2_C3_C1", Key: []string{"C2", "C3", "C1"}},
+				{Name: "UQ_H_C3", Key: []string{"C3"}},
+				{Name: "UQ_H_C3_C1", Key: []string{"C3", "C1"}},
+				{Name: "UQ_H_C3_C1_C2", Key: []string{"C3", "C1", "C2"}},
+				{Name: "UQ_H_C3_C2", Key: []string{"C3", "C2"}},
+				{Name: "UQ_H_C3_C2_C1", Key: []string{"C3", "C2", "C1"}},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "UQ_H_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "UQ_H_C1_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C2"}}},
+				{Name: "UQ_H_C1_C2_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C2"}, {Name: "C3"}}},
+				{Name: "UQ_H_C1_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C3"}}},
+				{Name: "UQ_H_C1_C3_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C3"}, {Name: "C2"}}},
+				{Name: "UQ_H_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}}},
+				{Name: "UQ_H_C2_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C1"}}},
+				{Name: "UQ_H_C2_C1_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C1"}, {Name: "C3"}}},
+				{Name: "UQ_H_C2_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C3"}}},
+				{Name: "UQ_H_C2_C3_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C3"}, {Name: "C1"}}},
+				{Name: "UQ_H_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}}},
+				{Name: "UQ_H_C3_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C1"}}},
+				{Name: "UQ_H_C3_C1_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C1"}, {Name: "C2"}}},
+				{Name: "UQ_H_C3_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C2"}}},
+				{Name: "UQ_H_C3_C2_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C2"}, {Name: "C1"}}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_08_unique_keys_column",
+		table: "I",
+		want: schema.SchemaTable{
+			Name: "I",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int"},
+				{Name: "C1", Type: "int"},
+				{Name: "C2", Type: "int"},
+				{Name: "C3", Type: "int"},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "C1", Key: []string{"C1"}},
+				{Name: "C2", Key: []string{"C2"}},
+				{Name: "C3", Key: []string{"C3"}},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}}},
+				{Name: "C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_10_indexes",
+		table: "L",
+		want: schema.SchemaTable{
+			Name: "L",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int"},
+				{Name: "C1", Type: "int"},
+				{Name: "C2", Type: "varchar(50)", Nullable: true},
+				{Name: "C3", Type: "double", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "UQ_L_C3", Key: []string{"C3"}},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_L_C1", Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "IDX_L_C2_C1", Key: []schema.SchemaIndexKey{{Name: "C2", Desc: true}, {Name: "C1"}}},
+				{Name: "IDX_L_Expression", Key: []schema.SchemaIndexKey{{Expression: "lower(`C2`)"}}},
+				{Name: "UQ_L_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3", Desc: true}}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_11_column_defaults",
+		table: "M",
+		want: schema.SchemaTable{
+			Name: "M",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int", AutoIncrement: true},
+				{Name: "C1", Type: "int", Default: "0"},
+				{Name: "C2", Type: "varchar(50)", Nullable: true, Default: "'abc'"},
+				{Name: "C3", Type: "timestamp", Nullable: true, Default: "CURRENT_TIMESTAMP"},
+				{Name: "C4", Type: "int", Nullable: true, Default: "(1 + 2)"},
+				{Name: "C5", Type: "int", Nullable: true, Generated: "(`C1` * 2)"},
+				{Name: "C6", Type: "varchar(50)", Nullable: true, Generated: "upper(`C2`)", Stored: true},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	},
+	{
+		ddl:   "ddl_12_checks",
+		table: "N",
+		want: schema.SchemaTable{
+			Name: "N",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int"},
+				{Name: "C1", Type: "int"},
+				{Name: "C2", Type: "int", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			Checks: []schema.SchemaCheck{
+				{Name: "CK_N_C1_C2", Expression: "(`C1` < `C2`)"},
+				{Name: "N_chk_1", Expression: "(`C1` >= 0)"},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_13_foreign_key_actions",
+		table: "O_2",
+		want: schema.SchemaTable{
+			Name: "O_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int"},
+				{Name: "R1", Type: "int", Nullable: true},
+				{Name: "R2", Type: "int", Nullable: true},
+				{Name: "R3", Type: "int", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_O_2_1", ReferencedTable: "O_1", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"R1"}, OnDelete: "CASCADE", OnUpdate: "RESTRICT"},
+				{Name: "FK_O_2_2", ReferencedTable: "O_1", ReferencedKey: []string{"C1"}, ReferencingKey: []string{"R2"}, OnDelete: "SET NULL"},
+				{Name: "O_2_ibfk_1", ReferencedTable: "O_1", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"R3"}, OnUpdate: "CASCADE"},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "FK_O_2_1", Key: []schema.SchemaIndexKey{{Name: "R1"}}},
+				{Name: "FK_O_2_2", Key: []schema.SchemaIndexKey{{Name: "R2"}}},
+				{Name: "R3", Key: []schema.SchemaIndexKey{{Name: "R3"}}},
+			},
+		},
+	},
+}
+
+func TestFetcher(t *testing.T) {
+	for number, testcase := range fetcherTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			now := time.Now().Unix()
+			dbName := fmt.Sprintf("test_fetcher_%03d_%d", number, now)
+			db, teardown := test.Setup(t, *test.DataSource, dbName)
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db)
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assertEqualSchemaTable(t, testcase.want, got)
+		})
+	}
+}
+
+var listTablesTestcases = []struct {
+	ddl  string
+	want []string
+}{
+	{ddl: "ddl_00_all_types", want: []string{"A"}},
+	{ddl: "ddl_02_foreign_keys", want: []string{"C_1", "C_2", "C_3", "C_4", "C_5"}},
+	{ddl: "ddl_03_foreign_loop_1", want: []string{"D_1"}},
+	{ddl: "ddl_04_foreign_loop_2", want: []string{"E_1", "E_2"}},
+	{ddl: "ddl_05_foreign_loop_3", want: []string{"F_1", "F_2", "F_3"}},
+	{ddl: "ddl_06_unique_keys_index", want: []string{"G"}},
+	{ddl: "ddl_07_unique_keys_constraint", want: []string{"H"}},
+	{ddl: "ddl_08_unique_keys_column", want: []string{"I"}},
+	{ddl: "ddl_10_indexes", want: []string{"L"}},
+	{ddl: "ddl_11_column_defaults", want: []string{"M"}},
+	{ddl: "ddl_12_checks", want: []string{"N"}},
+	{ddl: "ddl_13_foreign_key_actions", want: []string{"O_1", "O_2"}},
+	{ddl: "ddl_14_views", want: []string{"P_1", "P_2"}},
+}
+
+func TestListTables(t *testing.T) {
+	for number, testcase := range listTablesTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			now := time.Now().Unix()
+			dbName := fmt.Sprintf("test_lister_%03d_%d", number, now)
+			db, teardown := test.Setup(t, *test.DataSource, dbName)
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db)
+			got, err := sut.ListTables(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+var referencedByTestcases = []struct {
+	ddl   string
+	table string
+	want  []schema.SchemaReference
+}{
+	{ddl: "ddl_00_all_types", table: "A"},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_2",
+		want: []schema.SchemaReference{
+			{Name: "FK_C_3_2", ReferencingTable: "C_3", ReferencingKey: []string{"PK_31", "PK_32"}, ReferencedKey: []string{"PK_21", "PK_22"}},
+			{Name: "FK_C_4_2", ReferencingTable: "C_4", ReferencingKey: []string{"PK_41", "PK_42"}, ReferencedKey: []string{"PK_21", "PK_22"}},
+		},
+	},
+	{
+		ddl:   "ddl_03_foreign_loop_1",
+		table: "D_1",
+		want: []schema.SchemaReference{
+			{Name: "FK_D_1_1", ReferencingTable: "D_1", ReferencingKey: []string{"PK_11"}, ReferencedKey: []string{"PK_12"}},
+		},
+	},
+}
+
+func TestFetcher_WithReferencedBy(t *testing.T) {
+	for number, testcase := range referencedByTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			now := time.Now().Unix()
+			dbName := fmt.Sprintf("test_referenced_by_%03d_%d", number, now)
+			db, teardown := test.Setup(t, *test.DataSource, dbName)
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db).WithReferencedBy()
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got.ReferencedBy)
+		})
+	}
+}
+
+func assertEqualSchemaTable(t *testing.T, want schema.SchemaTable, got schema.SchemaTable) {
+	t.Helper()
+	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.PrimaryKey, got.PrimaryKey)
+	assert.Equal(t, want.Columns, got.Columns)
+	assert.ElementsMatch(t, want.ForeignKeys, got.ForeignKeys)
+	assert.ElementsMatch(t, want.UniqueKeys, got.UniqueKeys)
+	assert.ElementsMatch(t, want.Indexes, got.Indexes)
+	assert.Equal(t, want.Checks, got.Checks)
+}
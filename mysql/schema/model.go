@@ -0,0 +1,225 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+// ConvertTable converts the table into the dialect-neutral schema.
+func ConvertTable(table SchemaTable) schema.Table {
+	return schema.Table{
+		Name: table.Name,
+		Columns: lo.Map(table.Columns, func(column SchemaColumn, _ int) schema.Column {
+			return schema.Column{
+				Name:          column.Name,
+				Type:          ConvertType(column.Type),
+				NativeType:    column.Type,
+				Nullable:      column.Nullable,
+				Default:       column.Default,
+				Generated:     column.Generated,
+				Stored:        column.Stored,
+				AutoIncrement: column.AutoIncrement,
+			}
+		}),
+		PrimaryKey: table.PrimaryKey,
+		ForeignKeys: lo.Map(table.ForeignKeys, func(key SchemaForeignKey, _ int) schema.ForeignKey {
+			return schema.ForeignKey{
+				Name:            key.Name,
+				ReferencedTable: key.ReferencedTable,
+				ReferencedKey:   key.ReferencedKey,
+				ReferencingKey:  key.ReferencingKey,
+				OnDelete:        key.OnDelete,
+				OnUpdate:        key.OnUpdate,
+			}
+		}),
+		UniqueKeys: lo.Map(table.UniqueKeys, func(key SchemaUniqueKey, _ int) schema.UniqueKey {
+			return schema.UniqueKey{Name: key.Name, Key: key.Key}
+		}),
+		Indexes: lo.Map(table.Indexes, func(index SchemaIndex, _ int) schema.Index {
+			return schema.Index{
+				Name:   index.Name,
+				Unique: index.Unique,
+				Key: lo.Map(index.Key, func(key SchemaIndexKey, _ int) schema.IndexKey {
+					return schema.IndexKey{Name: key.Name, Desc: key.Desc, Expression: key.Expression}
+				}),
+			}
+		}),
+		Checks: lo.Map(table.Checks, func(check SchemaCheck, _ int) schema.Check {
+			return schema.Check{Name: check.Name, Expression: check.Expression}
+		}),
+	}
+}
+
+// ConvertType converts the column type shown by information_schema into the logical type, in which tinyint(1) is regarded as boolean.
+// Values of enum and set are regarded as strings.
+func ConvertType(columnType string) schema.Type {
+	columnType = strings.ToLower(columnType)
+	if columnType == "tinyint(1)" {
+		return schema.Type{Kind: schema.TypeKindBool}
+	}
+	typeName, rest, _ := strings.Cut(columnType, "(")
+	typeName, _, _ = strings.Cut(typeName, " ")
+	rest, _, _ = strings.Cut(rest, ")")
+	params := []int64{}
+	for _, param := range strings.Split(rest, ",") {
+		if value, err := strconv.ParseInt(strings.TrimSpace(param), 10, 64); err == nil {
+			params = append(params, value)
+		}
+	}
+	param := func(index int) int64 {
+		if index < len(params) {
+			return params[index]
+		}
+		return 0
+	}
+
+	switch typeName {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint", "year":
+		return schema.Type{Kind: schema.TypeKindInteger}
+	case "float", "double", "real":
+		return schema.Type{Kind: schema.TypeKindFloat}
+	case "decimal", "numeric":
+		return schema.Type{Kind: schema.TypeKindDecimal, Precision: param(0), Scale: param(1)}
+	case "char", "varchar":
+		return schema.Type{Kind: schema.TypeKindString, Length: param(0)}
+	case "tinytext", "text", "mediumtext", "longtext", "enum", "set":
+		return schema.Type{Kind: schema.TypeKindString}
+	case "binary", "varbinary":
+		return schema.Type{Kind: schema.TypeKindBytes, Length: param(0)}
+	case "tinyblob", "blob", "mediumblob", "longblob":
+		return schema.Type{Kind: schema.TypeKindBytes}
+	case "bool", "boolean":
+		return schema.Type{Kind: schema.TypeKindBool}
+	case "date":
+		return schema.Type{Kind: schema.TypeKindDate}
+	case "datetime", "timestamp":
+		return schema.Type{Kind: schema.TypeKindTimestamp}
+	case "json":
+		return schema.Type{Kind: schema.TypeKindJSON}
+	default:
+		return schema.Type{Kind: schema.TypeKindOther}
+	}
+}
+
+// ConvertFromTables converts the dialect-neutral tables into tables in MySQL with warnings on parts that cannot be converted.
+// Interleaved tables are converted into tables having foreign keys referencing their parents, and expressions are converted as written.
+func ConvertFromTables(tables []schema.Table) ([]SchemaTable, []schema.Warning) {
+	var warnings []schema.Warning
+	warn := func(table schema.Table, format string, args ...any) {
+		warnings = append(warnings, schema.Warning{Table: table.Name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	converted := []SchemaTable{}
+	for _, table := range tables {
+		if table.Schema != "" && table.Schema != "public" {
+			warn(table, "schema %s is ignored", table.Schema)
+		}
+		mysqlTable := SchemaTable{
+			Name:       table.Name,
+			PrimaryKey: table.PrimaryKey,
+		}
+		for _, column := range table.Columns {
+			columnType, ok := ConvertFromType(column.Type)
+			if !ok {
+				warn(table, "type %s of column %s is converted into %s", column.NativeType, column.Name, columnType)
+			}
+			mysqlColumn := SchemaColumn{
+				Name:      column.Name,
+				Type:      columnType,
+				Nullable:  column.Nullable,
+				Default:   column.Default,
+				Generated: column.Generated,
+				Stored:    column.Stored,
+			}
+			if column.AutoIncrement {
+				mysqlColumn.Default = ""
+				if column.Type.Kind == schema.TypeKindInteger {
+					mysqlColumn.AutoIncrement = true
+				} else {
+					warn(table, "auto-increment of column %s is ignored since the column is not an integer", column.Name)
+				}
+			}
+			mysqlTable.Columns = append(mysqlTable.Columns, mysqlColumn)
+		}
+		if table.Parent != "" {
+			if foreignKey, found := schema.ParentForeignKey(table, tables); found {
+				warn(table, "interleaving in %s is converted into a foreign key", table.Parent)
+				table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+			} else {
+				warn(table, "interleaving in %s is ignored", table.Parent)
+			}
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			mysqlTable.ForeignKeys = append(mysqlTable.ForeignKeys, SchemaForeignKey{
+				Name:            foreignKey.Name,
+				ReferencedTable: foreignKey.ReferencedTable,
+				ReferencedKey:   foreignKey.ReferencedKey,
+				ReferencingKey:  foreignKey.ReferencingKey,
+				OnDelete:        foreignKey.OnDelete,
+				OnUpdate:        foreignKey.OnUpdate,
+			})
+		}
+		for _, uniqueKey := range table.UniqueKeys {
+			mysqlTable.UniqueKeys = append(mysqlTable.UniqueKeys, SchemaUniqueKey{Name: uniqueKey.Name, Key: uniqueKey.Key})
+		}
+		for _, index := range table.Indexes {
+			if index.Predicate != "" {
+				warn(table, "predicate of partial index %s is ignored", index.Name)
+			}
+			mysqlTable.Indexes = append(mysqlTable.Indexes, SchemaIndex{
+				Name:   index.Name,
+				Unique: index.Unique,
+				Key: lo.Map(index.Key, func(key schema.IndexKey, _ int) SchemaIndexKey {
+					return SchemaIndexKey{Name: key.Name, Desc: key.Desc, Expression: key.Expression}
+				}),
+			})
+		}
+		for _, check := range table.Checks {
+			mysqlTable.Checks = append(mysqlTable.Checks, SchemaCheck{Name: check.Name, Expression: check.Expression})
+		}
+		converted = append(converted, mysqlTable)
+	}
+	return converted, warnings
+}
+
+// ConvertFromType returns the MySQL column type corresponding to the logical type.
+// It returns json and false for arrays, and longtext and false for the other types without counterparts.
+func ConvertFromType(t schema.Type) (string, bool) {
+	switch t.Kind {
+	case schema.TypeKindInteger:
+		return "bigint", true
+	case schema.TypeKindFloat:
+		return "double", true
+	case schema.TypeKindDecimal:
+		if t.Precision > 0 {
+			return fmt.Sprintf("decimal(%d,%d)", t.Precision, t.Scale), true
+		}
+		return "decimal(65,30)", true
+	case schema.TypeKindString:
+		if t.Length > 0 {
+			return fmt.Sprintf("varchar(%d)", t.Length), true
+		}
+		return "longtext", true
+	case schema.TypeKindBytes:
+		if t.Length > 0 {
+			return fmt.Sprintf("varbinary(%d)", t.Length), true
+		}
+		return "longblob", true
+	case schema.TypeKindBool:
+		return "tinyint(1)", true
+	case schema.TypeKindDate:
+		return "date", true
+	case schema.TypeKindTimestamp:
+		return "datetime(6)", true
+	case schema.TypeKindJSON:
+		return "json", true
+	case schema.TypeKindArray:
+		return "json", false
+	default:
+		return "longtext", false
+	}
+}
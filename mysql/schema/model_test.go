@@ -0,0 +1,105 @@
+package schema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertType(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want gf_schema.Type
+	}{
+		{in: "int", want: gf_schema.Type{Kind: gf_schema.TypeKindInteger}},
+		{in: "bigint unsigned", want: gf_schema.Type{Kind: gf_schema.TypeKindInteger}},
+		{in: "tinyint(1)", want: gf_schema.Type{Kind: gf_schema.TypeKindBool}},
+		{in: "double", want: gf_schema.Type{Kind: gf_schema.TypeKindFloat}},
+		{in: "decimal(10,2)", want: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 10, Scale: 2}},
+		{in: "varchar(50)", want: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 50}},
+		{in: "text", want: gf_schema.Type{Kind: gf_schema.TypeKindString}},
+		{in: "enum('a','b')", want: gf_schema.Type{Kind: gf_schema.TypeKindString}},
+		{in: "varbinary(50)", want: gf_schema.Type{Kind: gf_schema.TypeKindBytes, Length: 50}},
+		{in: "blob", want: gf_schema.Type{Kind: gf_schema.TypeKindBytes}},
+		{in: "date", want: gf_schema.Type{Kind: gf_schema.TypeKindDate}},
+		{in: "datetime", want: gf_schema.Type{Kind: gf_schema.TypeKindTimestamp}},
+		{in: "json", want: gf_schema.Type{Kind: gf_schema.TypeKindJSON}},
+		{in: "bit(50)", want: gf_schema.Type{Kind: gf_schema.TypeKindOther}},
+		{in: "time", want: gf_schema.Type{Kind: gf_schema.TypeKindOther}},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			got := schema.ConvertType(testcase.in)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestConvertFromType(t *testing.T) {
+	testcases := []struct {
+		in     gf_schema.Type
+		want   string
+		wantOk bool
+	}{
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, want: "bigint", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindBool}, want: "tinyint(1)", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}, want: "varchar(10)", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindString}, want: "longtext", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindBool}}, want: "json", wantOk: false},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			got, ok := schema.ConvertFromType(testcase.in)
+			assert.Equal(t, testcase.want, got)
+			assert.Equal(t, testcase.wantOk, ok)
+		})
+	}
+}
+
+func TestConvertTable(t *testing.T) {
+	table := schema.SchemaTable{
+		Name: "child",
+		Columns: []schema.SchemaColumn{
+			{Name: "id", Type: "bigint", AutoIncrement: true},
+			{Name: "parent_id", Type: "int"},
+			{Name: "code", Type: "varchar(10)", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []schema.SchemaForeignKey{
+			{Name: "fk_parent", ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"parent_id"}, OnDelete: "CASCADE"},
+		},
+		UniqueKeys: []schema.SchemaUniqueKey{{Name: "code", Key: []string{"code"}}},
+		Indexes: []schema.SchemaIndex{
+			{Name: "code", Unique: true, Key: []schema.SchemaIndexKey{{Name: "code"}}},
+			{Name: "fk_parent", Key: []schema.SchemaIndexKey{{Name: "parent_id"}}},
+		},
+		Checks: []schema.SchemaCheck{{Name: "child_chk_1", Expression: "(`id` > 0)"}},
+	}
+
+	got := schema.ConvertTable(table)
+
+	want := gf_schema.Table{
+		Name: "child",
+		Columns: []gf_schema.Column{
+			{Name: "id", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "bigint", AutoIncrement: true},
+			{Name: "parent_id", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "int"},
+			{Name: "code", Type: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}, NativeType: "varchar(10)", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []gf_schema.ForeignKey{
+			{Name: "fk_parent", ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"parent_id"}, OnDelete: "CASCADE"},
+		},
+		UniqueKeys: []gf_schema.UniqueKey{{Name: "code", Key: []string{"code"}}},
+		Indexes: []gf_schema.Index{
+			{Name: "code", Unique: true, Key: []gf_schema.IndexKey{{Name: "code"}}},
+			{Name: "fk_parent", Key: []gf_schema.IndexKey{{Name: "parent_id"}}},
+		},
+		Checks: []gf_schema.Check{{Name: "child_chk_1", Expression: "(`id` > 0)"}},
+	}
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,54 @@
+CREATE TABLE A (
+    PK     INT                NOT NULL,
+    Col_01 BIGINT,
+    Col_02 BIGINT             NOT NULL,
+    Col_03 BINARY(50),
+    Col_04 BINARY(50)         NOT NULL,
+    Col_05 BIT(50),
+    Col_06 BIT(50)            NOT NULL,
+    Col_07 BLOB,
+    Col_08 BLOB               NOT NULL,
+    Col_09 BOOLEAN,
+    Col_10 BOOLEAN            NOT NULL,
+    Col_11 CHAR(50),
+    Col_12 CHAR(50)           NOT NULL,
+    Col_13 DATE,
+    Col_14 DATE               NOT NULL,
+    Col_15 DATETIME,
+    Col_16 DATETIME           NOT NULL,
+    Col_17 DECIMAL(10, 2),
+    Col_18 DECIMAL(10, 2)     NOT NULL,
+    Col_19 DOUBLE,
+    Col_20 DOUBLE             NOT NULL,
+    Col_21 ENUM('a', 'b'),
+    Col_22 ENUM('a', 'b')     NOT NULL,
+    Col_23 FLOAT,
+    Col_24 FLOAT              NOT NULL,
+    Col_25 INT,
+    Col_26 INT                NOT NULL,
+    Col_27 JSON,
+    Col_28 JSON               NOT NULL,
+    Col_29 MEDIUMINT,
+    Col_30 MEDIUMINT          NOT NULL,
+    Col_31 SET('a', 'b'),
+    Col_32 SET('a', 'b')      NOT NULL,
+    Col_33 SMALLINT,
+    Col_34 SMALLINT           NOT NULL,
+    Col_35 TEXT,
+    Col_36 TEXT               NOT NULL,
+    Col_37 TIME,
+    Col_38 TIME               NOT NULL,
+    Col_39 TIMESTAMP          NULL,
+    Col_40 TIMESTAMP          NOT NULL,
+    Col_41 TINYINT,
+    Col_42 TINYINT            NOT NULL,
+    Col_43 VARBINARY(50),
+    Col_44 VARBINARY(50)      NOT NULL,
+    Col_45 VARCHAR(50),
+    Col_46 VARCHAR(50)        NOT NULL,
+    Col_47 YEAR,
+    Col_48 YEAR               NOT NULL,
+    Col_49 BIGINT UNSIGNED,
+    Col_50 BIGINT UNSIGNED    NOT NULL,
+    PRIMARY KEY (PK)
+);
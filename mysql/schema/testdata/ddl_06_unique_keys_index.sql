@@ -0,0 +1,23 @@
+CREATE TABLE G (
+    PK INT NOT NULL,
+    C1 INT NOT NULL,
+    C2 INT NOT NULL,
+    C3 INT NOT NULL,
+    PRIMARY KEY (PK)
+);
+
+CREATE UNIQUE INDEX UQ_G_C1 ON G (C1);
+CREATE UNIQUE INDEX UQ_G_C2 ON G (C2);
+CREATE UNIQUE INDEX UQ_G_C3 ON G (C3);
+CREATE UNIQUE INDEX UQ_G_C1_C2 ON G (C1, C2);
+CREATE UNIQUE INDEX UQ_G_C2_C1 ON G (C2, C1);
+CREATE UNIQUE INDEX UQ_G_C2_C3 ON G (C2, C3);
+CREATE UNIQUE INDEX UQ_G_C3_C2 ON G (C3, C2);
+CREATE UNIQUE INDEX UQ_G_C3_C1 ON G (C3, C1);
+CREATE UNIQUE INDEX UQ_G_C1_C3 ON G (C1, C3);
+CREATE UNIQUE INDEX UQ_G_C1_C2_C3 ON G (C1, C2, C3);
+CREATE UNIQUE INDEX UQ_G_C1_C3_C2 ON G (C1, C3, C2);
+CREATE UNIQUE INDEX UQ_G_C2_C3_C1 ON G (C2, C3, C1);
+CREATE UNIQUE INDEX UQ_G_C2_C1_C3 ON G (C2, C1, C3);
+CREATE UNIQUE INDEX UQ_G_C3_C1_C2 ON G (C3, C1, C2);
+CREATE UNIQUE INDEX UQ_G_C3_C2_C1 ON G (C3, C2, C1);
@@ -0,0 +1,24 @@
+
+CREATE TABLE H (
+    PK INT NOT NULL,
+    C1 INT NOT NULL,
+    C2 INT NOT NULL,
+    C3 INT NOT NULL,
+    CONSTRAINT UQ_H_C1 UNIQUE (C1),
+    CONSTRAINT UQ_H_C2 UNIQUE (C2),
+    CONSTRAINT UQ_H_C3 UNIQUE (C3),
+    CONSTRAINT UQ_H_C1_C2 UNIQUE (C1, C2),
+    CONSTRAINT UQ_H_C2_C1 UNIQUE (C2, C1),
+    CONSTRAINT UQ_H_C2_C3 UNIQUE (C2, C3),
+    CONSTRAINT UQ_H_C3_C2 UNIQUE (C3, C2),
+    CONSTRAINT UQ_H_C3_C1 UNIQUE (C3, C1),
+    CONSTRAINT UQ_H_C1_C3 UNIQUE (C1, C3),
+    CONSTRAINT UQ_H_C1_C2_C3 UNIQUE (C1, C2, C3),
+    CONSTRAINT UQ_H_C1_C3_C2 UNIQUE (C1, C3, C2),
+    CONSTRAINT UQ_H_C2_C3_C1 UNIQUE (C2, C3, C1),
+    CONSTRAINT UQ_H_C2_C1_C3 UNIQUE (C2, C1, C3),
+    CONSTRAINT UQ_H_C3_C1_C2 UNIQUE (C3, C1, C2),
+    CONSTRAINT UQ_H_C3_C2_C1 UNIQUE (C3, C2, C1),
+    PRIMARY KEY (PK)
+);
+
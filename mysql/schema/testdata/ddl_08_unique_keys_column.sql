@@ -0,0 +1,9 @@
+
+CREATE TABLE I (
+    PK INT NOT NULL,
+    C1 INT NOT NULL UNIQUE,
+    C2 INT NOT NULL UNIQUE,
+    C3 INT NOT NULL UNIQUE,
+    PRIMARY KEY (PK)
+);
+
@@ -0,0 +1,12 @@
+CREATE TABLE L (
+    PK INT NOT NULL,
+    C1 INT NOT NULL,
+    C2 VARCHAR(50),
+    C3 DOUBLE,
+    PRIMARY KEY (PK)
+);
+
+CREATE INDEX IDX_L_C1 ON L (C1);
+CREATE INDEX IDX_L_C2_C1 ON L (C2 DESC, C1 ASC);
+CREATE UNIQUE INDEX UQ_L_C3 ON L (C3 DESC);
+CREATE INDEX IDX_L_Expression ON L ((lower(C2)));
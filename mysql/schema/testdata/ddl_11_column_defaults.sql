@@ -0,0 +1,10 @@
+CREATE TABLE M (
+    PK INT NOT NULL AUTO_INCREMENT,
+    C1 INT NOT NULL DEFAULT 0,
+    C2 VARCHAR(50) DEFAULT 'abc',
+    C3 TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    C4 INT DEFAULT (1 + 2),
+    C5 INT GENERATED ALWAYS AS (C1 * 2) VIRTUAL,
+    C6 VARCHAR(50) AS (upper(C2)) STORED,
+    PRIMARY KEY (PK)
+);
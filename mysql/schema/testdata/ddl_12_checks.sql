@@ -0,0 +1,7 @@
+CREATE TABLE N (
+    PK INT NOT NULL,
+    C1 INT NOT NULL CHECK (C1 >= 0),
+    C2 INT,
+    PRIMARY KEY (PK),
+    CONSTRAINT CK_N_C1_C2 CHECK (C1 < C2)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE O_1 (
+    PK INT NOT NULL,
+    C1 INT NOT NULL,
+    PRIMARY KEY (PK),
+    UNIQUE (C1)
+);
+
+CREATE TABLE O_2 (
+    PK INT NOT NULL,
+    R1 INT,
+    R2 INT,
+    R3 INT,
+    PRIMARY KEY (PK),
+    CONSTRAINT FK_O_2_1 FOREIGN KEY (R1) REFERENCES O_1 (PK) ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT FK_O_2_2 FOREIGN KEY (R2) REFERENCES O_1 (C1) ON DELETE SET NULL,
+    FOREIGN KEY (R3) REFERENCES O_1 (PK) ON UPDATE CASCADE
+);
@@ -0,0 +1,20 @@
+CREATE TABLE P_1 (
+    PK INT NOT NULL,
+    C1 VARCHAR(20) NOT NULL,
+    PRIMARY KEY (PK)
+);
+
+CREATE TABLE P_2 (
+    PK INT NOT NULL,
+    R1 INT,
+    C2 DOUBLE,
+    PRIMARY KEY (PK),
+    FOREIGN KEY (R1) REFERENCES P_1 (PK)
+);
+
+CREATE SQL SECURITY INVOKER VIEW V_1 AS SELECT PK, C1 FROM P_1 WHERE C1 <> '';
+
+CREATE VIEW V_2 (K, Total) AS
+    SELECT v.PK, SUM(p.C2)
+    FROM V_1 AS v JOIN P_2 AS p ON v.PK = p.R1
+    GROUP BY v.PK;
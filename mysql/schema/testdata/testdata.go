@@ -0,0 +1,42 @@
+package testdata
+
+import _ "embed"
+
+//go:embed ddl_00_all_types.sql
+var DDL00AllTypesSQL string
+
+//go:embed ddl_02_foreign_keys.sql
+var DDL02ForeignKeysSQL string
+
+//go:embed ddl_03_foreign_loop_1.sql
+var DDL03ForeignLoop1SQL string
+
+//go:embed ddl_04_foreign_loop_2.sql
+var DDL04ForeignLoop2SQL string
+
+//go:embed ddl_05_foreign_loop_3.sql
+var DDL05ForeignLoop3SQL string
+
+//go:embed ddl_06_unique_keys_index.sql
+var DDL06UniqueKeysIndexSQL string
+
+//go:embed ddl_07_unique_keys_constraint.sql
+var DDL07UniqueKeysConstraintSQL string
+
+//go:embed ddl_08_unique_keys_column.sql
+var DDL08UniqueKeysColumnSQL string
+
+//go:embed ddl_10_indexes.sql
+var DDL10IndexesSQL string
+
+//go:embed ddl_11_column_defaults.sql
+var DDL11ColumnDefaultsSQL string
+
+//go:embed ddl_12_checks.sql
+var DDL12ChecksSQL string
+
+//go:embed ddl_13_foreign_key_actions.sql
+var DDL13ForeignKeyActionsSQL string
+
+//go:embed ddl_14_views.sql
+var DDL14ViewsSQL string
@@ -0,0 +1,141 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jumpaku/go-assert"
+	gf_mysql "github.com/Jumpaku/gotaface/mysql"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+type SchemaView struct {
+	Name string `json:"name"`
+	// Columns are the columns of the view, whose types and nullability are derived from the query by MySQL.
+	Columns []SchemaColumn `json:"columns"`
+	// Definition is the query of the view as shown by information_schema, in which identifiers are quoted and qualified.
+	Definition string `json:"definition"`
+	// SecurityInvoker reports whether the view is declared with SQL SECURITY INVOKER rather than SQL SECURITY DEFINER.
+	SecurityInvoker bool `json:"security_invoker"`
+	// Dependencies are the names of the tables and views referenced by the view in the current database.
+	Dependencies []string `json:"dependencies"`
+}
+
+var _ schema.ViewFetcher[SchemaView] = fetcher{}
+var _ schema.ViewLister = fetcher{}
+
+func (fetcher fetcher) FetchView(ctx context.Context, view string) (SchemaView, error) {
+	wrapError := func(err error) (SchemaView, error) {
+		assert.Params(err != nil, "wrapped error must be not nil")
+		return SchemaView{}, fmt.Errorf(`fail to fetch view %s: %w`, view, err)
+	}
+
+	schemaView, err := queryView(ctx, fetcher.queryer, view)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView.Columns, err = queryColumns(ctx, fetcher.queryer, view)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView.Dependencies, err = queryViewDependencies(ctx, fetcher.queryer, view)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return schemaView, nil
+}
+
+func (fetcher fetcher) ListViews(ctx context.Context) ([]string, error) {
+	sql := `-- query user view names
+SELECT
+	TABLE_NAME AS Name
+FROM information_schema.VIEWS
+WHERE TABLE_SCHEMA = DATABASE()
+ORDER BY TABLE_NAME`
+	rows, err := fetcher.queryer.QueryxContext(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list views: %w`, err)
+	}
+	type view struct {
+		Name string `db:"Name"`
+	}
+	views, err := gf_mysql.ScanRowsStruct[view](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list views: %w`, err)
+	}
+	return lo.Map(views, func(it view, i int) string { return it.Name }), nil
+}
+
+func queryIsView(ctx context.Context, tx gf_mysql.Queryer, name string) (bool, error) {
+	sql := `-- query whether the name is of a view
+SELECT
+	COUNT(*) > 0 AS IsView
+FROM information_schema.TABLES
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND TABLE_TYPE = 'VIEW'`
+	rows, err := tx.QueryxContext(ctx, sql, name)
+	if err != nil {
+		return false, fmt.Errorf(`fail to get type of %s: %w`, name, err)
+	}
+	type result struct {
+		IsView bool `db:"IsView"`
+	}
+	results, err := gf_mysql.ScanRowsStruct[result](rows)
+	if err != nil {
+		return false, fmt.Errorf(`fail to get type of %s: %w`, name, err)
+	}
+	return len(results) > 0 && results[0].IsView, nil
+}
+
+func queryView(ctx context.Context, tx gf_mysql.Queryer, view string) (SchemaView, error) {
+	sql := `-- query view definition
+SELECT
+	VIEW_DEFINITION AS Definition,
+	SECURITY_TYPE = 'INVOKER' AS SecurityInvoker
+FROM information_schema.VIEWS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`
+	rows, err := tx.QueryxContext(ctx, sql, view)
+	if err != nil {
+		return SchemaView{}, fmt.Errorf(`fail to get definition of %s: %w`, view, err)
+	}
+	type definition struct {
+		Definition      string `db:"Definition"`
+		SecurityInvoker bool   `db:"SecurityInvoker"`
+	}
+	definitions, err := gf_mysql.ScanRowsStruct[definition](rows)
+	if err != nil {
+		return SchemaView{}, fmt.Errorf(`fail to get definition of %s: %w`, view, err)
+	}
+	if len(definitions) == 0 {
+		return SchemaView{}, fmt.Errorf(`fail to get definition of %s: view not found`, view)
+	}
+	return SchemaView{
+		Name:            view,
+		Definition:      definitions[0].Definition,
+		SecurityInvoker: definitions[0].SecurityInvoker,
+	}, nil
+}
+
+func queryViewDependencies(ctx context.Context, tx gf_mysql.Queryer, view string) ([]string, error) {
+	sql := `-- query tables and views referenced by view
+SELECT DISTINCT
+	TABLE_NAME AS Name
+FROM information_schema.VIEW_TABLE_USAGE
+WHERE VIEW_SCHEMA = DATABASE() AND VIEW_NAME = ? AND TABLE_SCHEMA = DATABASE()
+ORDER BY TABLE_NAME`
+	rows, err := tx.QueryxContext(ctx, sql, view)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+	type dependency struct {
+		Name string `db:"Name"`
+	}
+	dependencies, err := gf_mysql.ScanRowsStruct[dependency](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+	return lo.Map(dependencies, func(it dependency, i int) string { return it.Name }), nil
+}
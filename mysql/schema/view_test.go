@@ -0,0 +1,88 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/mysql/schema"
+	"github.com/Jumpaku/gotaface/mysql/test"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var fetchViewTestcases = []struct {
+	ddl  string
+	view string
+	want schema.SchemaView
+}{
+	{
+		ddl:  "ddl_14_views",
+		view: "V_1",
+		want: schema.SchemaView{
+			Name: "V_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "int", Nullable: false},
+				{Name: "C1", Type: "varchar(20)", Nullable: false},
+			},
+			Definition:      "`P_1`",
+			SecurityInvoker: true,
+			Dependencies:    []string{"P_1"},
+		},
+	},
+	{
+		ddl:  "ddl_14_views",
+		view: "V_2",
+		want: schema.SchemaView{
+			Name: "V_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "K", Type: "int", Nullable: false},
+				{Name: "Total", Type: "double", Nullable: true},
+			},
+			Definition:   "`V_1`",
+			Dependencies: []string{"P_2", "V_1"},
+		},
+	},
+}
+
+func TestFetcher_FetchView(t *testing.T) {
+	for number, testcase := range fetchViewTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.view), func(t *testing.T) {
+			now := time.Now().Unix()
+			dbName := fmt.Sprintf("test_fetch_view_%03d_%d", number, now)
+			db, teardown := test.Setup(t, *test.DataSource, dbName)
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db)
+			got, err := sut.FetchView(context.Background(), testcase.view)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want.Name, got.Name)
+			assert.Equal(t, testcase.want.Columns, lo.Map(got.Columns, func(it schema.SchemaColumn, _ int) schema.SchemaColumn {
+				return schema.SchemaColumn{Name: it.Name, Type: it.Type, Nullable: it.Nullable}
+			}))
+			// the definition is normalized by MySQL, in which the referenced names are quoted
+			assert.Contains(t, got.Definition, testcase.want.Definition)
+			assert.Equal(t, testcase.want.SecurityInvoker, got.SecurityInvoker)
+			assert.Equal(t, testcase.want.Dependencies, got.Dependencies)
+
+			_, err = sut.Fetch(context.Background(), testcase.view)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestListViews(t *testing.T) {
+	now := time.Now().Unix()
+	db, teardown := test.Setup(t, *test.DataSource, fmt.Sprintf("test_list_views_%d", now))
+	defer teardown()
+
+	test.InitDDLs(t, db, []string{ddls["ddl_14_views"]})
+
+	sut := schema.NewFetcher(db)
+	got, err := sut.ListViews(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"V_1", "V_2"}, got)
+}
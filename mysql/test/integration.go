@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+var DataSource = flag.String("data-source", "", "data source name")
+
+func SkipWithoutDataSource(t *testing.T) {
+	if *DataSource == "" {
+		t.Skipf("flag %q is not specified", "data-source")
+	}
+}
+
+func Setup(t *testing.T, dataSource string, dbName string) (db *sqlx.DB, teardown func()) {
+	t.Helper()
+
+	SkipWithoutDataSource(t)
+	ctx := context.Background()
+
+	config, err := mysql.ParseDSN(dataSource)
+	if err != nil {
+		t.Fatalf(`fail to parse data source name: %v`, err)
+	}
+	{
+		admin, err := sqlx.Open("mysql", config.FormatDSN())
+		if err != nil {
+			t.Fatalf(`fail to create mysql admin client: %v`, err)
+		}
+		defer admin.Close()
+		_, err = admin.ExecContext(ctx, "CREATE DATABASE `"+dbName+"`")
+		if err != nil {
+			t.Fatalf(`fail to create database: %v`, err)
+		}
+	}
+
+	config.DBName = dbName
+	// test data consist of multiple statements
+	config.MultiStatements = true
+	db, err = sqlx.Open("mysql", config.FormatDSN())
+	if err != nil {
+		t.Fatalf(`fail to create mysql client: %v`, err)
+	}
+
+	teardown = func() {
+		db.ExecContext(context.Background(), "DROP DATABASE `"+dbName+"`")
+		db.Close()
+	}
+
+	return db, teardown
+}
+
+func InitDDLs(t *testing.T, db *sqlx.DB, ddls []string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	for _, stmt := range ddls {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf(`fail to execute ddl: %v`, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package clean
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/postgres/dependency"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/lo"
+)
+
+// Execer executes statements, which is implemented by *pgx.Conn, *pgxpool.Pool, and pgx.Tx.
+type Execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+type cleaner struct {
+	execer          Execer
+	keep            []string
+	restartIdentity bool
+	cascade         bool
+}
+
+// NewCleaner returns a cleaner that truncates tables in a TRUNCATE statement.
+func NewCleaner(execer Execer) cleaner {
+	return cleaner{execer: execer}
+}
+
+// WithKeep returns a cleaner that keeps rows of the tables, such as reference tables holding master data.
+// The tables are named by their qualified names, or by their names to keep the tables of the names in all the schemas.
+func (c cleaner) WithKeep(tables ...string) cleaner {
+	c.keep = append(slices.Clone(c.keep), tables...)
+	return c
+}
+
+// WithRestartIdentity returns a cleaner that also restarts sequences owned by columns of the truncated tables.
+func (c cleaner) WithRestartIdentity() cleaner {
+	c.restartIdentity = true
+	return c
+}
+
+// WithCascade returns a cleaner that also truncates tables referencing the truncated tables, which may include tables not given to Clean or kept tables.
+func (c cleaner) WithCascade() cleaner {
+	c.cascade = true
+	return c
+}
+
+// Clean truncates the tables except the kept tables, which are listed in delete order resolved from their foreign keys.
+// Tables referencing each other are truncated together in the statement.
+func (c cleaner) Clean(ctx context.Context, tables []schema.SchemaTable) error {
+	targets := lo.Reject(tables, func(table schema.SchemaTable, _ int) bool {
+		return lo.Contains(c.keep, table.Name) || lo.Contains(c.keep, table.QualifiedName())
+	})
+	if len(targets) == 0 {
+		return nil
+	}
+	order, err := gf_dependency.Resolve(dependency.NewGraph(targets))
+	if err != nil {
+		return fmt.Errorf(`fail to resolve delete order: %w`, err)
+	}
+
+	tableMap := lo.SliceToMap(targets, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.QualifiedName(), table })
+	stmt := "TRUNCATE TABLE " + strings.Join(lo.Map(order.DeleteOrder, func(name string, _ int) string {
+		table := tableMap[name]
+		if table.Schema != "" {
+			return quoteIdentifier(table.Schema) + "." + quoteIdentifier(table.Name)
+		}
+		return quoteIdentifier(table.Name)
+	}), ", ")
+	if c.restartIdentity {
+		stmt += " RESTART IDENTITY"
+	}
+	if c.cascade {
+		stmt += " CASCADE"
+	}
+	if _, err := c.execer.Exec(ctx, stmt); err != nil {
+		return fmt.Errorf(`fail to truncate tables: %w`, err)
+	}
+	return nil
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
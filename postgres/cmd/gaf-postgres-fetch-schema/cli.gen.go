@@ -11,24 +11,32 @@ type Func[Input any] func(subcommand []string, input Input, inputErr error) (err
 
 type CLI struct {
 	FUNC Func[CLI_Input]
+
+	Sub_Diff CLI_Diff
 }
 
 func (CLI) DESC_Simple() string {
-	return "gaf-postgres-fetch-schema (v0.0.2):\nFetches schema data from a table in a SQLite3 database.\n\nUsage:\n    $ gaf-postgres-fetch-schema [<option>|<argument>]... [-- [<argument>]...]\n\nOptions:\n    -format, -help, -input-txt-tpl, -output\n\nArguments:\n    <data_source> <target_tables>...\n\n"
+	return "gaf-postgres-fetch-schema (v0.0.2):\nFetches schema data from tables in a PostgreSQL database.\n\nUsage:\n    $ gaf-postgres-fetch-schema [<option>|<argument>]... [-- [<argument>]...]\n\nOptions:\n    -format, -go-package, -help, -input-txt-tpl, -output, -referenced-by, -search-path\n\nArguments:\n    <data_source> <target_tables>...\n\nSubcommands:\n    diff\n\n"
 }
 func (CLI) DESC_Detail() string {
-	return "gaf-postgres-fetch-schema (v0.0.2):\nFetches schema data from a table in a SQLite3 database.\n\nUsage:\n    $ gaf-postgres-fetch-schema [<option>|<argument>]... [-- [<argument>]...]\n\n\nOptions:\n    -format=<string>  (default=\"json\"):\n        Specifies output format:\n         * json: outputs in JSON format.\n         * txt.tpl: processes template text from stdin in form Go's text/template and outputs result. Available data in the template is described in https://github.com/Jumpaku/gotaface/blob/main/sqlite3/schema/fetch.go#L25\n\n    -help[=<boolean>], -h[=<boolean>]  (default=false):\n        Shows help.\n\n    -input-txt-tpl=<string>  (default=\"\"):\n        Specifies input template file. It can be used with -format=txt.tpl. The stdin is specified in default.\n\n    -output=<string>  (default=\"\"):\n        Specifies output path. The stdout is specified in default.\n\n\nArguments:\n    [0]  <data_source:string>\n        Specifies path to SQLite3 database file.\n\n    [1:] [<target_tables:string>]...\n        Specify target tables to be fetched schemas.\n\n"
+	return "gaf-postgres-fetch-schema (v0.0.2):\nFetches schema data from tables in a PostgreSQL database.\n\nUsage:\n    $ gaf-postgres-fetch-schema [<option>|<argument>]... [-- [<argument>]...]\n\n\nOptions:\n    -format=<string>  (default=\"json\"):\n        Specifies output format:\n         * json: outputs in JSON format.\n         * go: outputs Go source code that defines a struct for each of the fetched tables.\n         * sql: outputs CREATE TABLE statements of the fetched tables.\n         * mermaid: outputs an ER diagram of the fetched tables in Mermaid.\n         * plantuml: outputs an ER diagram of the fetched tables in PlantUML.\n         * dot: outputs an ER diagram of the fetched tables in DOT language of Graphviz.\n         * txt.tpl: processes template text from stdin in form Go's text/template and outputs result. Available data in the template is described in https://github.com/Jumpaku/gotaface/blob/main/postgres/schema/fetch.go#L29\n\n    -go-package=<string>  (default=\"model\"):\n        Specifies package name of the Go source code. It can be used with -format=go.\n\n    -help[=<boolean>], -h[=<boolean>]  (default=false):\n        Shows help.\n\n    -input-txt-tpl=<string>  (default=\"\"):\n        Specifies input template file. It can be used with -format=txt.tpl. The stdin is specified in default.\n\n    -output=<string>  (default=\"\"):\n        Specifies output path. The stdout is specified in default.\n\n    -referenced-by[=<boolean>]  (default=false):\n        Fetches foreign keys referencing each of the fetched tables into referenced_by.\n\n    -search-path=<string>  (default=\"\"):\n        Specifies comma-separated schemas in which unqualified table names are resolved in order. The search_path of the session is used in default.\n\n\nArguments:\n    [0]  <data_source:string>\n        Specifies connection string of PostgreSQL database.\n\n    [1:] [<target_tables:string>]...\n        Specify target tables to be fetched schemas, whose names can be qualified by schemas such as schema.table. All tables are fetched if omitted.\n\n\nSubcommands:\n    diff:\n        Compares two schema snapshots, which are outputs of this command in JSON format, and outputs the differences.\n\n"
 }
 
 type CLI_Input struct {
 	Opt_Format string
 
+	Opt_GoPackage string
+
 	Opt_Help bool
 
 	Opt_InputTxtTpl string
 
 	Opt_Output string
 
+	Opt_ReferencedBy bool
+
+	Opt_SearchPath string
+
 	Arg_DataSource string
 
 	Arg_TargetTables []string
@@ -39,11 +47,17 @@ func resolve_CLI_Input(input *CLI_Input, restArgs []string) error {
 
 		Opt_Format: "json",
 
+		Opt_GoPackage: "model",
+
 		Opt_Help: false,
 
 		Opt_InputTxtTpl: "",
 
 		Opt_Output: "",
+
+		Opt_ReferencedBy: false,
+
+		Opt_SearchPath: "",
 	}
 
 	var arguments []string
@@ -72,6 +86,15 @@ func resolve_CLI_Input(input *CLI_Input, restArgs []string) error {
 				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
 			}
 
+		case "-go-package":
+			if !cut {
+				return fmt.Errorf("value is not specified to option %q", optName)
+
+			}
+			if err := parseValue(&input.Opt_GoPackage, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
 		case "-help", "-h":
 			if !cut {
 				lit = "true"
@@ -99,6 +122,24 @@ func resolve_CLI_Input(input *CLI_Input, restArgs []string) error {
 				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
 			}
 
+		case "-referenced-by":
+			if !cut {
+				lit = "true"
+
+			}
+			if err := parseValue(&input.Opt_ReferencedBy, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
+		case "-search-path":
+			if !cut {
+				return fmt.Errorf("value is not specified to option %q", optName)
+
+			}
+			if err := parseValue(&input.Opt_SearchPath, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
 		}
 	}
 
@@ -119,6 +160,107 @@ func resolve_CLI_Input(input *CLI_Input, restArgs []string) error {
 	return nil
 }
 
+type CLI_Diff struct {
+	FUNC Func[CLI_Diff_Input]
+}
+
+func (CLI_Diff) DESC_Simple() string {
+	return "gaf-postgres-fetch-schema diff:\nCompares two schema snapshots, which are outputs of this command in JSON format, and outputs the differences.\n\nUsage:\n    $ gaf-postgres-fetch-schema diff [<option>|<argument>]... [-- [<argument>]...]\n\nOptions:\n    -format, -help, -output\n\nArguments:\n    <before_snapshot> <after_snapshot>\n\n"
+}
+func (CLI_Diff) DESC_Detail() string {
+	return "gaf-postgres-fetch-schema diff:\nCompares two schema snapshots, which are outputs of this command in JSON format, and outputs the differences.\n\nUsage:\n    $ gaf-postgres-fetch-schema diff [<option>|<argument>]... [-- [<argument>]...]\n\n\nOptions:\n    -format=<string>  (default=\"json\"):\n        Specifies output format:\n         * json: outputs the differences in JSON format.\n         * sql: outputs statements to migrate the schema from the before snapshot to the after snapshot.\n\n    -help[=<boolean>], -h[=<boolean>]  (default=false):\n        Shows help.\n\n    -output=<string>  (default=\"\"):\n        Specifies output path. The stdout is specified in default.\n\n\nArguments:\n    [0]  <before_snapshot:string>\n        Specifies path to JSON file of the schema before migration.\n\n    [1]  <after_snapshot:string>\n        Specifies path to JSON file of the schema after migration.\n\n"
+}
+
+type CLI_Diff_Input struct {
+	Opt_Format string
+
+	Opt_Help bool
+
+	Opt_Output string
+
+	Arg_BeforeSnapshot string
+
+	Arg_AfterSnapshot string
+}
+
+func resolve_CLI_Diff_Input(input *CLI_Diff_Input, restArgs []string) error {
+	*input = CLI_Diff_Input{
+
+		Opt_Format: "json",
+
+		Opt_Help: false,
+
+		Opt_Output: "",
+	}
+
+	var arguments []string
+	for idx, arg := range restArgs {
+		if arg == "--" {
+			arguments = append(arguments, restArgs[idx+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			arguments = append(arguments, arg)
+			continue
+		}
+		optName, lit, cut := strings.Cut(arg, "=")
+		consumeVariables(optName, lit, cut)
+
+		switch optName {
+		default:
+			return fmt.Errorf("unknown option %q", optName)
+
+		case "-format":
+			if !cut {
+				return fmt.Errorf("value is not specified to option %q", optName)
+
+			}
+			if err := parseValue(&input.Opt_Format, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
+		case "-help", "-h":
+			if !cut {
+				lit = "true"
+
+			}
+			if err := parseValue(&input.Opt_Help, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
+		case "-output":
+			if !cut {
+				return fmt.Errorf("value is not specified to option %q", optName)
+
+			}
+			if err := parseValue(&input.Opt_Output, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
+		}
+	}
+
+	if len(arguments) <= 0 {
+		return fmt.Errorf("too few arguments")
+	}
+	if err := parseValue(&input.Arg_BeforeSnapshot, arguments[0]); err != nil {
+		return fmt.Errorf("value is not assignable to argument at [%d]", 0)
+	}
+
+	if len(arguments) <= 1 {
+		return fmt.Errorf("too few arguments")
+	}
+	if err := parseValue(&input.Arg_AfterSnapshot, arguments[1]); err != nil {
+		return fmt.Errorf("value is not assignable to argument at [%d]", 1)
+	}
+
+	if len(arguments) > 2 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	return nil
+}
+
 func NewCLI() CLI {
 	return CLI{}
 }
@@ -136,6 +278,15 @@ func Run(cli CLI, args []string) error {
 		err := resolve_CLI_Input(&input, restArgs)
 		return funcMethod(subcommandPath, input, err)
 
+	case "diff":
+		funcMethod := cli.Sub_Diff.FUNC
+		if funcMethod == nil {
+			return fmt.Errorf("%q is unsupported: cli.Sub_Diff.FUNC not assigned", "diff")
+		}
+		var input CLI_Diff_Input
+		err := resolve_CLI_Diff_Input(&input, restArgs)
+		return funcMethod(subcommandPath, input, err)
+
 	}
 	return nil
 }
@@ -146,6 +297,8 @@ func resolveSubcommand(args []string) (subcommandPath []string, restArgs []strin
 	}
 	subcommandSet := map[string]bool{
 		"": true,
+
+		"diff": true,
 	}
 
 	for _, arg := range args[1:] {
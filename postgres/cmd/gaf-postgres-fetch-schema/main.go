@@ -8,9 +8,14 @@ import (
 	"io"
 	"log"
 	"os"
+	"strings"
 	"text/template"
 
+	"github.com/Jumpaku/gotaface/erd"
+	"github.com/Jumpaku/gotaface/postgres/codegen"
 	"github.com/Jumpaku/gotaface/postgres/schema"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
 )
 
 //go:generate go run "github.com/Jumpaku/cyamli/cmd/cyamli@latest" golang -schema-path=cli.yaml -out-path=cli.gen.go
@@ -18,6 +23,7 @@ var cli = NewCLI()
 
 func main() {
 	cli.FUNC = fetch
+	cli.Sub_Diff.FUNC = diffSnapshots
 	if err := Run(cli, os.Args); err != nil {
 		log.Fatalf("%+v\n", err)
 	}
@@ -35,17 +41,31 @@ func fetch(subcommand []string, input CLI_Input, inputErr error) (err error) {
 	ctx := context.Background()
 	dbx, err := pgx.Connect(ctx, input.Arg_DataSource)
 	if err != nil {
-		return fmt.Errorf("fail to open SQLite3 database: %w", err)
+		return fmt.Errorf("fail to open PostgreSQL database: %w", err)
 	}
 	defer dbx.Close(ctx)
 
 	fetcher := schema.NewFetcher(dbx)
+	if input.Opt_SearchPath != "" {
+		fetcher = fetcher.WithSearchPath(strings.Split(input.Opt_SearchPath, ",")...)
+	}
+	if input.Opt_ReferencedBy {
+		fetcher = fetcher.WithReferencedBy()
+	}
+
+	targetTables := input.Arg_TargetTables
+	if len(targetTables) == 0 {
+		targetTables, err = fetcher.ListTables(ctx)
+		if err != nil {
+			return fmt.Errorf("fail to list tables in PostgreSQL database: %w", err)
+		}
+	}
 
 	schemas := []schema.SchemaTable{}
-	for _, targetTable := range input.Arg_TargetTables {
+	for _, targetTable := range targetTables {
 		result, err := fetcher.Fetch(ctx, targetTable)
 		if err != nil {
-			return fmt.Errorf("fail to fetch schema of %q in Spanner database: %w", targetTable, err)
+			return fmt.Errorf("fail to fetch schema of %q in PostgreSQL database: %w", targetTable, err)
 		}
 		schemas = append(schemas, result)
 	}
@@ -63,7 +83,7 @@ func fetch(subcommand []string, input CLI_Input, inputErr error) (err error) {
 
 	switch input.Opt_Format {
 	default:
-		return fmt.Errorf("invalid option value for format, which must be one of json, txt.tpl, sql")
+		return fmt.Errorf("invalid option value for format, which must be one of json, go, sql, mermaid, plantuml, dot, txt.tpl")
 	case "json":
 		encoder := json.NewEncoder(out)
 		for _, schema := range schemas {
@@ -71,6 +91,29 @@ func fetch(subcommand []string, input CLI_Input, inputErr error) (err error) {
 				return fmt.Errorf("fail to encode schema of %q into JSON: %w", schema.Name, err)
 			}
 		}
+	case "go":
+		src, err := codegen.Generate(input.Opt_GoPackage, schemas)
+		if err != nil {
+			return fmt.Errorf("fail to generate Go source code: %w", err)
+		}
+		if _, err := out.Write(src); err != nil {
+			return fmt.Errorf("fail to write Go source code: %w", err)
+		}
+	case "sql":
+		for _, stmt := range schema.GenerateDDL(schemas) {
+			if _, err := fmt.Fprintf(out, "%s;\n\n", stmt); err != nil {
+				return fmt.Errorf("fail to write DDL: %w", err)
+			}
+		}
+	case "mermaid", "plantuml", "dot":
+		tables := lo.Map(schemas, func(table schema.SchemaTable, _ int) gf_schema.Table { return schema.ConvertTable(table) })
+		diagram, err := erd.Generate(erd.Format(input.Opt_Format), tables)
+		if err != nil {
+			return fmt.Errorf("fail to generate ER diagram: %w", err)
+		}
+		if _, err := io.WriteString(out, diagram); err != nil {
+			return fmt.Errorf("fail to write ER diagram: %w", err)
+		}
 	case "txt.tpl":
 		var in io.Reader = os.Stdin
 		if input.Opt_InputTxtTpl != "" {
@@ -0,0 +1,86 @@
+package codegen
+
+import (
+	gf_codegen "github.com/Jumpaku/gotaface/codegen"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/samber/lo"
+)
+
+// Generate returns source code of a Go package that defines a struct for each table, whose fields are tagged with db and json.
+func Generate(packageName string, tables []schema.SchemaTable) ([]byte, error) {
+	imports := []string{}
+	codegenTables := lo.Map(tables, func(table schema.SchemaTable, _ int) gf_codegen.Table {
+		return gf_codegen.Table{
+			Name: table.Name,
+			Columns: lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) gf_codegen.Column {
+				goType, importPath := GoType(column)
+				if importPath != "" {
+					imports = append(imports, importPath)
+				}
+				return gf_codegen.Column{Name: column.Name, GoType: goType}
+			}),
+			PrimaryKey: table.PrimaryKey,
+			UniqueKeys: lo.Map(table.UniqueKeys, func(key schema.SchemaUniqueKey, _ int) []string { return key.Key }),
+			ForeignKeys: lo.Map(table.ForeignKeys, func(key schema.SchemaForeignKey, _ int) gf_codegen.ForeignKey {
+				return gf_codegen.ForeignKey{ReferencedTable: key.ReferencedTable, ReferencedKey: key.ReferencedKey, ReferencingKey: key.ReferencingKey}
+			}),
+		}
+	})
+
+	return gf_codegen.Generate(gf_codegen.Config{
+		Package: packageName,
+		Imports: imports,
+		Tags:    []string{"db", "json"},
+		KeyType: "[]any",
+	}, codegenTables)
+}
+
+// GoType returns the Go type of the field for the column and the import path of the package that the type requires.
+// Nullable columns are mapped to sql.Null* types, or to pointers if there are no corresponding sql.Null* types.
+// Arrays of built-in types are mapped to slices of the types of the elements, which are assumed to be not NULL.
+func GoType(column schema.SchemaColumn) (goType string, importPath string) {
+	nullable := func(notNullType, nullType, nullImportPath string) (string, string) {
+		if column.Nullable {
+			return nullType, nullImportPath
+		}
+		return notNullType, ""
+	}
+
+	switch column.Type {
+	case "integer":
+		return nullable("int32", "sql.NullInt32", "database/sql")
+	case "bigint":
+		return nullable("int64", "sql.NullInt64", "database/sql")
+	case "smallint":
+		return nullable("int16", "sql.NullInt16", "database/sql")
+	case "boolean":
+		return nullable("bool", "sql.NullBool", "database/sql")
+	case "real":
+		return nullable("float32", "*float32", "")
+	case "double precision":
+		return nullable("float64", "sql.NullFloat64", "database/sql")
+	case "numeric", "money", "character", "character varying", "text", "uuid", "xml", "bit", "bit varying", "interval",
+		"time without time zone", "time with time zone", "inet", "cidr", "macaddr", "macaddr8":
+		return nullable("string", "sql.NullString", "database/sql")
+	case "date", "timestamp without time zone", "timestamp with time zone":
+		if column.Nullable {
+			return "sql.NullTime", "database/sql"
+		}
+		return "time.Time", "time"
+	case "json", "jsonb":
+		return "json.RawMessage", "encoding/json"
+	case "bytea":
+		return "[]byte", ""
+	case "ARRAY":
+		if column.ElemType == "" || column.ElemType == "USER-DEFINED" {
+			return "any", ""
+		}
+		elemType, elemImportPath := GoType(schema.SchemaColumn{Type: column.ElemType})
+		if elemType == "any" {
+			return "any", ""
+		}
+		return "[]" + elemType, elemImportPath
+	default:
+		return "any", ""
+	}
+}
@@ -0,0 +1,70 @@
+package codegen_test
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/postgres/codegen"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoType(t *testing.T) {
+	testcases := []struct {
+		column         schema.SchemaColumn
+		wantGoType     string
+		wantImportPath string
+	}{
+		{column: schema.SchemaColumn{Type: "integer"}, wantGoType: "int32", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "integer", Nullable: true}, wantGoType: "sql.NullInt32", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "bigint"}, wantGoType: "int64", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "smallint", Nullable: true}, wantGoType: "sql.NullInt16", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "boolean"}, wantGoType: "bool", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "real", Nullable: true}, wantGoType: "*float32", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "double precision", Nullable: true}, wantGoType: "sql.NullFloat64", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "character varying"}, wantGoType: "string", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "numeric", Nullable: true}, wantGoType: "sql.NullString", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "timestamp with time zone"}, wantGoType: "time.Time", wantImportPath: "time"},
+		{column: schema.SchemaColumn{Type: "date", Nullable: true}, wantGoType: "sql.NullTime", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "jsonb", Nullable: true}, wantGoType: "json.RawMessage", wantImportPath: "encoding/json"},
+		{column: schema.SchemaColumn{Type: "bytea"}, wantGoType: "[]byte", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "USER-DEFINED"}, wantGoType: "any", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "ARRAY", ElemType: "text", Nullable: true}, wantGoType: "[]string", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "ARRAY", ElemType: "date"}, wantGoType: "[]time.Time", wantImportPath: "time"},
+		{column: schema.SchemaColumn{Type: "ARRAY", ElemType: "USER-DEFINED"}, wantGoType: "any", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "ARRAY"}, wantGoType: "any", wantImportPath: ""},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.column.Type), func(t *testing.T) {
+			gotGoType, gotImportPath := codegen.GoType(testcase.column)
+			assert.Equal(t, testcase.wantGoType, gotGoType)
+			assert.Equal(t, testcase.wantImportPath, gotImportPath)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tables := []schema.SchemaTable{
+		{
+			Name:       "t",
+			Columns:    []schema.SchemaColumn{{Name: "id", Type: "integer"}, {Name: "at", Type: "timestamp with time zone", Nullable: true}, {Name: "doc", Type: "jsonb"}},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	got, err := codegen.Generate("model", tables)
+	assert.Nil(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "model.go", got, parser.ImportsOnly)
+	assert.Nil(t, err)
+	assert.Equal(t, "model", file.Name.Name)
+	imports := []string{}
+	for _, spec := range file.Imports {
+		imports = append(imports, spec.Path.Value)
+	}
+	assert.Equal(t, []string{`"database/sql"`, `"encoding/json"`}, imports)
+	assert.Contains(t, string(got), "func (row T) PrimaryKey() []any {")
+}
@@ -0,0 +1,29 @@
+package dependency
+
+import (
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/samber/lo"
+)
+
+// NewGraph returns a dependency graph of the tables implied by their foreign keys, in which the tables are identified by their qualified names.
+func NewGraph(tables []schema.SchemaTable) gf_dependency.Graph {
+	graph := gf_dependency.Graph{}
+	for _, table := range tables {
+		graph.Tables = append(graph.Tables, table.QualifiedName())
+		nullable := lo.SliceToMap(table.Columns, func(column schema.SchemaColumn) (string, bool) {
+			return column.Name, column.Nullable
+		})
+		for index, foreignKey := range table.ForeignKeys {
+			graph.Edges = append(graph.Edges, gf_dependency.Edge{
+				From:     table.QualifiedName(),
+				To:       table.ReferencedQualifiedName(foreignKey),
+				Kind:     gf_dependency.EdgeKindForeignKey,
+				Name:     foreignKey.Name,
+				Index:    index,
+				Nullable: lo.EveryBy(foreignKey.ReferencingKey, func(column string) bool { return nullable[column] }),
+			})
+		}
+	}
+	return graph
+}
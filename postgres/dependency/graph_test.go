@@ -0,0 +1,50 @@
+package dependency_test
+
+import (
+	"testing"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/postgres/dependency"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGraph_Schemas(t *testing.T) {
+	tables := []schema.SchemaTable{
+		{
+			Name:    "T",
+			Schema:  "S_2",
+			Columns: []schema.SchemaColumn{{Name: "PK"}, {Name: "R", Nullable: true}},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_T_1", ReferencedTable: "S_1.T", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"R"}},
+			},
+		},
+		{
+			Name:    "U",
+			Schema:  "S_1",
+			Columns: []schema.SchemaColumn{{Name: "PK"}, {Name: "R"}},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_T", ReferencedTable: "T", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"R"}},
+			},
+		},
+		{
+			Name:    "T",
+			Schema:  "S_1",
+			Columns: []schema.SchemaColumn{{Name: "PK"}},
+		},
+	}
+
+	got := dependency.NewGraph(tables)
+	assert.Equal(t, gf_dependency.Graph{
+		Tables: []string{"S_2.T", "S_1.U", "S_1.T"},
+		Edges: []gf_dependency.Edge{
+			{From: "S_2.T", To: "S_1.T", Kind: gf_dependency.EdgeKindForeignKey, Name: "FK_T_1", Index: 0, Nullable: true},
+			{From: "S_1.U", To: "S_1.T", Kind: gf_dependency.EdgeKindForeignKey, Name: "FK_T", Index: 0, Nullable: false},
+		},
+	}, got)
+
+	order, err := gf_dependency.Resolve(got)
+	assert.Nil(t, err)
+	assert.Equal(t, "S_1.T", order.InsertOrder[0])
+	assert.Empty(t, order.Cycles)
+}
@@ -0,0 +1,346 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/samber/lo"
+)
+
+type ColumnDiff struct {
+	Name   string              `json:"name"`
+	Before schema.SchemaColumn `json:"before"`
+	After  schema.SchemaColumn `json:"after"`
+}
+
+type TableDiff struct {
+	Name               string                    `json:"name"`
+	Schema             string                    `json:"schema"`
+	Before             schema.SchemaTable        `json:"before"`
+	After              schema.SchemaTable        `json:"after"`
+	AddedColumns       []schema.SchemaColumn     `json:"added_columns"`
+	DroppedColumns     []schema.SchemaColumn     `json:"dropped_columns"`
+	ModifiedColumns    []ColumnDiff              `json:"modified_columns"`
+	PrimaryKeyChanged  bool                      `json:"primary_key_changed"`
+	AddedForeignKeys   []schema.SchemaForeignKey `json:"added_foreign_keys"`
+	DroppedForeignKeys []schema.SchemaForeignKey `json:"dropped_foreign_keys"`
+	AddedUniqueKeys    []schema.SchemaUniqueKey  `json:"added_unique_keys"`
+	DroppedUniqueKeys  []schema.SchemaUniqueKey  `json:"dropped_unique_keys"`
+	AddedIndexes       []schema.SchemaIndex      `json:"added_indexes"`
+	DroppedIndexes     []schema.SchemaIndex      `json:"dropped_indexes"`
+	AddedChecks        []schema.SchemaCheck      `json:"added_checks"`
+	DroppedChecks      []schema.SchemaCheck      `json:"dropped_checks"`
+}
+
+type Diff struct {
+	AddedTables    []schema.SchemaTable `json:"added_tables"`
+	DroppedTables  []schema.SchemaTable `json:"dropped_tables"`
+	ModifiedTables []TableDiff          `json:"modified_tables"`
+}
+
+// Compare returns differences to migrate tables from before to after.
+func Compare(before, after []schema.SchemaTable) Diff {
+	beforeTables := lo.SliceToMap(before, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.QualifiedName(), table })
+	afterTables := lo.SliceToMap(after, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.QualifiedName(), table })
+
+	diff := Diff{}
+	for _, table := range before {
+		if _, found := afterTables[table.QualifiedName()]; !found {
+			diff.DroppedTables = append(diff.DroppedTables, table)
+		}
+	}
+	for _, table := range after {
+		beforeTable, found := beforeTables[table.QualifiedName()]
+		if !found {
+			diff.AddedTables = append(diff.AddedTables, table)
+			continue
+		}
+		if tableDiff := compareTable(beforeTable, table); !tableDiff.IsEmpty() {
+			diff.ModifiedTables = append(diff.ModifiedTables, tableDiff)
+		}
+	}
+
+	return diff
+}
+
+func (diff Diff) IsEmpty() bool {
+	return len(diff.AddedTables) == 0 && len(diff.DroppedTables) == 0 && len(diff.ModifiedTables) == 0
+}
+
+func (diff TableDiff) IsEmpty() bool {
+	return len(diff.AddedColumns) == 0 &&
+		len(diff.DroppedColumns) == 0 &&
+		len(diff.ModifiedColumns) == 0 &&
+		!diff.PrimaryKeyChanged &&
+		len(diff.AddedForeignKeys) == 0 &&
+		len(diff.DroppedForeignKeys) == 0 &&
+		len(diff.AddedUniqueKeys) == 0 &&
+		len(diff.DroppedUniqueKeys) == 0 &&
+		len(diff.AddedIndexes) == 0 &&
+		len(diff.DroppedIndexes) == 0 &&
+		len(diff.AddedChecks) == 0 &&
+		len(diff.DroppedChecks) == 0
+}
+
+func compareTable(before, after schema.SchemaTable) TableDiff {
+	diff := TableDiff{Name: after.Name, Schema: after.Schema, Before: before, After: after}
+
+	beforeColumns := lo.SliceToMap(before.Columns, func(column schema.SchemaColumn) (string, schema.SchemaColumn) { return column.Name, column })
+	afterColumns := lo.SliceToMap(after.Columns, func(column schema.SchemaColumn) (string, schema.SchemaColumn) { return column.Name, column })
+	for _, column := range before.Columns {
+		if _, found := afterColumns[column.Name]; !found {
+			diff.DroppedColumns = append(diff.DroppedColumns, column)
+		}
+	}
+	for _, column := range after.Columns {
+		beforeColumn, found := beforeColumns[column.Name]
+		switch {
+		case !found:
+			diff.AddedColumns = append(diff.AddedColumns, column)
+		case beforeColumn != column:
+			diff.ModifiedColumns = append(diff.ModifiedColumns, ColumnDiff{Name: column.Name, Before: beforeColumn, After: column})
+		}
+	}
+
+	diff.PrimaryKeyChanged = !slices.Equal(before.PrimaryKey, after.PrimaryKey)
+
+	diff.DroppedForeignKeys = lo.Filter(before.ForeignKeys, func(foreignKey schema.SchemaForeignKey, _ int) bool {
+		return !lo.ContainsBy(after.ForeignKeys, func(it schema.SchemaForeignKey) bool { return equalForeignKey(it, foreignKey) })
+	})
+	diff.AddedForeignKeys = lo.Filter(after.ForeignKeys, func(foreignKey schema.SchemaForeignKey, _ int) bool {
+		return !lo.ContainsBy(before.ForeignKeys, func(it schema.SchemaForeignKey) bool { return equalForeignKey(it, foreignKey) })
+	})
+
+	diff.DroppedUniqueKeys = lo.Filter(before.UniqueKeys, func(uniqueKey schema.SchemaUniqueKey, _ int) bool {
+		return !lo.ContainsBy(after.UniqueKeys, func(it schema.SchemaUniqueKey) bool { return equalUniqueKey(it, uniqueKey) })
+	})
+	diff.AddedUniqueKeys = lo.Filter(after.UniqueKeys, func(uniqueKey schema.SchemaUniqueKey, _ int) bool {
+		return !lo.ContainsBy(before.UniqueKeys, func(it schema.SchemaUniqueKey) bool { return equalUniqueKey(it, uniqueKey) })
+	})
+
+	diff.DroppedIndexes = lo.Filter(before.Indexes, func(index schema.SchemaIndex, _ int) bool {
+		return !lo.ContainsBy(after.Indexes, func(it schema.SchemaIndex) bool { return equalIndex(it, index) })
+	})
+	diff.AddedIndexes = lo.Filter(after.Indexes, func(index schema.SchemaIndex, _ int) bool {
+		return !lo.ContainsBy(before.Indexes, func(it schema.SchemaIndex) bool { return equalIndex(it, index) })
+	})
+
+	diff.DroppedChecks = lo.Filter(before.Checks, func(check schema.SchemaCheck, _ int) bool {
+		return !lo.Contains(after.Checks, check)
+	})
+	diff.AddedChecks = lo.Filter(after.Checks, func(check schema.SchemaCheck, _ int) bool {
+		return !lo.Contains(before.Checks, check)
+	})
+
+	return diff
+}
+
+func equalForeignKey(a, b schema.SchemaForeignKey) bool {
+	return a.Name == b.Name &&
+		a.ReferencedTable == b.ReferencedTable &&
+		slices.Equal(a.ReferencedKey, b.ReferencedKey) &&
+		slices.Equal(a.ReferencingKey, b.ReferencingKey) &&
+		a.OnDelete == b.OnDelete &&
+		a.OnUpdate == b.OnUpdate &&
+		a.Match == b.Match &&
+		a.Deferrable == b.Deferrable &&
+		a.InitiallyDeferred == b.InitiallyDeferred
+}
+
+func equalUniqueKey(a, b schema.SchemaUniqueKey) bool {
+	return a.Name == b.Name && slices.Equal(a.Key, b.Key)
+}
+
+func equalIndex(a, b schema.SchemaIndex) bool {
+	return a.Name == b.Name &&
+		a.Unique == b.Unique &&
+		slices.Equal(a.Key, b.Key) &&
+		a.Method == b.Method &&
+		a.Predicate == b.Predicate
+}
+
+// MigrationDDL returns statements to migrate tables according to the diff.
+// Columns whose generation expressions are changed are dropped and added again, in which case their values are computed again.
+// Constraints and indexes are dropped before tables and columns are dropped, and they are added after tables and columns are added.
+// Unnamed constraints are identified by the default names that PostgreSQL assigns.
+func MigrationDDL(diff Diff) []string {
+	stmts := []string{}
+
+	for _, table := range diff.ModifiedTables {
+		for _, foreignKey := range table.DroppedForeignKeys {
+			stmts = append(stmts, dropConstraintDDL(table.Schema, table.Name, foreignKeyName(table.Name, foreignKey)))
+		}
+	}
+	for _, table := range diff.DroppedTables {
+		for _, foreignKey := range table.ForeignKeys {
+			stmts = append(stmts, dropConstraintDDL(table.Schema, table.Name, foreignKeyName(table.Name, foreignKey)))
+		}
+	}
+	for _, table := range diff.ModifiedTables {
+		for _, uniqueKey := range table.DroppedUniqueKeys {
+			stmts = append(stmts, dropConstraintDDL(table.Schema, table.Name, uniqueKeyName(table.Name, uniqueKey)))
+		}
+		if table.PrimaryKeyChanged && len(table.Before.PrimaryKey) > 0 {
+			stmts = append(stmts, dropConstraintDDL(table.Schema, table.Name, primaryKeyName(table.Before)))
+		}
+		for _, index := range table.DroppedIndexes {
+			stmts = append(stmts, fmt.Sprintf(`DROP INDEX %s`, quoteTableName(table.Schema, index.Name)))
+		}
+		for _, check := range table.DroppedChecks {
+			stmts = append(stmts, dropConstraintDDL(table.Schema, table.Name, check.Name))
+		}
+	}
+	for _, table := range diff.DroppedTables {
+		stmts = append(stmts, fmt.Sprintf(`DROP TABLE %s`, quoteTableName(table.Schema, table.Name)))
+	}
+	for _, table := range diff.ModifiedTables {
+		for _, column := range table.DroppedColumns {
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s DROP COLUMN %s`, quoteTableName(table.Schema, table.Name), quoteIdentifier(column.Name)))
+		}
+		for _, column := range table.ModifiedColumns {
+			if requiresRecreate(column) {
+				stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s DROP COLUMN %s`, quoteTableName(table.Schema, table.Name), quoteIdentifier(column.Name)))
+			}
+		}
+	}
+
+	for _, table := range diff.AddedTables {
+		stmts = append(stmts, schema.CreateTableDDL(table))
+	}
+	for _, table := range diff.ModifiedTables {
+		for _, column := range table.AddedColumns {
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s`, quoteTableName(table.Schema, table.Name), schema.ColumnDefinition(column)))
+		}
+		for _, column := range table.ModifiedColumns {
+			if requiresRecreate(column) {
+				stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s`, quoteTableName(table.Schema, table.Name), schema.ColumnDefinition(column.After)))
+				continue
+			}
+			for _, action := range alterColumnActions(column) {
+				stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s %s`, quoteTableName(table.Schema, table.Name), quoteIdentifier(column.Name), action))
+			}
+		}
+		if table.PrimaryKeyChanged && len(table.After.PrimaryKey) > 0 {
+			constraint := ""
+			if table.After.PrimaryKeyName != "" {
+				constraint = "CONSTRAINT " + quoteIdentifier(table.After.PrimaryKeyName) + " "
+			}
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD %sPRIMARY KEY (%s)`, quoteTableName(table.Schema, table.Name), constraint, quoteIdentifiers(table.After.PrimaryKey)))
+		}
+		for _, uniqueKey := range table.AddedUniqueKeys {
+			constraint := ""
+			if uniqueKey.Name != "" {
+				constraint = "CONSTRAINT " + quoteIdentifier(uniqueKey.Name) + " "
+			}
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD %sUNIQUE (%s)`, quoteTableName(table.Schema, table.Name), constraint, quoteIdentifiers(uniqueKey.Key)))
+		}
+		for _, check := range table.AddedChecks {
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD %s`, quoteTableName(table.Schema, table.Name), schema.CheckDefinition(check)))
+		}
+	}
+	for _, table := range diff.AddedTables {
+		for _, index := range table.Indexes {
+			stmts = append(stmts, schema.CreateIndexDDL(table.QualifiedName(), index))
+		}
+	}
+	for _, table := range diff.ModifiedTables {
+		for _, index := range table.AddedIndexes {
+			stmts = append(stmts, schema.CreateIndexDDL(table.After.QualifiedName(), index))
+		}
+	}
+	for _, table := range diff.AddedTables {
+		for _, foreignKey := range table.ForeignKeys {
+			stmts = append(stmts, schema.AddForeignKeyDDL(table.QualifiedName(), foreignKey))
+		}
+	}
+	for _, table := range diff.ModifiedTables {
+		for _, foreignKey := range table.AddedForeignKeys {
+			stmts = append(stmts, schema.AddForeignKeyDDL(table.After.QualifiedName(), foreignKey))
+		}
+	}
+
+	return stmts
+}
+
+func dropConstraintDDL(schemaName string, table string, constraint string) string {
+	return fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT %s`, quoteTableName(schemaName, table), quoteIdentifier(constraint))
+}
+
+// foreignKeyName returns the name of the foreign key, which is the one given by PostgreSQL if the foreign key is unnamed.
+func foreignKeyName(table string, foreignKey schema.SchemaForeignKey) string {
+	if foreignKey.Name != "" {
+		return foreignKey.Name
+	}
+	return table + "_" + strings.Join(foreignKey.ReferencingKey, "_") + "_fkey"
+}
+
+// primaryKeyName returns the name of the primary key of the table, which is the one given by PostgreSQL if the name is not fetched.
+func primaryKeyName(table schema.SchemaTable) string {
+	if table.PrimaryKeyName != "" {
+		return table.PrimaryKeyName
+	}
+	return table.Name + "_pkey"
+}
+
+// uniqueKeyName returns the name of the unique key, which is the one given by PostgreSQL if the name is not fetched.
+func uniqueKeyName(table string, uniqueKey schema.SchemaUniqueKey) string {
+	if uniqueKey.Name != "" {
+		return uniqueKey.Name
+	}
+	return table + "_" + strings.Join(uniqueKey.Key, "_") + "_key"
+}
+
+// requiresRecreate returns true if the column is dropped and added again since its generation expression is changed.
+func requiresRecreate(column ColumnDiff) bool {
+	return column.After.Generated != "" && (column.Before.Generated != column.After.Generated || column.Before.Stored != column.After.Stored)
+}
+
+// alterColumnActions returns actions of ALTER COLUMN to modify the column.
+// The default value is dropped before the column becomes an identity column, and set after the column stops being an identity column.
+func alterColumnActions(column ColumnDiff) []string {
+	actions := []string{}
+	if column.Before.Generated != "" && column.After.Generated == "" {
+		actions = append(actions, "DROP EXPRESSION")
+	}
+	if column.Before.Type != column.After.Type || column.Before.TypeName != column.After.TypeName {
+		actions = append(actions, "TYPE "+lo.Ternary(column.After.TypeName != "", column.After.TypeName, column.After.Type))
+	}
+	if column.Before.Nullable != column.After.Nullable {
+		actions = append(actions, lo.Ternary(column.After.Nullable, "DROP NOT NULL", "SET NOT NULL"))
+	}
+	if column.Before.Identity != "" && column.After.Identity == "" {
+		actions = append(actions, "DROP IDENTITY")
+	}
+	if column.Before.Default != column.After.Default {
+		if column.After.Default == "" {
+			actions = append(actions, "DROP DEFAULT")
+		} else {
+			actions = append(actions, "SET DEFAULT ("+column.After.Default+")")
+		}
+	}
+	if column.After.Identity != "" && column.Before.Identity != column.After.Identity {
+		if column.Before.Identity == "" {
+			actions = append(actions, "ADD GENERATED "+column.After.Identity+" AS IDENTITY")
+		} else {
+			actions = append(actions, "SET GENERATED "+column.After.Identity)
+		}
+	}
+	return actions
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func quoteTableName(schemaName string, table string) string {
+	if schemaName == "" {
+		return quoteIdentifier(table)
+	}
+	return quoteIdentifier(schemaName) + "." + quoteIdentifier(table)
+}
+
+func quoteIdentifiers(identifiers []string) string {
+	return strings.Join(lo.Map(identifiers, func(identifier string, _ int) string { return quoteIdentifier(identifier) }), ", ")
+}
@@ -0,0 +1,287 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/Jumpaku/gotaface/postgres/diff"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationDDL(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer"},
+				{Name: "C1", Type: "text", Nullable: true},
+				{Name: "C2", Type: "text"},
+			},
+			PrimaryKey:  []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{{ReferencedTable: "B", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"PK"}}},
+			UniqueKeys:  []schema.SchemaUniqueKey{{Key: []string{"C2"}}},
+		},
+		{
+			Name:       "B",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "integer"}},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer"},
+				{Name: "C1", Type: "character varying"},
+				{Name: "C3", Type: "bigint", Nullable: true},
+			},
+			PrimaryKey:  []string{"PK", "C1"},
+			ForeignKeys: []schema.SchemaForeignKey{{ReferencedTable: "C", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"C3"}}},
+			UniqueKeys:  []schema.SchemaUniqueKey{{Key: []string{"C1"}}},
+		},
+		{
+			Name:       "C",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "bigint"}},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+	want := []string{
+		`ALTER TABLE "A" DROP CONSTRAINT "A_PK_fkey"`,
+		`ALTER TABLE "A" DROP CONSTRAINT "A_C2_key"`,
+		`ALTER TABLE "A" DROP CONSTRAINT "A_pkey"`,
+		`DROP TABLE "B"`,
+		`ALTER TABLE "A" DROP COLUMN "C2"`,
+		"CREATE TABLE \"C\" (\n    \"PK\" bigint NOT NULL,\n    PRIMARY KEY (\"PK\")\n)",
+		`ALTER TABLE "A" ADD COLUMN "C3" bigint`,
+		`ALTER TABLE "A" ALTER COLUMN "C1" TYPE character varying`,
+		`ALTER TABLE "A" ALTER COLUMN "C1" SET NOT NULL`,
+		`ALTER TABLE "A" ADD PRIMARY KEY ("PK", "C1")`,
+		`ALTER TABLE "A" ADD UNIQUE ("C1")`,
+		`ALTER TABLE "A" ADD FOREIGN KEY ("C3") REFERENCES "C" ("PK")`,
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+
+	assert.Empty(t, diff.MigrationDDL(diff.Compare(after, after)))
+}
+
+func TestMigrationDDL_ConstraintNames(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name:           "A",
+			Columns:        []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "C", Type: "integer"}},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "PK_A",
+			UniqueKeys:     []schema.SchemaUniqueKey{{Name: "UQ_A", Key: []string{"C"}}},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name:           "A",
+			Columns:        []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "C", Type: "integer"}},
+			PrimaryKey:     []string{"PK", "C"},
+			PrimaryKeyName: "PK_A_C",
+			UniqueKeys:     []schema.SchemaUniqueKey{{Name: "A_C_key", Key: []string{"C"}}},
+		},
+	}
+	want := []string{
+		`ALTER TABLE "A" DROP CONSTRAINT "UQ_A"`,
+		`ALTER TABLE "A" DROP CONSTRAINT "PK_A"`,
+		`ALTER TABLE "A" ADD CONSTRAINT "PK_A_C" PRIMARY KEY ("PK", "C")`,
+		`ALTER TABLE "A" ADD CONSTRAINT "A_C_key" UNIQUE ("C")`,
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+}
+
+func TestMigrationDDL_Schemas(t *testing.T) {
+	before := []schema.SchemaTable{
+		{Name: "A", Schema: "s1", Columns: []schema.SchemaColumn{{Name: "PK", Type: "integer"}}},
+	}
+	after := []schema.SchemaTable{
+		{Name: "A", Schema: "s2", Columns: []schema.SchemaColumn{{Name: "PK", Type: "integer"}}, PrimaryKey: []string{"PK"}},
+		{
+			Name:        "B",
+			Schema:      "s2",
+			Columns:     []schema.SchemaColumn{{Name: "PK", Type: "integer"}},
+			ForeignKeys: []schema.SchemaForeignKey{{ReferencedTable: "A", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"PK"}}},
+		},
+	}
+	want := []string{
+		`DROP TABLE "s1"."A"`,
+		"CREATE TABLE \"s2\".\"A\" (\n    \"PK\" integer NOT NULL,\n    PRIMARY KEY (\"PK\")\n)",
+		"CREATE TABLE \"s2\".\"B\" (\n    \"PK\" integer NOT NULL\n)",
+		`ALTER TABLE "s2"."B" ADD FOREIGN KEY ("PK") REFERENCES "s2"."A" ("PK")`,
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+}
+
+func TestMigrationDDL_Indexes(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name:    "A",
+			Schema:  "s1",
+			Columns: []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "C1", Type: "text"}},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_A_C1", Key: []schema.SchemaIndexKey{{Name: "C1"}}, Method: "btree"},
+				{Name: "IDX_A_PK", Key: []schema.SchemaIndexKey{{Name: "PK"}}, Method: "btree"},
+			},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name:    "A",
+			Schema:  "s1",
+			Columns: []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "C1", Type: "text"}},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_A_C1", Key: []schema.SchemaIndexKey{{Name: "C1", Desc: true}, {Name: "PK"}}, Method: "btree", Predicate: `("C1" <> ''::text)`},
+				{Name: "IDX_A_PK", Key: []schema.SchemaIndexKey{{Name: "PK"}}, Method: "btree"},
+			},
+		},
+		{
+			Name:    "B",
+			Columns: []schema.SchemaColumn{{Name: "PK", Type: "integer"}},
+			Indexes: []schema.SchemaIndex{{Name: "UQ_B_PK", Unique: true, Key: []schema.SchemaIndexKey{{Name: "PK"}}, Method: "btree"}},
+		},
+	}
+	want := []string{
+		`DROP INDEX "s1"."IDX_A_C1"`,
+		"CREATE TABLE \"B\" (\n    \"PK\" integer NOT NULL\n)",
+		`CREATE UNIQUE INDEX "UQ_B_PK" ON "B" USING btree ("PK")`,
+		`CREATE INDEX "IDX_A_C1" ON "s1"."A" USING btree ("C1" DESC, "PK") WHERE ("C1" <> ''::text)`,
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+}
+
+func TestMigrationDDL_Columns(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", Identity: "ALWAYS"},
+				{Name: "C1", Type: "integer", Default: "0"},
+				{Name: "C2", Type: "integer", Nullable: true, Generated: `("C1" * 2)`, Stored: true},
+				{Name: "C3", Type: "integer", Nullable: true, Generated: `("C1" * 3)`, Stored: true},
+				{Name: "C4", Type: "integer", Default: `nextval('"A_C4_seq"'::regclass)`},
+				{Name: "C6", Type: "character varying", TypeName: "character varying(10)"},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", Identity: "BY DEFAULT"},
+				{Name: "C1", Type: "integer", Nullable: true},
+				{Name: "C2", Type: "integer", Nullable: true, Generated: `("C1" * 4)`, Stored: true},
+				{Name: "C3", Type: "integer", Nullable: true},
+				{Name: "C4", Type: "integer", Identity: "ALWAYS"},
+				{Name: "C5", Type: "bigint", Default: `nextval('"A_C5_seq"'::regclass)`},
+				{Name: "C6", Type: "character varying", TypeName: "character varying(20)"},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+	want := []string{
+		`ALTER TABLE "A" DROP COLUMN "C2"`,
+		`ALTER TABLE "A" ADD COLUMN "C5" bigserial NOT NULL`,
+		`ALTER TABLE "A" ALTER COLUMN "PK" SET GENERATED BY DEFAULT`,
+		`ALTER TABLE "A" ALTER COLUMN "C1" DROP NOT NULL`,
+		`ALTER TABLE "A" ALTER COLUMN "C1" DROP DEFAULT`,
+		`ALTER TABLE "A" ADD COLUMN "C2" integer GENERATED ALWAYS AS (("C1" * 4)) STORED`,
+		`ALTER TABLE "A" ALTER COLUMN "C3" DROP EXPRESSION`,
+		`ALTER TABLE "A" ALTER COLUMN "C4" DROP DEFAULT`,
+		`ALTER TABLE "A" ALTER COLUMN "C4" ADD GENERATED ALWAYS AS IDENTITY`,
+		`ALTER TABLE "A" ALTER COLUMN "C6" TYPE character varying(20)`,
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+}
+
+func TestMigrationDDL_Checks(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name:       "A",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "C1", Type: "integer", Nullable: true}},
+			PrimaryKey: []string{"PK"},
+			Checks: []schema.SchemaCheck{
+				{Name: "A_C1_check", Expression: `("C1" >= 0)`},
+				{Name: "CK_A", Expression: `("C1" < 10)`},
+			},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name:       "A",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "C1", Type: "integer", Nullable: true}},
+			PrimaryKey: []string{"PK"},
+			Checks: []schema.SchemaCheck{
+				{Name: "A_C1_check", Expression: `("C1" >= 0)`},
+				{Name: "CK_A", Expression: `("C1" < 100)`},
+				{Name: "CK_A_PK", Expression: `("PK" <> "C1")`},
+			},
+		},
+	}
+	want := []string{
+		`ALTER TABLE "A" DROP CONSTRAINT "CK_A"`,
+		`ALTER TABLE "A" ADD CONSTRAINT "CK_A" CHECK (("C1" < 100))`,
+		`ALTER TABLE "A" ADD CONSTRAINT "CK_A_PK" CHECK (("PK" <> "C1"))`,
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+}
+
+func TestMigrationDDL_ForeignKeys(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name:       "A",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "integer"}},
+			PrimaryKey: []string{"PK"},
+		},
+		{
+			Name:       "B",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "A", Type: "integer"}},
+			PrimaryKey: []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_B_A", ReferencedTable: "A", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"A"}},
+			},
+		},
+	}
+	after := []schema.SchemaTable{
+		before[0],
+		{
+			Name:       "B",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "A", Type: "integer"}},
+			PrimaryKey: []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:              "FK_B_A",
+					ReferencedTable:   "A",
+					ReferencedKey:     []string{"PK"},
+					ReferencingKey:    []string{"A"},
+					OnDelete:          "CASCADE",
+					OnUpdate:          "SET NULL",
+					Match:             "FULL",
+					Deferrable:        true,
+					InitiallyDeferred: true,
+				},
+			},
+		},
+	}
+	want := []string{
+		`ALTER TABLE "B" DROP CONSTRAINT "FK_B_A"`,
+		`ALTER TABLE "B" ADD CONSTRAINT "FK_B_A" FOREIGN KEY ("A") REFERENCES "A" ("PK") MATCH FULL ON DELETE CASCADE ON UPDATE SET NULL DEFERRABLE INITIALLY DEFERRED`,
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+}
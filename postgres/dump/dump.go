@@ -0,0 +1,158 @@
+package dump
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jumpaku/gotaface/dump"
+	gf_postgres "github.com/Jumpaku/gotaface/postgres"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+)
+
+type dumper struct {
+	queryer           gf_postgres.Queryer
+	where             string
+	limit             int64
+	orderByPrimaryKey bool
+}
+
+// NewDumper returns a dumper that dumps all rows of tables in arbitrary order.
+func NewDumper(queryer gf_postgres.Queryer) dumper {
+	return dumper{queryer: queryer}
+}
+
+// WithWhere returns a dumper that dumps only rows satisfying the condition.
+func (d dumper) WithWhere(condition string) dumper {
+	d.where = condition
+	return d
+}
+
+// WithLimit returns a dumper that dumps at most limit rows of each table.
+func (d dumper) WithLimit(limit int64) dumper {
+	d.limit = limit
+	return d
+}
+
+// WithOrderByPrimaryKey returns a dumper that dumps rows in order of the primary key, which makes outputs stable.
+func (d dumper) WithOrderByPrimaryKey() dumper {
+	d.orderByPrimaryKey = true
+	return d
+}
+
+// Dump writes rows of the table, whose values are typed according to the data types of the columns.
+func (d dumper) Dump(ctx context.Context, table schema.SchemaTable, writer dump.Writer) error {
+	columns := lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) string { return column.Name })
+	query := dump.Query{
+		Table:   quoteIdentifier(table.Name),
+		Columns: lo.Map(columns, func(column string, _ int) string { return quoteIdentifier(column) }),
+		Where:   d.where,
+		Limit:   d.limit,
+	}
+	if table.Schema != "" {
+		query.Table = quoteIdentifier(table.Schema) + "." + query.Table
+	}
+	if d.orderByPrimaryKey {
+		query.OrderBy = lo.Map(table.PrimaryKey, func(column string, _ int) string { return quoteIdentifier(column) })
+	}
+
+	rows, err := d.queryer.Query(ctx, query.String())
+	if err != nil {
+		return fmt.Errorf(`fail to query rows of %s: %w`, table.QualifiedName(), err)
+	}
+	defer rows.Close()
+
+	if err := writer.WriteHeader(columns); err != nil {
+		return fmt.Errorf(`fail to write header of %s: %w`, table.QualifiedName(), err)
+	}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return fmt.Errorf(`fail to scan row of %s: %w`, table.QualifiedName(), err)
+		}
+		for i, column := range table.Columns {
+			values[i] = ConvertValue(column, values[i])
+		}
+		if err := writer.WriteRow(values); err != nil {
+			return fmt.Errorf(`fail to write row of %s: %w`, table.QualifiedName(), err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf(`fail to iterate rows of %s: %w`, table.QualifiedName(), err)
+	}
+	return nil
+}
+
+// ConvertValue converts the value decoded by pgx from the column into a value accepted by dump.Writer.
+// Values of json and jsonb are re-encoded into JSON, and arrays are converted element-wise.
+// Values of types without counterparts in dump.Writer such as uuid and interval are converted into their text representations.
+func ConvertValue(column schema.SchemaColumn, value any) any {
+	if value == nil {
+		return nil
+	}
+	switch schema.ConvertType(column.Type).Kind {
+	case gf_schema.TypeKindDate:
+		if v, ok := value.(time.Time); ok {
+			return v.Format(time.DateOnly)
+		}
+	case gf_schema.TypeKindJSON:
+		if b, err := json.Marshal(value); err == nil {
+			return json.RawMessage(b)
+		}
+	}
+	return normalize(value)
+}
+
+func normalize(value any) any {
+	switch value := value.(type) {
+	case nil, bool, int64, float64, string, []byte, time.Time:
+		return value
+	case int16:
+		return int64(value)
+	case int32:
+		return int64(value)
+	case float32:
+		return float64(value)
+	case pgtype.Numeric:
+		v, err := value.Value()
+		if s, ok := v.(string); err == nil && ok {
+			if isNumber(s) {
+				return json.Number(s)
+			}
+			return s
+		}
+		return nil
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", value[0:4], value[4:6], value[6:8], value[8:10], value[10:16])
+	case []any:
+		return lo.Map(value, func(elem any, _ int) any { return normalize(elem) })
+	case driver.Valuer:
+		v, err := value.Value()
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		// driver.Value is one of nil, int64, float64, bool, []byte, string, and time.Time.
+		return v
+	case fmt.Stringer:
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// isNumber returns true if the string is a number literal in JSON.
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil && json.Valid([]byte(s))
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
@@ -0,0 +1,41 @@
+package dump_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/postgres/dump"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		columnType string
+		in         any
+		want       any
+	}{
+		{columnType: "integer", in: int32(1), want: int64(1)},
+		{columnType: "smallint", in: int16(2), want: int64(2)},
+		{columnType: "real", in: float32(0.5), want: float64(0.5)},
+		{columnType: "numeric", in: pgtype.Numeric{Int: big.NewInt(1250), Exp: -2, Valid: true}, want: json.Number("12.50")},
+		{columnType: "numeric", in: pgtype.Numeric{NaN: true, Valid: true}, want: "NaN"},
+		{columnType: "bytea", in: []byte{1, 2}, want: []byte{1, 2}},
+		{columnType: "date", in: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), want: "2024-01-02"},
+		{columnType: "jsonb", in: map[string]any{"k": []any{1.0, "x"}}, want: json.RawMessage(`{"k":[1,"x"]}`)},
+		{columnType: "uuid", in: [16]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}, want: "12345678-9abc-def0-1234-56789abcdef0"},
+		{columnType: "ARRAY", in: []any{int32(1), nil, pgtype.Numeric{Int: big.NewInt(5), Valid: true}}, want: []any{int64(1), nil, json.Number("5")}},
+		{columnType: "text", in: nil, want: nil},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.columnType), func(t *testing.T) {
+			got := dump.ConvertValue(schema.SchemaColumn{Name: "C", Type: testcase.columnType}, testcase.in)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/fixture"
+	"github.com/Jumpaku/gotaface/postgres/dependency"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+)
+
+// Beginner begins transactions, which is implemented by *pgx.Conn and *pgxpool.Pool.
+type Beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type loader struct {
+	beginner Beginner
+}
+
+// NewLoader returns a loader that inserts rows of fixtures in a transaction.
+func NewLoader(beginner Beginner) loader {
+	return loader{beginner: beginner}
+}
+
+// Load inserts rows of the fixture into the tables in insert order resolved from their foreign keys, in which all the rows are inserted or none of them are.
+// Rows of each table are inserted in a batch of INSERT statements.
+// Deferrable constraints are deferred until commit if the tables reference each other, whose violations are reported without the positions of rows.
+// Foreign keys in the reference cycles which are not deferrable must be nullable, whose columns are inserted as NULL and updated by the primary keys after all the rows are inserted.
+// The tables must include all the tables in the fixture, which are named by their qualified names or by their names if the names are unique among the tables.
+func (l loader) Load(ctx context.Context, tables []schema.SchemaTable, f fixture.Fixture) error {
+	// tables are identified by their qualified names, which are mapped to the names in the fixture
+	tableMap := map[string]schema.SchemaTable{}
+	fixtureNames := map[string]string{}
+	targets := []schema.SchemaTable{}
+	for _, name := range f.Tables() {
+		table, err := findTable(tables, name)
+		if err != nil {
+			return fmt.Errorf(`fail to load fixture: %w`, err)
+		}
+		if other, found := fixtureNames[table.QualifiedName()]; found {
+			return fmt.Errorf(`fail to load fixture: %q and %q are the same table`, other, name)
+		}
+		tableMap[table.QualifiedName()], fixtureNames[table.QualifiedName()] = table, name
+		targets = append(targets, table)
+	}
+	order, err := gf_dependency.Resolve(dependency.NewGraph(targets))
+	if err != nil {
+		return fmt.Errorf(`fail to resolve insert order: %w`, err)
+	}
+
+	postponed, err := postponedColumns(tableMap, order.Cycles)
+	if err != nil {
+		return fmt.Errorf(`fail to load fixture: %w`, err)
+	}
+
+	tx, err := l.beginner.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf(`fail to begin transaction: %w`, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if len(order.Cycles) > 0 {
+		if _, err := tx.Exec(ctx, `SET CONSTRAINTS ALL DEFERRED`); err != nil {
+			return fmt.Errorf(`fail to defer constraints: %w`, err)
+		}
+	}
+
+	for _, name := range order.InsertOrder {
+		if err := insertRows(ctx, tx, tableMap[name], f[fixtureNames[name]], postponed[name]); err != nil {
+			return err
+		}
+	}
+	for _, name := range order.InsertOrder {
+		if err := updateRows(ctx, tx, tableMap[name], f[fixtureNames[name]], postponed[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.TableName != "" {
+			return &fixture.RowError{Table: pgErr.TableName, Index: -1, Constraint: constraint(err), Err: err}
+		}
+		return fmt.Errorf(`fail to commit fixture: %w`, err)
+	}
+	return nil
+}
+
+// findTable returns the table whose qualified name is the name, or otherwise the only table whose name is the name.
+func findTable(tables []schema.SchemaTable, name string) (schema.SchemaTable, error) {
+	if table, found := lo.Find(tables, func(table schema.SchemaTable) bool { return table.QualifiedName() == name }); found {
+		return table, nil
+	}
+	found := lo.Filter(tables, func(table schema.SchemaTable, _ int) bool { return table.Name == name })
+	switch len(found) {
+	case 0:
+		return schema.SchemaTable{}, fmt.Errorf(`table %q is not found`, name)
+	case 1:
+		return found[0], nil
+	default:
+		return schema.SchemaTable{}, fmt.Errorf(`table %q is ambiguous among %v`, name, lo.Map(found, func(table schema.SchemaTable, _ int) string { return table.QualifiedName() }))
+	}
+}
+
+// postponedColumns returns the referencing columns of the foreign keys breaking the cycles which are not deferrable for each table.
+// It fails if such a foreign key is not nullable or the referencing table has no primary key to update the rows.
+func postponedColumns(tableMap map[string]schema.SchemaTable, cycles []gf_dependency.Cycle) (map[string][]string, error) {
+	postponed := map[string][]string{}
+	for _, cycle := range cycles {
+		for _, edge := range cycle.BreakEdges {
+			table := tableMap[edge.From]
+			foreignKey := table.ForeignKeys[edge.Index]
+			if foreignKey.Deferrable {
+				continue
+			}
+			if !edge.Nullable {
+				return nil, fmt.Errorf(`foreign key %s of %s referencing %s in a cycle is neither deferrable nor nullable`, foreignKey.Name, edge.From, edge.To)
+			}
+			if len(table.PrimaryKey) == 0 {
+				return nil, fmt.Errorf(`foreign key %s of %s referencing %s in a cycle is not deferrable and %s has no primary key`, foreignKey.Name, edge.From, edge.To, edge.From)
+			}
+			postponed[edge.From] = lo.Union(postponed[edge.From], foreignKey.ReferencingKey)
+		}
+	}
+	return postponed, nil
+}
+
+// quoteTableName returns the name of the table qualified by the schema if the schema is not empty.
+func quoteTableName(table schema.SchemaTable) string {
+	if table.Schema == "" {
+		return quoteIdentifier(table.Name)
+	}
+	return quoteIdentifier(table.Schema) + "." + quoteIdentifier(table.Name)
+}
+
+// insertRows inserts the rows into the table, in which values of the postponed columns are replaced with NULL.
+func insertRows(ctx context.Context, tx pgx.Tx, table schema.SchemaTable, rows []fixture.Row, postponed []string) error {
+	tableName := quoteTableName(table)
+	batch := &pgx.Batch{}
+	for index, row := range rows {
+		if len(postponed) > 0 {
+			row = lo.MapEntries(row, func(column string, value any) (string, any) {
+				return column, lo.Ternary(lo.Contains(postponed, column), nil, value)
+			})
+		}
+		stmt, values, err := insertStatement(tableName, table, row)
+		if err != nil {
+			return &fixture.RowError{Table: table.Name, Index: index, Err: err}
+		}
+		batch.Queue(stmt, values...)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+	for index := range rows {
+		if _, err := results.Exec(); err != nil {
+			return &fixture.RowError{Table: table.Name, Index: index, Constraint: constraint(err), Err: err}
+		}
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf(`fail to insert rows of %s: %w`, table.QualifiedName(), err)
+	}
+	return nil
+}
+
+// updateRows sets values of the postponed columns of the inserted rows, which are identified by their primary keys.
+func updateRows(ctx context.Context, tx pgx.Tx, table schema.SchemaTable, rows []fixture.Row, postponed []string) error {
+	if len(postponed) == 0 {
+		return nil
+	}
+	tableName := quoteTableName(table)
+	batch := &pgx.Batch{}
+	indexes := []int{}
+	for index, row := range rows {
+		columns := lo.Filter(postponed, func(column string, _ int) bool { return row[column] != nil })
+		if len(columns) == 0 {
+			continue
+		}
+		if lo.SomeBy(table.PrimaryKey, func(column string) bool { return row[column] == nil }) {
+			return &fixture.RowError{Table: table.Name, Index: index, Err: fmt.Errorf(`primary key (%s) must be specified to set %s after insertion`, strings.Join(table.PrimaryKey, ", "), strings.Join(columns, ", "))}
+		}
+		stmt, values, err := updateStatement(tableName, table, row, columns)
+		if err != nil {
+			return &fixture.RowError{Table: table.Name, Index: index, Err: err}
+		}
+		batch.Queue(stmt, values...)
+		indexes = append(indexes, index)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+	for _, index := range indexes {
+		if _, err := results.Exec(); err != nil {
+			return &fixture.RowError{Table: table.Name, Index: index, Constraint: constraint(err), Err: err}
+		}
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf(`fail to update rows of %s: %w`, table.QualifiedName(), err)
+	}
+	return nil
+}
+
+func updateStatement(tableName string, table schema.SchemaTable, row fixture.Row, columns []string) (string, []any, error) {
+	columnMap := lo.SliceToMap(table.Columns, func(column schema.SchemaColumn) (string, schema.SchemaColumn) { return column.Name, column })
+	values := []any{}
+	placeholders := func(columns []string) ([]string, error) {
+		assignments := []string{}
+		for _, column := range columns {
+			value, err := ConvertValue(columnMap[column], row[column])
+			if err != nil {
+				return nil, fmt.Errorf(`fail to convert value of %s: %w`, column, err)
+			}
+			values = append(values, value)
+			assignments = append(assignments, fmt.Sprintf(`%s = $%d`, quoteIdentifier(column), len(values)))
+		}
+		return assignments, nil
+	}
+	set, err := placeholders(columns)
+	if err != nil {
+		return "", nil, err
+	}
+	where, err := placeholders(table.PrimaryKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf(`UPDATE %s SET %s WHERE %s`, tableName, strings.Join(set, ", "), strings.Join(where, " AND ")), values, nil
+}
+
+func insertStatement(tableName string, table schema.SchemaTable, row fixture.Row) (string, []any, error) {
+	columns, err := fixture.Columns(lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) string { return column.Name }), row)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(columns) == 0 {
+		return fmt.Sprintf(`INSERT INTO %s DEFAULT VALUES`, tableName), nil, nil
+	}
+	values := []any{}
+	for _, column := range table.Columns {
+		if !lo.Contains(columns, column.Name) {
+			continue
+		}
+		value, err := ConvertValue(column, row[column.Name])
+		if err != nil {
+			return "", nil, fmt.Errorf(`fail to convert value of %s: %w`, column.Name, err)
+		}
+		values = append(values, value)
+	}
+	stmt := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`,
+		tableName,
+		strings.Join(lo.Map(columns, func(column string, _ int) string { return quoteIdentifier(column) }), ", "),
+		strings.Join(lo.Map(columns, func(_ string, i int) string { return fmt.Sprintf("$%d", i+1) }), ", "))
+	return stmt, values, nil
+}
+
+// constraint returns the name of the constraint violated by the error if available.
+func constraint(err error) string {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return ""
+	}
+	return pgErr.ConstraintName
+}
+
+// ConvertValue converts the value parsed from a fixture into a value to be inserted into the column, which is encodable by pgx.
+// Decimals are converted into pgtype.Numeric and dates into pgtype.Date because pgx does not encode strings into them.
+func ConvertValue(column schema.SchemaColumn, value any) (any, error) {
+	t := schema.ConvertType(column.Type)
+	converted, err := fixture.ConvertValue(t, value)
+	if err != nil || converted == nil {
+		return converted, err
+	}
+	switch t.Kind {
+	case gf_schema.TypeKindDecimal:
+		var numeric pgtype.Numeric
+		if err := numeric.Scan(converted); err != nil {
+			return nil, fmt.Errorf(`fail to convert %v into numeric: %w`, value, err)
+		}
+		return numeric, nil
+	case gf_schema.TypeKindDate:
+		var date pgtype.Date
+		if err := date.Scan(converted); err != nil {
+			return nil, fmt.Errorf(`fail to convert %v into date: %w`, value, err)
+		}
+		return date, nil
+	}
+	return converted, nil
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
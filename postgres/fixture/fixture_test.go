@@ -0,0 +1,90 @@
+package fixture_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	gf_fixture "github.com/Jumpaku/gotaface/fixture"
+	"github.com/Jumpaku/gotaface/postgres/fixture"
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		columnType string
+		in         any
+		want       any
+	}{
+		{columnType: "integer", in: json.Number("1"), want: int64(1)},
+		{columnType: "double precision", in: json.Number("0.5"), want: float64(0.5)},
+		{columnType: "numeric", in: json.Number("12.50"), want: pgtype.Numeric{Int: big.NewInt(1250), Exp: -2, Valid: true}},
+		{columnType: "text", in: "abc", want: "abc"},
+		{columnType: "bytea", in: "AQI=", want: []byte{1, 2}},
+		{columnType: "boolean", in: true, want: true},
+		{columnType: "date", in: "2024-01-02", want: pgtype.Date{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true}},
+		{columnType: "timestamp with time zone", in: "2024-01-02T03:04:05Z", want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{columnType: "jsonb", in: map[string]any{"k": []any{json.Number("1"), "x"}}, want: `{"k":[1,"x"]}`},
+		{columnType: "ARRAY", in: []any{json.Number("1"), nil, "x"}, want: []any{int64(1), nil, "x"}},
+		{columnType: "uuid", in: "12345678-9abc-def0-1234-56789abcdef0", want: "12345678-9abc-def0-1234-56789abcdef0"},
+		{columnType: "numeric", in: nil, want: nil},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.columnType), func(t *testing.T) {
+			got, err := fixture.ConvertValue(schema.SchemaColumn{Name: "C", Type: testcase.columnType}, testcase.in)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestConvertValue_Error(t *testing.T) {
+	testcases := []struct {
+		columnType string
+		in         any
+	}{
+		{columnType: "numeric", in: "abc"},
+		{columnType: "date", in: "2024/01/02"},
+		{columnType: "bytea", in: "not base64"},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.columnType), func(t *testing.T) {
+			_, err := fixture.ConvertValue(schema.SchemaColumn{Name: "C", Type: testcase.columnType}, testcase.in)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestLoader_Load_NotDeferrable(t *testing.T) {
+	tables := []schema.SchemaTable{
+		{
+			Name:        "A",
+			Schema:      "public",
+			Columns:     []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "B", Type: "integer"}},
+			PrimaryKey:  []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{{Name: "FK_A_B", ReferencedTable: "B", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"B"}}},
+		},
+		{
+			Name:        "B",
+			Schema:      "public",
+			Columns:     []schema.SchemaColumn{{Name: "PK", Type: "integer"}, {Name: "A", Type: "integer"}},
+			PrimaryKey:  []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{{Name: "FK_B_A", ReferencedTable: "A", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"A"}}},
+		},
+	}
+	f := gf_fixture.Fixture{
+		"A": {{"PK": json.Number("1"), "B": json.Number("1")}},
+		"B": {{"PK": json.Number("1"), "A": json.Number("1")}},
+	}
+
+	// the foreign keys are checked before the transaction begins
+	err := fixture.NewLoader(nil).Load(context.Background(), tables, f)
+	assert.ErrorContains(t, err, "FK_A_B")
+}
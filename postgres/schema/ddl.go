@@ -0,0 +1,231 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// GenerateDDL returns statements to create the given tables.
+// Indexes are created by CREATE INDEX statements following all CREATE TABLE statements.
+// Foreign keys are added by ALTER TABLE statements following all CREATE TABLE statements so that tables referencing each other can be created.
+// Enum types, domain types, and composite types used by the tables are created in this order before the tables.
+// Schemas of the tables and the types other than the public schema are created if they do not exist.
+func GenerateDDL(tables []SchemaTable) []string {
+	stmts := []string{}
+	types := lo.UniqBy(lo.FlatMap(tables, func(table SchemaTable, _ int) []SchemaType { return table.Types }), func(t SchemaType) string {
+		return t.QualifiedName()
+	})
+	schemas := lo.Uniq(append(
+		lo.Map(tables, func(table SchemaTable, _ int) string { return table.Schema }),
+		lo.Map(types, func(t SchemaType, _ int) string { return t.Schema })...,
+	))
+	for _, schemaName := range schemas {
+		if schemaName != "" && schemaName != "public" {
+			stmts = append(stmts, fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, quoteIdentifier(schemaName)))
+		}
+	}
+	for _, kind := range []string{"ENUM", "DOMAIN", "COMPOSITE"} {
+		for _, t := range types {
+			if t.Kind == kind {
+				stmts = append(stmts, CreateTypeDDL(t))
+			}
+		}
+	}
+	for _, table := range tables {
+		stmts = append(stmts, CreateTableDDL(table))
+	}
+	for _, table := range tables {
+		for _, index := range table.Indexes {
+			stmts = append(stmts, CreateIndexDDL(table.QualifiedName(), index))
+		}
+	}
+	for _, table := range tables {
+		for _, foreignKey := range table.ForeignKeys {
+			stmts = append(stmts, AddForeignKeyDDL(table.QualifiedName(), foreignKey))
+		}
+	}
+	return stmts
+}
+
+// CreateTableDDL returns a CREATE TABLE statement with columns, primary key, unique keys, and CHECK constraints of the table.
+// The table name is qualified by the schema if the schema is not empty.
+func CreateTableDDL(table SchemaTable) string {
+	definitions := []string{}
+	for _, column := range table.Columns {
+		definitions = append(definitions, ColumnDefinition(column))
+	}
+	if len(table.PrimaryKey) > 0 {
+		definition := fmt.Sprintf(`PRIMARY KEY (%s)`, quoteIdentifiers(table.PrimaryKey))
+		if table.PrimaryKeyName != "" {
+			definition = fmt.Sprintf(`CONSTRAINT %s %s`, quoteIdentifier(table.PrimaryKeyName), definition)
+		}
+		definitions = append(definitions, definition)
+	}
+	for _, uniqueKey := range table.UniqueKeys {
+		definition := fmt.Sprintf(`UNIQUE (%s)`, quoteIdentifiers(uniqueKey.Key))
+		if uniqueKey.Name != "" {
+			definition = fmt.Sprintf(`CONSTRAINT %s %s`, quoteIdentifier(uniqueKey.Name), definition)
+		}
+		definitions = append(definitions, definition)
+	}
+	for _, check := range table.Checks {
+		definitions = append(definitions, CheckDefinition(check))
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", quoteQualifiedName(table.QualifiedName()), strings.Join(definitions, ",\n    "))
+}
+
+// CheckDefinition returns a CHECK constraint definition used in CREATE TABLE and ALTER TABLE ADD statements.
+func CheckDefinition(check SchemaCheck) string {
+	definition := fmt.Sprintf(`CHECK (%s)`, check.Expression)
+	if check.Name != "" {
+		definition = fmt.Sprintf(`CONSTRAINT %s %s`, quoteIdentifier(check.Name), definition)
+	}
+	return definition
+}
+
+// AddForeignKeyDDL returns an ALTER TABLE statement adding the foreign key to the table, whose name can be qualified by a schema.
+// An unqualified referenced table is regarded as a table in the same schema as the table.
+func AddForeignKeyDDL(table string, foreignKey SchemaForeignKey) string {
+	schemaName, _ := splitQualifiedName(table)
+	referencedSchema, referencedTable := splitQualifiedName(foreignKey.ReferencedTable)
+	if referencedSchema == "" {
+		referencedSchema = schemaName
+	}
+	stmt := fmt.Sprintf(`ALTER TABLE %s ADD %sFOREIGN KEY (%s) REFERENCES %s (%s)`,
+		quoteQualifiedName(table),
+		lo.Ternary(foreignKey.Name == "", "", "CONSTRAINT "+quoteIdentifier(foreignKey.Name)+" "),
+		quoteIdentifiers(foreignKey.ReferencingKey),
+		quoteQualifiedName(qualifiedName(referencedSchema, referencedTable)),
+		quoteIdentifiers(foreignKey.ReferencedKey),
+	)
+	if foreignKey.Match != "" {
+		stmt += " MATCH " + foreignKey.Match
+	}
+	if foreignKey.OnDelete != "" {
+		stmt += " ON DELETE " + foreignKey.OnDelete
+	}
+	if foreignKey.OnUpdate != "" {
+		stmt += " ON UPDATE " + foreignKey.OnUpdate
+	}
+	if foreignKey.Deferrable {
+		stmt += " DEFERRABLE" + lo.Ternary(foreignKey.InitiallyDeferred, " INITIALLY DEFERRED", "")
+	}
+	return stmt
+}
+
+// CreateTypeDDL returns a CREATE TYPE statement of the enum type or the composite type, or a CREATE DOMAIN statement of the domain type.
+// It returns an empty string for the other kinds of types.
+func CreateTypeDDL(t SchemaType) string {
+	name := quoteQualifiedName(t.QualifiedName())
+	switch t.Kind {
+	case "ENUM":
+		labels := lo.Map(t.Labels, func(label string, _ int) string { return `'` + strings.ReplaceAll(label, `'`, `''`) + `'` })
+		return fmt.Sprintf(`CREATE TYPE %s AS ENUM (%s)`, name, strings.Join(labels, ", "))
+	case "COMPOSITE":
+		fields := lo.Map(t.Fields, func(field SchemaField, _ int) string { return quoteIdentifier(field.Name) + " " + field.Type })
+		return fmt.Sprintf(`CREATE TYPE %s AS (%s)`, name, strings.Join(fields, ", "))
+	case "DOMAIN":
+		stmt := fmt.Sprintf(`CREATE DOMAIN %s AS %s`, name, t.BaseType)
+		if t.NotNull {
+			stmt += " NOT NULL"
+		}
+		if t.Default != "" {
+			stmt += " DEFAULT (" + t.Default + ")"
+		}
+		for _, check := range t.Checks {
+			stmt += " " + CheckDefinition(check)
+		}
+		return stmt
+	default:
+		return ""
+	}
+}
+
+// CreateIndexDDL returns a CREATE INDEX statement for the index of the table, whose name can be qualified by a schema.
+// The index is created in the schema of the table.
+func CreateIndexDDL(table string, index SchemaIndex) string {
+	stmt := fmt.Sprintf(`CREATE %sINDEX %s ON %s`,
+		lo.Ternary(index.Unique, "UNIQUE ", ""),
+		quoteIdentifier(index.Name),
+		quoteQualifiedName(table),
+	)
+	if index.Method != "" {
+		stmt += " USING " + index.Method
+	}
+	stmt += fmt.Sprintf(` (%s)`, quoteIndexKey(index.Key))
+	if index.Predicate != "" {
+		stmt += " WHERE " + index.Predicate
+	}
+	return stmt
+}
+
+// serialTypeOf maps integer types to serial types, which create sequences owned by the columns.
+var serialTypeOf = map[string]string{"smallint": "smallserial", "integer": "serial", "bigint": "bigserial"}
+
+// ColumnDefinition returns a column definition used in CREATE TABLE and ALTER TABLE ADD COLUMN statements.
+// The column type is TypeName if it is given, and otherwise the type written from Type, ElemType, and UserType.
+// Integer columns whose defaults call nextval are defined with serial types so that their sequences are created.
+func ColumnDefinition(column SchemaColumn) string {
+	columnType, columnDefault := lo.Ternary(column.TypeName != "", column.TypeName, typeNameOf(column)), column.Default
+	if serialType, found := serialTypeOf[column.Type]; found && strings.HasPrefix(column.Default, "nextval(") {
+		columnType, columnDefault = serialType, ""
+	}
+
+	definition := quoteIdentifier(column.Name) + " " + columnType
+	if !column.Nullable {
+		definition += " NOT NULL"
+	}
+	if columnDefault != "" {
+		definition += " DEFAULT (" + columnDefault + ")"
+	}
+	if column.Generated != "" {
+		definition += " GENERATED ALWAYS AS (" + column.Generated + ")" + lo.Ternary(column.Stored, " STORED", " VIRTUAL")
+	}
+	if column.Identity != "" {
+		definition += " GENERATED " + column.Identity + " AS IDENTITY"
+	}
+	return definition
+}
+
+// typeNameOf writes the type of the column without TypeName, in which user-defined types are written as UserType and arrays as their element types followed by [].
+func typeNameOf(column SchemaColumn) string {
+	elemType := column.Type
+	if column.Type == "ARRAY" {
+		elemType = column.ElemType
+	}
+	if column.UserType != "" && (elemType == "USER-DEFINED" || elemType == "") {
+		elemType = quoteQualifiedName(column.UserType)
+	}
+	if column.Type == "ARRAY" {
+		return elemType + "[]"
+	}
+	return elemType
+}
+
+func quoteIndexKey(key []SchemaIndexKey) string {
+	return strings.Join(lo.Map(key, func(column SchemaIndexKey, _ int) string {
+		if column.Expression != "" {
+			return "(" + column.Expression + ")" + lo.Ternary(column.Desc, " DESC", "")
+		}
+		return quoteIdentifier(column.Name) + lo.Ternary(column.Desc, " DESC", "")
+	}), ", ")
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func quoteQualifiedName(name string) string {
+	schemaName, table := splitQualifiedName(name)
+	if schemaName == "" {
+		return quoteIdentifier(table)
+	}
+	return quoteIdentifier(schemaName) + "." + quoteIdentifier(table)
+}
+
+func quoteIdentifiers(identifiers []string) string {
+	return strings.Join(lo.Map(identifiers, func(identifier string, _ int) string { return quoteIdentifier(identifier) }), ", ")
+}
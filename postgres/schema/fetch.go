@@ -6,92 +6,341 @@ import (
 	"slices"
 
 	"github.com/Jumpaku/go-assert"
+	"github.com/Jumpaku/gotaface/ddl"
 	gf_postgres "github.com/Jumpaku/gotaface/postgres"
 	"github.com/Jumpaku/gotaface/schema"
 	"github.com/samber/lo"
 )
 
 type SchemaColumn struct {
-	Name     string `json:"name"`
-	Type     string `json:"type"`
+	Name string `json:"name"`
+	// Type is the data_type in information_schema, which is USER-DEFINED for user-defined types and ARRAY for arrays.
+	Type string `json:"type"`
+	// TypeName is the type as written in DDL with modifiers such as character varying(50), text[], and status_enum.
+	TypeName string `json:"type_name"`
+	// ElemType is the data_type of the elements if the column is an array, and otherwise empty.
+	ElemType string `json:"elem_type"`
+	// UserType is the name of the user-defined type of the column or of its elements, which is empty for built-in types.
+	// It is qualified by the schema if the type belongs to a schema other than the schema of the table.
+	UserType string `json:"user_type"`
 	Nullable bool   `json:"nullable"`
+	// Default is the expression of the default value, which is empty if the column has no default value.
+	// Columns of serial types have defaults calling nextval of their sequences.
+	Default string `json:"default"`
+	// Generated is the expression of the generated column, which is empty if the column is not generated.
+	Generated string `json:"generated"`
+	// Stored reports whether the generated column is STORED rather than VIRTUAL.
+	Stored bool `json:"stored"`
+	// Identity is ALWAYS or BY DEFAULT if the column is an identity column, and otherwise empty.
+	Identity string `json:"identity"`
 }
 type SchemaForeignKey struct {
+	Name            string   `json:"name"`
 	ReferencedTable string   `json:"referenced_table"`
 	ReferencedKey   []string `json:"referenced_key"`
 	ReferencingKey  []string `json:"referencing_key"`
+	// OnDelete and OnUpdate are the referential actions CASCADE, SET NULL, SET DEFAULT, or RESTRICT, which are empty for NO ACTION.
+	OnDelete string `json:"on_delete"`
+	OnUpdate string `json:"on_update"`
+	// Match is FULL or PARTIAL, which is empty for MATCH SIMPLE.
+	Match             string `json:"match"`
+	Deferrable        bool   `json:"deferrable"`
+	InitiallyDeferred bool   `json:"initially_deferred"`
+}
+
+// SchemaReference is a foreign key of a table referencing the table that has the reference.
+type SchemaReference struct {
+	Name string `json:"name"`
+	// ReferencingTable is qualified by the schema if it belongs to a schema other than the schema of the referenced table.
+	ReferencingTable string   `json:"referencing_table"`
+	ReferencingKey   []string `json:"referencing_key"`
+	ReferencedKey    []string `json:"referenced_key"`
 }
 type SchemaUniqueKey struct {
 	Name string   `json:"name"`
 	Key  []string `json:"key"`
 }
+type SchemaIndexKey struct {
+	// Name is the column of the key part, which is empty if the key part is an expression.
+	Name string `json:"name"`
+	Desc bool   `json:"desc"`
+	// Expression is the expression of the key part as written, which is empty if the key part is a column.
+	Expression string `json:"expression"`
+}
+type SchemaIndex struct {
+	Name   string           `json:"name"`
+	Unique bool             `json:"unique"`
+	Key    []SchemaIndexKey `json:"key"`
+	// Method is the access method of the index such as btree, hash, gist, and gin.
+	Method string `json:"method"`
+	// Predicate is the condition of a partial index, which is empty if the index is not partial.
+	Predicate string `json:"predicate"`
+}
+type SchemaCheck struct {
+	Name string `json:"name"`
+	// Expression is the condition of the CHECK constraint without the enclosing parentheses.
+	Expression string `json:"expression"`
+}
 type SchemaTable struct {
-	Name        string             `json:"name"`
-	Columns     []SchemaColumn     `json:"columns"`
-	PrimaryKey  []string           `json:"primary_key"`
-	ForeignKeys []SchemaForeignKey `json:"foreign_key"`
-	UniqueKeys  []SchemaUniqueKey  `json:"unique_key"`
+	Name       string         `json:"name"`
+	Schema     string         `json:"schema"`
+	Columns    []SchemaColumn `json:"columns"`
+	PrimaryKey []string       `json:"primary_key"`
+	// PrimaryKeyName is the name of the primary key constraint, which is empty if the table has no primary key.
+	PrimaryKeyName string             `json:"primary_key_name"`
+	ForeignKeys    []SchemaForeignKey `json:"foreign_key"`
+	UniqueKeys     []SchemaUniqueKey  `json:"unique_key"`
+	// Indexes are the indexes other than those backing primary keys, unique constraints, and exclusion constraints.
+	Indexes []SchemaIndex `json:"index"`
+	Checks  []SchemaCheck `json:"check"`
+	// ReferencedBy are the foreign keys referencing the table, which are fetched only by fetchers configured with WithReferencedBy.
+	ReferencedBy []SchemaReference `json:"referenced_by"`
+	// Types are the user-defined types used by the columns.
+	Types []SchemaType `json:"types"`
+	// Sequences are the sequences owned by the columns.
+	Sequences []SchemaSequence `json:"sequences"`
+}
+
+// QualifiedName returns the name of the table qualified by the schema, which is a form accepted by Fetch.
+func (table SchemaTable) QualifiedName() string {
+	return qualifiedName(table.Schema, table.Name)
+}
+
+// ReferencedQualifiedName returns the name of the table referenced by the foreign key of the table in the same form as QualifiedName.
+func (table SchemaTable) ReferencedQualifiedName(foreignKey SchemaForeignKey) string {
+	schemaName, name := splitQualifiedName(foreignKey.ReferencedTable)
+	return qualifiedName(lo.Ternary(schemaName == "", table.Schema, schemaName), name)
 }
 
 type fetcher struct {
-	queryer gf_postgres.Queryer
+	queryer      gf_postgres.Queryer
+	searchPath   []string
+	referencedBy bool
 }
 
+// NewFetcher returns a fetcher that resolves unqualified table names according to the search_path of the session.
 func NewFetcher(queryer gf_postgres.Queryer) fetcher {
 	return fetcher{queryer: queryer}
 }
 
+// WithSearchPath returns a copy of the fetcher that resolves unqualified table names in the given schemas in order instead of the search_path of the session.
+func (fetcher fetcher) WithSearchPath(schemas ...string) fetcher {
+	fetcher.searchPath = slices.Clone(schemas)
+	return fetcher
+}
+
+// WithReferencedBy returns a copy of the fetcher that also fetches foreign keys referencing the table into ReferencedBy.
+func (fetcher fetcher) WithReferencedBy() fetcher {
+	fetcher.referencedBy = true
+	return fetcher
+}
+
 var _ schema.Fetcher[SchemaTable] = fetcher{}
+var _ schema.Lister = fetcher{}
 
+// Fetch returns the schema of the table, whose name can be qualified by a schema such as schema.table.
+// An unqualified table name is resolved to the first schema containing the table in the search path.
+// Referenced tables of foreign keys are qualified if they belong to schemas other than the schema of the table.
 func (fetcher fetcher) Fetch(ctx context.Context, table string) (SchemaTable, error) {
 	wrapError := func(err error) (SchemaTable, error) {
 		assert.Params(err != nil, "wrapped error must be not nil")
 		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: %w`, table, err)
 	}
 
-	schemaTable := SchemaTable{Name: table}
+	schemaName, tableName := splitQualifiedName(table)
+	if schemaName == "" {
+		var err error
+		schemaName, err = queryRelationSchema(ctx, fetcher.queryer, fetcher.searchPath, tableName, []string{"r", "p"})
+		if err != nil {
+			return wrapError(err)
+		}
+	}
+
+	isView, err := queryIsView(ctx, fetcher.queryer, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
+	if isView {
+		return wrapError(fmt.Errorf(`%s is a view`, table))
+	}
+
+	schemaTable := SchemaTable{Name: tableName, Schema: schemaName}
+
+	schemaTable.Columns, err = queryColumns(ctx, fetcher.queryer, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.PrimaryKeyName, schemaTable.PrimaryKey, err = queryPrimaryKey(ctx, fetcher.queryer, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.ForeignKeys, err = queryForeignKeys(ctx, fetcher.queryer, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.UniqueKeys, err = queryUniqueKeys(ctx, fetcher.queryer, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
 
-	var err error
-	schemaTable.Columns, err = queryColumns(ctx, fetcher.queryer, table)
+	schemaTable.Indexes, err = queryIndexes(ctx, fetcher.queryer, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
-	schemaTable.PrimaryKey, err = queryPrimaryKey(ctx, fetcher.queryer, table)
+	schemaTable.Checks, err = queryChecks(ctx, fetcher.queryer, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
-	schemaTable.ForeignKeys, err = queryForeignKeys(ctx, fetcher.queryer, table)
+	schemaTable.Types, err = queryTypes(ctx, fetcher.queryer, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
-	schemaTable.UniqueKeys, err = queryUniqueKeys(ctx, fetcher.queryer, table)
+	schemaTable.Sequences, err = querySequences(ctx, fetcher.queryer, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
+	if fetcher.referencedBy {
+		schemaTable.ReferencedBy, err = queryReferencedBy(ctx, fetcher.queryer, schemaName, tableName)
+		if err != nil {
+			return wrapError(err)
+		}
+	}
+
 	return schemaTable, nil
 }
 
-func queryColumns(ctx context.Context, tx gf_postgres.Queryer, table string) ([]SchemaColumn, error) {
+// ListTables returns names of tables in all the user schemas.
+// The names are qualified by schemas unless they are resolved to the tables without qualification according to the search path.
+func (fetcher fetcher) ListTables(ctx context.Context) ([]string, error) {
+	tables, err := listRelations(ctx, fetcher.queryer, fetcher.searchPath, []string{"r", "p"})
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	return tables, nil
+}
+
+// listRelations returns names of relations of the given kinds in all the user schemas, which are qualified in the same way as ListTables.
+func listRelations(ctx context.Context, tx gf_postgres.Queryer, searchPath []string, relkinds []string) ([]string, error) {
+	sql := `--sql query user relation names
+SELECT
+	n.nspname AS "Schema",
+	c.relname AS "Name",
+	s.ord AS "SearchOrder"
+FROM pg_class AS c
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	LEFT JOIN unnest(COALESCE($1::text[], current_schemas(false)::text[])) WITH ORDINALITY AS s(name, ord)
+		ON s.name = n.nspname
+WHERE c.relkind::text = ANY($2::text[])
+	AND n.nspname <> 'information_schema' AND n.nspname NOT LIKE 'pg\_%'
+ORDER BY c.relname, s.ord`
+	rows, err := tx.Query(ctx, sql, searchPath, relkinds)
+	if err != nil {
+		return nil, err
+	}
+	type table struct {
+		Schema      string `db:"Schema"`
+		Name        string `db:"Name"`
+		SearchOrder *int64 `db:"SearchOrder"`
+	}
+	tables, err := gf_postgres.ScanRowsStruct[table](rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// tables are ordered so that the first one for each name is the table resolved by the name without qualification
+	resolved := map[string]bool{}
+	names := []string{}
+	for _, table := range tables {
+		if table.SearchOrder != nil && !resolved[table.Name] {
+			resolved[table.Name] = true
+			names = append(names, qualifiedName("", table.Name))
+			continue
+		}
+		names = append(names, qualifiedName(table.Schema, table.Name))
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// queryRelationSchema returns the first schema in the search path containing the relation of the given kinds.
+func queryRelationSchema(ctx context.Context, tx gf_postgres.Queryer, searchPath []string, table string, relkinds []string) (string, error) {
+	sql := `--sql query schema of relation in search path
+SELECT
+	s.name AS "Name"
+FROM unnest(COALESCE($1::text[], current_schemas(false)::text[])) WITH ORDINALITY AS s(name, ord)
+	JOIN pg_namespace AS n ON n.nspname = s.name
+	JOIN pg_class AS c ON c.relnamespace = n.oid
+WHERE c.relname = $2 AND c.relkind::text = ANY($3::text[])
+ORDER BY s.ord
+LIMIT 1`
+	rows, err := tx.Query(ctx, sql, searchPath, table, relkinds)
+	if err != nil {
+		return "", fmt.Errorf(`fail to get schema of %s: %w`, table, err)
+	}
+	type namespace struct {
+		Name string `db:"Name"`
+	}
+	namespaces, err := gf_postgres.ScanRowsStruct[namespace](rows)
+	if err != nil {
+		return "", fmt.Errorf(`fail to get schema of %s: %w`, table, err)
+	}
+	if len(namespaces) == 0 {
+		return "", fmt.Errorf(`%s is not found in search path`, table)
+	}
+	return namespaces[0].Name, nil
+}
+
+func queryColumns(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) ([]SchemaColumn, error) {
 	sql := `--sql query column information
 SELECT 
-	column_name AS "Name",
-	data_type AS "Type",
-	is_nullable = 'YES' AS "Nullable"
-FROM information_schema.columns
-WHERE table_name = $1
-ORDER BY ordinal_position`
-	rows, err := tx.Query(ctx, sql, table)
+	c.column_name AS "Name",
+	c.data_type AS "Type",
+	format_type(a.atttypid, a.atttypmod) AS "TypeName",
+	CASE WHEN c.data_type <> 'ARRAY' THEN ''
+		WHEN btn.nspname = 'pg_catalog' THEN format_type(bt.oid, NULL)
+		ELSE 'USER-DEFINED' END AS "ElemType",
+	CASE WHEN utn.nspname IN ('pg_catalog', 'information_schema') THEN NULL ELSE utn.nspname END AS "UserTypeSchema",
+	ut.typname AS "UserTypeName",
+	c.is_nullable = 'YES' AS "Nullable",
+	COALESCE(c.column_default, '') AS "Default",
+	COALESCE(c.generation_expression, '') AS "Generated",
+	a.attgenerated = 's' AS "Stored",
+	COALESCE(c.identity_generation, '') AS "Identity"
+FROM information_schema.columns AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.nspname = c.table_schema
+	JOIN pg_catalog.pg_class AS t ON t.relnamespace = n.oid AND t.relname = c.table_name
+	JOIN pg_catalog.pg_attribute AS a ON a.attrelid = t.oid AND a.attname = c.column_name
+	JOIN pg_catalog.pg_type AS ct ON ct.oid = a.atttypid
+	JOIN pg_catalog.pg_type AS ut ON ut.oid = CASE WHEN ct.typelem <> 0 AND ct.typlen = -1 THEN ct.typelem ELSE ct.oid END
+	JOIN pg_catalog.pg_namespace AS utn ON utn.oid = ut.typnamespace
+	JOIN pg_catalog.pg_type AS bt ON bt.oid = CASE WHEN ut.typtype = 'd' THEN ut.typbasetype ELSE ut.oid END
+	JOIN pg_catalog.pg_namespace AS btn ON btn.oid = bt.typnamespace
+WHERE c.table_schema = $1 AND c.table_name = $2
+ORDER BY c.ordinal_position`
+	rows, err := tx.Query(ctx, sql, schemaName, table)
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get columns of %s: %w`, table, err)
 	}
 	type column struct {
 		Name     string `db:"Name"`
 		Type     string `db:"Type"`
-		Nullable bool   `db:"Nullable"`
+		TypeName string `db:"TypeName"`
+		ElemType string `db:"ElemType"`
+		// UserTypeSchema is NULL if the type of the column or its elements is built-in.
+		UserTypeSchema *string `db:"UserTypeSchema"`
+		UserTypeName   string  `db:"UserTypeName"`
+		Nullable       bool    `db:"Nullable"`
+		Default        string  `db:"Default"`
+		Generated      string  `db:"Generated"`
+		Stored         bool    `db:"Stored"`
+		Identity       string  `db:"Identity"`
 	}
 	columns, err := gf_postgres.ScanRowsStruct[column](rows)
 	if err != nil {
@@ -99,68 +348,94 @@ ORDER BY ordinal_position`
 	}
 
 	return lo.Map(columns, func(column column, index int) SchemaColumn {
+		userType := ""
+		if column.UserTypeSchema != nil {
+			userType = qualifiedName(lo.Ternary(*column.UserTypeSchema == schemaName, "", *column.UserTypeSchema), column.UserTypeName)
+		}
 		return SchemaColumn{
-			Name:     column.Name,
-			Type:     column.Type,
-			Nullable: column.Nullable,
+			Name:      column.Name,
+			Type:      column.Type,
+			TypeName:  column.TypeName,
+			ElemType:  column.ElemType,
+			UserType:  userType,
+			Nullable:  column.Nullable,
+			Default:   column.Default,
+			Generated: column.Generated,
+			Stored:    column.Stored,
+			Identity:  column.Identity,
 		}
 	}), nil
 }
 
-func queryPrimaryKey(ctx context.Context, tx gf_postgres.Queryer, table string) ([]string, error) {
+// queryPrimaryKey returns the name of the primary key constraint and the columns of the primary key.
+func queryPrimaryKey(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) (string, []string, error) {
 	sql := `--sql query primary key information
 SELECT
-    kcu.column_name AS "Name"
-FROM information_schema.table_constraints AS tc
-     JOIN information_schema.key_column_usage AS kcu
-          ON kcu.constraint_name = tc.constraint_name
-WHERE kcu.table_name = $1 AND tc.constraint_type = 'PRIMARY KEY'
-ORDER BY kcu.ordinal_position;`
+	con.conname AS "ConstraintName",
+	a.attname AS "Name"
+FROM pg_constraint AS con
+	JOIN pg_class AS c ON c.oid = con.conrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	CROSS JOIN LATERAL unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+	JOIN pg_attribute AS a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+WHERE n.nspname = $1 AND c.relname = $2 AND con.contype = 'p'
+ORDER BY k.ord`
 	type key struct {
-		Name string `db:"Name"`
+		ConstraintName string `db:"ConstraintName"`
+		Name           string `db:"Name"`
 	}
-	rows, err := tx.Query(ctx, sql, table)
+	rows, err := tx.Query(ctx, sql, schemaName, table)
 	if err != nil {
-		return nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
+		return "", nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
 	}
 	primaryKey, err := gf_postgres.ScanRowsStruct[key](rows)
 	if err != nil {
-		return nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
+		return "", nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
+	}
+	if len(primaryKey) == 0 {
+		return "", nil, nil
 	}
-	return lo.Map(primaryKey, func(it key, i int) string { return it.Name }), nil
+	return primaryKey[0].ConstraintName, lo.Map(primaryKey, func(it key, i int) string { return it.Name }), nil
 }
 
-func queryForeignKeys(ctx context.Context, tx gf_postgres.Queryer, table string) ([]SchemaForeignKey, error) {
+func queryForeignKeys(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) ([]SchemaForeignKey, error) {
 	sql := `--sql query foreign key information
 SELECT
-    tc.constraint_name AS "Name",
-    ctu.table_name AS "ReferencedTable",
-    kcu1.column_name AS "ReferencingKey",
-    kcu2.column_name AS "ReferencedKey"
-FROM
-    information_schema.table_constraints tc
-        JOIN information_schema.referential_constraints rc
-            ON rc.constraint_name = tc.constraint_name
-        JOIN information_schema.constraint_table_usage ctu
-            ON ctu.constraint_name = rc.unique_constraint_name
-        JOIN information_schema.key_column_usage kcu1
-            ON kcu1.constraint_name = rc.constraint_name
-        JOIN information_schema.key_column_usage kcu2
-            ON kcu2.constraint_name = rc.unique_constraint_name
-                AND kcu2.ordinal_position = kcu1.ordinal_position
-WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_name = $1
-ORDER BY "Name", kcu1.ordinal_position;
-
-`
-	rows, err := tx.Query(ctx, sql, table)
+	con.conname AS "Name",
+	fn.nspname AS "ReferencedSchema",
+	fc.relname AS "ReferencedTable",
+	a.attname AS "ReferencingKey",
+	fa.attname AS "ReferencedKey",
+	CASE con.confdeltype WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' WHEN 'r' THEN 'RESTRICT' ELSE '' END AS "OnDelete",
+	CASE con.confupdtype WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' WHEN 'r' THEN 'RESTRICT' ELSE '' END AS "OnUpdate",
+	CASE con.confmatchtype WHEN 'f' THEN 'FULL' WHEN 'p' THEN 'PARTIAL' ELSE '' END AS "Match",
+	con.condeferrable AS "Deferrable",
+	con.condeferred AS "InitiallyDeferred"
+FROM pg_constraint AS con
+	JOIN pg_class AS c ON c.oid = con.conrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_class AS fc ON fc.oid = con.confrelid
+	JOIN pg_namespace AS fn ON fn.oid = fc.relnamespace
+	CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, fattnum, ord)
+	JOIN pg_attribute AS a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+	JOIN pg_attribute AS fa ON fa.attrelid = con.confrelid AND fa.attnum = k.fattnum
+WHERE n.nspname = $1 AND c.relname = $2 AND con.contype = 'f'
+ORDER BY con.conname, k.ord`
+	rows, err := tx.Query(ctx, sql, schemaName, table)
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get foreign keys of %s: %w`, table, err)
 	}
 	type fkRow struct {
-		Name            string `db:"Name"`
-		ReferencedTable string `db:"ReferencedTable"`
-		ReferencingKey  string `db:"ReferencingKey"`
-		ReferencedKey   string `db:"ReferencedKey"`
+		Name              string `db:"Name"`
+		ReferencedSchema  string `db:"ReferencedSchema"`
+		ReferencedTable   string `db:"ReferencedTable"`
+		ReferencingKey    string `db:"ReferencingKey"`
+		ReferencedKey     string `db:"ReferencedKey"`
+		OnDelete          string `db:"OnDelete"`
+		OnUpdate          string `db:"OnUpdate"`
+		Match             string `db:"Match"`
+		Deferrable        bool   `db:"Deferrable"`
+		InitiallyDeferred bool   `db:"InitiallyDeferred"`
 	}
 	fkRows, err := gf_postgres.ScanRowsStruct[fkRow](rows)
 	if err != nil {
@@ -175,26 +450,83 @@ ORDER BY "Name", kcu1.ordinal_position;
 	for _, id := range groupNames {
 		g := group[id]
 		foreignKeys = append(foreignKeys, SchemaForeignKey{
-			ReferencedTable: g[0].ReferencedTable,
-			ReferencedKey:   lo.Map(g, func(fkRow fkRow, _ int) string { return fkRow.ReferencedKey }),
-			ReferencingKey:  lo.Map(g, func(fkRow fkRow, _ int) string { return fkRow.ReferencingKey }),
+			Name:              id,
+			ReferencedTable:   qualifiedName(lo.Ternary(g[0].ReferencedSchema == schemaName, "", g[0].ReferencedSchema), g[0].ReferencedTable),
+			ReferencedKey:     lo.Map(g, func(fkRow fkRow, _ int) string { return fkRow.ReferencedKey }),
+			ReferencingKey:    lo.Map(g, func(fkRow fkRow, _ int) string { return fkRow.ReferencingKey }),
+			OnDelete:          g[0].OnDelete,
+			OnUpdate:          g[0].OnUpdate,
+			Match:             g[0].Match,
+			Deferrable:        g[0].Deferrable,
+			InitiallyDeferred: g[0].InitiallyDeferred,
 		})
 	}
 
 	return foreignKeys, nil
 }
 
-func queryUniqueKeys(ctx context.Context, tx gf_postgres.Queryer, table string) ([]SchemaUniqueKey, error) {
-	sql := `--sql query primary key information
+func queryReferencedBy(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) ([]SchemaReference, error) {
+	sql := `--sql query foreign keys referencing the table
 SELECT
-    tc.constraint_name AS "Name",
-    kcu.column_name AS "ColumnName"
-FROM information_schema.table_constraints AS tc
-	 JOIN information_schema.key_column_usage AS kcu
-		  ON kcu.constraint_name = tc.constraint_name
-WHERE kcu.table_name = $1 AND tc.constraint_type = 'UNIQUE'
-ORDER BY tc.constraint_name, kcu.ordinal_position;`
-	rows, err := tx.Query(ctx, sql, table)
+	con.conname AS "Name",
+	n.nspname AS "ReferencingSchema",
+	c.relname AS "ReferencingTable",
+	a.attname AS "ReferencingKey",
+	fa.attname AS "ReferencedKey"
+FROM pg_constraint AS con
+	JOIN pg_class AS c ON c.oid = con.conrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_class AS fc ON fc.oid = con.confrelid
+	JOIN pg_namespace AS fn ON fn.oid = fc.relnamespace
+	CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, fattnum, ord)
+	JOIN pg_attribute AS a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+	JOIN pg_attribute AS fa ON fa.attrelid = con.confrelid AND fa.attnum = k.fattnum
+WHERE fn.nspname = $1 AND fc.relname = $2 AND con.contype = 'f'
+ORDER BY n.nspname, c.relname, con.conname, k.ord`
+	rows, err := tx.Query(ctx, sql, schemaName, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys referencing %s: %w`, table, err)
+	}
+	type referenceRow struct {
+		Name              string `db:"Name"`
+		ReferencingSchema string `db:"ReferencingSchema"`
+		ReferencingTable  string `db:"ReferencingTable"`
+		ReferencingKey    string `db:"ReferencingKey"`
+		ReferencedKey     string `db:"ReferencedKey"`
+	}
+	referenceRows, err := gf_postgres.ScanRowsStruct[referenceRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys referencing %s: %w`, table, err)
+	}
+
+	var references []SchemaReference
+	for _, g := range lo.PartitionBy(referenceRows, func(row referenceRow) [3]string {
+		return [3]string{row.ReferencingSchema, row.ReferencingTable, row.Name}
+	}) {
+		references = append(references, SchemaReference{
+			Name:             g[0].Name,
+			ReferencingTable: qualifiedName(lo.Ternary(g[0].ReferencingSchema == schemaName, "", g[0].ReferencingSchema), g[0].ReferencingTable),
+			ReferencingKey:   lo.Map(g, func(row referenceRow, _ int) string { return row.ReferencingKey }),
+			ReferencedKey:    lo.Map(g, func(row referenceRow, _ int) string { return row.ReferencedKey }),
+		})
+	}
+
+	return references, nil
+}
+
+func queryUniqueKeys(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) ([]SchemaUniqueKey, error) {
+	sql := `--sql query unique key information
+SELECT
+	con.conname AS "Name",
+	a.attname AS "ColumnName"
+FROM pg_constraint AS con
+	JOIN pg_class AS c ON c.oid = con.conrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	CROSS JOIN LATERAL unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+	JOIN pg_attribute AS a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+WHERE n.nspname = $1 AND c.relname = $2 AND con.contype = 'u'
+ORDER BY con.conname, k.ord`
+	rows, err := tx.Query(ctx, sql, schemaName, table)
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get unique keys of %s: %w`, table, err)
 	}
@@ -214,7 +546,8 @@ ORDER BY tc.constraint_name, kcu.ordinal_position;`
 	for _, name := range groupNames {
 		g := group[name]
 		uk := SchemaUniqueKey{
-			Key: lo.Map(g, func(ukRow ukRow, _ int) string { return ukRow.ColumnName }),
+			Name: name,
+			Key:  lo.Map(g, func(ukRow ukRow, _ int) string { return ukRow.ColumnName }),
 		}
 
 		uniqueKeys = append(uniqueKeys, uk)
@@ -222,3 +555,144 @@ ORDER BY tc.constraint_name, kcu.ordinal_position;`
 
 	return uniqueKeys, nil
 }
+
+func queryChecks(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) ([]SchemaCheck, error) {
+	sql := `--sql query check constraint information
+SELECT
+	con.conname AS "Name",
+	pg_get_constraintdef(con.oid) AS "Definition"
+FROM pg_constraint AS con
+	JOIN pg_class AS c ON c.oid = con.conrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+WHERE n.nspname = $1 AND c.relname = $2 AND con.contype = 'c'
+ORDER BY con.conname`
+	rows, err := tx.Query(ctx, sql, schemaName, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get checks of %s: %w`, table, err)
+	}
+	type checkRow struct {
+		Name       string `db:"Name"`
+		Definition string `db:"Definition"`
+	}
+	checkRows, err := gf_postgres.ScanRowsStruct[checkRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get checks of %s: %w`, table, err)
+	}
+
+	var checks []SchemaCheck
+	for _, checkRow := range checkRows {
+		expression, err := checkExpression(checkRow.Definition)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to get checks of %s: %w`, table, err)
+		}
+		checks = append(checks, SchemaCheck{Name: checkRow.Name, Expression: expression})
+	}
+	return checks, nil
+}
+
+// checkExpression extracts the condition from a definition of a CHECK constraint such as CHECK ((amount >= 0)) NOT VALID.
+func checkExpression(definition string) (string, error) {
+	tokens, err := ddl.Tokenize(definition)
+	if err != nil {
+		return "", fmt.Errorf(`fail to parse %q: %w`, definition, err)
+	}
+	p := ddl.NewParser(definition, tokens)
+	if err := p.ExpectKeyword("CHECK"); err != nil {
+		return "", fmt.Errorf(`fail to parse %q: %w`, definition, err)
+	}
+	expression, err := parseCheckExpression(p)
+	if err != nil {
+		return "", fmt.Errorf(`fail to parse %q: %w`, definition, err)
+	}
+	return expression, nil
+}
+
+// indexExpression returns the expression of a key part such as (a + b) or lower(c) without the enclosing parentheses.
+func indexExpression(definition string) (string, error) {
+	keyList := "(" + definition + ")"
+	tokens, err := ddl.Tokenize(keyList)
+	if err != nil {
+		return "", fmt.Errorf(`fail to parse %q: %w`, definition, err)
+	}
+	key, err := parseIndexKeyList(ddl.NewParser(keyList, tokens))
+	if err != nil {
+		return "", fmt.Errorf(`fail to parse %q: %w`, definition, err)
+	}
+	if len(key) != 1 || key[0].Expression == "" {
+		return "", fmt.Errorf(`fail to parse %q: expression is expected`, definition)
+	}
+	return key[0].Expression, nil
+}
+
+func queryIndexes(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) ([]SchemaIndex, error) {
+	sql := `--sql query index information
+SELECT
+	ic.relname AS "Name",
+	i.indisunique AS "Unique",
+	am.amname AS "Method",
+	COALESCE(pg_get_expr(i.indpred, i.indrelid), '') AS "Predicate",
+	a.attname AS "ColumnName",
+	pg_get_indexdef(i.indexrelid, k.ord::int, true) AS "Definition",
+	(i.indoption[k.ord::int - 1] & 1) = 1 AS "Desc"
+FROM pg_index AS i
+	JOIN pg_class AS ic ON ic.oid = i.indexrelid
+	JOIN pg_class AS c ON c.oid = i.indrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_am AS am ON am.oid = ic.relam
+	CROSS JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, ord)
+	LEFT JOIN pg_attribute AS a ON a.attrelid = i.indrelid AND a.attnum = k.attnum AND k.attnum <> 0
+WHERE n.nspname = $1 AND c.relname = $2 AND k.ord <= i.indnkeyatts
+	AND NOT EXISTS (
+		SELECT 1 FROM pg_constraint AS con
+		WHERE con.conindid = i.indexrelid AND con.contype IN ('p', 'u', 'x')
+	)
+ORDER BY ic.relname, k.ord`
+	rows, err := tx.Query(ctx, sql, schemaName, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+	}
+	type indexRow struct {
+		Name      string `db:"Name"`
+		Unique    bool   `db:"Unique"`
+		Method    string `db:"Method"`
+		Predicate string `db:"Predicate"`
+		// ColumnName is NULL if the key part is an expression.
+		ColumnName *string `db:"ColumnName"`
+		// Definition is the column or the expression of the key part.
+		Definition string `db:"Definition"`
+		Desc       bool   `db:"Desc"`
+	}
+	indexRows, err := gf_postgres.ScanRowsStruct[indexRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+	}
+	group := lo.GroupBy(indexRows, func(indexRow indexRow) string { return indexRow.Name })
+	groupNames := lo.Keys(group)
+	slices.Sort(groupNames)
+
+	var indexes []SchemaIndex
+	for _, name := range groupNames {
+		g := group[name]
+		key := []SchemaIndexKey{}
+		for _, indexRow := range g {
+			if indexRow.ColumnName != nil {
+				key = append(key, SchemaIndexKey{Name: *indexRow.ColumnName, Desc: indexRow.Desc})
+				continue
+			}
+			expression, err := indexExpression(indexRow.Definition)
+			if err != nil {
+				return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+			}
+			key = append(key, SchemaIndexKey{Expression: expression, Desc: indexRow.Desc})
+		}
+		indexes = append(indexes, SchemaIndex{
+			Name:      name,
+			Unique:    g[0].Unique,
+			Key:       key,
+			Method:    g[0].Method,
+			Predicate: g[0].Predicate,
+		})
+	}
+
+	return indexes, nil
+}
@@ -21,335 +21,602 @@ var ddls = map[string]string{
 	"ddl_05_foreign_loop_3":         testdata.DDL05ForeignLoop3SQL,
 	"ddl_07_unique_keys_constraint": testdata.DDL07UniqueKeysConstraintSQL,
 	"ddl_08_unique_keys_column":     testdata.DDL08UniqueKeysColumnSQL,
+	"ddl_09_schemas":                testdata.DDL09SchemasSQL,
+	"ddl_10_indexes":                testdata.DDL10IndexesSQL,
+	"ddl_11_column_defaults":        testdata.DDL11ColumnDefaultsSQL,
+	"ddl_12_checks":                 testdata.DDL12ChecksSQL,
+	"ddl_13_foreign_key_actions":    testdata.DDL13ForeignKeyActionsSQL,
+	"ddl_14_views":                  testdata.DDL14ViewsSQL,
+	"ddl_15_types":                  testdata.DDL15TypesSQL,
 }
 
-func TestFetcher(t *testing.T) {
-	testcases := []struct {
-		ddl   string
-		table string
-		want  schema.SchemaTable
-	}{
-		{
-			ddl:   "ddl_00_all_types",
-			table: "A",
-			want: schema.SchemaTable{
-				Name: "A",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "integer", Nullable: false},
-					{Name: "Col_01", Type: "bigint", Nullable: true},
-					{Name: "Col_02", Type: "bigint", Nullable: false},
-					{Name: "Col_04", Type: "bigint", Nullable: false},
-					{Name: "Col_05", Type: "bit", Nullable: true},
-					{Name: "Col_06", Type: "bit", Nullable: false},
-					{Name: "Col_07", Type: "bit varying", Nullable: true},
-					{Name: "Col_08", Type: "bit varying", Nullable: false},
-					{Name: "Col_09", Type: "boolean", Nullable: true},
-					{Name: "Col_10", Type: "boolean", Nullable: false},
-					{Name: "Col_11", Type: "bytea", Nullable: true},
-					{Name: "Col_12", Type: "bytea", Nullable: false},
-					{Name: "Col_13", Type: "character", Nullable: true},
-					{Name: "Col_14", Type: "character", Nullable: false},
-					{Name: "Col_15", Type: "character varying", Nullable: true},
-					{Name: "Col_16", Type: "character varying", Nullable: false},
-					{Name: "Col_17", Type: "date", Nullable: true},
-					{Name: "Col_18", Type: "date", Nullable: false},
-					{Name: "Col_19", Type: "double precision", Nullable: true},
-					{Name: "Col_20", Type: "double precision", Nullable: false},
-					{Name: "Col_21", Type: "integer", Nullable: true},
-					{Name: "Col_22", Type: "integer", Nullable: false},
-					{Name: "Col_23", Type: "json", Nullable: true},
-					{Name: "Col_24", Type: "json", Nullable: false},
-					{Name: "Col_25", Type: "money", Nullable: true},
-					{Name: "Col_26", Type: "money", Nullable: false},
-					{Name: "Col_27", Type: "numeric", Nullable: true},
-					{Name: "Col_28", Type: "numeric", Nullable: false},
-					{Name: "Col_29", Type: "real", Nullable: true},
-					{Name: "Col_30", Type: "real", Nullable: false},
-					{Name: "Col_31", Type: "smallint", Nullable: true},
-					{Name: "Col_32", Type: "smallint", Nullable: false},
-					{Name: "Col_34", Type: "smallint", Nullable: false},
-					{Name: "Col_36", Type: "integer", Nullable: false},
-					{Name: "Col_37", Type: "text", Nullable: true},
-					{Name: "Col_38", Type: "text", Nullable: false},
-					{Name: "Col_39", Type: "time without time zone", Nullable: true},
-					{Name: "Col_40", Type: "time without time zone", Nullable: false},
-					{Name: "Col_41", Type: "time with time zone", Nullable: true},
-					{Name: "Col_42", Type: "time with time zone", Nullable: false},
-					{Name: "Col_43", Type: "timestamp without time zone", Nullable: true},
-					{Name: "Col_44", Type: "timestamp without time zone", Nullable: false},
-					{Name: "Col_45", Type: "timestamp with time zone", Nullable: true},
-					{Name: "Col_46", Type: "timestamp with time zone", Nullable: false},
-					{Name: "Col_47", Type: "uuid", Nullable: true},
-					{Name: "Col_48", Type: "uuid", Nullable: false},
-					{Name: "Col_49", Type: "xml", Nullable: true},
-					{Name: "Col_50", Type: "xml", Nullable: false},
-				},
-				PrimaryKey: []string{"PK"},
+var fetcherTestcases = []struct {
+	ddl   string
+	table string
+	want  schema.SchemaTable
+}{
+	{
+		ddl:   "ddl_00_all_types",
+		table: "A",
+		want: schema.SchemaTable{
+			Name:   "A",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer", Nullable: false},
+				{Name: "Col_01", Type: "bigint", TypeName: "bigint", Nullable: true},
+				{Name: "Col_02", Type: "bigint", TypeName: "bigint", Nullable: false},
+				{Name: "Col_04", Type: "bigint", TypeName: "bigint", Nullable: false, Default: `nextval('"A_Col_04_seq"'::regclass)`},
+				{Name: "Col_05", Type: "bit", TypeName: "bit(50)", Nullable: true},
+				{Name: "Col_06", Type: "bit", TypeName: "bit(50)", Nullable: false},
+				{Name: "Col_07", Type: "bit varying", TypeName: "bit varying(50)", Nullable: true},
+				{Name: "Col_08", Type: "bit varying", TypeName: "bit varying(50)", Nullable: false},
+				{Name: "Col_09", Type: "boolean", TypeName: "boolean", Nullable: true},
+				{Name: "Col_10", Type: "boolean", TypeName: "boolean", Nullable: false},
+				{Name: "Col_11", Type: "bytea", TypeName: "bytea", Nullable: true},
+				{Name: "Col_12", Type: "bytea", TypeName: "bytea", Nullable: false},
+				{Name: "Col_13", Type: "character", TypeName: "character(50)", Nullable: true},
+				{Name: "Col_14", Type: "character", TypeName: "character(50)", Nullable: false},
+				{Name: "Col_15", Type: "character varying", TypeName: "character varying(50)", Nullable: true},
+				{Name: "Col_16", Type: "character varying", TypeName: "character varying(50)", Nullable: false},
+				{Name: "Col_17", Type: "date", TypeName: "date", Nullable: true},
+				{Name: "Col_18", Type: "date", TypeName: "date", Nullable: false},
+				{Name: "Col_19", Type: "double precision", TypeName: "double precision", Nullable: true},
+				{Name: "Col_20", Type: "double precision", TypeName: "double precision", Nullable: false},
+				{Name: "Col_21", Type: "integer", TypeName: "integer", Nullable: true},
+				{Name: "Col_22", Type: "integer", TypeName: "integer", Nullable: false},
+				{Name: "Col_23", Type: "json", TypeName: "json", Nullable: true},
+				{Name: "Col_24", Type: "json", TypeName: "json", Nullable: false},
+				{Name: "Col_25", Type: "money", TypeName: "money", Nullable: true},
+				{Name: "Col_26", Type: "money", TypeName: "money", Nullable: false},
+				{Name: "Col_27", Type: "numeric", TypeName: "numeric", Nullable: true},
+				{Name: "Col_28", Type: "numeric", TypeName: "numeric", Nullable: false},
+				{Name: "Col_29", Type: "real", TypeName: "real", Nullable: true},
+				{Name: "Col_30", Type: "real", TypeName: "real", Nullable: false},
+				{Name: "Col_31", Type: "smallint", TypeName: "smallint", Nullable: true},
+				{Name: "Col_32", Type: "smallint", TypeName: "smallint", Nullable: false},
+				{Name: "Col_34", Type: "smallint", TypeName: "smallint", Nullable: false, Default: `nextval('"A_Col_34_seq"'::regclass)`},
+				{Name: "Col_36", Type: "integer", TypeName: "integer", Nullable: false, Default: `nextval('"A_Col_36_seq"'::regclass)`},
+				{Name: "Col_37", Type: "text", TypeName: "text", Nullable: true},
+				{Name: "Col_38", Type: "text", TypeName: "text", Nullable: false},
+				{Name: "Col_39", Type: "time without time zone", TypeName: "time without time zone", Nullable: true},
+				{Name: "Col_40", Type: "time without time zone", TypeName: "time without time zone", Nullable: false},
+				{Name: "Col_41", Type: "time with time zone", TypeName: "time with time zone", Nullable: true},
+				{Name: "Col_42", Type: "time with time zone", TypeName: "time with time zone", Nullable: false},
+				{Name: "Col_43", Type: "timestamp without time zone", TypeName: "timestamp without time zone", Nullable: true},
+				{Name: "Col_44", Type: "timestamp without time zone", TypeName: "timestamp without time zone", Nullable: false},
+				{Name: "Col_45", Type: "timestamp with time zone", TypeName: "timestamp with time zone", Nullable: true},
+				{Name: "Col_46", Type: "timestamp with time zone", TypeName: "timestamp with time zone", Nullable: false},
+				{Name: "Col_47", Type: "uuid", TypeName: "uuid", Nullable: true},
+				{Name: "Col_48", Type: "uuid", TypeName: "uuid", Nullable: false},
+				{Name: "Col_49", Type: "xml", TypeName: "xml", Nullable: true},
+				{Name: "Col_50", Type: "xml", TypeName: "xml", Nullable: false},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "A_pkey",
+			Sequences: []schema.SchemaSequence{
+				{Name: "A_Col_04_seq", Column: "Col_04", Start: 1, Increment: 1},
+				{Name: "A_Col_34_seq", Column: "Col_34", Start: 1, Increment: 1},
+				{Name: "A_Col_36_seq", Column: "Col_36", Start: 1, Increment: 1},
 			},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_1",
-			want: schema.SchemaTable{
-				Name: "C_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "integer"},
-					{Name: "PK_12", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_1",
+		want: schema.SchemaTable{
+			Name:   "C_1",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "integer", TypeName: "integer"},
+				{Name: "PK_12", Type: "integer", TypeName: "integer"},
 			},
+			PrimaryKey:     []string{"PK_11", "PK_12"},
+			PrimaryKeyName: "C_1_pkey",
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_2",
-			want: schema.SchemaTable{
-				Name: "C_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "integer"},
-					{Name: "PK_22", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "C_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_2",
+		want: schema.SchemaTable{
+			Name:   "C_2",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "integer", TypeName: "integer"},
+				{Name: "PK_22", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_21", "PK_22"},
+			PrimaryKeyName: "C_2_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_2_1",
+					ReferencedTable: "C_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_3",
-			want: schema.SchemaTable{
-				Name: "C_3",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_31", Type: "integer"},
-					{Name: "PK_32", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_31", "PK_32"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "C_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_31", "PK_32"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_3",
+		want: schema.SchemaTable{
+			Name:   "C_3",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_31", Type: "integer", TypeName: "integer"},
+				{Name: "PK_32", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_31", "PK_32"},
+			PrimaryKeyName: "C_3_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_3_2",
+					ReferencedTable: "C_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_31", "PK_32"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_4",
-			want: schema.SchemaTable{
-				Name: "C_4",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_41", Type: "integer"},
-					{Name: "PK_42", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_41", "PK_42"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "C_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_41", "PK_42"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_4",
+		want: schema.SchemaTable{
+			Name:   "C_4",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_41", Type: "integer", TypeName: "integer"},
+				{Name: "PK_42", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_41", "PK_42"},
+			PrimaryKeyName: "C_4_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_4_2",
+					ReferencedTable: "C_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_41", "PK_42"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_5",
-			want: schema.SchemaTable{
-				Name: "C_5",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_51", Type: "integer"},
-					{Name: "PK_52", Type: "integer"},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_5",
+		want: schema.SchemaTable{
+			Name:   "C_5",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_51", Type: "integer", TypeName: "integer"},
+				{Name: "PK_52", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_51", "PK_52"},
+			PrimaryKeyName: "C_5_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_5_4",
+					ReferencedTable: "C_4",
+					ReferencedKey:   []string{"PK_41", "PK_42"},
+					ReferencingKey:  []string{"PK_51", "PK_52"},
 				},
-				PrimaryKey: []string{"PK_51", "PK_52"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "C_4",
-						ReferencedKey:   []string{"PK_41", "PK_42"},
-						ReferencingKey:  []string{"PK_51", "PK_52"},
-					},
-					{
-						ReferencedTable: "C_3",
-						ReferencedKey:   []string{"PK_31", "PK_32"},
-						ReferencingKey:  []string{"PK_51", "PK_52"},
-					},
+				{
+					Name:            "FK_C_5_3",
+					ReferencedTable: "C_3",
+					ReferencedKey:   []string{"PK_31", "PK_32"},
+					ReferencingKey:  []string{"PK_51", "PK_52"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_03_foreign_loop_1",
-			table: "D_1",
-			want: schema.SchemaTable{
-				Name: "D_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "integer"},
-					{Name: "PK_12", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "D_1",
-						ReferencedKey:   []string{"PK_12"},
-						ReferencingKey:  []string{"PK_11"},
-					},
-				},
-				UniqueKeys: []schema.SchemaUniqueKey{
-					{Name: "", Key: []string{"PK_12"}},
+	},
+	{
+		ddl:   "ddl_03_foreign_loop_1",
+		table: "D_1",
+		want: schema.SchemaTable{
+			Name:   "D_1",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "integer", TypeName: "integer"},
+				{Name: "PK_12", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_11", "PK_12"},
+			PrimaryKeyName: "D_1_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_D_1_1",
+					ReferencedTable: "D_1",
+					ReferencedKey:   []string{"PK_12"},
+					ReferencingKey:  []string{"PK_11"},
 				},
 			},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "D_1_PK_12_key", Key: []string{"PK_12"}},
+			},
 		},
-		{
-			ddl:   "ddl_04_foreign_loop_2",
-			table: "E_1",
-			want: schema.SchemaTable{
-				Name: "E_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "integer"},
-					{Name: "PK_12", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "E_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_11", "PK_12"},
-					},
+	},
+	{
+		ddl:   "ddl_04_foreign_loop_2",
+		table: "E_1",
+		want: schema.SchemaTable{
+			Name:   "E_1",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "integer", TypeName: "integer"},
+				{Name: "PK_12", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_11", "PK_12"},
+			PrimaryKeyName: "E_1_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_E_1_2",
+					ReferencedTable: "E_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_11", "PK_12"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_04_foreign_loop_2",
-			table: "E_2",
-			want: schema.SchemaTable{
-				Name: "E_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "integer"},
-					{Name: "PK_22", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "E_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
+	},
+	{
+		ddl:   "ddl_04_foreign_loop_2",
+		table: "E_2",
+		want: schema.SchemaTable{
+			Name:   "E_2",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "integer", TypeName: "integer"},
+				{Name: "PK_22", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_21", "PK_22"},
+			PrimaryKeyName: "E_2_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_E_2_1",
+					ReferencedTable: "E_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_1",
-			want: schema.SchemaTable{
-				Name: "F_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "integer"},
-					{Name: "PK_12", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "F_3",
-						ReferencedKey:   []string{"PK_31", "PK_32"},
-						ReferencingKey:  []string{"PK_11", "PK_12"},
-					},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_1",
+		want: schema.SchemaTable{
+			Name:   "F_1",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "integer", TypeName: "integer"},
+				{Name: "PK_12", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_11", "PK_12"},
+			PrimaryKeyName: "F_1_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_1_3",
+					ReferencedTable: "F_3",
+					ReferencedKey:   []string{"PK_31", "PK_32"},
+					ReferencingKey:  []string{"PK_11", "PK_12"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_2",
-			want: schema.SchemaTable{
-				Name: "F_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "integer"},
-					{Name: "PK_22", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "F_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_2",
+		want: schema.SchemaTable{
+			Name:   "F_2",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "integer", TypeName: "integer"},
+				{Name: "PK_22", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_21", "PK_22"},
+			PrimaryKeyName: "F_2_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_2_1",
+					ReferencedTable: "F_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_3",
-			want: schema.SchemaTable{
-				Name: "F_3",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_31", Type: "integer"},
-					{Name: "PK_32", Type: "integer"},
-				},
-				PrimaryKey: []string{"PK_31", "PK_32"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "F_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_31", "PK_32"},
-					},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_3",
+		want: schema.SchemaTable{
+			Name:   "F_3",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_31", Type: "integer", TypeName: "integer"},
+				{Name: "PK_32", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK_31", "PK_32"},
+			PrimaryKeyName: "F_3_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_3_2",
+					ReferencedTable: "F_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_31", "PK_32"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_07_unique_keys_constraint",
-			table: "H",
-			want: schema.SchemaTable{
-				Name: "H",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "integer"},
-					{Name: "C1", Type: "integer"},
-					{Name: "C2", Type: "integer"},
-					{Name: "C3", Type: "integer"},
+	},
+	{
+		ddl:   "ddl_07_unique_keys_constraint",
+		table: "H",
+		want: schema.SchemaTable{
+			Name:   "H",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer"},
+				{Name: "C1", Type: "integer", TypeName: "integer"},
+				{Name: "C2", Type: "integer", TypeName: "integer"},
+				{Name: "C3", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "H_pkey",
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "UQ_H_C1", Key: []string{"C1"}},
+				{Name: "UQ_H_C1_C2", Key: []string{"C1", "C2"}},
+				{Name: "UQ_H_C1_C2_C3", Key: []string{"C1", "C2", "C3"}},
+				{Name: "UQ_H_C1_C3", Key: []string{"C1", "C3"}},
+				{Name: "UQ_H_C1_C3_C2", Key: []string{"C1", "C3", "C2"}},
+				{Name: "UQ_H_C2", Key: []string{"C2"}},
+				{Name: "UQ_H_C2_C1", Key: []string{"C2", "C1"}},
+				{Name: "UQ_H_C2_C1_C3", Key: []string{"C2", "C1", "C3"}},
+				{Name: "UQ_H_C2_C3", Key: []string{"C2", "C3"}},
+				{Name: "UQ_H_C2_C3_C1", Key: []string{"C2", "C3", "C1"}},
+				{Name: "UQ_H_C3", Key: []string{"C3"}},
+				{Name: "UQ_H_C3_C1", Key: []string{"C3", "C1"}},
+				{Name: "UQ_H_C3_C1_C2", Key: []string{"C3", "C1", "C2"}},
+				{Name: "UQ_H_C3_C2", Key: []string{"C3", "C2"}},
+				{Name: "UQ_H_C3_C2_C1", Key: []string{"C3", "C2", "C1"}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_08_unique_keys_column",
+		table: "I",
+		want: schema.SchemaTable{
+			Name:   "I",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer"},
+				{Name: "C1", Type: "integer", TypeName: "integer"},
+				{Name: "C2", Type: "integer", TypeName: "integer"},
+				{Name: "C3", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "I_pkey",
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "I_C1_key", Key: []string{"C1"}},
+				{Name: "I_C2_key", Key: []string{"C2"}},
+				{Name: "I_C3_key", Key: []string{"C3"}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_09_schemas",
+		table: "J",
+		want: schema.SchemaTable{
+			Name:           "J",
+			Schema:         "public",
+			Columns:        []schema.SchemaColumn{{Name: "PK", Type: "integer", TypeName: "integer"}},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "J_pkey",
+		},
+	},
+	{
+		ddl:   "ddl_09_schemas",
+		table: "S_1.J",
+		want: schema.SchemaTable{
+			Name:   "J",
+			Schema: "S_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer"},
+				{Name: "C", Type: "integer", TypeName: "integer"},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "J_pkey",
+			UniqueKeys:     []schema.SchemaUniqueKey{{Name: "UQ_J", Key: []string{"C"}}},
+		},
+	},
+	{
+		ddl:   "ddl_09_schemas",
+		table: `"S_2"."J"`,
+		want: schema.SchemaTable{
+			Name:   "J",
+			Schema: "S_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "bigint", TypeName: "bigint"},
+				{Name: "R", Type: "integer", TypeName: "integer", Nullable: true},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "J_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_J", ReferencedTable: "S_1.J", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"R"}},
+			},
+			UniqueKeys: []schema.SchemaUniqueKey{{Name: "UQ_J", Key: []string{"PK", "R"}}},
+		},
+	},
+	{
+		ddl:   "ddl_09_schemas",
+		table: "S_2.K",
+		want: schema.SchemaTable{
+			Name:   "K",
+			Schema: "S_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer"},
+				{Name: "J", Type: "bigint", TypeName: "bigint"},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "K_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_J", ReferencedTable: "J", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"J"}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_10_indexes",
+		table: "L",
+		want: schema.SchemaTable{
+			Name:   "L",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer"},
+				{Name: "C1", Type: "integer", TypeName: "integer"},
+				{Name: "C2", Type: "character varying", TypeName: "character varying(50)", Nullable: true},
+				{Name: "C3", Type: "double precision", TypeName: "double precision", Nullable: true},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "L_pkey",
+			UniqueKeys:     []schema.SchemaUniqueKey{{Name: "UQ_L_C1", Key: []string{"C1"}}},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_L_C1", Key: []schema.SchemaIndexKey{{Name: "C1"}}, Method: "btree"},
+				{Name: "IDX_L_C1_Hash", Key: []schema.SchemaIndexKey{{Name: "C1"}}, Method: "hash"},
+				{Name: "IDX_L_C2_C1", Key: []schema.SchemaIndexKey{{Name: "C2", Desc: true}, {Name: "C1"}}, Method: "btree"},
+				{Name: "IDX_L_C2_Partial", Key: []schema.SchemaIndexKey{{Name: "C2"}}, Method: "btree", Predicate: `("C2" IS NOT NULL)`},
+				{Name: "IDX_L_Expression", Key: []schema.SchemaIndexKey{{Expression: `lower(("C2")::text)`}}, Method: "btree"},
+				{Name: "IDX_L_Expression_Desc", Key: []schema.SchemaIndexKey{{Expression: `"C1" + 1`, Desc: true}, {Name: "C3"}}, Method: "btree"},
+				{Name: "UQ_L_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3", Desc: true}}, Method: "btree"},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_11_column_defaults",
+		table: "M",
+		want: schema.SchemaTable{
+			Name:   "M",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer", Default: `nextval('"M_PK_seq"'::regclass)`},
+				{Name: "C1", Type: "integer", TypeName: "integer", Default: "0"},
+				{Name: "C2", Type: "character varying", TypeName: "character varying(50)", Nullable: true, Default: "'abc'::character varying"},
+				{Name: "C3", Type: "timestamp with time zone", TypeName: "timestamp with time zone", Nullable: true, Default: "now()"},
+				{Name: "C4", Type: "bigint", TypeName: "bigint", Identity: "ALWAYS"},
+				{Name: "C5", Type: "integer", TypeName: "integer", Identity: "BY DEFAULT"},
+				{Name: "C6", Type: "integer", TypeName: "integer", Nullable: true, Generated: `("C1" * 2)`, Stored: true},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "M_pkey",
+			Sequences: []schema.SchemaSequence{
+				{Name: "M_PK_seq", Column: "PK", Start: 1, Increment: 1},
+				{Name: "M_C4_seq", Column: "C4", Start: 1, Increment: 1},
+				{Name: "M_C5_seq", Column: "C5", Start: 1, Increment: 1},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_12_checks",
+		table: "N",
+		want: schema.SchemaTable{
+			Name:   "N",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer"},
+				{Name: "C1", Type: "integer", TypeName: "integer"},
+				{Name: "C2", Type: "text", TypeName: "text", Nullable: true},
+				{Name: "C3", Type: "integer", TypeName: "integer", Nullable: true},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "N_pkey",
+			Checks: []schema.SchemaCheck{
+				{Name: "CK_N_C1_C3", Expression: `("C1" < "C3")`},
+				{Name: "CK_N_C2", Expression: `(length("C2") < 10)`},
+				{Name: "N_C1_check", Expression: `("C1" >= 0)`},
+				{Name: "N_C3_check", Expression: `("C3" <> 0)`},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_13_foreign_key_actions",
+		table: "O_2",
+		want: schema.SchemaTable{
+			Name:   "O_2",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer"},
+				{Name: "R1", Type: "integer", TypeName: "integer", Nullable: true},
+				{Name: "R2", Type: "integer", TypeName: "integer", Nullable: true},
+				{Name: "R3", Type: "integer", TypeName: "integer", Nullable: true},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "O_2_pkey",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_O_2_1",
+					ReferencedTable: "O_1",
+					ReferencedKey:   []string{"PK"},
+					ReferencingKey:  []string{"R1"},
+					OnDelete:        "CASCADE",
+					OnUpdate:        "RESTRICT",
+				},
+				{
+					Name:              "FK_O_2_2",
+					ReferencedTable:   "O_1",
+					ReferencedKey:     []string{"C1"},
+					ReferencingKey:    []string{"R2"},
+					OnDelete:          "SET NULL",
+					Match:             "FULL",
+					Deferrable:        true,
+					InitiallyDeferred: true,
 				},
-				PrimaryKey: []string{"PK"},
-				UniqueKeys: []schema.SchemaUniqueKey{
-					{Name: "", Key: []string{"C1"}},
-					{Name: "", Key: []string{"C1", "C2"}},
-					{Name: "", Key: []string{"C1", "C2", "C3"}},
-					{Name: "", Key: []string{"C1", "C3"}},
-					{Name: "", Key: []string{"C1", "C3", "C2"}},
-					{Name: "", Key: []string{"C2"}},
-					{Name: "", Key: []string{"C2", "C1"}},
-					{Name: "", Key: []string{"C2", "C1", "C3"}},
-					{Name: "", Key: []string{"C2", "C3"}},
-					{Name: "", Key: []string{"C2", "C3", "C1"}},
-					{Name: "", Key: []string{"C3"}},
-					{Name: "", Key: []string{"C3", "C1"}},
-					{Name: "", Key: []string{"C3", "C1", "C2"}},
-					{Name: "", Key: []string{"C3", "C2"}},
-					{Name: "", Key: []string{"C3", "C2", "C1"}},
+				{
+					Name:            "FK_O_2_3",
+					ReferencedTable: "O_1",
+					ReferencedKey:   []string{"PK"},
+					ReferencingKey:  []string{"R3"},
+					OnUpdate:        "SET DEFAULT",
+					Deferrable:      true,
 				},
 			},
 		},
-		{
-			ddl:   "ddl_08_unique_keys_column",
-			table: "I",
-			want: schema.SchemaTable{
-				Name: "I",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "integer"},
-					{Name: "C1", Type: "integer"},
-					{Name: "C2", Type: "integer"},
-					{Name: "C3", Type: "integer"},
+	},
+	{
+		ddl:   "ddl_15_types",
+		table: "Q",
+		want: schema.SchemaTable{
+			Name:   "Q",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "bigint", TypeName: "bigint", Identity: "BY DEFAULT"},
+				{Name: "C1", Type: "USER-DEFINED", TypeName: `"Mood"`, UserType: "Mood"},
+				{Name: "C2", Type: "ARRAY", TypeName: `"Mood"[]`, ElemType: "USER-DEFINED", UserType: "Mood", Nullable: true},
+				{Name: "C3", Type: "integer", TypeName: `"Positive"`, UserType: "Positive"},
+				{Name: "C4", Type: "USER-DEFINED", TypeName: `"S_4"."Point"`, UserType: "S_4.Point", Nullable: true},
+				{Name: "C5", Type: "ARRAY", TypeName: "text[]", ElemType: "text", Nullable: true},
+				{Name: "C6", Type: "numeric", TypeName: "numeric(10,2)", Nullable: true},
+				{Name: "C7", Type: "timestamp with time zone", TypeName: "timestamp(3) with time zone", Nullable: true},
+			},
+			PrimaryKey:     []string{"PK"},
+			PrimaryKeyName: "Q_pkey",
+			Types: []schema.SchemaType{
+				{
+					Name:   "Point",
+					Schema: "S_4",
+					Kind:   "COMPOSITE",
+					Fields: []schema.SchemaField{{Name: "X", Type: "double precision"}, {Name: "Y", Type: "double precision"}},
 				},
-				PrimaryKey: []string{"PK"},
-				UniqueKeys: []schema.SchemaUniqueKey{
-					{Name: "", Key: []string{"C1"}},
-					{Name: "", Key: []string{"C2"}},
-					{Name: "", Key: []string{"C3"}},
+				{Name: "Mood", Schema: "public", Kind: "ENUM", Labels: []string{"sad", "ok", "happy"}},
+				{
+					Name:     "Positive",
+					Schema:   "public",
+					Kind:     "DOMAIN",
+					BaseType: "integer",
+					NotNull:  true,
+					Default:  "1",
+					Checks:   []schema.SchemaCheck{{Name: "Positive_check", Expression: "(VALUE > 0)"}},
 				},
 			},
+			Sequences: []schema.SchemaSequence{{Name: "Q_PK_seq", Column: "PK", Start: 100, Increment: 10}},
 		},
-	}
+	},
+}
 
-	for number, testcase := range testcases {
+func TestFetcher(t *testing.T) {
+	for number, testcase := range fetcherTestcases {
 		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
 			now := time.Now().Unix()
 			dbName := fmt.Sprintf("test_fetcher_%03d_%d", number, now)
@@ -366,11 +633,138 @@ func TestFetcher(t *testing.T) {
 	}
 }
 
+var listTablesTestcases = []struct {
+	ddl  string
+	want []string
+}{
+	{ddl: "ddl_00_all_types", want: []string{"A"}},
+	{ddl: "ddl_02_foreign_keys", want: []string{"C_1", "C_2", "C_3", "C_4", "C_5"}},
+	{ddl: "ddl_03_foreign_loop_1", want: []string{"D_1"}},
+	{ddl: "ddl_04_foreign_loop_2", want: []string{"E_1", "E_2"}},
+	{ddl: "ddl_05_foreign_loop_3", want: []string{"F_1", "F_2", "F_3"}},
+	{ddl: "ddl_07_unique_keys_constraint", want: []string{"H"}},
+	{ddl: "ddl_08_unique_keys_column", want: []string{"I"}},
+	{ddl: "ddl_09_schemas", want: []string{"J", "S_1.J", "S_2.J", "S_2.K"}},
+	{ddl: "ddl_10_indexes", want: []string{"L"}},
+	{ddl: "ddl_11_column_defaults", want: []string{"M"}},
+	{ddl: "ddl_12_checks", want: []string{"N"}},
+	{ddl: "ddl_13_foreign_key_actions", want: []string{"O_1", "O_2"}},
+	{ddl: "ddl_14_views", want: []string{"P_1", "S_3.P_2"}},
+	{ddl: "ddl_15_types", want: []string{"Q"}},
+}
+
+func TestListTables(t *testing.T) {
+	for number, testcase := range listTablesTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			now := time.Now().Unix()
+			dbName := fmt.Sprintf("test_lister_%03d_%d", number, now)
+			db, teardown := test.Setup(t, *test.DataSource, dbName)
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db)
+			got, err := sut.ListTables(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+var referencedByTestcases = []struct {
+	ddl   string
+	table string
+	want  []schema.SchemaReference
+}{
+	{ddl: "ddl_00_all_types", table: "A"},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_2",
+		want: []schema.SchemaReference{
+			{Name: "FK_C_3_2", ReferencingTable: "C_3", ReferencingKey: []string{"PK_31", "PK_32"}, ReferencedKey: []string{"PK_21", "PK_22"}},
+			{Name: "FK_C_4_2", ReferencingTable: "C_4", ReferencingKey: []string{"PK_41", "PK_42"}, ReferencedKey: []string{"PK_21", "PK_22"}},
+		},
+	},
+	{
+		ddl:   "ddl_03_foreign_loop_1",
+		table: "D_1",
+		want: []schema.SchemaReference{
+			{Name: "FK_D_1_1", ReferencingTable: "D_1", ReferencingKey: []string{"PK_11"}, ReferencedKey: []string{"PK_12"}},
+		},
+	},
+	{
+		ddl:   "ddl_09_schemas",
+		table: "S_1.J",
+		want: []schema.SchemaReference{
+			{Name: "FK_J", ReferencingTable: "S_2.J", ReferencingKey: []string{"R"}, ReferencedKey: []string{"PK"}},
+		},
+	},
+	{
+		ddl:   "ddl_09_schemas",
+		table: "S_2.J",
+		want: []schema.SchemaReference{
+			{Name: "FK_J", ReferencingTable: "K", ReferencingKey: []string{"J"}, ReferencedKey: []string{"PK"}},
+		},
+	},
+}
+
+func TestFetcher_WithReferencedBy(t *testing.T) {
+	for number, testcase := range referencedByTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			now := time.Now().Unix()
+			dbName := fmt.Sprintf("test_referenced_by_%03d_%d", number, now)
+			db, teardown := test.Setup(t, *test.DataSource, dbName)
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db).WithReferencedBy()
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got.ReferencedBy)
+		})
+	}
+}
+
+func TestFetcher_WithSearchPath(t *testing.T) {
+	now := time.Now().Unix()
+	db, teardown := test.Setup(t, *test.DataSource, fmt.Sprintf("test_search_path_%d", now))
+	defer teardown()
+
+	test.InitDDLs(t, db, []string{ddls["ddl_09_schemas"]})
+
+	sut := schema.NewFetcher(db).WithSearchPath("S_2", "S_1")
+	t.Run("fetch", func(t *testing.T) {
+		got, err := sut.Fetch(context.Background(), "J")
+		assert.Nil(t, err)
+		assert.Equal(t, "S_2", got.Schema)
+		assert.Equal(t, "J", got.Name)
+		assert.Equal(t, []schema.SchemaForeignKey{
+			{Name: "FK_J", ReferencedTable: "S_1.J", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"R"}},
+		}, got.ForeignKeys)
+	})
+	t.Run("list", func(t *testing.T) {
+		got, err := sut.ListTables(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"J", "K", "S_1.J", "public.J"}, got)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		_, err := schema.NewFetcher(db).WithSearchPath("S_1").Fetch(context.Background(), "K")
+		assert.NotNil(t, err)
+	})
+}
+
 func assertEqualSchemaTable(t *testing.T, want schema.SchemaTable, got schema.SchemaTable) {
 	t.Helper()
 	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.Schema, got.Schema)
 	assert.Equal(t, want.PrimaryKey, got.PrimaryKey)
+	assert.Equal(t, want.PrimaryKeyName, got.PrimaryKeyName)
 	assert.Equal(t, want.Columns, got.Columns)
 	assert.ElementsMatch(t, want.ForeignKeys, got.ForeignKeys)
 	assert.ElementsMatch(t, want.UniqueKeys, got.UniqueKeys)
+	assert.ElementsMatch(t, want.Indexes, got.Indexes)
+	assert.Equal(t, want.Checks, got.Checks)
+	assert.Equal(t, want.Types, got.Types)
+	assert.Equal(t, want.Sequences, got.Sequences)
 }
@@ -0,0 +1,259 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+// ConvertTable converts the table into the dialect-neutral schema.
+// Identity columns and columns whose defaults call nextval such as serial columns are regarded as auto-incremented.
+// Native types are TypeName if it is given, from which lengths of strings and precisions of decimals are taken.
+func ConvertTable(table SchemaTable) schema.Table {
+	return schema.Table{
+		Name:   table.Name,
+		Schema: table.Schema,
+		Columns: lo.Map(table.Columns, func(column SchemaColumn, _ int) schema.Column {
+			return schema.Column{
+				Name:          column.Name,
+				Type:          convertColumnType(table, column),
+				NativeType:    lo.Ternary(column.TypeName != "", column.TypeName, column.Type),
+				Nullable:      column.Nullable,
+				Default:       column.Default,
+				Generated:     column.Generated,
+				Stored:        column.Stored,
+				AutoIncrement: column.Identity != "" || strings.HasPrefix(column.Default, "nextval("),
+			}
+		}),
+		PrimaryKey: table.PrimaryKey,
+		ForeignKeys: lo.Map(table.ForeignKeys, func(key SchemaForeignKey, _ int) schema.ForeignKey {
+			return schema.ForeignKey{
+				Name:            key.Name,
+				ReferencedTable: key.ReferencedTable,
+				ReferencedKey:   key.ReferencedKey,
+				ReferencingKey:  key.ReferencingKey,
+				OnDelete:        key.OnDelete,
+				OnUpdate:        key.OnUpdate,
+			}
+		}),
+		UniqueKeys: lo.Map(table.UniqueKeys, func(key SchemaUniqueKey, _ int) schema.UniqueKey {
+			return schema.UniqueKey{Name: key.Name, Key: key.Key}
+		}),
+		Indexes: lo.Map(table.Indexes, func(index SchemaIndex, _ int) schema.Index {
+			return schema.Index{
+				Name:   index.Name,
+				Unique: index.Unique,
+				Key: lo.Map(index.Key, func(key SchemaIndexKey, _ int) schema.IndexKey {
+					return schema.IndexKey{Name: key.Name, Desc: key.Desc, Expression: key.Expression}
+				}),
+				Predicate: index.Predicate,
+			}
+		}),
+		Checks: lo.Map(table.Checks, func(check SchemaCheck, _ int) schema.Check {
+			return schema.Check{Name: check.Name, Expression: check.Expression}
+		}),
+	}
+}
+
+// ConvertType converts the data type shown by information_schema into the logical type.
+// Lengths of strings are not available and element types of arrays are regarded as other since the data type does not include them.
+func ConvertType(dataType string) schema.Type {
+	switch dataType {
+	case "smallint", "integer", "bigint":
+		return schema.Type{Kind: schema.TypeKindInteger}
+	case "real", "double precision":
+		return schema.Type{Kind: schema.TypeKindFloat}
+	case "numeric", "money":
+		return schema.Type{Kind: schema.TypeKindDecimal}
+	case "character", "character varying", "text":
+		return schema.Type{Kind: schema.TypeKindString}
+	case "bytea":
+		return schema.Type{Kind: schema.TypeKindBytes}
+	case "boolean":
+		return schema.Type{Kind: schema.TypeKindBool}
+	case "date":
+		return schema.Type{Kind: schema.TypeKindDate}
+	case "timestamp without time zone", "timestamp with time zone":
+		return schema.Type{Kind: schema.TypeKindTimestamp}
+	case "json", "jsonb":
+		return schema.Type{Kind: schema.TypeKindJSON}
+	case "ARRAY":
+		return schema.Type{Kind: schema.TypeKindArray, Elem: &schema.Type{Kind: schema.TypeKindOther}}
+	default:
+		return schema.Type{Kind: schema.TypeKindOther}
+	}
+}
+
+// convertColumnType converts the type of the column into the logical type, in which values of enum types are regarded as strings.
+func convertColumnType(table SchemaTable, column SchemaColumn) schema.Type {
+	convert := func(dataType string) schema.Type {
+		if dataType != "USER-DEFINED" {
+			return ConvertType(dataType)
+		}
+		if userType, found := table.UserType(column); found && userType.Kind == "ENUM" {
+			return schema.Type{Kind: schema.TypeKindString}
+		}
+		return schema.Type{Kind: schema.TypeKindOther}
+	}
+
+	if column.Type == "ARRAY" && column.ElemType != "" {
+		elem := convert(column.ElemType)
+		return schema.Type{Kind: schema.TypeKindArray, Elem: &elem}
+	}
+
+	t := convert(column.Type)
+	_, rest, found := strings.Cut(column.TypeName, "(")
+	if !found || column.UserType != "" {
+		return t
+	}
+	rest, _, _ = strings.Cut(rest, ")")
+	params := []int64{}
+	for _, param := range strings.Split(rest, ",") {
+		if value, err := strconv.ParseInt(strings.TrimSpace(param), 10, 64); err == nil {
+			params = append(params, value)
+		}
+	}
+	switch {
+	case t.Kind == schema.TypeKindString && len(params) == 1:
+		t.Length = params[0]
+	case t.Kind == schema.TypeKindDecimal && len(params) == 2:
+		t.Precision, t.Scale = params[0], params[1]
+	}
+	return t
+}
+
+// ConvertFromTables converts the dialect-neutral tables into tables in PostgreSQL with warnings on parts that cannot be converted.
+// Interleaved tables are converted into tables having foreign keys referencing their parents.
+// Auto-incremented integer columns are converted into identity columns, and expressions are converted as written.
+func ConvertFromTables(tables []schema.Table) ([]SchemaTable, []schema.Warning) {
+	var warnings []schema.Warning
+	warn := func(table schema.Table, format string, args ...any) {
+		warnings = append(warnings, schema.Warning{Table: table.Name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	converted := []SchemaTable{}
+	for _, table := range tables {
+		postgresTable := SchemaTable{
+			Name:       table.Name,
+			Schema:     table.Schema,
+			PrimaryKey: table.PrimaryKey,
+		}
+		for _, column := range table.Columns {
+			columnType, ok := ConvertFromType(column.Type)
+			if !ok {
+				warn(table, "type %s of column %s is converted into %s", column.NativeType, column.Name, columnType)
+			}
+			dataType, elemType := dataTypeOf(columnType)
+			postgresColumn := SchemaColumn{
+				Name:      column.Name,
+				Type:      dataType,
+				TypeName:  columnType,
+				ElemType:  elemType,
+				Nullable:  column.Nullable,
+				Default:   column.Default,
+				Generated: column.Generated,
+				Stored:    column.Stored,
+			}
+			if column.Generated != "" && !column.Stored {
+				warn(table, "virtual generated column %s is converted into a stored generated column", column.Name)
+				postgresColumn.Stored = true
+			}
+			if column.AutoIncrement {
+				if column.Type.Kind == schema.TypeKindInteger {
+					postgresColumn.Default, postgresColumn.Identity = "", "BY DEFAULT"
+				} else {
+					warn(table, "auto-increment of column %s is ignored since the column is not an integer", column.Name)
+				}
+			}
+			postgresTable.Columns = append(postgresTable.Columns, postgresColumn)
+		}
+		if table.Parent != "" {
+			if foreignKey, found := schema.ParentForeignKey(table, tables); found {
+				warn(table, "interleaving in %s is converted into a foreign key", table.Parent)
+				table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+			} else {
+				warn(table, "interleaving in %s is ignored", table.Parent)
+			}
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			postgresTable.ForeignKeys = append(postgresTable.ForeignKeys, SchemaForeignKey{
+				Name:            foreignKey.Name,
+				ReferencedTable: foreignKey.ReferencedTable,
+				ReferencedKey:   foreignKey.ReferencedKey,
+				ReferencingKey:  foreignKey.ReferencingKey,
+				OnDelete:        foreignKey.OnDelete,
+				OnUpdate:        foreignKey.OnUpdate,
+			})
+		}
+		for _, uniqueKey := range table.UniqueKeys {
+			postgresTable.UniqueKeys = append(postgresTable.UniqueKeys, SchemaUniqueKey{Name: uniqueKey.Name, Key: uniqueKey.Key})
+		}
+		for _, index := range table.Indexes {
+			postgresTable.Indexes = append(postgresTable.Indexes, SchemaIndex{
+				Name:   index.Name,
+				Unique: index.Unique,
+				Key: lo.Map(index.Key, func(key schema.IndexKey, _ int) SchemaIndexKey {
+					return SchemaIndexKey{Name: key.Name, Desc: key.Desc, Expression: key.Expression}
+				}),
+				Predicate: index.Predicate,
+			})
+		}
+		for _, check := range table.Checks {
+			postgresTable.Checks = append(postgresTable.Checks, SchemaCheck{Name: check.Name, Expression: check.Expression})
+		}
+		converted = append(converted, postgresTable)
+	}
+	return converted, warnings
+}
+
+// dataTypeOf returns the data_type of the type written by ConvertFromType and the data_type of its elements if the type is an array.
+func dataTypeOf(typeName string) (dataType string, elemType string) {
+	if elem, found := strings.CutSuffix(typeName, "[]"); found {
+		elemType, _ = dataTypeOf(elem)
+		return "ARRAY", elemType
+	}
+	dataType, _, _ = strings.Cut(typeName, "(")
+	return dataType, ""
+}
+
+// ConvertFromType returns the PostgreSQL type corresponding to the logical type.
+// It returns text and false if the logical type has no counterpart.
+func ConvertFromType(t schema.Type) (string, bool) {
+	switch t.Kind {
+	case schema.TypeKindInteger:
+		return "bigint", true
+	case schema.TypeKindFloat:
+		return "double precision", true
+	case schema.TypeKindDecimal:
+		if t.Precision > 0 {
+			return fmt.Sprintf("numeric(%d,%d)", t.Precision, t.Scale), true
+		}
+		return "numeric", true
+	case schema.TypeKindString:
+		if t.Length > 0 {
+			return fmt.Sprintf("character varying(%d)", t.Length), true
+		}
+		return "text", true
+	case schema.TypeKindBytes:
+		return "bytea", true
+	case schema.TypeKindBool:
+		return "boolean", true
+	case schema.TypeKindDate:
+		return "date", true
+	case schema.TypeKindTimestamp:
+		return "timestamp with time zone", true
+	case schema.TypeKindJSON:
+		return "jsonb", true
+	case schema.TypeKindArray:
+		if t.Elem == nil {
+			return "text[]", false
+		}
+		elemType, ok := ConvertFromType(*t.Elem)
+		return elemType + "[]", ok
+	default:
+		return "text", false
+	}
+}
@@ -0,0 +1,126 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertType(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want gf_schema.Type
+	}{
+		{in: "smallint", want: gf_schema.Type{Kind: gf_schema.TypeKindInteger}},
+		{in: "bigint", want: gf_schema.Type{Kind: gf_schema.TypeKindInteger}},
+		{in: "double precision", want: gf_schema.Type{Kind: gf_schema.TypeKindFloat}},
+		{in: "numeric", want: gf_schema.Type{Kind: gf_schema.TypeKindDecimal}},
+		{in: "character varying", want: gf_schema.Type{Kind: gf_schema.TypeKindString}},
+		{in: "bytea", want: gf_schema.Type{Kind: gf_schema.TypeKindBytes}},
+		{in: "boolean", want: gf_schema.Type{Kind: gf_schema.TypeKindBool}},
+		{in: "date", want: gf_schema.Type{Kind: gf_schema.TypeKindDate}},
+		{in: "timestamp with time zone", want: gf_schema.Type{Kind: gf_schema.TypeKindTimestamp}},
+		{in: "jsonb", want: gf_schema.Type{Kind: gf_schema.TypeKindJSON}},
+		{in: "ARRAY", want: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindOther}}},
+		{in: "uuid", want: gf_schema.Type{Kind: gf_schema.TypeKindOther}},
+		{in: "USER-DEFINED", want: gf_schema.Type{Kind: gf_schema.TypeKindOther}},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			got := schema.ConvertType(testcase.in)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestConvertFromType(t *testing.T) {
+	testcases := []struct {
+		in     gf_schema.Type
+		want   string
+		wantOk bool
+	}{
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, want: "bigint", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}, want: "character varying(10)", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 10, Scale: 2}, want: "numeric(10,2)", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindBool}}, want: "boolean[]", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindOther}, want: "text", wantOk: false},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			got, ok := schema.ConvertFromType(testcase.in)
+			assert.Equal(t, testcase.want, got)
+			assert.Equal(t, testcase.wantOk, ok)
+		})
+	}
+}
+
+func TestConvertTable(t *testing.T) {
+	sut, err := schema.NewDDLFetcher(`CREATE TYPE mood AS ENUM ('sad', 'happy');
+CREATE TABLE app.parent (id int PRIMARY KEY);
+CREATE TABLE app.child (
+	id bigserial PRIMARY KEY,
+	seq int GENERATED BY DEFAULT AS IDENTITY,
+	parent_id int NOT NULL CONSTRAINT fk_parent REFERENCES app.parent ON DELETE CASCADE,
+	code varchar(10) UNIQUE,
+	price numeric DEFAULT 0 CHECK (price >= 0),
+	amount numeric(10, 2),
+	moods mood[],
+	tags text[]
+);
+CREATE INDEX child_code_idx ON app.child (code DESC);`)
+	assert.Nil(t, err)
+	table, err := sut.Fetch(context.Background(), "app.child")
+	assert.Nil(t, err)
+
+	got := schema.ConvertTable(table)
+
+	want := gf_schema.Table{
+		Name:   "child",
+		Schema: "app",
+		Columns: []gf_schema.Column{
+			{Name: "id", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "bigint", Default: "nextval('app.child_id_seq'::regclass)", AutoIncrement: true},
+			{Name: "seq", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "integer", AutoIncrement: true},
+			{Name: "parent_id", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "integer"},
+			{Name: "code", Type: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}, NativeType: "character varying(10)", Nullable: true},
+			{Name: "price", Type: gf_schema.Type{Kind: gf_schema.TypeKindDecimal}, NativeType: "numeric", Nullable: true, Default: "0"},
+			{Name: "amount", Type: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 10, Scale: 2}, NativeType: "numeric(10,2)", Nullable: true},
+			{Name: "moods", Type: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindString}}, NativeType: "mood[]", Nullable: true},
+			{Name: "tags", Type: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindString}}, NativeType: "text[]", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []gf_schema.ForeignKey{
+			{Name: "fk_parent", ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"parent_id"}, OnDelete: "CASCADE"},
+		},
+		UniqueKeys: []gf_schema.UniqueKey{{Name: "child_code_key", Key: []string{"code"}}},
+		Indexes: []gf_schema.Index{
+			{Name: "child_code_idx", Key: []gf_schema.IndexKey{{Name: "code", Desc: true}}},
+		},
+		Checks: []gf_schema.Check{{Name: "child_price_check", Expression: "price >= 0"}},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestConvertFromTables_Types(t *testing.T) {
+	tables := []gf_schema.Table{{
+		Name: "T",
+		Columns: []gf_schema.Column{
+			{Name: "C1", Type: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 50}},
+			{Name: "C2", Type: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 10, Scale: 2}},
+			{Name: "C3", Type: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}}},
+		},
+	}}
+
+	got, warnings := schema.ConvertFromTables(tables)
+	assert.Empty(t, warnings)
+	assert.Equal(t, []schema.SchemaColumn{
+		{Name: "C1", Type: "character varying", TypeName: "character varying(50)"},
+		{Name: "C2", Type: "numeric", TypeName: "numeric(10,2)"},
+		{Name: "C3", Type: "ARRAY", TypeName: "character varying(10)[]", ElemType: "character varying"},
+	}, got[0].Columns)
+}
@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"strings"
+)
+
+// splitQualifiedName splits a table name possibly qualified by a schema such as schema.table into the schema and the table.
+// Parts containing dots or double quotes can be written as quoted identifiers such as "my.schema"."my.table".
+// The returned schema is empty if the name is not qualified, and the whole name is regarded as a table if it is not well-formed.
+func splitQualifiedName(name string) (schemaName string, table string) {
+	parts := []string{}
+	for i := 0; ; i++ {
+		var part strings.Builder
+		if strings.HasPrefix(name[i:], `"`) {
+			for i++; ; i++ {
+				if i >= len(name) {
+					return "", name
+				}
+				if name[i] == '"' {
+					if i+1 < len(name) && name[i+1] == '"' {
+						part.WriteByte('"')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				part.WriteByte(name[i])
+			}
+		} else {
+			for ; i < len(name) && name[i] != '.'; i++ {
+				part.WriteByte(name[i])
+			}
+		}
+		parts = append(parts, part.String())
+
+		if i >= len(name) {
+			break
+		}
+		if name[i] != '.' {
+			return "", name
+		}
+	}
+
+	switch len(parts) {
+	case 1:
+		return "", parts[0]
+	case 2:
+		return parts[0], parts[1]
+	default:
+		return "", name
+	}
+}
+
+// qualifiedName returns the table name qualified by the schema, which is a form accepted by splitQualifiedName.
+// The table name is not qualified if the schema is empty.
+func qualifiedName(schemaName string, table string) string {
+	quote := func(part string) string {
+		if !strings.ContainsAny(part, `."`) {
+			return part
+		}
+		return `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	if schemaName == "" {
+		return quote(table)
+	}
+	return quote(schemaName) + "." + quote(table)
+}
@@ -0,0 +1,1669 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/ddl"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+type ddlForeignKey struct {
+	name string
+	key  SchemaForeignKey
+}
+
+type ddlUniqueKey struct {
+	name string
+	key  []string
+}
+
+type ddlTable struct {
+	name           string
+	schema         string
+	columns        []SchemaColumn
+	primaryKeyName string
+	primaryKey     []string
+	foreignKeys    []ddlForeignKey
+	uniqueKeys     []ddlUniqueKey
+	indexes        []SchemaIndex
+	checks         []SchemaCheck
+	sequences      []SchemaSequence
+}
+
+// ddlUserType is a user-defined type created by CREATE TYPE or CREATE DOMAIN.
+type ddlUserType struct {
+	schemaType SchemaType
+	// base is the underlying type of the domain type.
+	base ddlType
+}
+
+// defaultSchema is the schema of tables whose names are not qualified in DDL statements.
+const defaultSchema = "public"
+
+type ddlFetcher struct {
+	tables       map[string]*ddlTable
+	types        map[string]*ddlUserType
+	referencedBy bool
+}
+
+// NewDDLFetcher parses DDL statements and returns a fetcher that provides schemas of the defined tables without querying a database.
+// CREATE TABLE, CREATE INDEX, CREATE TYPE, CREATE DOMAIN, ALTER TABLE, ALTER TYPE ... ADD VALUE, DROP TABLE, DROP INDEX, DROP TYPE, and DROP DOMAIN statements are interpreted in order and the other statements are ignored.
+// Column types are normalized to data_type of information_schema.columns and formatted in the same way as format_type into TypeName, while default values, generation expressions, and predicates of partial indexes are kept as written.
+// Columns of serial types have defaults calling nextval of the sequences named in the same way as PostgreSQL.
+// Sequences owned by serial columns and identity columns are provided without current values.
+// Tables whose names are not qualified by schemas are regarded as tables in the public schema.
+// Unique indexes are not unique constraints in PostgreSQL, so they are not contained in the unique keys unless they are added by ALTER TABLE ... ADD CONSTRAINT ... UNIQUE USING INDEX.
+func NewDDLFetcher(ddlStatements string) (ddlFetcher, error) {
+	tokens, err := ddl.Tokenize(ddlStatements)
+	if err != nil {
+		return ddlFetcher{}, fmt.Errorf(`fail to parse DDL: %w`, err)
+	}
+
+	parser := ddlParser{tables: map[string]*ddlTable{}, types: map[string]*ddlUserType{}}
+	for _, stmt := range ddl.Split(tokens) {
+		if err := parser.parseStatement(ddl.NewParser(ddlStatements, stmt)); err != nil {
+			return ddlFetcher{}, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+	}
+
+	return ddlFetcher{tables: parser.tables, types: parser.types}, nil
+}
+
+// WithReferencedBy returns a copy of the fetcher that also provides foreign keys referencing the table in ReferencedBy.
+func (fetcher ddlFetcher) WithReferencedBy() ddlFetcher {
+	fetcher.referencedBy = true
+	return fetcher
+}
+
+var _ schema.Fetcher[SchemaTable] = ddlFetcher{}
+var _ schema.Lister = ddlFetcher{}
+
+func (fetcher ddlFetcher) Fetch(ctx context.Context, table string) (SchemaTable, error) {
+	t, found := fetcher.tables[tableKey(splitQualifiedName(table))]
+	if !found {
+		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: table %q not found`, table, table)
+	}
+
+	schemaTable := SchemaTable{
+		Name:       t.name,
+		Schema:     t.schema,
+		Columns:    slices.Clone(t.columns),
+		PrimaryKey: slices.Clone(t.primaryKey),
+	}
+	if len(t.primaryKey) > 0 {
+		schemaTable.PrimaryKeyName = t.primaryKeyName
+	}
+
+	foreignKeys := slices.Clone(t.foreignKeys)
+	slices.SortStableFunc(foreignKeys, func(a, b ddlForeignKey) int { return strings.Compare(a.name, b.name) })
+	for _, foreignKey := range foreignKeys {
+		key := foreignKey.key
+		key.Name = foreignKey.name
+		if len(key.ReferencedKey) == 0 {
+			if referenced, found := fetcher.tables[key.ReferencedTable]; found {
+				key.ReferencedKey = slices.Clone(referenced.primaryKey)
+			}
+		}
+		if referencedSchema, referencedTable := splitQualifiedName(key.ReferencedTable); referencedSchema == t.schema {
+			key.ReferencedTable = qualifiedName("", referencedTable)
+		}
+		schemaTable.ForeignKeys = append(schemaTable.ForeignKeys, key)
+	}
+
+	uniqueKeys := slices.Clone(t.uniqueKeys)
+	slices.SortStableFunc(uniqueKeys, func(a, b ddlUniqueKey) int { return strings.Compare(a.name, b.name) })
+	for _, uniqueKey := range uniqueKeys {
+		schemaTable.UniqueKeys = append(schemaTable.UniqueKeys, SchemaUniqueKey{Name: uniqueKey.name, Key: slices.Clone(uniqueKey.key)})
+	}
+
+	for _, index := range t.indexes {
+		index.Key = slices.Clone(index.Key)
+		schemaTable.Indexes = append(schemaTable.Indexes, index)
+	}
+	slices.SortStableFunc(schemaTable.Indexes, func(a, b SchemaIndex) int { return strings.Compare(a.Name, b.Name) })
+
+	for _, check := range t.checks {
+		schemaTable.Checks = append(schemaTable.Checks, check)
+	}
+	slices.SortStableFunc(schemaTable.Checks, func(a, b SchemaCheck) int { return strings.Compare(a.Name, b.Name) })
+
+	schemaTable.Types = fetcher.typesOf(t)
+
+	for _, sequence := range t.sequences {
+		schemaTable.Sequences = append(schemaTable.Sequences, sequence)
+	}
+	slices.SortStableFunc(schemaTable.Sequences, func(a, b SchemaSequence) int {
+		columnIndex := func(name string) int {
+			return slices.IndexFunc(t.columns, func(it SchemaColumn) bool { return it.Name == name })
+		}
+		return columnIndex(a.Column) - columnIndex(b.Column)
+	})
+
+	if fetcher.referencedBy {
+		schemaTable.ReferencedBy = fetcher.referencedByOf(t)
+	}
+
+	return schemaTable, nil
+}
+
+// typesOf returns the user-defined types used by the columns of the table in the same order as the fetcher querying a database.
+func (fetcher ddlFetcher) typesOf(t *ddlTable) []SchemaType {
+	var types []SchemaType
+	for _, column := range t.columns {
+		if column.UserType == "" {
+			continue
+		}
+		schemaName, name := splitQualifiedName(column.UserType)
+		userType, found := fetcher.types[tableKey(lo.Ternary(schemaName == "", t.schema, schemaName), name)]
+		if !found || lo.ContainsBy(types, func(it SchemaType) bool {
+			return it.Schema == userType.schemaType.Schema && it.Name == userType.schemaType.Name
+		}) {
+			continue
+		}
+		schemaType := userType.schemaType
+		schemaType.Labels = slices.Clone(schemaType.Labels)
+		schemaType.Checks = slices.Clone(schemaType.Checks)
+		slices.SortStableFunc(schemaType.Checks, func(a, b SchemaCheck) int { return strings.Compare(a.Name, b.Name) })
+		schemaType.Fields = slices.Clone(schemaType.Fields)
+		types = append(types, schemaType)
+	}
+	slices.SortStableFunc(types, func(a, b SchemaType) int {
+		if c := strings.Compare(a.Schema, b.Schema); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return types
+}
+
+// referencedByOf returns foreign keys referencing the table in the same order as the fetcher querying a database.
+func (fetcher ddlFetcher) referencedByOf(t *ddlTable) []SchemaReference {
+	keys := lo.Keys(fetcher.tables)
+	slices.Sort(keys)
+	var references []SchemaReference
+	for _, key := range keys {
+		referencing := fetcher.tables[key]
+		foreignKeys := lo.Filter(referencing.foreignKeys, func(it ddlForeignKey, _ int) bool {
+			return it.key.ReferencedTable == tableKey(t.schema, t.name)
+		})
+		slices.SortStableFunc(foreignKeys, func(a, b ddlForeignKey) int { return strings.Compare(a.name, b.name) })
+		for _, foreignKey := range foreignKeys {
+			referencedKey := foreignKey.key.ReferencedKey
+			if len(referencedKey) == 0 {
+				referencedKey = t.primaryKey
+			}
+			references = append(references, SchemaReference{
+				Name:             foreignKey.name,
+				ReferencingTable: qualifiedName(lo.Ternary(referencing.schema == t.schema, "", referencing.schema), referencing.name),
+				ReferencingKey:   slices.Clone(foreignKey.key.ReferencingKey),
+				ReferencedKey:    slices.Clone(referencedKey),
+			})
+		}
+	}
+	return references
+}
+
+func (fetcher ddlFetcher) ListTables(ctx context.Context) ([]string, error) {
+	tables := lo.MapToSlice(fetcher.tables, func(_ string, table *ddlTable) string {
+		return qualifiedName(lo.Ternary(table.schema == defaultSchema, "", table.schema), table.name)
+	})
+	slices.Sort(tables)
+	return tables, nil
+}
+
+type ddlParser struct {
+	tables map[string]*ddlTable
+	types  map[string]*ddlUserType
+}
+
+func (parser ddlParser) parseStatement(p *ddl.Parser) error {
+	switch {
+	case p.Keyword("CREATE"):
+		_ = p.Keyword("GLOBAL") || p.Keyword("LOCAL")
+		_ = p.Keyword("TEMPORARY") || p.Keyword("TEMP") || p.Keyword("UNLOGGED")
+		switch {
+		case p.Keyword("TABLE"):
+			return parser.parseCreateTable(p)
+		case p.Keyword("UNIQUE", "INDEX"):
+			return parser.parseCreateIndex(p, true)
+		case p.Keyword("INDEX"):
+			return parser.parseCreateIndex(p, false)
+		case p.Keyword("TYPE"):
+			return parser.parseCreateType(p)
+		case p.Keyword("DOMAIN"):
+			return parser.parseCreateDomain(p)
+		}
+	case p.Keyword("ALTER", "TABLE"):
+		return parser.parseAlterTable(p)
+	case p.Keyword("ALTER", "TYPE"):
+		return parser.parseAlterType(p)
+	case p.Keyword("DROP", "TYPE"), p.Keyword("DROP", "DOMAIN"):
+		_ = p.Keyword("IF", "EXISTS")
+		for {
+			schemaName, name, err := parseQualifiedName(p)
+			if err != nil {
+				return err
+			}
+			delete(parser.types, tableKey(schemaName, name))
+			if !p.Symbol(",") {
+				break
+			}
+		}
+	case p.Keyword("DROP", "TABLE"):
+		_ = p.Keyword("IF", "EXISTS")
+		for {
+			schemaName, name, err := parseQualifiedName(p)
+			if err != nil {
+				return err
+			}
+			delete(parser.tables, tableKey(schemaName, name))
+			if !p.Symbol(",") {
+				break
+			}
+		}
+	case p.Keyword("DROP", "INDEX"):
+		_ = p.Keyword("CONCURRENTLY")
+		_ = p.Keyword("IF", "EXISTS")
+		for {
+			schemaName, name, err := parseQualifiedName(p)
+			if err != nil {
+				return err
+			}
+			for _, table := range parser.tables {
+				if table.schema == lo.Ternary(schemaName == "", defaultSchema, schemaName) {
+					table.indexes = lo.Reject(table.indexes, func(it SchemaIndex, _ int) bool { return it.Name == name })
+				}
+			}
+			if !p.Symbol(",") {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (parser ddlParser) parseCreateTable(p *ddl.Parser) error {
+	ifNotExists := p.Keyword("IF", "NOT", "EXISTS")
+	schemaName, name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	if !p.Symbol("(") {
+		return fmt.Errorf(`CREATE TABLE %s without column definitions is not supported`, name)
+	}
+
+	table := &ddlTable{name: name, schema: lo.Ternary(schemaName == "", defaultSchema, schemaName)}
+	for !p.Symbol(")") {
+		if err := parser.parseTableElement(p, table); err != nil {
+			return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+		}
+		if !p.Symbol(",") {
+			if err := p.ExpectSymbol(")"); err != nil {
+				return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+			}
+			break
+		}
+	}
+	table.setNotNull(table.primaryKey)
+
+	key := tableKey(schemaName, name)
+	if _, found := parser.tables[key]; found {
+		if ifNotExists {
+			return nil
+		}
+		return fmt.Errorf(`table %s already exists`, name)
+	}
+	parser.tables[key] = table
+
+	return nil
+}
+
+// parseCreateIndex reads a CREATE INDEX statement, in which the name of the index is generated in the same way as PostgreSQL if it is omitted.
+func (parser ddlParser) parseCreateIndex(p *ddl.Parser, unique bool) error {
+	_ = p.Keyword("CONCURRENTLY")
+	_ = p.Keyword("IF", "NOT", "EXISTS")
+	name := ""
+	if !p.PeekKeyword("ON") {
+		var err error
+		if _, name, err = parseQualifiedName(p); err != nil {
+			return err
+		}
+	}
+	if err := p.ExpectKeyword("ON"); err != nil {
+		return err
+	}
+	_ = p.Keyword("ONLY")
+	schemaName, tableName, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	table, found := parser.tables[tableKey(schemaName, tableName)]
+	if !found {
+		return fmt.Errorf(`fail to parse CREATE INDEX %s: table %s not found`, name, tableName)
+	}
+	index := SchemaIndex{Unique: unique, Method: "btree"}
+	if p.Keyword("USING") {
+		if index.Method, err = parseIdentifier(p); err != nil {
+			return err
+		}
+	}
+	if index.Key, err = parseIndexKeyList(p); err != nil {
+		return err
+	}
+	index.Name = name
+	if index.Name == "" {
+		index.Name = table.name + "_" + strings.Join(lo.Map(index.Key, func(column SchemaIndexKey, _ int) string { return indexColumnName(column) }), "_") + "_idx"
+	}
+	// skips INCLUDE, NULLS DISTINCT, WITH, and TABLESPACE clauses
+	if err := p.SkipUntil(func() bool { return p.PeekKeyword("WHERE") }); err != nil {
+		return err
+	}
+	if p.Keyword("WHERE") {
+		begin := p.Pos()
+		for !p.EOF() {
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		}
+		index.Predicate = p.Text(begin, p.Pos())
+	}
+	table.indexes = append(table.indexes, index)
+	return nil
+}
+
+func (parser ddlParser) parseAlterTable(p *ddl.Parser) error {
+	_ = p.Keyword("IF", "EXISTS")
+	_ = p.Keyword("ONLY")
+	schemaName, name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	_ = p.Symbol("*")
+	table, found := parser.tables[tableKey(schemaName, name)]
+	if !found {
+		return fmt.Errorf(`fail to parse ALTER TABLE %s: table not found`, name)
+	}
+
+	if p.Keyword("RENAME") {
+		return parser.parseRename(p, table)
+	}
+
+	for {
+		if err := parser.parseAlterTableAction(p, table); err != nil {
+			return fmt.Errorf(`fail to parse ALTER TABLE %s: %w`, name, err)
+		}
+		if err := p.SkipUntil(nil); err != nil {
+			return err
+		}
+		if !p.Symbol(",") {
+			return nil
+		}
+	}
+}
+
+func (parser ddlParser) parseAlterTableAction(p *ddl.Parser, table *ddlTable) error {
+	switch {
+	case p.Keyword("ADD"):
+		if peekTableConstraint(p) {
+			if err := parser.parseTableConstraint(p, table); err != nil {
+				return err
+			}
+			table.setNotNull(table.primaryKey)
+			return nil
+		}
+		_ = p.Keyword("COLUMN")
+		_ = p.Keyword("IF", "NOT", "EXISTS")
+		if err := parser.parseColumnDefinition(p, table); err != nil {
+			return err
+		}
+		table.setNotNull(table.primaryKey)
+	case p.Keyword("DROP"):
+		if p.Keyword("CONSTRAINT") {
+			_ = p.Keyword("IF", "EXISTS")
+			constraint, err := parseIdentifier(p)
+			if err != nil {
+				return err
+			}
+			table.dropConstraint(constraint)
+			return nil
+		}
+		_ = p.Keyword("COLUMN")
+		_ = p.Keyword("IF", "EXISTS")
+		column, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		table.dropColumn(column)
+	case p.Keyword("ALTER", "CONSTRAINT"):
+		constraint, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		index := slices.IndexFunc(table.foreignKeys, func(it ddlForeignKey) bool { return it.name == constraint })
+		if index < 0 {
+			return fmt.Errorf(`foreign key %s not found`, constraint)
+		}
+		key := &table.foreignKeys[index].key
+		for {
+			switch {
+			case p.Keyword("DEFERRABLE"):
+				key.Deferrable = true
+			case p.Keyword("NOT", "DEFERRABLE"):
+				key.Deferrable, key.InitiallyDeferred = false, false
+			case p.Keyword("INITIALLY", "DEFERRED"):
+				key.InitiallyDeferred = true
+			case p.Keyword("INITIALLY", "IMMEDIATE"):
+				key.InitiallyDeferred = false
+			default:
+				return nil
+			}
+		}
+	case p.Keyword("ALTER"):
+		_ = p.Keyword("COLUMN")
+		column, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		index := slices.IndexFunc(table.columns, func(it SchemaColumn) bool { return it.Name == column })
+		if index < 0 {
+			return fmt.Errorf(`column %s not found`, column)
+		}
+		switch {
+		case p.Keyword("SET", "NOT", "NULL"):
+			table.columns[index].Nullable = false
+		case p.Keyword("DROP", "NOT", "NULL"):
+			table.columns[index].Nullable = true
+		case p.Keyword("SET", "DEFAULT"):
+			begin := p.Pos()
+			if err := p.SkipUntil(nil); err != nil {
+				return err
+			}
+			table.columns[index].Default = defaultExpression(p.Text(begin, p.Pos()))
+		case p.Keyword("DROP", "DEFAULT"):
+			table.columns[index].Default = ""
+		case p.Keyword("SET", "EXPRESSION", "AS"):
+			begin := p.Pos()
+			if err := p.Skip(); err != nil {
+				return err
+			}
+			table.columns[index].Generated = p.Text(begin+1, p.Pos()-1)
+		case p.Keyword("DROP", "EXPRESSION"):
+			table.columns[index].Generated, table.columns[index].Stored = "", false
+		case p.Keyword("ADD", "GENERATED"):
+			table.columns[index].Identity = lo.Ternary(p.Keyword("ALWAYS"), "ALWAYS", "BY DEFAULT")
+			table.columns[index].Nullable = false
+			_ = p.Keyword("BY", "DEFAULT")
+			_ = p.Keyword("AS", "IDENTITY")
+			if err := table.addSequence(p, column); err != nil {
+				return err
+			}
+		case p.Keyword("SET", "GENERATED"):
+			table.columns[index].Identity = lo.Ternary(p.Keyword("ALWAYS"), "ALWAYS", "BY DEFAULT")
+		case p.Keyword("DROP", "IDENTITY"):
+			table.columns[index].Identity = ""
+			table.sequences = lo.Reject(table.sequences, func(it SchemaSequence, _ int) bool { return it.Column == column })
+		case p.Keyword("SET", "DATA", "TYPE") || p.Keyword("TYPE"):
+			begin := p.Pos()
+			if err := p.SkipUntil(func() bool { return p.PeekKeyword("COLLATE") || p.PeekKeyword("USING") }); err != nil {
+				return err
+			}
+			columnType, err := parser.parseType(p.Tokens(begin, p.Pos()))
+			if err != nil {
+				return err
+			}
+			table.columns[index].Type = columnType.dataType
+			table.columns[index].TypeName = columnType.typeName
+			table.columns[index].ElemType = columnType.elemType
+			table.columns[index].UserType = columnType.userType(table.schema)
+		}
+	}
+	return nil
+}
+
+func (parser ddlParser) parseRename(p *ddl.Parser, table *ddlTable) error {
+	switch {
+	case p.Keyword("TO"):
+		name, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		before, after := tableKey(table.schema, table.name), tableKey(table.schema, name)
+		delete(parser.tables, before)
+		for _, t := range parser.tables {
+			for i := range t.foreignKeys {
+				if t.foreignKeys[i].key.ReferencedTable == before {
+					t.foreignKeys[i].key.ReferencedTable = after
+				}
+			}
+		}
+		table.name = name
+		parser.tables[after] = table
+	case p.Keyword("CONSTRAINT"):
+		before, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		if err := p.ExpectKeyword("TO"); err != nil {
+			return err
+		}
+		after, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		table.renameConstraint(before, after)
+	default:
+		_ = p.Keyword("COLUMN")
+		before, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		if err := p.ExpectKeyword("TO"); err != nil {
+			return err
+		}
+		after, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		table.renameColumn(before, after)
+		for _, t := range parser.tables {
+			for i := range t.foreignKeys {
+				if t.foreignKeys[i].key.ReferencedTable == tableKey(table.schema, table.name) {
+					t.foreignKeys[i].key.ReferencedKey = renameKey(t.foreignKeys[i].key.ReferencedKey, before, after)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (parser ddlParser) parseTableElement(p *ddl.Parser, table *ddlTable) error {
+	if p.PeekKeyword("LIKE") {
+		return fmt.Errorf(`LIKE clause is not supported`)
+	}
+	if peekTableConstraint(p) {
+		return parser.parseTableConstraint(p, table)
+	}
+	return parser.parseColumnDefinition(p, table)
+}
+
+func peekTableConstraint(p *ddl.Parser) bool {
+	return p.PeekKeyword("CONSTRAINT") ||
+		p.PeekKeyword("PRIMARY", "KEY") ||
+		p.PeekKeyword("UNIQUE") ||
+		p.PeekKeyword("FOREIGN", "KEY") ||
+		p.PeekKeyword("CHECK") ||
+		p.PeekKeyword("EXCLUDE")
+}
+
+func (parser ddlParser) parseTableConstraint(p *ddl.Parser, table *ddlTable) error {
+	name := ""
+	if p.Keyword("CONSTRAINT") {
+		var err error
+		if name, err = parseIdentifier(p); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case p.Keyword("PRIMARY", "KEY"):
+		key, err := parseColumnList(p)
+		if err != nil {
+			return err
+		}
+		table.addPrimaryKey(name, key)
+	case p.Keyword("UNIQUE"):
+		_ = p.Keyword("NULLS", "NOT", "DISTINCT") || p.Keyword("NULLS", "DISTINCT")
+		if p.Keyword("USING", "INDEX") {
+			index, err := parseIdentifier(p)
+			if err != nil {
+				return err
+			}
+			found, ok := lo.Find(table.indexes, func(it SchemaIndex) bool { return it.Name == index && it.Unique })
+			if !ok {
+				return fmt.Errorf(`unique index %s not found`, index)
+			}
+			if name == "" {
+				name = index
+			}
+			// the index becomes the one backing the constraint
+			table.indexes = lo.Reject(table.indexes, func(it SchemaIndex, _ int) bool { return it.Name == index })
+			table.addUniqueKey(name, lo.Map(found.Key, func(column SchemaIndexKey, _ int) string { return column.Name }))
+			break
+		}
+		key, err := parseColumnList(p)
+		if err != nil {
+			return err
+		}
+		table.addUniqueKey(name, key)
+	case p.Keyword("FOREIGN", "KEY"):
+		key, err := parseColumnList(p)
+		if err != nil {
+			return err
+		}
+		if err := p.ExpectKeyword("REFERENCES"); err != nil {
+			return err
+		}
+		if err := parser.parseReferences(p, table, name, key); err != nil {
+			return err
+		}
+	case p.Keyword("CHECK"):
+		expression, err := parseCheckExpression(p)
+		if err != nil {
+			return err
+		}
+		table.addCheck(name, expression)
+	case p.Keyword("EXCLUDE"):
+	default:
+		return fmt.Errorf(`unexpected table constraint %s`, name)
+	}
+
+	return p.SkipUntil(nil)
+}
+
+func (parser ddlParser) parseReferences(p *ddl.Parser, table *ddlTable, name string, key []string) error {
+	referencedSchema, referencedTable, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	var referencedKey []string
+	if p.PeekSymbol("(") {
+		if referencedKey, err = parseColumnList(p); err != nil {
+			return err
+		}
+	}
+	foreignKey := SchemaForeignKey{ReferencedTable: tableKey(referencedSchema, referencedTable), ReferencedKey: referencedKey, ReferencingKey: key}
+
+	for {
+		switch {
+		case p.Keyword("MATCH"):
+			match, err := p.Next()
+			if err != nil {
+				return err
+			}
+			foreignKey.Match = strings.ToUpper(match.Value)
+			if foreignKey.Match == "SIMPLE" {
+				foreignKey.Match = ""
+			}
+		case p.Keyword("ON", "DELETE"):
+			if foreignKey.OnDelete, err = parseReferentialAction(p); err != nil {
+				return err
+			}
+		case p.Keyword("ON", "UPDATE"):
+			if foreignKey.OnUpdate, err = parseReferentialAction(p); err != nil {
+				return err
+			}
+		case p.Keyword("DEFERRABLE"):
+			foreignKey.Deferrable = true
+		case p.Keyword("NOT", "DEFERRABLE"):
+			foreignKey.Deferrable = false
+		case p.Keyword("INITIALLY", "DEFERRED"):
+			foreignKey.InitiallyDeferred = true
+		case p.Keyword("INITIALLY", "IMMEDIATE"):
+			foreignKey.InitiallyDeferred = false
+		default:
+			// INITIALLY DEFERRED implies DEFERRABLE
+			foreignKey.Deferrable = foreignKey.Deferrable || foreignKey.InitiallyDeferred
+			table.addForeignKey(name, foreignKey)
+			return nil
+		}
+	}
+}
+
+// parseReferentialAction reads the action of ON DELETE or ON UPDATE and returns it in upper case, which is empty for NO ACTION.
+func parseReferentialAction(p *ddl.Parser) (string, error) {
+	if p.Keyword("SET") {
+		action := lo.Ternary(p.Keyword("NULL"), "SET NULL", "SET DEFAULT")
+		if action == "SET DEFAULT" {
+			if err := p.ExpectKeyword("DEFAULT"); err != nil {
+				return "", err
+			}
+		}
+		// the column list of SET NULL and SET DEFAULT is not supported
+		if p.PeekSymbol("(") {
+			if err := p.Skip(); err != nil {
+				return "", err
+			}
+		}
+		return action, nil
+	}
+	switch {
+	case p.Keyword("NO", "ACTION"):
+		return "", nil
+	case p.Keyword("RESTRICT"):
+		return "RESTRICT", nil
+	case p.Keyword("CASCADE"):
+		return "CASCADE", nil
+	default:
+		return "", fmt.Errorf(`unexpected referential action`)
+	}
+}
+
+var columnConstraintKeywords = []string{"CONSTRAINT", "NOT", "NULL", "PRIMARY", "UNIQUE", "DEFAULT", "REFERENCES", "CHECK", "GENERATED", "COLLATE", "DEFERRABLE", "INITIALLY"}
+
+func peekColumnConstraint(p *ddl.Parser) bool {
+	return lo.SomeBy(columnConstraintKeywords, func(keyword string) bool { return p.PeekKeyword(keyword) })
+}
+
+func (parser ddlParser) parseColumnDefinition(p *ddl.Parser, table *ddlTable) error {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+
+	begin := p.Pos()
+	if err := p.SkipUntil(func() bool { return peekColumnConstraint(p) }); err != nil {
+		return err
+	}
+	columnType, err := parser.parseType(p.Tokens(begin, p.Pos()))
+	if err != nil {
+		return err
+	}
+	column := SchemaColumn{
+		Name:     name,
+		Type:     columnType.dataType,
+		TypeName: columnType.typeName,
+		ElemType: columnType.elemType,
+		UserType: columnType.userType(table.schema),
+		Nullable: !columnType.serial,
+	}
+	if columnType.serial {
+		column.Default = serialDefault(table, name)
+		table.sequences = append(table.sequences, SchemaSequence{Name: table.name + "_" + name + "_seq", Column: name, Start: 1, Increment: 1})
+	}
+
+	// CHECK constraints are added after the column so that they can be named after the column
+	checks := []SchemaCheck{}
+	for !p.EOF() && !p.PeekSymbol(",") && !p.PeekSymbol(")") {
+		constraint := ""
+		if p.Keyword("CONSTRAINT") {
+			if constraint, err = parseIdentifier(p); err != nil {
+				return err
+			}
+		}
+		switch {
+		case p.Keyword("NOT", "NULL"):
+			column.Nullable = false
+		case p.Keyword("NULL"):
+			column.Nullable = true
+		case p.Keyword("PRIMARY", "KEY"):
+			column.Nullable = false
+			table.addPrimaryKey(constraint, []string{name})
+		case p.Keyword("UNIQUE"):
+			_ = p.Keyword("NULLS", "NOT", "DISTINCT") || p.Keyword("NULLS", "DISTINCT")
+			table.addUniqueKey(constraint, []string{name})
+		case p.Keyword("REFERENCES"):
+			if err := parser.parseReferences(p, table, constraint, []string{name}); err != nil {
+				return err
+			}
+		case p.PeekKeyword("GENERATED", "ALWAYS", "AS", "IDENTITY"), p.PeekKeyword("GENERATED", "BY", "DEFAULT", "AS", "IDENTITY"):
+			_ = p.Keyword("GENERATED")
+			column.Nullable = false
+			column.Identity = lo.Ternary(p.Keyword("ALWAYS"), "ALWAYS", "BY DEFAULT")
+			_ = p.Keyword("BY", "DEFAULT")
+			_ = p.Keyword("AS", "IDENTITY")
+			if err := table.addSequence(p, name); err != nil {
+				return err
+			}
+		case p.Keyword("GENERATED", "ALWAYS", "AS"):
+			begin := p.Pos()
+			if err := p.Skip(); err != nil {
+				return err
+			}
+			column.Generated = p.Text(begin+1, p.Pos()-1)
+		case p.Keyword("STORED"):
+			column.Stored = true
+		case p.Keyword("DEFAULT"):
+			begin := p.Pos()
+			if err := p.Skip(); err != nil {
+				return err
+			}
+			if err := p.SkipUntil(func() bool { return peekColumnConstraint(p) }); err != nil {
+				return err
+			}
+			column.Default = defaultExpression(p.Text(begin, p.Pos()))
+		case p.Keyword("CHECK"):
+			expression, err := parseCheckExpression(p)
+			if err != nil {
+				return err
+			}
+			checks = append(checks, SchemaCheck{Name: constraint, Expression: expression})
+		case p.Keyword("COLLATE"):
+			if err := p.Skip(); err != nil {
+				return err
+			}
+			if err := p.SkipUntil(func() bool { return peekColumnConstraint(p) }); err != nil {
+				return err
+			}
+		default:
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if columnType.notNull {
+		column.Nullable = false
+	}
+	table.columns = append(table.columns, column)
+	for _, check := range checks {
+		table.addCheck(check.Name, check.Expression)
+	}
+	return nil
+}
+
+// parseCheckExpression reads the condition of a CHECK constraint and returns it as written without the enclosing parentheses.
+func parseCheckExpression(p *ddl.Parser) (string, error) {
+	if !p.PeekSymbol("(") {
+		return "", fmt.Errorf(`CHECK constraint without parentheses`)
+	}
+	begin := p.Pos()
+	if err := p.Skip(); err != nil {
+		return "", err
+	}
+	return p.Text(begin+1, p.Pos()-1), nil
+}
+
+// defaultExpression returns the default value as written, which is empty for NULL since PostgreSQL does not store a NULL default.
+func defaultExpression(expression string) string {
+	if strings.EqualFold(expression, "NULL") {
+		return ""
+	}
+	return expression
+}
+
+// serialDefault returns the default value of the column of a serial type, which calls nextval of the sequence named by PostgreSQL.
+func serialDefault(table *ddlTable, column string) string {
+	sequence := quoteIdentifierIfNeeded(table.name + "_" + column + "_seq")
+	if table.schema != defaultSchema {
+		sequence = quoteIdentifierIfNeeded(table.schema) + "." + sequence
+	}
+	return "nextval('" + strings.ReplaceAll(sequence, "'", "''") + "'::regclass)"
+}
+
+// quoteIdentifierIfNeeded quotes the identifier in the same way as quote_ident except that keywords are not quoted.
+func quoteIdentifierIfNeeded(identifier string) string {
+	for i, r := range identifier {
+		if !(r == '_' || 'a' <= r && r <= 'z' || i > 0 && ('0' <= r && r <= '9' || r == '$')) {
+			return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+		}
+	}
+	if identifier == "" {
+		return `""`
+	}
+	return identifier
+}
+
+func (table *ddlTable) addPrimaryKey(name string, key []string) {
+	if name == "" {
+		name = table.name + "_pkey"
+	}
+	table.primaryKeyName = name
+	table.primaryKey = key
+}
+
+func (table *ddlTable) addUniqueKey(name string, key []string) {
+	if name == "" {
+		name = table.name + "_" + strings.Join(key, "_") + "_key"
+	}
+	table.uniqueKeys = append(table.uniqueKeys, ddlUniqueKey{name: name, key: key})
+}
+
+func (table *ddlTable) addForeignKey(name string, key SchemaForeignKey) {
+	if name == "" {
+		name = table.name + "_" + strings.Join(key.ReferencingKey, "_") + "_fkey"
+	}
+	table.foreignKeys = append(table.foreignKeys, ddlForeignKey{name: name, key: key})
+}
+
+// addCheck adds the CHECK constraint, which is named in the same way as PostgreSQL if the name is empty.
+// The name is table_column_check if the condition refers to exactly one column and table_check otherwise, to which a number is appended if the name is already used.
+func (table *ddlTable) addCheck(name string, expression string) {
+	if name == "" {
+		columns := table.referencedColumns(expression)
+		base := table.name + lo.Ternary(len(columns) == 1, "_"+strings.Join(columns, ""), "") + "_check"
+		name = base
+		for i := 1; table.hasConstraint(name); i++ {
+			name = base + strconv.Itoa(i)
+		}
+	}
+	table.checks = append(table.checks, SchemaCheck{Name: name, Expression: expression})
+}
+
+// addSequence adds the sequence of the identity column named in the same way as PostgreSQL, reading the sequence options if they follow.
+func (table *ddlTable) addSequence(p *ddl.Parser, column string) error {
+	sequence := SchemaSequence{Name: table.name + "_" + column + "_seq", Column: column, Start: 1, Increment: 1}
+	if p.PeekSymbol("(") {
+		if err := parseSequenceOptions(p, &sequence); err != nil {
+			return err
+		}
+	}
+	table.sequences = append(table.sequences, sequence)
+	return nil
+}
+
+func (table *ddlTable) hasConstraint(name string) bool {
+	return table.primaryKeyName == name ||
+		lo.ContainsBy(table.foreignKeys, func(it ddlForeignKey) bool { return it.name == name }) ||
+		lo.ContainsBy(table.uniqueKeys, func(it ddlUniqueKey) bool { return it.name == name }) ||
+		lo.ContainsBy(table.checks, func(it SchemaCheck) bool { return it.Name == name })
+}
+
+// referencedColumns returns the distinct columns of the table referred to in the expression.
+func (table *ddlTable) referencedColumns(expression string) []string {
+	tokens, err := ddl.Tokenize(expression)
+	if err != nil {
+		return nil
+	}
+	columns := []string{}
+	for _, token := range tokens {
+		name := token.Value
+		switch token.Kind {
+		case ddl.TokenKindWord:
+			name = strings.ToLower(name)
+		case ddl.TokenKindQuotedIdentifier:
+		default:
+			continue
+		}
+		if lo.ContainsBy(table.columns, func(it SchemaColumn) bool { return it.Name == name }) && !slices.Contains(columns, name) {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+func (table *ddlTable) setNotNull(columns []string) {
+	for i, column := range table.columns {
+		if slices.Contains(columns, column.Name) {
+			table.columns[i].Nullable = false
+		}
+	}
+}
+
+func (table *ddlTable) dropConstraint(name string) {
+	if table.primaryKeyName == name {
+		table.primaryKeyName, table.primaryKey = "", nil
+	}
+	table.foreignKeys = lo.Reject(table.foreignKeys, func(it ddlForeignKey, _ int) bool { return it.name == name })
+	table.uniqueKeys = lo.Reject(table.uniqueKeys, func(it ddlUniqueKey, _ int) bool { return it.name == name })
+	table.checks = lo.Reject(table.checks, func(it SchemaCheck, _ int) bool { return it.Name == name })
+}
+
+func (table *ddlTable) dropColumn(name string) {
+	table.sequences = lo.Reject(table.sequences, func(it SchemaSequence, _ int) bool { return it.Column == name })
+	table.checks = lo.Reject(table.checks, func(it SchemaCheck, _ int) bool {
+		return slices.Contains(table.referencedColumns(it.Expression), name)
+	})
+	table.columns = lo.Reject(table.columns, func(it SchemaColumn, _ int) bool { return it.Name == name })
+	if slices.Contains(table.primaryKey, name) {
+		table.primaryKeyName, table.primaryKey = "", nil
+	}
+	table.foreignKeys = lo.Reject(table.foreignKeys, func(it ddlForeignKey, _ int) bool { return slices.Contains(it.key.ReferencingKey, name) })
+	table.uniqueKeys = lo.Reject(table.uniqueKeys, func(it ddlUniqueKey, _ int) bool { return slices.Contains(it.key, name) })
+	table.indexes = lo.Reject(table.indexes, func(it SchemaIndex, _ int) bool {
+		return lo.ContainsBy(it.Key, func(column SchemaIndexKey) bool { return column.Name == name })
+	})
+}
+
+func (table *ddlTable) renameConstraint(before, after string) {
+	if table.primaryKeyName == before {
+		table.primaryKeyName = after
+	}
+	for i := range table.foreignKeys {
+		if table.foreignKeys[i].name == before {
+			table.foreignKeys[i].name = after
+		}
+	}
+	for i := range table.uniqueKeys {
+		if table.uniqueKeys[i].name == before {
+			table.uniqueKeys[i].name = after
+		}
+	}
+	for i := range table.checks {
+		if table.checks[i].Name == before {
+			table.checks[i].Name = after
+		}
+	}
+}
+
+func (table *ddlTable) renameColumn(before, after string) {
+	for i := range table.columns {
+		if table.columns[i].Name == before {
+			table.columns[i].Name = after
+		}
+	}
+	table.primaryKey = renameKey(table.primaryKey, before, after)
+	for i := range table.sequences {
+		if table.sequences[i].Column == before {
+			table.sequences[i].Column = after
+		}
+	}
+	for i := range table.foreignKeys {
+		table.foreignKeys[i].key.ReferencingKey = renameKey(table.foreignKeys[i].key.ReferencingKey, before, after)
+	}
+	for i := range table.uniqueKeys {
+		table.uniqueKeys[i].key = renameKey(table.uniqueKeys[i].key, before, after)
+	}
+	for i := range table.indexes {
+		for j := range table.indexes[i].Key {
+			if table.indexes[i].Key[j].Name == before {
+				table.indexes[i].Key[j].Name = after
+			}
+		}
+	}
+}
+
+func renameKey(key []string, before, after string) []string {
+	return lo.Map(key, func(it string, _ int) string { return lo.Ternary(it == before, after, it) })
+}
+
+// parseIdentifier reads an identifier, in which unquoted identifiers are folded to lower case.
+func parseIdentifier(p *ddl.Parser) (string, error) {
+	token, err := p.Identifier()
+	if err != nil {
+		return "", err
+	}
+	if token.Kind == ddl.TokenKindWord {
+		return strings.ToLower(token.Value), nil
+	}
+	return token.Value, nil
+}
+
+// parseQualifiedName reads a name possibly qualified by a schema and returns the schema and the name.
+// The returned schema is empty if the name is not qualified, and the database name in a name qualified by a database and a schema is ignored.
+func parseQualifiedName(p *ddl.Parser) (schemaName string, name string, err error) {
+	name, err = parseIdentifier(p)
+	if err != nil {
+		return "", "", err
+	}
+	for p.Symbol(".") {
+		schemaName = name
+		if name, err = parseIdentifier(p); err != nil {
+			return "", "", err
+		}
+	}
+	return schemaName, name, nil
+}
+
+// tableKey returns the key of a table in the parsed tables, in which the table name is always qualified by the schema.
+func tableKey(schemaName string, name string) string {
+	return qualifiedName(lo.Ternary(schemaName == "", defaultSchema, schemaName), name)
+}
+
+func parseColumnList(p *ddl.Parser) ([]string, error) {
+	key, err := parseIndexedColumnList(p)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(key, func(column SchemaIndexKey, _ int) string { return column.Name }), nil
+}
+
+// parseIndexedColumnList reads a list of columns with their sort orders.
+func parseIndexedColumnList(p *ddl.Parser) ([]SchemaIndexKey, error) {
+	if err := p.ExpectSymbol("("); err != nil {
+		return nil, err
+	}
+	key := []SchemaIndexKey{}
+	for {
+		column, err := parseIdentifier(p)
+		if err != nil {
+			return nil, err
+		}
+		if token, ok := p.Peek(); ok && token.Kind != ddl.TokenKindWord && !p.PeekSymbol(",") && !p.PeekSymbol(")") {
+			return nil, fmt.Errorf(`expressions in column list are not supported`)
+		}
+		// skips options such as COLLATE, operator classes, ASC, and NULLS FIRST
+		if err := p.SkipUntil(func() bool { return p.PeekKeyword("DESC") }); err != nil {
+			return nil, err
+		}
+		desc := p.Keyword("DESC")
+		if err := p.SkipUntil(nil); err != nil {
+			return nil, err
+		}
+		key = append(key, SchemaIndexKey{Name: column, Desc: desc})
+		if !p.Symbol(",") {
+			break
+		}
+	}
+	if err := p.ExpectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// parseIndexKeyList reads a list of columns or expressions with their sort orders, in which expressions are kept as written without the enclosing parentheses.
+func parseIndexKeyList(p *ddl.Parser) ([]SchemaIndexKey, error) {
+	if err := p.ExpectSymbol("("); err != nil {
+		return nil, err
+	}
+	key := []SchemaIndexKey{}
+	for {
+		keyPart := SchemaIndexKey{}
+		begin := p.Pos()
+		switch {
+		case p.PeekSymbol("("):
+			if err := p.Skip(); err != nil {
+				return nil, err
+			}
+			keyPart.Expression = p.Text(begin+1, p.Pos()-1)
+		default:
+			column, err := parseIdentifier(p)
+			if err != nil {
+				return nil, err
+			}
+			if !p.PeekSymbol("(") && !p.PeekSymbol(".") {
+				if token, ok := p.Peek(); ok && token.Kind != ddl.TokenKindWord && !p.PeekSymbol(",") && !p.PeekSymbol(")") {
+					return nil, fmt.Errorf(`expressions in index key must be function calls or enclosed in parentheses`)
+				}
+				keyPart.Name = column
+				break
+			}
+			// a function call possibly qualified by a schema
+			for !p.PeekSymbol("(") {
+				if _, err := p.Next(); err != nil {
+					return nil, err
+				}
+			}
+			if err := p.Skip(); err != nil {
+				return nil, err
+			}
+			keyPart.Expression = p.Text(begin, p.Pos())
+		}
+		// skips options such as COLLATE, operator classes, ASC, and NULLS FIRST
+		if err := p.SkipUntil(func() bool { return p.PeekKeyword("DESC") }); err != nil {
+			return nil, err
+		}
+		keyPart.Desc = p.Keyword("DESC")
+		if err := p.SkipUntil(nil); err != nil {
+			return nil, err
+		}
+		key = append(key, keyPart)
+		if !p.Symbol(",") {
+			break
+		}
+	}
+	if err := p.ExpectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// indexColumnName returns the name of the key part used to name the index in the same way as PostgreSQL.
+// The name of an expression is the function name if the expression is a function call and expr otherwise.
+func indexColumnName(key SchemaIndexKey) string {
+	if key.Expression == "" {
+		return key.Name
+	}
+	tokens, err := ddl.Tokenize(key.Expression)
+	if err != nil {
+		return "expr"
+	}
+	p := ddl.NewParser(key.Expression, tokens)
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return "expr"
+	}
+	for p.Symbol(".") {
+		if name, err = parseIdentifier(p); err != nil {
+			return "expr"
+		}
+	}
+	if !p.PeekSymbol("(") || p.Skip() != nil || !p.EOF() {
+		return "expr"
+	}
+	return name
+}
+
+var dataTypes = map[string]string{
+	"int":                         "integer",
+	"int4":                        "integer",
+	"integer":                     "integer",
+	"serial":                      "integer",
+	"serial4":                     "integer",
+	"bigint":                      "bigint",
+	"int8":                        "bigint",
+	"bigserial":                   "bigint",
+	"serial8":                     "bigint",
+	"smallint":                    "smallint",
+	"int2":                        "smallint",
+	"smallserial":                 "smallint",
+	"serial2":                     "smallint",
+	"bool":                        "boolean",
+	"boolean":                     "boolean",
+	"varchar":                     "character varying",
+	"char varying":                "character varying",
+	"character varying":           "character varying",
+	"char":                        "character",
+	"character":                   "character",
+	"bpchar":                      "character",
+	"bit":                         "bit",
+	"varbit":                      "bit varying",
+	"bit varying":                 "bit varying",
+	"float":                       "double precision",
+	"float8":                      "double precision",
+	"double precision":            "double precision",
+	"float4":                      "real",
+	"real":                        "real",
+	"decimal":                     "numeric",
+	"numeric":                     "numeric",
+	"time":                        "time without time zone",
+	"time without time zone":      "time without time zone",
+	"timetz":                      "time with time zone",
+	"time with time zone":         "time with time zone",
+	"timestamp":                   "timestamp without time zone",
+	"timestamp without time zone": "timestamp without time zone",
+	"timestamptz":                 "timestamp with time zone",
+	"timestamp with time zone":    "timestamp with time zone",
+}
+
+var builtinTypes = []string{
+	"box", "bytea", "cidr", "circle", "date", "daterange", "datemultirange", "inet", "int4range", "int4multirange",
+	"int8range", "int8multirange", "interval", "json", "jsonb", "jsonpath", "line", "lseg", "macaddr", "macaddr8",
+	"money", "name", "numrange", "nummultirange", "oid", "path", "pg_lsn", "pg_snapshot", "point", "polygon",
+	"regclass", "regproc", "regtype", "text", "tsmultirange", "tsquery", "tsrange", "tstzmultirange", "tstzrange",
+	"tsvector", "txid_snapshot", "uuid", "xid", "xml",
+}
+
+var serialTypes = []string{"serial", "serial2", "serial4", "serial8", "smallserial", "bigserial"}
+
+// normalizeType converts the type written in a column definition into data_type of information_schema.columns and reports whether it is a serial type.
+func normalizeType(tokens []ddl.Token) (dataType string, serial bool) {
+	words := []string{}
+	precision := ""
+	depth := 0
+	for _, token := range tokens {
+		switch {
+		case token.Kind == ddl.TokenKindSymbol && token.Value == "(":
+			depth++
+		case token.Kind == ddl.TokenKindSymbol && token.Value == ")":
+			depth--
+		case token.Kind == ddl.TokenKindSymbol && token.Value == "[":
+			return "ARRAY", false
+		case token.Kind == ddl.TokenKindSymbol && token.Value == ".":
+			// drops the schema qualifier
+			words = words[:0]
+		case depth > 0:
+			if precision == "" && token.Kind == ddl.TokenKindNumber {
+				precision = token.Value
+			}
+		case token.Kind == ddl.TokenKindWord:
+			words = append(words, strings.ToLower(token.Value))
+		case token.Kind == ddl.TokenKindQuotedIdentifier:
+			words = append(words, token.Value)
+		}
+	}
+	if len(words) == 0 {
+		return "", false
+	}
+	if words[len(words)-1] == "array" {
+		return "ARRAY", false
+	}
+
+	name := strings.Join(words, " ")
+	if p, err := strconv.Atoi(precision); err == nil && name == "float" && p <= 24 {
+		return "real", false
+	}
+	if dataType, found := dataTypes[name]; found {
+		return dataType, slices.Contains(serialTypes, name)
+	}
+	if words[0] == "interval" {
+		return "interval", false
+	}
+	if slices.Contains(builtinTypes, name) {
+		return name, false
+	}
+	return "USER-DEFINED", false
+}
+
+// parseSequenceOptions reads sequence options such as (START WITH 10 INCREMENT BY 2), in which the options other than START and INCREMENT are ignored.
+// The start is -1 as PostgreSQL does if it is omitted and the increment is negative.
+func parseSequenceOptions(p *ddl.Parser, sequence *SchemaSequence) error {
+	if err := p.ExpectSymbol("("); err != nil {
+		return err
+	}
+	start := false
+	for !p.Symbol(")") {
+		switch {
+		case p.Keyword("START"):
+			_ = p.Keyword("WITH")
+			value, err := parseInteger(p)
+			if err != nil {
+				return err
+			}
+			sequence.Start, start = value, true
+		case p.Keyword("INCREMENT"):
+			_ = p.Keyword("BY")
+			value, err := parseInteger(p)
+			if err != nil {
+				return err
+			}
+			sequence.Increment = value
+		default:
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	if !start && sequence.Increment < 0 {
+		sequence.Start = -1
+	}
+	return nil
+}
+
+// parseInteger reads an integer possibly with a sign.
+func parseInteger(p *ddl.Parser) (int64, error) {
+	negative := p.Symbol("-")
+	if !negative {
+		_ = p.Symbol("+")
+	}
+	token, err := p.Next()
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseInt(token.Value, 10, 64)
+	if token.Kind != ddl.TokenKindNumber || err != nil {
+		return 0, fmt.Errorf(`integer is expected but %q appears`, token.Value)
+	}
+	return lo.Ternary(negative, -value, value), nil
+}
+
+// parseCreateType reads a CREATE TYPE statement of an enum type, a composite type, or a range type, and the other forms are ignored.
+func (parser ddlParser) parseCreateType(p *ddl.Parser) error {
+	schemaName, name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	userType := &ddlUserType{schemaType: SchemaType{Name: name, Schema: lo.Ternary(schemaName == "", defaultSchema, schemaName)}}
+	switch {
+	case p.Keyword("AS", "ENUM"):
+		userType.schemaType.Kind = "ENUM"
+		if err := p.ExpectSymbol("("); err != nil {
+			return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+		}
+		userType.schemaType.Labels = []string{}
+		for !p.Symbol(")") {
+			token, err := p.Next()
+			if err != nil {
+				return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+			}
+			if token.Kind != ddl.TokenKindString {
+				return fmt.Errorf(`fail to parse CREATE TYPE %s: label must be a string but %q appears`, name, token.Value)
+			}
+			userType.schemaType.Labels = append(userType.schemaType.Labels, token.Value)
+			if !p.Symbol(",") {
+				if err := p.ExpectSymbol(")"); err != nil {
+					return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+				}
+				break
+			}
+		}
+	case p.Keyword("AS", "RANGE"):
+		userType.schemaType.Kind = "RANGE"
+	case p.Keyword("AS"):
+		userType.schemaType.Kind = "COMPOSITE"
+		if err := p.ExpectSymbol("("); err != nil {
+			return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+		}
+		for !p.Symbol(")") {
+			field, err := parseIdentifier(p)
+			if err != nil {
+				return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+			}
+			begin := p.Pos()
+			if err := p.SkipUntil(func() bool { return p.PeekKeyword("COLLATE") }); err != nil {
+				return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+			}
+			fieldType, err := parser.parseType(p.Tokens(begin, p.Pos()))
+			if err != nil {
+				return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+			}
+			userType.schemaType.Fields = append(userType.schemaType.Fields, SchemaField{Name: field, Type: fieldType.typeName})
+			if err := p.SkipUntil(nil); err != nil {
+				return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+			}
+			if !p.Symbol(",") {
+				if err := p.ExpectSymbol(")"); err != nil {
+					return fmt.Errorf(`fail to parse CREATE TYPE %s: %w`, name, err)
+				}
+				break
+			}
+		}
+	default:
+		return nil
+	}
+
+	key := tableKey(schemaName, name)
+	if _, found := parser.types[key]; found {
+		return fmt.Errorf(`type %s already exists`, name)
+	}
+	parser.types[key] = userType
+	return nil
+}
+
+// parseCreateDomain reads a CREATE DOMAIN statement, in which CHECK constraints without names are named in the same way as PostgreSQL.
+func (parser ddlParser) parseCreateDomain(p *ddl.Parser) error {
+	schemaName, name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	_ = p.Keyword("AS")
+	begin := p.Pos()
+	if err := p.SkipUntil(func() bool { return peekColumnConstraint(p) }); err != nil {
+		return fmt.Errorf(`fail to parse CREATE DOMAIN %s: %w`, name, err)
+	}
+	base, err := parser.parseType(p.Tokens(begin, p.Pos()))
+	if err != nil {
+		return fmt.Errorf(`fail to parse CREATE DOMAIN %s: %w`, name, err)
+	}
+	domain := &ddlUserType{
+		schemaType: SchemaType{Name: name, Schema: lo.Ternary(schemaName == "", defaultSchema, schemaName), Kind: "DOMAIN", BaseType: base.typeName},
+		base:       base,
+	}
+	for !p.EOF() {
+		constraint := ""
+		if p.Keyword("CONSTRAINT") {
+			if constraint, err = parseIdentifier(p); err != nil {
+				return fmt.Errorf(`fail to parse CREATE DOMAIN %s: %w`, name, err)
+			}
+		}
+		switch {
+		case p.Keyword("NOT", "NULL"):
+			domain.schemaType.NotNull = true
+		case p.Keyword("NULL"):
+			domain.schemaType.NotNull = false
+		case p.Keyword("DEFAULT"):
+			begin := p.Pos()
+			if err := p.Skip(); err != nil {
+				return fmt.Errorf(`fail to parse CREATE DOMAIN %s: %w`, name, err)
+			}
+			if err := p.SkipUntil(func() bool { return peekColumnConstraint(p) }); err != nil {
+				return fmt.Errorf(`fail to parse CREATE DOMAIN %s: %w`, name, err)
+			}
+			domain.schemaType.Default = defaultExpression(p.Text(begin, p.Pos()))
+		case p.Keyword("CHECK"):
+			expression, err := parseCheckExpression(p)
+			if err != nil {
+				return fmt.Errorf(`fail to parse CREATE DOMAIN %s: %w`, name, err)
+			}
+			if constraint == "" {
+				constraint = name + "_check"
+				for i := 1; lo.ContainsBy(domain.schemaType.Checks, func(it SchemaCheck) bool { return it.Name == constraint }); i++ {
+					constraint = name + "_check" + strconv.Itoa(i)
+				}
+			}
+			domain.schemaType.Checks = append(domain.schemaType.Checks, SchemaCheck{Name: constraint, Expression: expression})
+		default:
+			if err := p.Skip(); err != nil {
+				return fmt.Errorf(`fail to parse CREATE DOMAIN %s: %w`, name, err)
+			}
+		}
+	}
+
+	key := tableKey(schemaName, name)
+	if _, found := parser.types[key]; found {
+		return fmt.Errorf(`type %s already exists`, name)
+	}
+	parser.types[key] = domain
+	return nil
+}
+
+// parseAlterType reads an ALTER TYPE ... ADD VALUE statement adding a label to an enum type, and the other forms are ignored.
+func (parser ddlParser) parseAlterType(p *ddl.Parser) error {
+	schemaName, name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	if !p.Keyword("ADD", "VALUE") {
+		return nil
+	}
+	userType, found := parser.types[tableKey(schemaName, name)]
+	if !found || userType.schemaType.Kind != "ENUM" {
+		return fmt.Errorf(`enum type %s not found`, name)
+	}
+	ifNotExists := p.Keyword("IF", "NOT", "EXISTS")
+	parseLabel := func() (string, error) {
+		token, err := p.Next()
+		if err != nil {
+			return "", err
+		}
+		if token.Kind != ddl.TokenKindString {
+			return "", fmt.Errorf(`label must be a string but %q appears`, token.Value)
+		}
+		return token.Value, nil
+	}
+	label, err := parseLabel()
+	if err != nil {
+		return fmt.Errorf(`fail to parse ALTER TYPE %s: %w`, name, err)
+	}
+	labels := userType.schemaType.Labels
+	if slices.Contains(labels, label) {
+		if ifNotExists {
+			return nil
+		}
+		return fmt.Errorf(`label %q of %s already exists`, label, name)
+	}
+	index := len(labels)
+	if before, after := p.Keyword("BEFORE"), p.Keyword("AFTER"); before || after {
+		neighbor, err := parseLabel()
+		if err != nil {
+			return fmt.Errorf(`fail to parse ALTER TYPE %s: %w`, name, err)
+		}
+		index = slices.Index(labels, neighbor)
+		if index < 0 {
+			return fmt.Errorf(`label %q of %s not found`, neighbor, name)
+		}
+		if after {
+			index++
+		}
+	}
+	userType.schemaType.Labels = slices.Insert(labels, index, label)
+	return nil
+}
+
+// ddlType is a type written in DDL statements.
+type ddlType struct {
+	// dataType is data_type of information_schema.columns, which is the data_type of the underlying type for domain types.
+	dataType string
+	// typeName is formatted in the same way as format_type of PostgreSQL with the search path containing only the public schema.
+	typeName string
+	// elemType is data_type of the elements if the type is an array, and otherwise empty.
+	elemType string
+	// userSchema and userName are the schema and the name of the user-defined type of the type or of its elements, which are empty for built-in types.
+	userSchema string
+	userName   string
+	serial     bool
+	// notNull reports whether the type is a domain type not allowing NULL, which makes columns of the type not nullable.
+	notNull bool
+}
+
+// userType returns the name of the user-defined type qualified by the schema if the schema is not the given schema.
+func (t ddlType) userType(schemaName string) string {
+	if t.userName == "" {
+		return ""
+	}
+	return qualifiedName(lo.Ternary(t.userSchema == schemaName, "", t.userSchema), t.userName)
+}
+
+// parseType interprets the written type, in which types other than the built-in types are resolved to the user-defined types if they are defined.
+func (parser ddlParser) parseType(tokens []ddl.Token) (ddlType, error) {
+	base, array := tokens, false
+	for i, token := range tokens {
+		if token.Kind == ddl.TokenKindSymbol && token.Value == "[" || token.Kind == ddl.TokenKindWord && strings.EqualFold(token.Value, "ARRAY") {
+			base, array = tokens[:i], true
+			break
+		}
+	}
+
+	dataType, serial := normalizeType(base)
+	t := ddlType{dataType: dataType, typeName: formatType(base, dataType), serial: serial}
+	if dataType == "USER-DEFINED" {
+		p := ddl.NewParser("", base)
+		schemaName, name, err := parseQualifiedName(p)
+		if err != nil {
+			return ddlType{}, fmt.Errorf(`fail to parse type: %w`, err)
+		}
+		if !p.EOF() {
+			token, _ := p.Peek()
+			return ddlType{}, fmt.Errorf(`fail to parse type: end of type is expected but %q appears at %d`, token.Value, token.Begin)
+		}
+		t.userSchema, t.userName = lo.Ternary(schemaName == "", defaultSchema, schemaName), name
+		t.typeName = quoteIdentifierIfNeeded(name)
+		if t.userSchema != defaultSchema {
+			t.typeName = quoteIdentifierIfNeeded(t.userSchema) + "." + t.typeName
+		}
+		if domain, found := parser.types[tableKey(t.userSchema, t.userName)]; found && domain.schemaType.Kind == "DOMAIN" {
+			t.dataType, t.elemType, t.notNull = domain.base.dataType, domain.base.elemType, domain.schemaType.NotNull
+		}
+	}
+	if array {
+		t.dataType, t.typeName, t.elemType, t.serial, t.notNull = "ARRAY", t.typeName+"[]", t.dataType, false, false
+	}
+	return t, nil
+}
+
+// formatType formats the built-in type in the same way as format_type of PostgreSQL, in which defaults of lengths are written explicitly and precisions of floats are dropped.
+func formatType(tokens []ddl.Token, dataType string) string {
+	words := []string{}
+	modifiers := []string{}
+	depth := 0
+	for _, token := range tokens {
+		switch {
+		case token.Kind == ddl.TokenKindSymbol && token.Value == "(":
+			depth++
+		case token.Kind == ddl.TokenKindSymbol && token.Value == ")":
+			depth--
+		case depth > 0:
+			if token.Kind == ddl.TokenKindNumber {
+				modifiers = append(modifiers, token.Value)
+			}
+		case token.Kind == ddl.TokenKindWord:
+			words = append(words, strings.ToLower(token.Value))
+		}
+	}
+	modifier := ""
+	if len(modifiers) > 0 {
+		modifier = "(" + strings.Join(modifiers, ",") + ")"
+	}
+
+	switch {
+	case len(words) > 0 && words[0] == "interval":
+		return strings.Join(words, " ") + modifier
+	case dataType == "real" || dataType == "double precision":
+		return dataType
+	case dataType == "numeric" && len(modifiers) == 1:
+		return "numeric(" + modifiers[0] + ",0)"
+	case dataType == "character" && strings.Join(words, " ") == "bpchar":
+		return "bpchar" + modifier
+	case (dataType == "character" || dataType == "bit") && modifier == "":
+		return dataType + "(1)"
+	case strings.HasPrefix(dataType, "time ") || strings.HasPrefix(dataType, "timestamp "):
+		typeName, zone, _ := strings.Cut(dataType, " ")
+		return typeName + modifier + " " + zone
+	default:
+		return dataType + modifier
+	}
+}
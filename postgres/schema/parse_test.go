@@ -0,0 +1,335 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDDLFetcher(t *testing.T) {
+	for number, testcase := range fetcherTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(ddls[testcase.ddl])
+			assert.Nil(t, err)
+
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assertEqualSchemaTable(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_ListTables(t *testing.T) {
+	for number, testcase := range listTablesTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(ddls[testcase.ddl])
+			assert.Nil(t, err)
+
+			got, err := sut.ListTables(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_WithReferencedBy(t *testing.T) {
+	for number, testcase := range referencedByTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(ddls[testcase.ddl])
+			assert.Nil(t, err)
+
+			got, err := sut.WithReferencedBy().Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got.ReferencedBy)
+		})
+	}
+}
+
+func TestDDLFetcher_Statements(t *testing.T) {
+	testcases := []struct {
+		name  string
+		ddl   string
+		table string
+		want  schema.SchemaTable
+	}{
+		{
+			name: "alter_table",
+			ddl: `CREATE TABLE parent (id serial PRIMARY KEY, code varchar(10));
+CREATE UNIQUE INDEX parent_code_idx ON parent (code);
+ALTER TABLE parent ADD CONSTRAINT parent_code_key UNIQUE USING INDEX parent_code_idx;
+CREATE TABLE public.child (
+	id bigint GENERATED ALWAYS AS IDENTITY,
+	parent_id integer REFERENCES parent ON DELETE SET NULL,
+	tags text[] NOT NULL DEFAULT '{}',
+	note "MyType" DEFAULT NULL,
+	dropped int
+);
+ALTER TABLE child ADD PRIMARY KEY (id), DROP COLUMN dropped, ALTER COLUMN note SET NOT NULL;
+ALTER TABLE child RENAME COLUMN note TO memo;
+CREATE FUNCTION f() RETURNS trigger AS $$ BEGIN RETURN NEW; END; $$ LANGUAGE plpgsql;`,
+			table: "child",
+			want: schema.SchemaTable{
+				Name:   "child",
+				Schema: "public",
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "bigint", TypeName: "bigint", Identity: "ALWAYS"},
+					{Name: "parent_id", Type: "integer", TypeName: "integer", Nullable: true},
+					{Name: "tags", Type: "ARRAY", TypeName: "text[]", ElemType: "text", Default: "'{}'"},
+					{Name: "memo", Type: "USER-DEFINED", TypeName: `"MyType"`, UserType: "MyType"},
+				},
+				Sequences:      []schema.SchemaSequence{{Name: "child_id_seq", Column: "id", Start: 1, Increment: 1}},
+				PrimaryKey:     []string{"id"},
+				PrimaryKeyName: "child_pkey",
+				ForeignKeys: []schema.SchemaForeignKey{
+					{Name: "child_parent_id_fkey", ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"parent_id"}, OnDelete: "SET NULL"},
+				},
+			},
+		},
+		{
+			name: "unique_using_index",
+			ddl: `CREATE TABLE parent (id serial PRIMARY KEY, code varchar(10));
+CREATE UNIQUE INDEX parent_code_idx ON parent (code);
+ALTER TABLE parent ADD CONSTRAINT parent_code_key UNIQUE USING INDEX parent_code_idx;`,
+			table: "parent",
+			want: schema.SchemaTable{
+				Name:   "parent",
+				Schema: "public",
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "integer", TypeName: "integer", Default: "nextval('parent_id_seq'::regclass)"},
+					{Name: "code", Type: "character varying", TypeName: "character varying(10)", Nullable: true},
+				},
+				Sequences:      []schema.SchemaSequence{{Name: "parent_id_seq", Column: "id", Start: 1, Increment: 1}},
+				PrimaryKey:     []string{"id"},
+				PrimaryKeyName: "parent_pkey",
+				UniqueKeys:     []schema.SchemaUniqueKey{{Name: "parent_code_key", Key: []string{"code"}}},
+			},
+		},
+		{
+			name: "indexes",
+			ddl: `CREATE TABLE app.items (id int PRIMARY KEY, code text, price numeric, dropped int);
+CREATE INDEX ON app.items (code DESC NULLS LAST, price);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS items_price_idx ON app.items USING BRIN (price) WITH (pages_per_range = 32) WHERE price > 0;
+CREATE INDEX items_id_idx ON app.items (id) INCLUDE (price);
+CREATE UNIQUE INDEX ON app.items (abs(price));
+CREATE INDEX ON app.items ((price * 2) DESC, pg_catalog.lower(code));
+CREATE INDEX items_dropped_idx ON app.items (dropped);
+CREATE INDEX items_temporary_idx ON app.items (id);
+ALTER TABLE app.items DROP COLUMN dropped;
+ALTER TABLE app.items RENAME COLUMN code TO name;
+DROP INDEX app.items_temporary_idx;`,
+			table: "app.items",
+			want: schema.SchemaTable{
+				Name:   "items",
+				Schema: "app",
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "integer", TypeName: "integer"},
+					{Name: "name", Type: "text", TypeName: "text", Nullable: true},
+					{Name: "price", Type: "numeric", TypeName: "numeric", Nullable: true},
+				},
+				PrimaryKey:     []string{"id"},
+				PrimaryKeyName: "items_pkey",
+				Indexes: []schema.SchemaIndex{
+					{Name: "items_code_price_idx", Key: []schema.SchemaIndexKey{{Name: "name", Desc: true}, {Name: "price"}}, Method: "btree"},
+					{Name: "items_abs_idx", Unique: true, Key: []schema.SchemaIndexKey{{Expression: "abs(price)"}}, Method: "btree"},
+					{Name: "items_expr_lower_idx", Key: []schema.SchemaIndexKey{{Expression: "price * 2", Desc: true}, {Expression: "pg_catalog.lower(code)"}}, Method: "btree"},
+					{Name: "items_id_idx", Key: []schema.SchemaIndexKey{{Name: "id"}}, Method: "btree"},
+					{Name: "items_price_idx", Key: []schema.SchemaIndexKey{{Name: "price"}}, Method: "brin", Predicate: "price > 0"},
+				},
+			},
+		},
+		{
+			name: "alter_column_defaults",
+			ddl: `CREATE TABLE app.items (id bigserial, code text DEFAULT 'x', price integer, total integer GENERATED ALWAYS AS (price * 2) STORED, seq integer);
+ALTER TABLE app.items ALTER COLUMN code DROP DEFAULT;
+ALTER TABLE app.items ALTER COLUMN price SET DEFAULT 100;
+ALTER TABLE app.items ALTER COLUMN total DROP EXPRESSION;
+ALTER TABLE app.items ALTER COLUMN seq SET NOT NULL, ALTER COLUMN seq ADD GENERATED BY DEFAULT AS IDENTITY (START WITH 10);`,
+			table: "app.items",
+			want: schema.SchemaTable{
+				Name:   "items",
+				Schema: "app",
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "bigint", TypeName: "bigint", Default: "nextval('app.items_id_seq'::regclass)"},
+					{Name: "code", Type: "text", TypeName: "text", Nullable: true},
+					{Name: "price", Type: "integer", TypeName: "integer", Nullable: true, Default: "100"},
+					{Name: "total", Type: "integer", TypeName: "integer", Nullable: true},
+					{Name: "seq", Type: "integer", TypeName: "integer", Identity: "BY DEFAULT"},
+				},
+				Sequences: []schema.SchemaSequence{
+					{Name: "items_id_seq", Column: "id", Start: 1, Increment: 1},
+					{Name: "items_seq_seq", Column: "seq", Start: 10, Increment: 1},
+				},
+			},
+		},
+		{
+			name: "checks",
+			ddl: `CREATE TABLE orders (id int PRIMARY KEY, amount int CHECK (amount >= 0), discount int, dropped int, CHECK (discount <= amount), CHECK (discount >= 0));
+ALTER TABLE orders ADD CHECK (dropped > 0), ADD CONSTRAINT orders_positive CHECK (id > 0) NOT VALID;
+ALTER TABLE orders ADD CONSTRAINT orders_id_check CHECK (id < 1000);
+ALTER TABLE orders RENAME CONSTRAINT orders_discount_check TO orders_discount_non_negative;
+ALTER TABLE orders DROP COLUMN dropped;`,
+			table: "orders",
+			want: schema.SchemaTable{
+				Name:   "orders",
+				Schema: "public",
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "integer", TypeName: "integer"},
+					{Name: "amount", Type: "integer", TypeName: "integer", Nullable: true},
+					{Name: "discount", Type: "integer", TypeName: "integer", Nullable: true},
+				},
+				PrimaryKey:     []string{"id"},
+				PrimaryKeyName: "orders_pkey",
+				Checks: []schema.SchemaCheck{
+					{Name: "orders_amount_check", Expression: "amount >= 0"},
+					{Name: "orders_check", Expression: "discount <= amount"},
+					{Name: "orders_discount_non_negative", Expression: "discount >= 0"},
+					{Name: "orders_id_check", Expression: "id < 1000"},
+					{Name: "orders_positive", Expression: "id > 0"},
+				},
+			},
+		},
+		{
+			name: "schemas",
+			ddl: `CREATE SCHEMA app;
+CREATE TABLE app.parent (id int PRIMARY KEY);
+CREATE TABLE parent (id bigint PRIMARY KEY);
+CREATE TABLE app.child (id int PRIMARY KEY, parent_id int REFERENCES app.parent, other_id bigint REFERENCES parent);
+ALTER TABLE app.parent RENAME TO mother;`,
+			table: "app.child",
+			want: schema.SchemaTable{
+				Name:   "child",
+				Schema: "app",
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "integer", TypeName: "integer"},
+					{Name: "parent_id", Type: "integer", TypeName: "integer", Nullable: true},
+					{Name: "other_id", Type: "bigint", TypeName: "bigint", Nullable: true},
+				},
+				PrimaryKey:     []string{"id"},
+				PrimaryKeyName: "child_pkey",
+				ForeignKeys: []schema.SchemaForeignKey{
+					{Name: "child_other_id_fkey", ReferencedTable: "public.parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"other_id"}},
+					{Name: "child_parent_id_fkey", ReferencedTable: "mother", ReferencedKey: []string{"id"}, ReferencingKey: []string{"parent_id"}},
+				},
+			},
+		},
+		{
+			name: "foreign_key_attributes",
+			ddl: `CREATE TABLE parent (id int PRIMARY KEY);
+CREATE TABLE child (
+	id int PRIMARY KEY,
+	a int REFERENCES parent ON UPDATE CASCADE ON DELETE NO ACTION INITIALLY DEFERRED,
+	b int CONSTRAINT fk_b REFERENCES parent (id) MATCH SIMPLE ON DELETE SET DEFAULT (b) NOT DEFERRABLE
+);
+ALTER TABLE child ADD FOREIGN KEY (id) REFERENCES parent MATCH FULL ON DELETE RESTRICT;
+ALTER TABLE child ALTER CONSTRAINT fk_b DEFERRABLE INITIALLY DEFERRED, ALTER CONSTRAINT child_a_fkey NOT DEFERRABLE;`,
+			table: "child",
+			want: schema.SchemaTable{
+				Name:   "child",
+				Schema: "public",
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "integer", TypeName: "integer"},
+					{Name: "a", Type: "integer", TypeName: "integer", Nullable: true},
+					{Name: "b", Type: "integer", TypeName: "integer", Nullable: true},
+				},
+				PrimaryKey:     []string{"id"},
+				PrimaryKeyName: "child_pkey",
+				ForeignKeys: []schema.SchemaForeignKey{
+					{Name: "child_a_fkey", ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"a"}, OnUpdate: "CASCADE"},
+					{Name: "child_id_fkey", ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"id"}, OnDelete: "RESTRICT", Match: "FULL"},
+					{Name: "fk_b", ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"b"}, OnDelete: "SET DEFAULT", Deferrable: true, InitiallyDeferred: true},
+				},
+			},
+		},
+		{
+			name: "user_defined_types",
+			ddl: `CREATE TYPE status AS ENUM ('draft', 'done');
+ALTER TYPE status ADD VALUE 'open' BEFORE 'done';
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'draft';
+ALTER TYPE status ADD VALUE 'archived';
+CREATE DOMAIN app.code AS varchar(8) CONSTRAINT code_upper CHECK (VALUE = upper(VALUE)) CHECK (length(VALUE) > 2);
+CREATE TYPE app.pair AS (key app.code, value text[] COLLATE "C");
+CREATE TYPE unused AS ENUM ();
+DROP TYPE IF EXISTS unused;
+CREATE TABLE app.tasks (
+	id int GENERATED ALWAYS AS IDENTITY (INCREMENT BY -1 CACHE 10),
+	status status[],
+	code app.code NULL,
+	pair app.pair,
+	amount float(10),
+	flag bit,
+	letter char
+);
+ALTER TABLE app.tasks ALTER COLUMN amount TYPE public.status USING 'draft';`,
+			table: "app.tasks",
+			want: schema.SchemaTable{
+				Name:   "tasks",
+				Schema: "app",
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "integer", TypeName: "integer", Identity: "ALWAYS"},
+					{Name: "status", Type: "ARRAY", TypeName: "status[]", ElemType: "USER-DEFINED", UserType: "public.status", Nullable: true},
+					{Name: "code", Type: "character varying", TypeName: "app.code", UserType: "code", Nullable: true},
+					{Name: "pair", Type: "USER-DEFINED", TypeName: "app.pair", UserType: "pair", Nullable: true},
+					{Name: "amount", Type: "USER-DEFINED", TypeName: "status", UserType: "public.status", Nullable: true},
+					{Name: "flag", Type: "bit", TypeName: "bit(1)", Nullable: true},
+					{Name: "letter", Type: "character", TypeName: "character(1)", Nullable: true},
+				},
+				Types: []schema.SchemaType{
+					{
+						Name:     "code",
+						Schema:   "app",
+						Kind:     "DOMAIN",
+						BaseType: "character varying(8)",
+						Checks: []schema.SchemaCheck{
+							{Name: "code_check", Expression: "length(VALUE) > 2"},
+							{Name: "code_upper", Expression: "VALUE = upper(VALUE)"},
+						},
+					},
+					{
+						Name:   "pair",
+						Schema: "app",
+						Kind:   "COMPOSITE",
+						Fields: []schema.SchemaField{{Name: "key", Type: "app.code"}, {Name: "value", Type: "text[]"}},
+					},
+					{Name: "status", Schema: "public", Kind: "ENUM", Labels: []string{"draft", "open", "done", "archived"}},
+				},
+				Sequences: []schema.SchemaSequence{{Name: "tasks_id_seq", Column: "id", Start: -1, Increment: -1}},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(testcase.ddl)
+			assert.Nil(t, err)
+
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assertEqualSchemaTable(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_Types_Error(t *testing.T) {
+	testcases := []struct {
+		name string
+		ddl  string
+	}{
+		{name: "label_not_string", ddl: `CREATE TYPE status AS ENUM (draft)`},
+		{name: "duplicated_type", ddl: `CREATE TYPE status AS ENUM ('a'); CREATE DOMAIN status AS text`},
+		{name: "label_duplicated", ddl: `CREATE TYPE status AS ENUM ('a'); ALTER TYPE status ADD VALUE 'a'`},
+		{name: "label_not_found", ddl: `CREATE TYPE status AS ENUM ('a'); ALTER TYPE status ADD VALUE 'b' AFTER 'c'`},
+		{name: "enum_not_found", ddl: `ALTER TYPE status ADD VALUE 'a'`},
+		{name: "sequence_option_invalid", ddl: `CREATE TABLE t (id int GENERATED ALWAYS AS IDENTITY (START WITH x))`},
+		{name: "column_name_missing", ddl: `CREATE TABLE "Q" ("C6" numeric(10, 2), timestamp(3) with time zone, PRIMARY KEY ("C6"));`},
+		{name: "user_type_invalid", ddl: `CREATE TABLE t (c status extra)`},
+	}
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			_, err := schema.NewDDLFetcher(testcase.ddl)
+			assert.NotNil(t, err)
+		})
+	}
+}
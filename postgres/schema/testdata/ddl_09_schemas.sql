@@ -0,0 +1,29 @@
+CREATE SCHEMA "S_1";
+CREATE SCHEMA "S_2";
+
+CREATE TABLE "J" (
+    "PK" integer NOT NULL,
+    PRIMARY KEY ("PK")
+);
+
+CREATE TABLE "S_1"."J" (
+    "PK" integer NOT NULL,
+    "C" integer NOT NULL,
+    CONSTRAINT "UQ_J" UNIQUE ("C"),
+    PRIMARY KEY ("PK")
+);
+
+CREATE TABLE "S_2"."J" (
+    "PK" bigint NOT NULL,
+    "R" integer,
+    CONSTRAINT "UQ_J" UNIQUE ("PK", "R"),
+    CONSTRAINT "FK_J" FOREIGN KEY ("R") REFERENCES "S_1"."J" ("PK"),
+    PRIMARY KEY ("PK")
+);
+
+CREATE TABLE "S_2"."K" (
+    "PK" integer NOT NULL,
+    "J" bigint NOT NULL,
+    CONSTRAINT "FK_J" FOREIGN KEY ("J") REFERENCES "S_2"."J" ("PK"),
+    PRIMARY KEY ("PK")
+);
@@ -0,0 +1,16 @@
+CREATE TABLE "L" (
+    "PK" integer NOT NULL,
+    "C1" integer NOT NULL,
+    "C2" character varying(50),
+    "C3" double precision,
+    PRIMARY KEY ("PK")
+);
+
+CREATE INDEX "IDX_L_C1" ON "L" ("C1");
+CREATE INDEX "IDX_L_C1_Hash" ON "L" USING hash ("C1");
+CREATE INDEX "IDX_L_C2_C1" ON "L" USING btree ("C2" DESC, "C1" ASC);
+CREATE INDEX "IDX_L_C2_Partial" ON "L" ("C2") WHERE ("C2" IS NOT NULL);
+CREATE INDEX "IDX_L_Expression" ON "L" (lower(("C2")::text));
+CREATE INDEX "IDX_L_Expression_Desc" ON "L" (("C1" + 1) DESC, "C3");
+CREATE UNIQUE INDEX "UQ_L_C3" ON "L" ("C3" DESC NULLS LAST);
+ALTER TABLE "L" ADD CONSTRAINT "UQ_L_C1" UNIQUE ("C1");
@@ -0,0 +1,10 @@
+CREATE TABLE "M" (
+    "PK" serial NOT NULL,
+    "C1" integer NOT NULL DEFAULT 0,
+    "C2" character varying(50) DEFAULT 'abc'::character varying,
+    "C3" timestamp with time zone DEFAULT now(),
+    "C4" bigint GENERATED ALWAYS AS IDENTITY,
+    "C5" integer GENERATED BY DEFAULT AS IDENTITY,
+    "C6" integer GENERATED ALWAYS AS (("C1" * 2)) STORED,
+    PRIMARY KEY ("PK")
+);
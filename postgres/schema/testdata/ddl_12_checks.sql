@@ -0,0 +1,9 @@
+CREATE TABLE "N" (
+    "PK" integer NOT NULL,
+    "C1" integer NOT NULL CHECK (("C1" >= 0)),
+    "C2" text CONSTRAINT "CK_N_C2" CHECK ((length("C2") < 10)),
+    "C3" integer,
+    PRIMARY KEY ("PK"),
+    CONSTRAINT "CK_N_C1_C3" CHECK (("C1" < "C3")),
+    CHECK (("C3" <> 0))
+);
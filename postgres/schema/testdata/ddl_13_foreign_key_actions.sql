@@ -0,0 +1,17 @@
+CREATE TABLE "O_1" (
+    "PK" integer NOT NULL,
+    "C1" integer NOT NULL,
+    PRIMARY KEY ("PK"),
+    UNIQUE ("C1")
+);
+
+CREATE TABLE "O_2" (
+    "PK" integer NOT NULL,
+    "R1" integer,
+    "R2" integer,
+    "R3" integer,
+    PRIMARY KEY ("PK"),
+    CONSTRAINT "FK_O_2_1" FOREIGN KEY ("R1") REFERENCES "O_1" ("PK") ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT "FK_O_2_2" FOREIGN KEY ("R2") REFERENCES "O_1" ("C1") MATCH FULL ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED,
+    CONSTRAINT "FK_O_2_3" FOREIGN KEY ("R3") REFERENCES "O_1" ("PK") ON UPDATE SET DEFAULT DEFERRABLE
+);
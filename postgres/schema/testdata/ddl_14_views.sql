@@ -0,0 +1,22 @@
+CREATE SCHEMA "S_3";
+
+CREATE TABLE "P_1" (
+    "PK" integer NOT NULL,
+    "C1" text NOT NULL,
+    PRIMARY KEY ("PK")
+);
+
+CREATE TABLE "S_3"."P_2" (
+    "PK" integer NOT NULL,
+    "R1" integer REFERENCES "P_1" ("PK"),
+    "C2" double precision,
+    "C3" integer[],
+    PRIMARY KEY ("PK")
+);
+
+CREATE VIEW "V_1" AS SELECT "PK", "C1" FROM "P_1" WHERE "C1" <> '';
+
+CREATE MATERIALIZED VIEW "V_2" ("K", "C", "Total", "Items") AS
+    SELECT v."PK", v."C1", SUM(p."C2"), p."C3"
+    FROM "V_1" AS v JOIN "S_3"."P_2" AS p ON v."PK" = p."R1"
+    GROUP BY v."PK", v."C1", p."C3";
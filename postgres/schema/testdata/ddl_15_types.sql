@@ -0,0 +1,22 @@
+CREATE SCHEMA "S_4";
+
+CREATE TYPE "Mood" AS ENUM ('sad', 'ok', 'happy');
+
+CREATE TYPE "S_4"."Point" AS (
+    "X" double precision,
+    "Y" double precision
+);
+
+CREATE DOMAIN "Positive" AS integer NOT NULL DEFAULT 1 CHECK ((VALUE > 0));
+
+CREATE TABLE "Q" (
+    "PK" bigint GENERATED BY DEFAULT AS IDENTITY (START WITH 100 INCREMENT BY 10),
+    "C1" "Mood" NOT NULL,
+    "C2" "Mood"[],
+    "C3" "Positive",
+    "C4" "S_4"."Point",
+    "C5" text[],
+    "C6" numeric(10, 2),
+    "C7" timestamp(3) with time zone,
+    PRIMARY KEY ("PK")
+);
@@ -24,3 +24,24 @@ var DDL07UniqueKeysConstraintSQL string
 
 //go:embed ddl_08_unique_keys_column.sql
 var DDL08UniqueKeysColumnSQL string
+
+//go:embed ddl_09_schemas.sql
+var DDL09SchemasSQL string
+
+//go:embed ddl_10_indexes.sql
+var DDL10IndexesSQL string
+
+//go:embed ddl_11_column_defaults.sql
+var DDL11ColumnDefaultsSQL string
+
+//go:embed ddl_12_checks.sql
+var DDL12ChecksSQL string
+
+//go:embed ddl_13_foreign_key_actions.sql
+var DDL13ForeignKeyActionsSQL string
+
+//go:embed ddl_14_views.sql
+var DDL14ViewsSQL string
+
+//go:embed ddl_15_types.sql
+var DDL15TypesSQL string
@@ -0,0 +1,237 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	gf_postgres "github.com/Jumpaku/gotaface/postgres"
+	"github.com/samber/lo"
+)
+
+// SchemaType is a user-defined type used by columns.
+type SchemaType struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+	// Kind is ENUM, DOMAIN, COMPOSITE, RANGE, or BASE.
+	Kind string `json:"kind"`
+	// Labels are the labels of the enum type in the sort order.
+	Labels []string `json:"labels"`
+	// BaseType is the underlying type of the domain type, which is represented in the same way as TypeName of columns.
+	BaseType string `json:"base_type"`
+	// NotNull reports whether the domain type does not allow NULL.
+	NotNull bool `json:"not_null"`
+	// Default is the expression of the default value of the domain type, which is empty if the domain type has no default value.
+	Default string `json:"default"`
+	// Checks are the CHECK constraints of the domain type, in which the value is referred to as VALUE.
+	Checks []SchemaCheck `json:"check"`
+	// Fields are the fields of the composite type.
+	Fields []SchemaField `json:"fields"`
+}
+
+// QualifiedName returns the name of the type qualified by the schema.
+func (t SchemaType) QualifiedName() string {
+	return qualifiedName(t.Schema, t.Name)
+}
+
+// SchemaField is a field of a composite type.
+type SchemaField struct {
+	Name string `json:"name"`
+	// Type is represented in the same way as TypeName of columns.
+	Type string `json:"type"`
+}
+
+// SchemaSequence is a sequence owned by a serial column or an identity column.
+type SchemaSequence struct {
+	// Name is qualified by the schema if it belongs to a schema other than the schema of the table.
+	Name   string `json:"name"`
+	Column string `json:"column"`
+	Start  int64  `json:"start"`
+	// Increment is the value added to the current value by nextval.
+	Increment int64 `json:"increment"`
+	// Current is the value last returned by nextval, which is nil if nextval has not been called.
+	Current *int64 `json:"current"`
+}
+
+// UserType returns the user-defined type of the column, or of its elements if the column is an array.
+// It reports false if the column has a built-in type.
+func (table SchemaTable) UserType(column SchemaColumn) (SchemaType, bool) {
+	if column.UserType == "" {
+		return SchemaType{}, false
+	}
+	return lo.Find(table.Types, func(t SchemaType) bool {
+		return qualifiedName(lo.Ternary(t.Schema == table.Schema, "", t.Schema), t.Name) == column.UserType
+	})
+}
+
+// queryTypes returns the user-defined types used by the columns of the table or by their elements.
+func queryTypes(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) ([]SchemaType, error) {
+	sql := `--sql query user-defined types of columns
+SELECT DISTINCT
+	utn.nspname AS "Schema",
+	ut.typname AS "Name",
+	CASE ut.typtype WHEN 'e' THEN 'ENUM' WHEN 'd' THEN 'DOMAIN' WHEN 'c' THEN 'COMPOSITE' WHEN 'r' THEN 'RANGE' ELSE 'BASE' END AS "Kind",
+	CASE WHEN ut.typtype = 'd' THEN format_type(ut.typbasetype, ut.typtypmod) ELSE '' END AS "BaseType",
+	ut.typnotnull AS "NotNull",
+	COALESCE(ut.typdefault, '') AS "Default"
+FROM pg_attribute AS a
+	JOIN pg_class AS c ON c.oid = a.attrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_type AS t ON t.oid = a.atttypid
+	JOIN pg_type AS ut ON ut.oid = CASE WHEN t.typelem <> 0 AND t.typlen = -1 THEN t.typelem ELSE t.oid END
+	JOIN pg_namespace AS utn ON utn.oid = ut.typnamespace
+WHERE n.nspname = $1 AND c.relname = $2 AND a.attnum > 0 AND NOT a.attisdropped
+	AND utn.nspname NOT IN ('pg_catalog', 'information_schema')
+ORDER BY utn.nspname, ut.typname`
+	rows, err := tx.Query(ctx, sql, schemaName, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get types of %s: %w`, table, err)
+	}
+	types, err := gf_postgres.ScanRowsStruct[SchemaType](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get types of %s: %w`, table, err)
+	}
+
+	for i, t := range types {
+		switch t.Kind {
+		case "ENUM":
+			if types[i].Labels, err = queryEnumLabels(ctx, tx, t.Schema, t.Name); err != nil {
+				return nil, fmt.Errorf(`fail to get types of %s: %w`, table, err)
+			}
+		case "DOMAIN":
+			if types[i].Checks, err = queryDomainChecks(ctx, tx, t.Schema, t.Name); err != nil {
+				return nil, fmt.Errorf(`fail to get types of %s: %w`, table, err)
+			}
+		case "COMPOSITE":
+			if types[i].Fields, err = queryCompositeFields(ctx, tx, t.Schema, t.Name); err != nil {
+				return nil, fmt.Errorf(`fail to get types of %s: %w`, table, err)
+			}
+		}
+	}
+	return types, nil
+}
+
+func queryEnumLabels(ctx context.Context, tx gf_postgres.Queryer, schemaName string, name string) ([]string, error) {
+	sql := `--sql query enum labels
+SELECT
+	e.enumlabel AS "Label"
+FROM pg_enum AS e
+	JOIN pg_type AS t ON t.oid = e.enumtypid
+	JOIN pg_namespace AS n ON n.oid = t.typnamespace
+WHERE n.nspname = $1 AND t.typname = $2
+ORDER BY e.enumsortorder`
+	rows, err := tx.Query(ctx, sql, schemaName, name)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get labels of %s: %w`, name, err)
+	}
+	type label struct {
+		Label string `db:"Label"`
+	}
+	labels, err := gf_postgres.ScanRowsStruct[label](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get labels of %s: %w`, name, err)
+	}
+	return lo.Map(labels, func(it label, _ int) string { return it.Label }), nil
+}
+
+func queryDomainChecks(ctx context.Context, tx gf_postgres.Queryer, schemaName string, name string) ([]SchemaCheck, error) {
+	sql := `--sql query domain check constraints
+SELECT
+	con.conname AS "Name",
+	pg_get_constraintdef(con.oid) AS "Definition"
+FROM pg_constraint AS con
+	JOIN pg_type AS t ON t.oid = con.contypid
+	JOIN pg_namespace AS n ON n.oid = t.typnamespace
+WHERE n.nspname = $1 AND t.typname = $2 AND con.contype = 'c'
+ORDER BY con.conname`
+	rows, err := tx.Query(ctx, sql, schemaName, name)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get checks of %s: %w`, name, err)
+	}
+	type checkRow struct {
+		Name       string `db:"Name"`
+		Definition string `db:"Definition"`
+	}
+	checkRows, err := gf_postgres.ScanRowsStruct[checkRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get checks of %s: %w`, name, err)
+	}
+
+	var checks []SchemaCheck
+	for _, checkRow := range checkRows {
+		expression, err := checkExpression(checkRow.Definition)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to get checks of %s: %w`, name, err)
+		}
+		checks = append(checks, SchemaCheck{Name: checkRow.Name, Expression: expression})
+	}
+	return checks, nil
+}
+
+func queryCompositeFields(ctx context.Context, tx gf_postgres.Queryer, schemaName string, name string) ([]SchemaField, error) {
+	sql := `--sql query composite type fields
+SELECT
+	a.attname AS "Name",
+	format_type(a.atttypid, a.atttypmod) AS "Type"
+FROM pg_type AS t
+	JOIN pg_namespace AS n ON n.oid = t.typnamespace
+	JOIN pg_attribute AS a ON a.attrelid = t.typrelid
+WHERE n.nspname = $1 AND t.typname = $2 AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`
+	rows, err := tx.Query(ctx, sql, schemaName, name)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get fields of %s: %w`, name, err)
+	}
+	fields, err := gf_postgres.ScanRowsStruct[SchemaField](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get fields of %s: %w`, name, err)
+	}
+	return fields, nil
+}
+
+// querySequences returns the sequences owned by serial columns and identity columns of the table.
+func querySequences(ctx context.Context, tx gf_postgres.Queryer, schemaName string, table string) ([]SchemaSequence, error) {
+	sql := `--sql query owned sequences
+SELECT
+	sn.nspname AS "Schema",
+	s.relname AS "Name",
+	a.attname AS "Column",
+	seq.seqstart AS "Start",
+	seq.seqincrement AS "Increment",
+	ps.last_value AS "Current"
+FROM pg_class AS c
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_depend AS d ON d.refclassid = 'pg_class'::regclass AND d.refobjid = c.oid
+		AND d.classid = 'pg_class'::regclass AND d.deptype IN ('a', 'i')
+	JOIN pg_class AS s ON s.oid = d.objid AND s.relkind = 'S'
+	JOIN pg_namespace AS sn ON sn.oid = s.relnamespace
+	JOIN pg_sequence AS seq ON seq.seqrelid = s.oid
+	JOIN pg_attribute AS a ON a.attrelid = c.oid AND a.attnum = d.refobjsubid
+	LEFT JOIN pg_sequences AS ps ON ps.schemaname = sn.nspname AND ps.sequencename = s.relname
+WHERE n.nspname = $1 AND c.relname = $2
+ORDER BY a.attnum`
+	rows, err := tx.Query(ctx, sql, schemaName, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get sequences of %s: %w`, table, err)
+	}
+	type sequenceRow struct {
+		Schema    string `db:"Schema"`
+		Name      string `db:"Name"`
+		Column    string `db:"Column"`
+		Start     int64  `db:"Start"`
+		Increment int64  `db:"Increment"`
+		Current   *int64 `db:"Current"`
+	}
+	sequenceRows, err := gf_postgres.ScanRowsStruct[sequenceRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get sequences of %s: %w`, table, err)
+	}
+	return lo.Map(sequenceRows, func(it sequenceRow, _ int) SchemaSequence {
+		return SchemaSequence{
+			Name:      qualifiedName(lo.Ternary(it.Schema == schemaName, "", it.Schema), it.Name),
+			Column:    it.Column,
+			Start:     it.Start,
+			Increment: it.Increment,
+			Current:   it.Current,
+		}
+	}), nil
+}
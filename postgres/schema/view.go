@@ -0,0 +1,200 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Jumpaku/go-assert"
+	gf_postgres "github.com/Jumpaku/gotaface/postgres"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+type SchemaView struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+	// Materialized reports whether the view is a materialized view.
+	Materialized bool `json:"materialized"`
+	// Columns are the columns of the view, whose types are represented in the same way as those of tables.
+	Columns []SchemaColumn `json:"columns"`
+	// Definition is the SELECT statement of the view reconstructed by pg_get_viewdef.
+	Definition string `json:"definition"`
+	// Dependencies are the tables and views referenced by the view, which are qualified if they belong to schemas other than the schema of the view.
+	Dependencies []string `json:"dependencies"`
+}
+
+// QualifiedName returns the name of the view qualified by the schema, which is a form accepted by FetchView.
+func (view SchemaView) QualifiedName() string {
+	return qualifiedName(view.Schema, view.Name)
+}
+
+var _ schema.ViewFetcher[SchemaView] = fetcher{}
+var _ schema.ViewLister = fetcher{}
+
+// FetchView returns the definition of the view or the materialized view, whose name can be qualified by a schema in the same way as Fetch.
+func (fetcher fetcher) FetchView(ctx context.Context, view string) (SchemaView, error) {
+	wrapError := func(err error) (SchemaView, error) {
+		assert.Params(err != nil, "wrapped error must be not nil")
+		return SchemaView{}, fmt.Errorf(`fail to fetch view %s: %w`, view, err)
+	}
+
+	schemaName, viewName := splitQualifiedName(view)
+	if schemaName == "" {
+		var err error
+		schemaName, err = queryRelationSchema(ctx, fetcher.queryer, fetcher.searchPath, viewName, []string{"v", "m"})
+		if err != nil {
+			return wrapError(err)
+		}
+	}
+
+	schemaView, err := queryView(ctx, fetcher.queryer, schemaName, viewName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView.Columns, err = queryViewColumns(ctx, fetcher.queryer, schemaName, viewName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView.Dependencies, err = queryViewDependencies(ctx, fetcher.queryer, schemaName, viewName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return schemaView, nil
+}
+
+// ListViews returns names of views and materialized views in all the user schemas, which are qualified in the same way as ListTables.
+func (fetcher fetcher) ListViews(ctx context.Context) ([]string, error) {
+	views, err := listRelations(ctx, fetcher.queryer, fetcher.searchPath, []string{"v", "m"})
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list views: %w`, err)
+	}
+	return views, nil
+}
+
+func queryIsView(ctx context.Context, tx gf_postgres.Queryer, schemaName string, name string) (bool, error) {
+	sql := `--sql query whether the relation is a view
+SELECT
+	COUNT(*) > 0 AS "IsView"
+FROM pg_class AS c
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('v', 'm')`
+	rows, err := tx.Query(ctx, sql, schemaName, name)
+	if err != nil {
+		return false, fmt.Errorf(`fail to get kind of %s: %w`, name, err)
+	}
+	type result struct {
+		IsView bool `db:"IsView"`
+	}
+	results, err := gf_postgres.ScanRowsStruct[result](rows)
+	if err != nil {
+		return false, fmt.Errorf(`fail to get kind of %s: %w`, name, err)
+	}
+	return len(results) > 0 && results[0].IsView, nil
+}
+
+func queryView(ctx context.Context, tx gf_postgres.Queryer, schemaName string, view string) (SchemaView, error) {
+	sql := `--sql query view definition
+SELECT
+	c.relkind = 'm' AS "Materialized",
+	pg_get_viewdef(c.oid) AS "Definition"
+FROM pg_class AS c
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('v', 'm')`
+	rows, err := tx.Query(ctx, sql, schemaName, view)
+	if err != nil {
+		return SchemaView{}, fmt.Errorf(`fail to get definition of %s: %w`, view, err)
+	}
+	type definition struct {
+		Materialized bool   `db:"Materialized"`
+		Definition   string `db:"Definition"`
+	}
+	definitions, err := gf_postgres.ScanRowsStruct[definition](rows)
+	if err != nil {
+		return SchemaView{}, fmt.Errorf(`fail to get definition of %s: %w`, view, err)
+	}
+	if len(definitions) == 0 {
+		return SchemaView{}, fmt.Errorf(`fail to get definition of %s: view not found`, view)
+	}
+	return SchemaView{
+		Name:         view,
+		Schema:       schemaName,
+		Materialized: definitions[0].Materialized,
+		// pg_get_viewdef returns the statement with leading spaces and the terminating semicolon
+		Definition: strings.TrimSuffix(strings.TrimSpace(definitions[0].Definition), ";"),
+	}, nil
+}
+
+// queryViewColumns returns columns of the view from pg_attribute since information_schema.columns does not contain columns of materialized views.
+// The types are represented in the same way as data_type of information_schema.columns, and TypeName is given by format_type.
+func queryViewColumns(ctx context.Context, tx gf_postgres.Queryer, schemaName string, view string) ([]SchemaColumn, error) {
+	sql := `--sql query view column information
+SELECT
+	a.attname AS "Name",
+	CASE
+		WHEN bt.typelem <> 0 AND bt.typlen = -1 THEN 'ARRAY'
+		WHEN btn.nspname = 'pg_catalog' THEN format_type(bt.oid, NULL)
+		ELSE 'USER-DEFINED'
+	END AS "Type",
+	format_type(a.atttypid, a.atttypmod) AS "TypeName",
+	NOT a.attnotnull AS "Nullable"
+FROM pg_attribute AS a
+	JOIN pg_class AS c ON c.oid = a.attrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_type AS t ON t.oid = a.atttypid
+	JOIN pg_type AS bt ON bt.oid = CASE WHEN t.typtype = 'd' THEN t.typbasetype ELSE t.oid END
+	JOIN pg_namespace AS btn ON btn.oid = bt.typnamespace
+WHERE n.nspname = $1 AND c.relname = $2 AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`
+	rows, err := tx.Query(ctx, sql, schemaName, view)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get columns of %s: %w`, view, err)
+	}
+	type column struct {
+		Name     string `db:"Name"`
+		Type     string `db:"Type"`
+		TypeName string `db:"TypeName"`
+		Nullable bool   `db:"Nullable"`
+	}
+	columns, err := gf_postgres.ScanRowsStruct[column](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get columns of %s: %w`, view, err)
+	}
+	return lo.Map(columns, func(column column, _ int) SchemaColumn {
+		return SchemaColumn{Name: column.Name, Type: column.Type, TypeName: column.TypeName, Nullable: column.Nullable}
+	}), nil
+}
+
+// queryViewDependencies returns the tables and views which the rewrite rule of the view depends on.
+func queryViewDependencies(ctx context.Context, tx gf_postgres.Queryer, schemaName string, view string) ([]string, error) {
+	sql := `--sql query relations referenced by view
+SELECT DISTINCT
+	dn.nspname AS "Schema",
+	dc.relname AS "Name"
+FROM pg_class AS c
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_rewrite AS r ON r.ev_class = c.oid
+	JOIN pg_depend AS d ON d.classid = 'pg_rewrite'::regclass AND d.objid = r.oid AND d.refclassid = 'pg_class'::regclass
+	JOIN pg_class AS dc ON dc.oid = d.refobjid
+	JOIN pg_namespace AS dn ON dn.oid = dc.relnamespace
+WHERE n.nspname = $1 AND c.relname = $2 AND dc.oid <> c.oid AND dc.relkind IN ('r', 'p', 'v', 'm', 'f')
+ORDER BY dn.nspname, dc.relname`
+	rows, err := tx.Query(ctx, sql, schemaName, view)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+	type relation struct {
+		Schema string `db:"Schema"`
+		Name   string `db:"Name"`
+	}
+	relations, err := gf_postgres.ScanRowsStruct[relation](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+	return lo.Map(relations, func(it relation, _ int) string {
+		return qualifiedName(lo.Ternary(it.Schema == schemaName, "", it.Schema), it.Name)
+	}), nil
+}
@@ -0,0 +1,101 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/Jumpaku/gotaface/postgres/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var fetchViewTestcases = []struct {
+	ddl  string
+	view string
+	want schema.SchemaView
+}{
+	{
+		ddl:  "ddl_14_views",
+		view: "V_1",
+		want: schema.SchemaView{
+			Name:   "V_1",
+			Schema: "public",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "integer", TypeName: "integer", Nullable: true},
+				{Name: "C1", Type: "text", TypeName: "text", Nullable: true},
+			},
+			Dependencies: []string{"P_1"},
+		},
+	},
+	{
+		ddl:  "ddl_14_views",
+		view: "public.V_2",
+		want: schema.SchemaView{
+			Name:         "V_2",
+			Schema:       "public",
+			Materialized: true,
+			Columns: []schema.SchemaColumn{
+				{Name: "K", Type: "integer", TypeName: "integer", Nullable: true},
+				{Name: "C", Type: "text", TypeName: "text", Nullable: true},
+				{Name: "Total", Type: "double precision", TypeName: "double precision", Nullable: true},
+				{Name: "Items", Type: "ARRAY", TypeName: "integer[]", Nullable: true},
+			},
+			Dependencies: []string{"V_1", "S_3.P_2"},
+		},
+	},
+}
+
+func TestFetcher_FetchView(t *testing.T) {
+	for number, testcase := range fetchViewTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.view), func(t *testing.T) {
+			now := time.Now().Unix()
+			dbName := fmt.Sprintf("test_fetch_view_%03d_%d", number, now)
+			db, teardown := test.Setup(t, *test.DataSource, dbName)
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db)
+			got, err := sut.FetchView(context.Background(), testcase.view)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want.Name, got.Name)
+			assert.Equal(t, testcase.want.Schema, got.Schema)
+			assert.Equal(t, testcase.want.Materialized, got.Materialized)
+			assert.Equal(t, testcase.want.Columns, got.Columns)
+			assert.ElementsMatch(t, testcase.want.Dependencies, got.Dependencies)
+			// the definition is reconstructed in a format depending on the server version
+			assert.Contains(t, got.Definition, "SELECT")
+		})
+	}
+}
+
+func TestFetcher_FetchView_Error(t *testing.T) {
+	now := time.Now().Unix()
+	db, teardown := test.Setup(t, *test.DataSource, fmt.Sprintf("test_fetch_view_error_%d", now))
+	defer teardown()
+
+	test.InitDDLs(t, db, []string{ddls["ddl_14_views"]})
+
+	sut := schema.NewFetcher(db)
+	_, err := sut.FetchView(context.Background(), "P_1")
+	assert.NotNil(t, err)
+	_, err = sut.Fetch(context.Background(), "V_1")
+	assert.NotNil(t, err)
+	_, err = sut.Fetch(context.Background(), "public.V_2")
+	assert.NotNil(t, err)
+}
+
+func TestListViews(t *testing.T) {
+	now := time.Now().Unix()
+	db, teardown := test.Setup(t, *test.DataSource, fmt.Sprintf("test_list_views_%d", now))
+	defer teardown()
+
+	test.InitDDLs(t, db, []string{ddls["ddl_14_views"]})
+
+	sut := schema.NewFetcher(db)
+	got, err := sut.ListViews(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"V_1", "V_2"}, got)
+}
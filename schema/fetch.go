@@ -1,7 +1,44 @@
 package schema
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type Fetcher[Schema any] interface {
 	Fetch(ctx context.Context, table string) (Schema, error)
 }
+
+// Lister enumerates names of user tables excluding system tables.
+type Lister interface {
+	ListTables(ctx context.Context) ([]string, error)
+}
+
+// ViewFetcher fetches the definition of a view.
+type ViewFetcher[View any] interface {
+	FetchView(ctx context.Context, view string) (View, error)
+}
+
+// ViewLister enumerates names of user views, which include materialized views if the database supports them.
+type ViewLister interface {
+	ListViews(ctx context.Context) ([]string, error)
+}
+
+// FetchAll fetches schemas of all tables enumerated by the lister.
+func FetchAll[Schema any](ctx context.Context, lister Lister, fetcher Fetcher[Schema]) ([]Schema, error) {
+	tables, err := lister.ListTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+
+	schemas := []Schema{}
+	for _, table := range tables {
+		schema, err := fetcher.Fetch(ctx, table)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to fetch schema of %s: %w`, table, err)
+		}
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, nil
+}
@@ -0,0 +1,146 @@
+package schema
+
+import (
+	"fmt"
+)
+
+// TypeKind is a logical type of columns, which is independent of SQL dialects.
+type TypeKind string
+
+const (
+	TypeKindInteger   TypeKind = "integer"
+	TypeKindFloat     TypeKind = "float"
+	TypeKindDecimal   TypeKind = "decimal"
+	TypeKindString    TypeKind = "string"
+	TypeKindBytes     TypeKind = "bytes"
+	TypeKindBool      TypeKind = "bool"
+	TypeKindDate      TypeKind = "date"
+	TypeKindTimestamp TypeKind = "timestamp"
+	TypeKindJSON      TypeKind = "json"
+	TypeKindArray     TypeKind = "array"
+	// TypeKindOther is a type that has no logical counterpart, whose details are available only in the native type.
+	TypeKindOther TypeKind = "other"
+)
+
+// Type is a normalized logical type of a column.
+type Type struct {
+	Kind TypeKind `json:"kind"`
+	// Length is the maximum length of string or bytes, which is 0 if the length is not limited.
+	Length int64 `json:"length"`
+	// Precision and Scale are those of decimal, which are 0 if they are not specified.
+	Precision int64 `json:"precision"`
+	Scale     int64 `json:"scale"`
+	// Elem is the type of the elements of array, which is nil for the other kinds.
+	Elem *Type `json:"elem"`
+}
+
+// String returns the type in form such as integer, string(n), decimal(p,s), and array<integer>.
+func (t Type) String() string {
+	switch t.Kind {
+	case TypeKindString, TypeKindBytes:
+		if t.Length > 0 {
+			return fmt.Sprintf("%s(%d)", t.Kind, t.Length)
+		}
+	case TypeKindDecimal:
+		if t.Precision > 0 {
+			return fmt.Sprintf("%s(%d,%d)", t.Kind, t.Precision, t.Scale)
+		}
+	case TypeKindArray:
+		elem := Type{Kind: TypeKindOther}
+		if t.Elem != nil {
+			elem = *t.Elem
+		}
+		return fmt.Sprintf("%s<%s>", t.Kind, elem)
+	}
+	return string(t.Kind)
+}
+
+type Column struct {
+	Name string `json:"name"`
+	Type Type   `json:"type"`
+	// NativeType is the type of the column in the dialect, from which Type is converted.
+	NativeType string `json:"native_type"`
+	Nullable   bool   `json:"nullable"`
+	// Default is the expression of the default value in the dialect, which is empty if the column has no default value.
+	Default string `json:"default"`
+	// Generated is the expression of the generated column in the dialect, which is empty if the column is not generated.
+	Generated string `json:"generated"`
+	Stored    bool   `json:"stored"`
+	// AutoIncrement reports whether values of the column are generated by the database such as identity and serial columns.
+	AutoIncrement bool `json:"auto_increment"`
+}
+type ForeignKey struct {
+	Name            string   `json:"name"`
+	ReferencedTable string   `json:"referenced_table"`
+	ReferencedKey   []string `json:"referenced_key"`
+	ReferencingKey  []string `json:"referencing_key"`
+	// OnDelete and OnUpdate are the referential actions, which are empty for NO ACTION.
+	OnDelete string `json:"on_delete"`
+	OnUpdate string `json:"on_update"`
+}
+type UniqueKey struct {
+	Name string   `json:"name"`
+	Key  []string `json:"key"`
+}
+type IndexKey struct {
+	Name string `json:"name"`
+	Desc bool   `json:"desc"`
+	// Expression is the key part on an expression in the dialect, which is empty if the key part is a column.
+	Expression string `json:"expression"`
+}
+type Index struct {
+	Name   string     `json:"name"`
+	Unique bool       `json:"unique"`
+	Key    []IndexKey `json:"key"`
+	// Predicate is the condition of a partial index in the dialect, which is empty if the index is not partial.
+	Predicate string `json:"predicate"`
+}
+type Check struct {
+	Name string `json:"name"`
+	// Expression is the condition of the CHECK constraint in the dialect.
+	Expression string `json:"expression"`
+}
+
+// Table is a dialect-neutral schema of a table, which is converted from schemas fetched in each dialect.
+type Table struct {
+	Name string `json:"name"`
+	// Schema is the namespace of the table, which is empty if the dialect has no namespaces.
+	Schema     string   `json:"schema"`
+	Columns    []Column `json:"columns"`
+	PrimaryKey []string `json:"primary_key"`
+	// Parent is the table in which the table is interleaved, which is empty if the table is not interleaved.
+	Parent      string       `json:"parent"`
+	ForeignKeys []ForeignKey `json:"foreign_key"`
+	UniqueKeys  []UniqueKey  `json:"unique_key"`
+	Indexes     []Index      `json:"index"`
+	Checks      []Check      `json:"check"`
+}
+
+// Warning describes a part of a table that cannot be converted into a dialect.
+type Warning struct {
+	Table   string `json:"table"`
+	Message string `json:"message"`
+}
+
+func (w Warning) String() string {
+	return w.Table + ": " + w.Message
+}
+
+// ParentForeignKey returns a foreign key that represents interleaving of the table in its parent for dialects without interleaving.
+// The foreign key references the primary key of the parent by the prefix of the primary key of the table.
+// It returns false if the table is not interleaved or the parent is not found in the tables.
+func ParentForeignKey(table Table, tables []Table) (ForeignKey, bool) {
+	if table.Parent == "" {
+		return ForeignKey{}, false
+	}
+	for _, parent := range tables {
+		if parent.Name == table.Parent && len(parent.PrimaryKey) <= len(table.PrimaryKey) {
+			return ForeignKey{
+				ReferencedTable: parent.Name,
+				ReferencedKey:   parent.PrimaryKey,
+				ReferencingKey:  table.PrimaryKey[:len(parent.PrimaryKey)],
+			}, true
+		}
+	}
+	return ForeignKey{}, false
+}
@@ -0,0 +1,67 @@
+package schema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestType_String(t *testing.T) {
+	testcases := []struct {
+		in   schema.Type
+		want string
+	}{
+		{in: schema.Type{Kind: schema.TypeKindInteger}, want: "integer"},
+		{in: schema.Type{Kind: schema.TypeKindString}, want: "string"},
+		{in: schema.Type{Kind: schema.TypeKindString, Length: 50}, want: "string(50)"},
+		{in: schema.Type{Kind: schema.TypeKindBytes, Length: 16}, want: "bytes(16)"},
+		{in: schema.Type{Kind: schema.TypeKindDecimal}, want: "decimal"},
+		{in: schema.Type{Kind: schema.TypeKindDecimal, Precision: 10, Scale: 2}, want: "decimal(10,2)"},
+		{in: schema.Type{Kind: schema.TypeKindArray, Elem: &schema.Type{Kind: schema.TypeKindString, Length: 10}}, want: "array<string(10)>"},
+		{in: schema.Type{Kind: schema.TypeKindArray}, want: "array<other>"},
+		{in: schema.Type{Kind: schema.TypeKindOther}, want: "other"},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.want), func(t *testing.T) {
+			assert.Equal(t, testcase.want, testcase.in.String())
+		})
+	}
+}
+
+func TestParentForeignKey(t *testing.T) {
+	tables := []schema.Table{
+		{Name: "B_1", PrimaryKey: []string{"PK_11"}},
+		{Name: "B_2", PrimaryKey: []string{"PK_11", "PK_21"}, Parent: "B_1"},
+		{Name: "B_3", PrimaryKey: []string{"PK_11", "PK_21", "PK_31"}, Parent: "B_2"},
+		{Name: "B_4", PrimaryKey: []string{"PK_41"}, Parent: "X"},
+	}
+	testcases := []struct {
+		table     schema.Table
+		want      schema.ForeignKey
+		wantFound bool
+	}{
+		{table: tables[0]},
+		{
+			table:     tables[1],
+			want:      schema.ForeignKey{ReferencedTable: "B_1", ReferencedKey: []string{"PK_11"}, ReferencingKey: []string{"PK_11"}},
+			wantFound: true,
+		},
+		{
+			table:     tables[2],
+			want:      schema.ForeignKey{ReferencedTable: "B_2", ReferencedKey: []string{"PK_11", "PK_21"}, ReferencingKey: []string{"PK_11", "PK_21"}},
+			wantFound: true,
+		},
+		{table: tables[3]},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.table.Name), func(t *testing.T) {
+			got, found := schema.ParentForeignKey(testcase.table, tables)
+			assert.Equal(t, testcase.wantFound, found)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
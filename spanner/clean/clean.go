@@ -0,0 +1,98 @@
+package clean
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"cloud.google.com/go/spanner"
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/spanner/dependency"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/samber/lo"
+)
+
+// Updater executes partitioned DML, which is implemented by *spanner.Client.
+type Updater interface {
+	PartitionedUpdate(ctx context.Context, statement spanner.Statement) (count int64, err error)
+}
+
+type cleaner struct {
+	updater Updater
+	keep    []string
+}
+
+// NewCleaner returns a cleaner that deletes all rows of tables by partitioned DML, which is not limited by the number of mutations in a transaction.
+func NewCleaner(updater Updater) cleaner {
+	return cleaner{updater: updater}
+}
+
+// WithKeep returns a cleaner that keeps rows of the tables, such as reference tables holding master data.
+// A kept table must not be interleaved in or reference by enforced foreign keys a table that is not kept,
+// since its rows would be deleted by ON DELETE CASCADE or would block deleting the rows of the table by NO ACTION.
+func (c cleaner) WithKeep(tables ...string) cleaner {
+	c.keep = append(slices.Clone(c.keep), tables...)
+	return c
+}
+
+// Clean deletes all rows of the tables except the kept tables in delete order resolved from their foreign keys and interleaving.
+// Tables interleaved with ON DELETE CASCADE in deleted tables are not deleted explicitly since their rows are deleted with the rows of their parents.
+// Each table is deleted by a partitioned DML statement, so rows deleted before a failure are not restored.
+func (c cleaner) Clean(ctx context.Context, tables []schema.SchemaTable) error {
+	targets := lo.Reject(tables, func(table schema.SchemaTable, _ int) bool { return lo.Contains(c.keep, table.Name) })
+	if err := checkKept(tables, targets); err != nil {
+		return err
+	}
+	order, err := gf_dependency.Resolve(dependency.NewGraph(targets))
+	if err != nil {
+		return fmt.Errorf(`fail to resolve delete order: %w`, err)
+	}
+
+	for _, name := range DeleteTables(targets, order.DeleteOrder) {
+		stmt := spanner.Statement{SQL: fmt.Sprintf("DELETE FROM %s WHERE TRUE", quoteIdentifier(name))}
+		if _, err := c.updater.PartitionedUpdate(ctx, stmt); err != nil {
+			return fmt.Errorf(`fail to delete rows of %s: %w`, name, err)
+		}
+	}
+	return nil
+}
+
+// checkKept returns an error if rows of a kept table would be deleted with or would block deleting rows of the deleted tables.
+func checkKept(tables []schema.SchemaTable, targets []schema.SchemaTable) error {
+	deleted := lo.SliceToMap(targets, func(table schema.SchemaTable) (string, bool) { return table.Name, true })
+	for _, table := range tables {
+		if deleted[table.Name] {
+			continue
+		}
+		if deleted[table.Parent] {
+			if table.ParentOnDelete == "CASCADE" {
+				return fmt.Errorf(`fail to keep %s: rows are deleted with the rows of the parent %s by ON DELETE CASCADE`, table.Name, table.Parent)
+			}
+			return fmt.Errorf(`fail to keep %s: rows block deleting the rows of the parent %s by ON DELETE NO ACTION`, table.Name, table.Parent)
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			if foreignKey.NotEnforced || !deleted[foreignKey.ReferencedTable] {
+				continue
+			}
+			if foreignKey.OnDelete == "CASCADE" {
+				return fmt.Errorf(`fail to keep %s: rows are deleted with the rows of %s by ON DELETE CASCADE of foreign key %s`, table.Name, foreignKey.ReferencedTable, foreignKey.Name)
+			}
+			return fmt.Errorf(`fail to keep %s: rows block deleting the rows of %s by ON DELETE NO ACTION of foreign key %s`, table.Name, foreignKey.ReferencedTable, foreignKey.Name)
+		}
+	}
+	return nil
+}
+
+// DeleteTables returns the tables in the delete order from which rows must be deleted explicitly, which excludes tables interleaved with ON DELETE CASCADE in the other tables.
+func DeleteTables(tables []schema.SchemaTable, deleteOrder []string) []string {
+	tableMap := lo.SliceToMap(tables, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+	return lo.Filter(deleteOrder, func(name string, _ int) bool {
+		table := tableMap[name]
+		_, parentDeleted := tableMap[table.Parent]
+		return !(parentDeleted && table.ParentOnDelete == "CASCADE")
+	})
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + identifier + "`"
+}
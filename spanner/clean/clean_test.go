@@ -0,0 +1,139 @@
+package clean_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/Jumpaku/gotaface/spanner/clean"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteTables(t *testing.T) {
+	testcases := []struct {
+		name        string
+		tables      []schema.SchemaTable
+		deleteOrder []string
+		want        []string
+	}{
+		{
+			name: "cascade",
+			tables: []schema.SchemaTable{
+				{Name: "A"},
+				{Name: "B", Parent: "A", ParentOnDelete: "CASCADE"},
+				{Name: "C", Parent: "B", ParentOnDelete: "CASCADE"},
+			},
+			deleteOrder: []string{"C", "B", "A"},
+			want:        []string{"A"},
+		},
+		{
+			name: "no_action",
+			tables: []schema.SchemaTable{
+				{Name: "A"},
+				{Name: "B", Parent: "A", ParentOnDelete: "CASCADE"},
+				{Name: "C", Parent: "B"},
+			},
+			deleteOrder: []string{"C", "B", "A"},
+			want:        []string{"C", "A"},
+		},
+		{
+			name: "parent_not_deleted",
+			tables: []schema.SchemaTable{
+				{Name: "B", Parent: "A", ParentOnDelete: "CASCADE"},
+			},
+			deleteOrder: []string{"B"},
+			want:        []string{"B"},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			got := clean.DeleteTables(testcase.tables, testcase.deleteOrder)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+type updater struct {
+	stmts []string
+}
+
+func (u *updater) PartitionedUpdate(ctx context.Context, statement spanner.Statement) (int64, error) {
+	u.stmts = append(u.stmts, statement.SQL)
+	return 0, nil
+}
+
+func TestCleaner_Clean(t *testing.T) {
+	tables := []schema.SchemaTable{
+		{Name: "A"},
+		{Name: "B", Parent: "A", ParentOnDelete: "CASCADE"},
+		{Name: "C", Parent: "B"},
+		{Name: "R"},
+		{Name: "D", ForeignKeys: []schema.SchemaForeignKey{{Name: "FK_D_R", ReferencedTable: "R"}}},
+		{Name: "E", ForeignKeys: []schema.SchemaForeignKey{{Name: "FK_E_A", ReferencedTable: "A"}}},
+		{Name: "F", ForeignKeys: []schema.SchemaForeignKey{{Name: "FK_F_A", ReferencedTable: "A", OnDelete: "CASCADE"}}},
+		{Name: "G", ForeignKeys: []schema.SchemaForeignKey{{Name: "FK_G_A", ReferencedTable: "A", NotEnforced: true}}},
+	}
+	testcases := []struct {
+		name    string
+		keep    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "keep_referenced",
+			keep: []string{"R", "G"},
+			want: []string{
+				"DELETE FROM `F` WHERE TRUE",
+				"DELETE FROM `E` WHERE TRUE",
+				"DELETE FROM `D` WHERE TRUE",
+				"DELETE FROM `C` WHERE TRUE",
+				"DELETE FROM `A` WHERE TRUE",
+			},
+		},
+		{
+			name:    "keep_interleaved_cascade",
+			keep:    []string{"B", "C"},
+			wantErr: true,
+		},
+		{
+			name:    "keep_interleaved_no_action",
+			keep:    []string{"C"},
+			wantErr: true,
+		},
+		{
+			name:    "keep_foreign_key_no_action",
+			keep:    []string{"E"},
+			wantErr: true,
+		},
+		{
+			name:    "keep_foreign_key_cascade",
+			keep:    []string{"F"},
+			wantErr: true,
+		},
+		{
+			name: "keep_with_parent",
+			keep: []string{"A", "B", "C", "E", "F", "G"},
+			want: []string{
+				"DELETE FROM `D` WHERE TRUE",
+				"DELETE FROM `R` WHERE TRUE",
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			u := &updater{}
+			err := clean.NewCleaner(u).WithKeep(testcase.keep...).Clean(context.Background(), tables)
+			if testcase.wantErr {
+				assert.NotNil(t, err)
+				assert.Empty(t, u.stmts)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, u.stmts)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"cloud.google.com/go/spanner"
+
+	gf_dump "github.com/Jumpaku/gotaface/dump"
+	"github.com/Jumpaku/gotaface/spanner/dump"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+)
+
+//go:generate go run "github.com/Jumpaku/cyamli/cmd/cyamli@latest" golang -schema-path=cli.yaml -out-path=cli.gen.go
+var cli = NewCLI()
+
+func main() {
+	cli.FUNC = dumpRows
+	if err := Run(cli, os.Args); err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+}
+
+func dumpRows(subcommand []string, input CLI_Input, inputErr error) (err error) {
+	if inputErr != nil {
+		fmt.Fprintln(os.Stderr, cli.DESC_Simple())
+		return fmt.Errorf("fail to resolve command line arguments: %w", inputErr)
+	}
+	if input.Opt_Help {
+		fmt.Fprintln(os.Stderr, cli.DESC_Detail())
+		return nil
+	}
+	format := gf_dump.Format(input.Opt_Format)
+	if format != gf_dump.FormatJSONL && format != gf_dump.FormatCSV {
+		return fmt.Errorf("invalid option value for format, which must be one of jsonl, csv")
+	}
+
+	ctx := context.Background()
+	client, err := spanner.NewClient(ctx, input.Arg_DataSource)
+	if err != nil {
+		return fmt.Errorf("fail to create Spanner client: %w", err)
+	}
+	defer client.Close()
+
+	// Schemas and rows are read in the same snapshot.
+	tx := client.ReadOnlyTransaction()
+	defer tx.Close()
+
+	fetcher := schema.NewFetcher(tx)
+	dumper := dump.NewDumper(tx)
+	if input.Opt_Where != "" {
+		dumper = dumper.WithWhere(input.Opt_Where)
+	}
+	if input.Opt_Limit > 0 {
+		dumper = dumper.WithLimit(input.Opt_Limit)
+	}
+	if input.Opt_OrderByPrimaryKey {
+		dumper = dumper.WithOrderByPrimaryKey()
+	}
+
+	targetTables := input.Arg_TargetTables
+	if len(targetTables) == 0 {
+		targetTables, err = fetcher.ListTables(ctx)
+		if err != nil {
+			return fmt.Errorf("fail to list tables in Spanner database: %w", err)
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if input.Opt_Output != "" && input.Opt_OutputDir == "" {
+		f, err := os.Create(input.Opt_Output)
+		if err != nil {
+			return fmt.Errorf("fail to open output file %q: %w", input.Opt_Output, err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	for _, targetTable := range targetTables {
+		table, err := fetcher.Fetch(ctx, targetTable)
+		if err != nil {
+			return fmt.Errorf("fail to fetch schema of %q in Spanner database: %w", targetTable, err)
+		}
+
+		tableOut := out
+		if input.Opt_OutputDir != "" {
+			path := filepath.Join(input.Opt_OutputDir, targetTable+"."+string(format))
+			f, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("fail to open output file %q: %w", path, err)
+			}
+			defer f.Close()
+
+			tableOut = f
+		}
+
+		writer, err := gf_dump.NewWriter(format, tableOut)
+		if err != nil {
+			return fmt.Errorf("fail to create writer: %w", err)
+		}
+		if err := dumper.Dump(ctx, table, writer); err != nil {
+			return fmt.Errorf("fail to dump rows of %q in Spanner database: %w", targetTable, err)
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("fail to write rows of %q: %w", targetTable, err)
+		}
+	}
+
+	return nil
+}
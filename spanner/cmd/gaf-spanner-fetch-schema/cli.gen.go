@@ -3,53 +3,57 @@ package main
 
 import (
 	"fmt"
-	"strings"
 	"strconv"
+	"strings"
 )
 
 type Func[Input any] func(subcommand []string, input Input, inputErr error) (err error)
 
-
-
-
-
 type CLI struct {
-
 	FUNC Func[CLI_Input]
+
+	Sub_Diff CLI_Diff
 }
+
 func (CLI) DESC_Simple() string {
-	return "gaf-spanner-fetch-schema (v0.0.2):\nFetches schema data from a table in a Spanner database.\n\nUsage:\n    $ gaf-spanner-fetch-schema [<option>|<argument>]... [-- [<argument>]...]\n\nOptions:\n    -format, -help, -input-txt-tpl, -output\n\nArguments:\n    <data_source> <target_tables>...\n\n"
+	return "gaf-spanner-fetch-schema (v0.0.2):\nFetches schema data from a table in a Spanner database.\n\nUsage:\n    $ gaf-spanner-fetch-schema [<option>|<argument>]... [-- [<argument>]...]\n\nOptions:\n    -format, -go-package, -help, -input-txt-tpl, -output, -referenced-by\n\nArguments:\n    <data_source> <target_tables>...\n\nSubcommands:\n    diff\n\n"
 }
 func (CLI) DESC_Detail() string {
-	return "gaf-spanner-fetch-schema (v0.0.2):\nFetches schema data from a table in a Spanner database.\n\nUsage:\n    $ gaf-spanner-fetch-schema [<option>|<argument>]... [-- [<argument>]...]\n\n\nOptions:\n    -format=<string>  (default=\"json\"):\n        Specifies output format:\n         * json: outputs in JSON format.\n         * txt.tpl: processes template text from stdin in form Go's text/template and outputs result. Available data in the template is described in https://github.com/Jumpaku/gotaface/blob/main/spanner/schema/fetch.go#L25\n\n    -help[=<boolean>], -h[=<boolean>]  (default=false):\n        Shows help.\n\n    -input-txt-tpl=<string>  (default=\"\"):\n        Specifies input template file. It can be used with -format=txt.tpl. The stdin is specified in default.\n\n    -output=<string>  (default=\"\"):\n        Specifies output path. The stdout is specified in default.\n\n\nArguments:\n    [0]  <data_source:string>\n        Specifies data source in form \"projects/<project>/instances/<instance>/databases/<database>\".\n\n    [1:] [<target_tables:string>]...\n        Specify target tables to be fetched schemas.\n\n"
+	return "gaf-spanner-fetch-schema (v0.0.2):\nFetches schema data from a table in a Spanner database.\n\nUsage:\n    $ gaf-spanner-fetch-schema [<option>|<argument>]... [-- [<argument>]...]\n\n\nOptions:\n    -format=<string>  (default=\"json\"):\n        Specifies output format:\n         * json: outputs in JSON format.\n         * go: outputs Go source code that defines a struct for each of the fetched tables.\n         * sql: outputs CREATE TABLE statements of the fetched tables.\n         * mermaid: outputs an ER diagram of the fetched tables in Mermaid.\n         * plantuml: outputs an ER diagram of the fetched tables in PlantUML.\n         * dot: outputs an ER diagram of the fetched tables in DOT language of Graphviz.\n         * txt.tpl: processes template text from stdin in form Go's text/template and outputs result. Available data in the template is described in https://github.com/Jumpaku/gotaface/blob/main/spanner/schema/fetch.go#L25\n\n    -go-package=<string>  (default=\"model\"):\n        Specifies package name of the Go source code. It can be used with -format=go.\n\n    -help[=<boolean>], -h[=<boolean>]  (default=false):\n        Shows help.\n\n    -input-txt-tpl=<string>  (default=\"\"):\n        Specifies input template file. It can be used with -format=txt.tpl. The stdin is specified in default.\n\n    -output=<string>  (default=\"\"):\n        Specifies output path. The stdout is specified in default.\n\n    -referenced-by[=<boolean>]  (default=false):\n        Fetches foreign keys referencing each of the fetched tables into referenced_by.\n\n\nArguments:\n    [0]  <data_source:string>\n        Specifies data source in form \"projects/<project>/instances/<instance>/databases/<database>\".\n\n    [1:] [<target_tables:string>]...\n        Specify target tables to be fetched schemas. All tables are fetched if omitted.\n\n\nSubcommands:\n    diff:\n        Compares two schema snapshots, which are outputs of this command in JSON format, and outputs the differences.\n\n"
 }
-type CLI_Input struct {
 
+type CLI_Input struct {
 	Opt_Format string
 
+	Opt_GoPackage string
+
 	Opt_Help bool
 
 	Opt_InputTxtTpl string
 
 	Opt_Output string
 
+	Opt_ReferencedBy bool
 
 	Arg_DataSource string
 
 	Arg_TargetTables []string
-
 }
+
 func resolve_CLI_Input(input *CLI_Input, restArgs []string) error {
 	*input = CLI_Input{
-	
+
 		Opt_Format: "json",
-	
+
+		Opt_GoPackage: "model",
+
 		Opt_Help: false,
-	
+
 		Opt_InputTxtTpl: "",
-	
+
 		Opt_Output: "",
-	
+
+		Opt_ReferencedBy: false,
 	}
 
 	var arguments []string
@@ -68,80 +72,186 @@ func resolve_CLI_Input(input *CLI_Input, restArgs []string) error {
 		switch optName {
 		default:
 			return fmt.Errorf("unknown option %q", optName)
-		
+
 		case "-format":
 			if !cut {
 				return fmt.Errorf("value is not specified to option %q", optName)
-				
+
 			}
 			if err := parseValue(&input.Opt_Format, lit); err != nil {
 				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
 			}
-		
+
+		case "-go-package":
+			if !cut {
+				return fmt.Errorf("value is not specified to option %q", optName)
+
+			}
+			if err := parseValue(&input.Opt_GoPackage, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
 		case "-help", "-h":
 			if !cut {
 				lit = "true"
-				
+
 			}
 			if err := parseValue(&input.Opt_Help, lit); err != nil {
 				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
 			}
-		
+
 		case "-input-txt-tpl":
 			if !cut {
 				return fmt.Errorf("value is not specified to option %q", optName)
-				
+
 			}
 			if err := parseValue(&input.Opt_InputTxtTpl, lit); err != nil {
 				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
 			}
-		
+
 		case "-output":
 			if !cut {
 				return fmt.Errorf("value is not specified to option %q", optName)
-				
+
 			}
 			if err := parseValue(&input.Opt_Output, lit); err != nil {
 				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
 			}
-		
+
+		case "-referenced-by":
+			if !cut {
+				lit = "true"
+
+			}
+			if err := parseValue(&input.Opt_ReferencedBy, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
 		}
 	}
 
-	
-		
-		if len(arguments) <= 0 {
-			return fmt.Errorf("too few arguments")
-		}
-		if err := parseValue(&input.Arg_DataSource, arguments[0]); err != nil {
-			return fmt.Errorf("value is not assignable to argument at [%d]", 0)
-		}
-		
-	
-		
-		if len(arguments) <= 1 - 1 {
-			return fmt.Errorf("too few arguments")
-		}
-		if err := parseValue(&input.Arg_TargetTables, arguments[1:]...); err != nil {
-			return fmt.Errorf("values [%s] are not assignable to arguments at [%d:]", strings.Join(arguments[1:], " "), 1)
-		}
-		
-	
+	if len(arguments) <= 0 {
+		return fmt.Errorf("too few arguments")
+	}
+	if err := parseValue(&input.Arg_DataSource, arguments[0]); err != nil {
+		return fmt.Errorf("value is not assignable to argument at [%d]", 0)
+	}
+
+	if len(arguments) <= 1-1 {
+		return fmt.Errorf("too few arguments")
+	}
+	if err := parseValue(&input.Arg_TargetTables, arguments[1:]...); err != nil {
+		return fmt.Errorf("values [%s] are not assignable to arguments at [%d:]", strings.Join(arguments[1:], " "), 1)
+	}
 
 	return nil
 }
 
+type CLI_Diff struct {
+	FUNC Func[CLI_Diff_Input]
+}
+
+func (CLI_Diff) DESC_Simple() string {
+	return "gaf-spanner-fetch-schema diff:\nCompares two schema snapshots, which are outputs of this command in JSON format, and outputs the differences.\n\nUsage:\n    $ gaf-spanner-fetch-schema diff [<option>|<argument>]... [-- [<argument>]...]\n\nOptions:\n    -format, -help, -output\n\nArguments:\n    <before_snapshot> <after_snapshot>\n\n"
+}
+func (CLI_Diff) DESC_Detail() string {
+	return "gaf-spanner-fetch-schema diff:\nCompares two schema snapshots, which are outputs of this command in JSON format, and outputs the differences.\n\nUsage:\n    $ gaf-spanner-fetch-schema diff [<option>|<argument>]... [-- [<argument>]...]\n\n\nOptions:\n    -format=<string>  (default=\"json\"):\n        Specifies output format:\n         * json: outputs the differences in JSON format.\n         * sql: outputs statements to migrate the schema from the before snapshot to the after snapshot.\n\n    -help[=<boolean>], -h[=<boolean>]  (default=false):\n        Shows help.\n\n    -output=<string>  (default=\"\"):\n        Specifies output path. The stdout is specified in default.\n\n\nArguments:\n    [0]  <before_snapshot:string>\n        Specifies path to JSON file of the schema before migration.\n\n    [1]  <after_snapshot:string>\n        Specifies path to JSON file of the schema after migration.\n\n"
+}
+
+type CLI_Diff_Input struct {
+	Opt_Format string
+
+	Opt_Help bool
+
+	Opt_Output string
+
+	Arg_BeforeSnapshot string
+
+	Arg_AfterSnapshot string
+}
+
+func resolve_CLI_Diff_Input(input *CLI_Diff_Input, restArgs []string) error {
+	*input = CLI_Diff_Input{
 
+		Opt_Format: "json",
+
+		Opt_Help: false,
+
+		Opt_Output: "",
+	}
+
+	var arguments []string
+	for idx, arg := range restArgs {
+		if arg == "--" {
+			arguments = append(arguments, restArgs[idx+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			arguments = append(arguments, arg)
+			continue
+		}
+		optName, lit, cut := strings.Cut(arg, "=")
+		consumeVariables(optName, lit, cut)
+
+		switch optName {
+		default:
+			return fmt.Errorf("unknown option %q", optName)
+
+		case "-format":
+			if !cut {
+				return fmt.Errorf("value is not specified to option %q", optName)
+
+			}
+			if err := parseValue(&input.Opt_Format, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
+
+		case "-help", "-h":
+			if !cut {
+				lit = "true"
 
+			}
+			if err := parseValue(&input.Opt_Help, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
 
+		case "-output":
+			if !cut {
+				return fmt.Errorf("value is not specified to option %q", optName)
 
+			}
+			if err := parseValue(&input.Opt_Output, lit); err != nil {
+				return fmt.Errorf("value %q is not assignable to option %q", lit, optName)
+			}
 
+		}
+	}
+
+	if len(arguments) <= 0 {
+		return fmt.Errorf("too few arguments")
+	}
+	if err := parseValue(&input.Arg_BeforeSnapshot, arguments[0]); err != nil {
+		return fmt.Errorf("value is not assignable to argument at [%d]", 0)
+	}
+
+	if len(arguments) <= 1 {
+		return fmt.Errorf("too few arguments")
+	}
+	if err := parseValue(&input.Arg_AfterSnapshot, arguments[1]); err != nil {
+		return fmt.Errorf("value is not assignable to argument at [%d]", 1)
+	}
+
+	if len(arguments) > 2 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	return nil
+}
 
 func NewCLI() CLI {
 	return CLI{}
 }
 
-
 func Run(cli CLI, args []string) error {
 	subcommandPath, restArgs := resolveSubcommand(args)
 	switch strings.Join(subcommandPath, " ") {
@@ -155,19 +265,27 @@ func Run(cli CLI, args []string) error {
 		err := resolve_CLI_Input(&input, restArgs)
 		return funcMethod(subcommandPath, input, err)
 
+	case "diff":
+		funcMethod := cli.Sub_Diff.FUNC
+		if funcMethod == nil {
+			return fmt.Errorf("%q is unsupported: cli.Sub_Diff.FUNC not assigned", "diff")
+		}
+		var input CLI_Diff_Input
+		err := resolve_CLI_Diff_Input(&input, restArgs)
+		return funcMethod(subcommandPath, input, err)
 
 	}
 	return nil
 }
 
-
 func resolveSubcommand(args []string) (subcommandPath []string, restArgs []string) {
 	if len(args) == 0 {
 		panic("command line arguments are too few")
 	}
 	subcommandSet := map[string]bool{
-	"": true,
-	
+		"": true,
+
+		"diff": true,
 	}
 
 	for _, arg := range args[1:] {
@@ -243,4 +361,4 @@ func parseValue(dstPtr any, strValue ...string) error {
 	return nil
 }
 
-func consumeVariables(...any){}
+func consumeVariables(...any) {}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"cloud.google.com/go/spanner"
+
+	gf_fixture "github.com/Jumpaku/gotaface/fixture"
+	"github.com/Jumpaku/gotaface/generate"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/Jumpaku/gotaface/spanner/fixture"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+)
+
+//go:generate go run "github.com/Jumpaku/cyamli/cmd/cyamli@latest" golang -schema-path=cli.yaml -out-path=cli.gen.go
+var cli = NewCLI()
+
+func main() {
+	cli.FUNC = generateRows
+	if err := Run(cli, os.Args); err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+}
+
+func generateRows(subcommand []string, input CLI_Input, inputErr error) (err error) {
+	if inputErr != nil {
+		fmt.Fprintln(os.Stderr, cli.DESC_Simple())
+		return fmt.Errorf("fail to resolve command line arguments: %w", inputErr)
+	}
+	if input.Opt_Help {
+		fmt.Fprintln(os.Stderr, cli.DESC_Detail())
+		return nil
+	}
+	format := gf_fixture.Format(input.Opt_Format)
+	if format != gf_fixture.FormatJSON && format != gf_fixture.FormatYAML {
+		return fmt.Errorf("invalid option value for format, which must be one of json, yaml")
+	}
+
+	ctx := context.Background()
+	client, err := spanner.NewClient(ctx, input.Arg_DataSource)
+	if err != nil {
+		return fmt.Errorf("fail to create Spanner client: %w", err)
+	}
+	defer client.Close()
+
+	tx := client.ReadOnlyTransaction()
+	defer tx.Close()
+
+	fetcher := schema.NewFetcher(tx)
+
+	targetTables := input.Arg_TargetTables
+	if len(targetTables) == 0 {
+		targetTables, err = fetcher.ListTables(ctx)
+		if err != nil {
+			return fmt.Errorf("fail to list tables in Spanner database: %w", err)
+		}
+	}
+
+	tables := []schema.SchemaTable{}
+	for _, targetTable := range targetTables {
+		table, err := fetcher.Fetch(ctx, targetTable)
+		if err != nil {
+			return fmt.Errorf("fail to fetch schema of %q in Spanner database: %w", targetTable, err)
+		}
+		tables = append(tables, table)
+	}
+
+	neutralTables := []gf_schema.Table{}
+	for _, table := range tables {
+		neutralTables = append(neutralTables, schema.ConvertTable(table))
+	}
+	generated, err := generate.NewGenerator(input.Opt_Seed).WithRows(int(input.Opt_Rows)).Generate(neutralTables)
+	if err != nil {
+		return fmt.Errorf("fail to generate rows: %w", err)
+	}
+
+	if input.Opt_Insert {
+		if err := fixture.NewLoader(client).Load(ctx, tables, generated); err != nil {
+			return fmt.Errorf("fail to insert rows into Spanner database: %w", err)
+		}
+		return nil
+	}
+
+	b, err := gf_fixture.Marshal(format, generated)
+	if err != nil {
+		return fmt.Errorf("fail to marshal fixture: %w", err)
+	}
+	out := os.Stdout
+	if input.Opt_Output != "" {
+		f, err := os.Create(input.Opt_Output)
+		if err != nil {
+			return fmt.Errorf("fail to open output file %q: %w", input.Opt_Output, err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+	if _, err := out.Write(b); err != nil {
+		return fmt.Errorf("fail to write fixture: %w", err)
+	}
+
+	return nil
+}
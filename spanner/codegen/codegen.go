@@ -0,0 +1,92 @@
+package codegen
+
+import (
+	"strings"
+
+	gf_codegen "github.com/Jumpaku/gotaface/codegen"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/samber/lo"
+)
+
+const spannerImportPath = "cloud.google.com/go/spanner"
+
+// Generate returns source code of a Go package that defines a struct for each table, whose fields are tagged with spanner and json.
+// The helper methods for keys return spanner.Key.
+func Generate(packageName string, tables []schema.SchemaTable) ([]byte, error) {
+	imports := []string{}
+	codegenTables := lo.Map(tables, func(table schema.SchemaTable, _ int) gf_codegen.Table {
+		if len(table.PrimaryKey) > 0 || len(table.UniqueKeys) > 0 || len(table.ForeignKeys) > 0 {
+			imports = append(imports, spannerImportPath)
+		}
+		return gf_codegen.Table{
+			Name: table.Name,
+			Columns: lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) gf_codegen.Column {
+				goType, importPath := GoType(column)
+				if importPath != "" {
+					imports = append(imports, importPath)
+				}
+				return gf_codegen.Column{Name: column.Name, GoType: goType}
+			}),
+			PrimaryKey: table.PrimaryKey,
+			UniqueKeys: lo.Map(table.UniqueKeys, func(key schema.SchemaUniqueKey, _ int) []string { return key.Key }),
+			ForeignKeys: lo.Map(table.ForeignKeys, func(key schema.SchemaForeignKey, _ int) gf_codegen.ForeignKey {
+				return gf_codegen.ForeignKey{ReferencedTable: key.ReferencedTable, ReferencedKey: key.ReferencedKey, ReferencingKey: key.ReferencingKey}
+			}),
+		}
+	})
+
+	return gf_codegen.Generate(gf_codegen.Config{
+		Package: packageName,
+		Imports: imports,
+		Tags:    []string{"spanner", "json"},
+		KeyType: "spanner.Key",
+	}, codegenTables)
+}
+
+// GoType returns the Go type of the field for the column and the import path of the package that the type requires.
+// Nullable columns and elements of arrays are mapped to spanner.Null* types.
+// Types of PostgreSQL-dialect databases are mapped after they are normalized by schema.GoogleSQLType.
+func GoType(column schema.SchemaColumn) (goType string, importPath string) {
+	// numeric and jsonb of PostgreSQL-dialect databases can be decoded only into spanner.PG* types
+	elementType, isArray := strings.CutSuffix(column.Type, "[]")
+	switch elementType {
+	case "numeric":
+		return lo.Ternary(isArray, "[]", "") + "spanner.PGNumeric", spannerImportPath
+	case "jsonb":
+		return lo.Ternary(isArray, "[]", "") + "spanner.PGJsonB", spannerImportPath
+	}
+	columnType := schema.GoogleSQLType(column.Type)
+	if elementType, found := strings.CutPrefix(columnType, "ARRAY<"); found {
+		elementGoType, importPath := scalarGoType(strings.TrimSuffix(elementType, ">"), true)
+		return "[]" + elementGoType, importPath
+	}
+	return scalarGoType(columnType, column.Nullable)
+}
+
+func scalarGoType(spannerType string, nullable bool) (string, string) {
+	typeName, _, _ := strings.Cut(spannerType, "(")
+	switch typeName {
+	case "BOOL":
+		return lo.Ternary(nullable, "spanner.NullBool", "bool"), lo.Ternary(nullable, spannerImportPath, "")
+	case "INT64":
+		return lo.Ternary(nullable, "spanner.NullInt64", "int64"), lo.Ternary(nullable, spannerImportPath, "")
+	case "FLOAT32":
+		return lo.Ternary(nullable, "spanner.NullFloat32", "float32"), lo.Ternary(nullable, spannerImportPath, "")
+	case "FLOAT64":
+		return lo.Ternary(nullable, "spanner.NullFloat64", "float64"), lo.Ternary(nullable, spannerImportPath, "")
+	case "STRING":
+		return lo.Ternary(nullable, "spanner.NullString", "string"), lo.Ternary(nullable, spannerImportPath, "")
+	case "BYTES":
+		return "[]byte", ""
+	case "DATE":
+		return lo.Ternary(nullable, "spanner.NullDate", "civil.Date"), lo.Ternary(nullable, spannerImportPath, "cloud.google.com/go/civil")
+	case "TIMESTAMP":
+		return lo.Ternary(nullable, "spanner.NullTime", "time.Time"), lo.Ternary(nullable, spannerImportPath, "time")
+	case "NUMERIC":
+		return lo.Ternary(nullable, "spanner.NullNumeric", "big.Rat"), lo.Ternary(nullable, spannerImportPath, "math/big")
+	case "JSON":
+		return "spanner.NullJSON", spannerImportPath
+	default:
+		return "spanner.GenericColumnValue", spannerImportPath
+	}
+}
@@ -0,0 +1,67 @@
+package codegen_test
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/spanner/codegen"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoType(t *testing.T) {
+	testcases := []struct {
+		column         schema.SchemaColumn
+		wantGoType     string
+		wantImportPath string
+	}{
+		{column: schema.SchemaColumn{Type: "INT64"}, wantGoType: "int64", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "INT64", Nullable: true}, wantGoType: "spanner.NullInt64", wantImportPath: "cloud.google.com/go/spanner"},
+		{column: schema.SchemaColumn{Type: "STRING(MAX)"}, wantGoType: "string", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "BYTES(100)", Nullable: true}, wantGoType: "[]byte", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "DATE"}, wantGoType: "civil.Date", wantImportPath: "cloud.google.com/go/civil"},
+		{column: schema.SchemaColumn{Type: "TIMESTAMP"}, wantGoType: "time.Time", wantImportPath: "time"},
+		{column: schema.SchemaColumn{Type: "NUMERIC"}, wantGoType: "big.Rat", wantImportPath: "math/big"},
+		{column: schema.SchemaColumn{Type: "JSON"}, wantGoType: "spanner.NullJSON", wantImportPath: "cloud.google.com/go/spanner"},
+		{column: schema.SchemaColumn{Type: "ARRAY<STRING(MAX)>"}, wantGoType: "[]spanner.NullString", wantImportPath: "cloud.google.com/go/spanner"},
+		{column: schema.SchemaColumn{Type: "ARRAY<BYTES(MAX)>", Nullable: true}, wantGoType: "[][]byte", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "PROTO<x.Y>"}, wantGoType: "spanner.GenericColumnValue", wantImportPath: "cloud.google.com/go/spanner"},
+		{column: schema.SchemaColumn{Type: "character varying(50)"}, wantGoType: "string", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "bigint[]"}, wantGoType: "[]spanner.NullInt64", wantImportPath: "cloud.google.com/go/spanner"},
+		{column: schema.SchemaColumn{Type: "numeric", Nullable: true}, wantGoType: "spanner.PGNumeric", wantImportPath: "cloud.google.com/go/spanner"},
+		{column: schema.SchemaColumn{Type: "jsonb[]"}, wantGoType: "[]spanner.PGJsonB", wantImportPath: "cloud.google.com/go/spanner"},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.column.Type), func(t *testing.T) {
+			gotGoType, gotImportPath := codegen.GoType(testcase.column)
+			assert.Equal(t, testcase.wantGoType, gotGoType)
+			assert.Equal(t, testcase.wantImportPath, gotImportPath)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tables := []schema.SchemaTable{
+		{
+			Name:       "T",
+			Columns:    []schema.SchemaColumn{{Name: "Id", Type: "INT64"}, {Name: "Day", Type: "DATE"}},
+			PrimaryKey: []string{"Id"},
+		},
+	}
+
+	got, err := codegen.Generate("model", tables)
+	assert.Nil(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "model.go", got, parser.ImportsOnly)
+	assert.Nil(t, err)
+	assert.Equal(t, "model", file.Name.Name)
+	imports := []string{}
+	for _, spec := range file.Imports {
+		imports = append(imports, spec.Path.Value)
+	}
+	assert.Equal(t, []string{`"cloud.google.com/go/civil"`, `"cloud.google.com/go/spanner"`}, imports)
+	assert.Contains(t, string(got), "func (row T) PrimaryKey() spanner.Key {")
+}
@@ -0,0 +1,50 @@
+package dependency_test
+
+import (
+	"testing"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/spanner/dependency"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGraph(t *testing.T) {
+	tables := []schema.SchemaTable{
+		{
+			Name:       "B_1",
+			Columns:    []schema.SchemaColumn{{Name: "PK_11", Type: "INT64"}},
+			PrimaryKey: []string{"PK_11"},
+		},
+		{
+			Name: "B_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "Ref", Type: "INT64", Nullable: true},
+			},
+			PrimaryKey: []string{"PK_11", "PK_21"},
+			Parent:     "B_1",
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_B_2_1", ReferencedTable: "B_1", ReferencedKey: []string{"PK_11"}, ReferencingKey: []string{"Ref"}},
+				{Name: "FK_B_2_2", ReferencedTable: "B_2", ReferencedKey: []string{"PK_21"}, ReferencingKey: []string{"PK_11"}},
+			},
+		},
+	}
+	want := gf_dependency.Graph{
+		Tables: []string{"B_1", "B_2"},
+		Edges: []gf_dependency.Edge{
+			{From: "B_2", To: "B_1", Kind: gf_dependency.EdgeKindInterleave},
+			{From: "B_2", To: "B_1", Kind: gf_dependency.EdgeKindForeignKey, Name: "FK_B_2_1", Index: 0, Nullable: true},
+			{From: "B_2", To: "B_2", Kind: gf_dependency.EdgeKindForeignKey, Name: "FK_B_2_2", Index: 1, Nullable: false},
+		},
+	}
+
+	got := dependency.NewGraph(tables)
+	assert.Equal(t, want, got)
+
+	order, err := gf_dependency.Resolve(got)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"B_1", "B_2"}, order.InsertOrder)
+	assert.Equal(t, []string{"B_2", "B_1"}, order.DeleteOrder)
+}
@@ -0,0 +1,24 @@
+package spanner
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	spanner_adminpb "cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+)
+
+// QueryDialect returns the dialect of the database, which is either GOOGLE_STANDARD_SQL or POSTGRESQL.
+// The query is written so that it is valid in both the dialects.
+func QueryDialect(ctx context.Context, queryer Queryer) (spanner_adminpb.DatabaseDialect, error) {
+	sql := `SELECT option_value AS dialect FROM information_schema.database_options WHERE option_name = 'database_dialect'`
+	type option struct{ Dialect string }
+	options, err := ScanRowsStruct[option](queryer.Query(ctx, spanner.Statement{SQL: sql}))
+	if err != nil {
+		return spanner_adminpb.DatabaseDialect_DATABASE_DIALECT_UNSPECIFIED, fmt.Errorf(`fail to get database dialect: %w`, err)
+	}
+	if len(options) > 0 && options[0].Dialect == spanner_adminpb.DatabaseDialect_POSTGRESQL.String() {
+		return spanner_adminpb.DatabaseDialect_POSTGRESQL, nil
+	}
+	return spanner_adminpb.DatabaseDialect_GOOGLE_STANDARD_SQL, nil
+}
@@ -0,0 +1,319 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/spanner/dependency"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/samber/lo"
+)
+
+type ColumnDiff struct {
+	Name   string              `json:"name"`
+	Before schema.SchemaColumn `json:"before"`
+	After  schema.SchemaColumn `json:"after"`
+}
+
+type TableDiff struct {
+	Name               string                    `json:"name"`
+	Before             schema.SchemaTable        `json:"before"`
+	After              schema.SchemaTable        `json:"after"`
+	AddedColumns       []schema.SchemaColumn     `json:"added_columns"`
+	DroppedColumns     []schema.SchemaColumn     `json:"dropped_columns"`
+	ModifiedColumns    []ColumnDiff              `json:"modified_columns"`
+	PrimaryKeyChanged  bool                      `json:"primary_key_changed"`
+	ParentChanged      bool                      `json:"parent_changed"`
+	AddedForeignKeys   []schema.SchemaForeignKey `json:"added_foreign_keys"`
+	DroppedForeignKeys []schema.SchemaForeignKey `json:"dropped_foreign_keys"`
+	AddedUniqueKeys    []schema.SchemaUniqueKey  `json:"added_unique_keys"`
+	DroppedUniqueKeys  []schema.SchemaUniqueKey  `json:"dropped_unique_keys"`
+	AddedIndexes       []schema.SchemaIndex      `json:"added_indexes"`
+	DroppedIndexes     []schema.SchemaIndex      `json:"dropped_indexes"`
+	AddedChecks        []schema.SchemaCheck      `json:"added_checks"`
+	DroppedChecks      []schema.SchemaCheck      `json:"dropped_checks"`
+}
+
+type Diff struct {
+	AddedTables    []schema.SchemaTable `json:"added_tables"`
+	DroppedTables  []schema.SchemaTable `json:"dropped_tables"`
+	ModifiedTables []TableDiff          `json:"modified_tables"`
+}
+
+// Compare returns differences to migrate tables from before to after.
+func Compare(before, after []schema.SchemaTable) Diff {
+	beforeTables := lo.SliceToMap(before, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+	afterTables := lo.SliceToMap(after, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+
+	diff := Diff{}
+	for _, table := range before {
+		if _, found := afterTables[table.Name]; !found {
+			diff.DroppedTables = append(diff.DroppedTables, table)
+		}
+	}
+	for _, table := range after {
+		beforeTable, found := beforeTables[table.Name]
+		if !found {
+			diff.AddedTables = append(diff.AddedTables, table)
+			continue
+		}
+		if tableDiff := compareTable(beforeTable, table); !tableDiff.IsEmpty() {
+			diff.ModifiedTables = append(diff.ModifiedTables, tableDiff)
+		}
+	}
+
+	return diff
+}
+
+func (diff Diff) IsEmpty() bool {
+	return len(diff.AddedTables) == 0 && len(diff.DroppedTables) == 0 && len(diff.ModifiedTables) == 0
+}
+
+func (diff TableDiff) IsEmpty() bool {
+	return len(diff.AddedColumns) == 0 &&
+		len(diff.DroppedColumns) == 0 &&
+		len(diff.ModifiedColumns) == 0 &&
+		!diff.PrimaryKeyChanged &&
+		!diff.ParentChanged &&
+		len(diff.AddedForeignKeys) == 0 &&
+		len(diff.DroppedForeignKeys) == 0 &&
+		len(diff.AddedUniqueKeys) == 0 &&
+		len(diff.DroppedUniqueKeys) == 0 &&
+		len(diff.AddedIndexes) == 0 &&
+		len(diff.DroppedIndexes) == 0 &&
+		len(diff.AddedChecks) == 0 &&
+		len(diff.DroppedChecks) == 0
+}
+
+func compareTable(before, after schema.SchemaTable) TableDiff {
+	diff := TableDiff{Name: after.Name, Before: before, After: after}
+
+	beforeColumns := lo.SliceToMap(before.Columns, func(column schema.SchemaColumn) (string, schema.SchemaColumn) { return column.Name, column })
+	afterColumns := lo.SliceToMap(after.Columns, func(column schema.SchemaColumn) (string, schema.SchemaColumn) { return column.Name, column })
+	for _, column := range before.Columns {
+		if _, found := afterColumns[column.Name]; !found {
+			diff.DroppedColumns = append(diff.DroppedColumns, column)
+		}
+	}
+	for _, column := range after.Columns {
+		beforeColumn, found := beforeColumns[column.Name]
+		switch {
+		case !found:
+			diff.AddedColumns = append(diff.AddedColumns, column)
+		case beforeColumn != column:
+			diff.ModifiedColumns = append(diff.ModifiedColumns, ColumnDiff{Name: column.Name, Before: beforeColumn, After: column})
+		}
+	}
+
+	diff.PrimaryKeyChanged = !slices.Equal(before.PrimaryKey, after.PrimaryKey)
+	diff.ParentChanged = before.Parent != after.Parent
+
+	diff.DroppedForeignKeys = lo.Filter(before.ForeignKeys, func(foreignKey schema.SchemaForeignKey, _ int) bool {
+		return !lo.ContainsBy(after.ForeignKeys, func(it schema.SchemaForeignKey) bool { return equalForeignKey(it, foreignKey) })
+	})
+	diff.AddedForeignKeys = lo.Filter(after.ForeignKeys, func(foreignKey schema.SchemaForeignKey, _ int) bool {
+		return !lo.ContainsBy(before.ForeignKeys, func(it schema.SchemaForeignKey) bool { return equalForeignKey(it, foreignKey) })
+	})
+
+	diff.DroppedUniqueKeys = lo.Filter(before.UniqueKeys, func(uniqueKey schema.SchemaUniqueKey, _ int) bool {
+		return !lo.ContainsBy(after.UniqueKeys, func(it schema.SchemaUniqueKey) bool { return equalUniqueKey(it, uniqueKey) })
+	})
+	diff.AddedUniqueKeys = lo.Filter(after.UniqueKeys, func(uniqueKey schema.SchemaUniqueKey, _ int) bool {
+		return !lo.ContainsBy(before.UniqueKeys, func(it schema.SchemaUniqueKey) bool { return equalUniqueKey(it, uniqueKey) })
+	})
+
+	diff.DroppedIndexes = lo.Filter(before.Indexes, func(index schema.SchemaIndex, _ int) bool {
+		return !lo.ContainsBy(after.Indexes, func(it schema.SchemaIndex) bool { return equalIndex(it, index) })
+	})
+	diff.AddedIndexes = lo.Filter(after.Indexes, func(index schema.SchemaIndex, _ int) bool {
+		return !lo.ContainsBy(before.Indexes, func(it schema.SchemaIndex) bool { return equalIndex(it, index) })
+	})
+
+	diff.DroppedChecks = lo.Filter(before.Checks, func(check schema.SchemaCheck, _ int) bool {
+		return !lo.Contains(after.Checks, check)
+	})
+	diff.AddedChecks = lo.Filter(after.Checks, func(check schema.SchemaCheck, _ int) bool {
+		return !lo.Contains(before.Checks, check)
+	})
+
+	return diff
+}
+
+func equalForeignKey(a, b schema.SchemaForeignKey) bool {
+	return a.Name == b.Name &&
+		a.ReferencedTable == b.ReferencedTable &&
+		slices.Equal(a.ReferencedKey, b.ReferencedKey) &&
+		slices.Equal(a.ReferencingKey, b.ReferencingKey) &&
+		a.OnDelete == b.OnDelete &&
+		a.NotEnforced == b.NotEnforced
+}
+
+func equalUniqueKey(a, b schema.SchemaUniqueKey) bool {
+	return a.Name == b.Name && slices.Equal(a.Key, b.Key)
+}
+
+func equalIndex(a, b schema.SchemaIndex) bool {
+	return a.Name == b.Name &&
+		a.Unique == b.Unique &&
+		slices.Equal(a.Key, b.Key) &&
+		a.NullFiltered == b.NullFiltered &&
+		slices.Equal(a.Storing, b.Storing) &&
+		a.Interleave == b.Interleave
+}
+
+// MigrationDDL returns statements to migrate tables according to the diff.
+// Constraints and indexes are dropped before tables and columns are dropped, and they are created after tables and columns are created.
+// Since Spanner cannot alter primary keys and interleaving, tables whose primary key or parent is changed are dropped and created again, in which case rows in the tables are lost.
+// Tables are created in the order where parents precede their children and dropped in the reverse order.
+// Unique keys having the same names as indexes are dropped and created as the indexes.
+// Since Spanner cannot alter generated columns, modified generated columns are dropped and added again.
+func MigrationDDL(diff Diff) []string {
+	recreated := lo.Filter(diff.ModifiedTables, func(table TableDiff, _ int) bool { return requiresRecreate(table) })
+	altered := lo.Filter(diff.ModifiedTables, func(table TableDiff, _ int) bool { return !requiresRecreate(table) })
+	droppedTables := append(slices.Clone(diff.DroppedTables), lo.Map(recreated, func(table TableDiff, _ int) schema.SchemaTable { return table.Before })...)
+	createdTables := append(slices.Clone(diff.AddedTables), lo.Map(recreated, func(table TableDiff, _ int) schema.SchemaTable { return table.After })...)
+
+	stmts := []string{}
+
+	for _, table := range altered {
+		for _, foreignKey := range table.DroppedForeignKeys {
+			stmts = append(stmts, dropConstraintDDL(table.Name, foreignKey.Name))
+		}
+		for _, check := range table.DroppedChecks {
+			stmts = append(stmts, dropConstraintDDL(table.Name, check.Name))
+		}
+	}
+	for _, table := range droppedTables {
+		for _, foreignKey := range table.ForeignKeys {
+			stmts = append(stmts, dropConstraintDDL(table.Name, foreignKey.Name))
+		}
+	}
+	for _, table := range altered {
+		for _, uniqueKey := range table.DroppedUniqueKeys {
+			if !schema.HasIndex(table.Before, uniqueKey.Name) {
+				stmts = append(stmts, fmt.Sprintf(`DROP INDEX %s`, quoteIdentifier(uniqueKey.Name)))
+			}
+		}
+		for _, index := range table.DroppedIndexes {
+			stmts = append(stmts, fmt.Sprintf(`DROP INDEX %s`, quoteIdentifier(index.Name)))
+		}
+	}
+	for _, table := range droppedTables {
+		for _, uniqueKey := range table.UniqueKeys {
+			if !schema.HasIndex(table, uniqueKey.Name) {
+				stmts = append(stmts, fmt.Sprintf(`DROP INDEX %s`, quoteIdentifier(uniqueKey.Name)))
+			}
+		}
+		for _, index := range table.Indexes {
+			stmts = append(stmts, fmt.Sprintf(`DROP INDEX %s`, quoteIdentifier(index.Name)))
+		}
+	}
+	for _, table := range orderTables(droppedTables, true) {
+		stmts = append(stmts, fmt.Sprintf(`DROP TABLE %s`, quoteIdentifier(table.Name)))
+	}
+	for _, table := range altered {
+		for _, column := range table.DroppedColumns {
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s DROP COLUMN %s`, quoteIdentifier(table.Name), quoteIdentifier(column.Name)))
+		}
+		for _, column := range table.ModifiedColumns {
+			if requiresRecreateColumn(column) {
+				stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s DROP COLUMN %s`, quoteIdentifier(table.Name), quoteIdentifier(column.Name)))
+			}
+		}
+	}
+
+	for _, table := range orderTables(createdTables, false) {
+		stmts = append(stmts, schema.CreateTableDDL(table))
+	}
+	for _, table := range altered {
+		for _, column := range table.AddedColumns {
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s`, quoteIdentifier(table.Name), schema.ColumnDefinition(column)))
+		}
+		for _, column := range table.ModifiedColumns {
+			if requiresRecreateColumn(column) {
+				stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s`, quoteIdentifier(table.Name), schema.ColumnDefinition(column.After)))
+				continue
+			}
+			stmts = append(stmts, alterColumnDDL(table.Name, column))
+		}
+		for _, check := range table.AddedChecks {
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD %s`, quoteIdentifier(table.Name), schema.CheckDefinition(check)))
+		}
+	}
+	for _, table := range createdTables {
+		for _, uniqueKey := range table.UniqueKeys {
+			if !schema.HasIndex(table, uniqueKey.Name) {
+				stmts = append(stmts, schema.CreateUniqueIndexDDL(table.Name, uniqueKey))
+			}
+		}
+		for _, index := range table.Indexes {
+			stmts = append(stmts, schema.CreateIndexDDL(table.Name, index))
+		}
+	}
+	for _, table := range altered {
+		for _, uniqueKey := range table.AddedUniqueKeys {
+			if !schema.HasIndex(table.After, uniqueKey.Name) {
+				stmts = append(stmts, schema.CreateUniqueIndexDDL(table.Name, uniqueKey))
+			}
+		}
+		for _, index := range table.AddedIndexes {
+			stmts = append(stmts, schema.CreateIndexDDL(table.Name, index))
+		}
+	}
+	for _, table := range createdTables {
+		for _, foreignKey := range table.ForeignKeys {
+			stmts = append(stmts, schema.AddForeignKeyDDL(table.Name, foreignKey))
+		}
+	}
+	for _, table := range altered {
+		for _, foreignKey := range table.AddedForeignKeys {
+			stmts = append(stmts, schema.AddForeignKeyDDL(table.Name, foreignKey))
+		}
+	}
+
+	return stmts
+}
+
+func requiresRecreate(table TableDiff) bool {
+	return table.PrimaryKeyChanged || table.ParentChanged
+}
+
+// requiresRecreateColumn returns true if the column must be dropped and added again since generated columns cannot be altered.
+func requiresRecreateColumn(column ColumnDiff) bool {
+	return column.Before.Generated != "" || column.After.Generated != ""
+}
+
+// alterColumnDDL returns a statement altering the whole definition of the column if its type or nullability is changed, or otherwise a statement altering only its default value.
+func alterColumnDDL(table string, column ColumnDiff) string {
+	before, after := column.Before, column.After
+	switch {
+	case before.Type != after.Type || before.Nullable != after.Nullable:
+		return fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s`, quoteIdentifier(table), schema.ColumnDefinition(after))
+	case after.Default == "":
+		return fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT`, quoteIdentifier(table), quoteIdentifier(after.Name))
+	default:
+		return fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s SET DEFAULT (%s)`, quoteIdentifier(table), quoteIdentifier(after.Name), after.Default)
+	}
+}
+
+func orderTables(tables []schema.SchemaTable, reverse bool) []schema.SchemaTable {
+	order, err := gf_dependency.Resolve(dependency.NewGraph(tables))
+	if err != nil {
+		return tables
+	}
+	names := order.InsertOrder
+	if reverse {
+		names = order.DeleteOrder
+	}
+	tableMap := lo.SliceToMap(tables, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+	return lo.Map(names, func(name string, _ int) schema.SchemaTable { return tableMap[name] })
+}
+
+func dropConstraintDDL(table string, constraint string) string {
+	return fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT %s`, quoteIdentifier(table), quoteIdentifier(constraint))
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + identifier + "`"
+}
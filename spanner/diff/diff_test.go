@@ -0,0 +1,260 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/Jumpaku/gotaface/spanner/diff"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationDDL(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "STRING(50)", Nullable: true},
+				{Name: "C2", Type: "STRING(MAX)"},
+			},
+			PrimaryKey:  []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{{Name: "FK_A_B", ReferencedTable: "B", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"PK"}}},
+			UniqueKeys:  []schema.SchemaUniqueKey{{Name: "UQ_A_C2", Key: []string{"C2"}}},
+		},
+		{
+			Name:       "B",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INT64"}},
+			PrimaryKey: []string{"PK"},
+		},
+		{
+			Name: "D",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "PK_2", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "STRING(100)"},
+				{Name: "C3", Type: "INT64", Nullable: true},
+			},
+			PrimaryKey:  []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{{Name: "FK_A_C", ReferencedTable: "C", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"C3"}}},
+			UniqueKeys:  []schema.SchemaUniqueKey{{Name: "UQ_A_C1", Key: []string{"C1"}}},
+		},
+		{
+			Name: "C_Child",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "PK_2", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK", "PK_2"},
+			Parent:     "C",
+		},
+		{
+			Name:       "C",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INT64"}},
+			PrimaryKey: []string{"PK"},
+		},
+		{
+			Name: "D",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "PK_2", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK", "PK_2"},
+		},
+	}
+	want := []string{
+		"ALTER TABLE `A` DROP CONSTRAINT `FK_A_B`",
+		"DROP INDEX `UQ_A_C2`",
+		"DROP TABLE `D`",
+		"DROP TABLE `B`",
+		"ALTER TABLE `A` DROP COLUMN `C2`",
+		"CREATE TABLE `C` (\n    `PK` INT64 NOT NULL\n) PRIMARY KEY (`PK`)",
+		"CREATE TABLE `C_Child` (\n    `PK` INT64 NOT NULL,\n    `PK_2` INT64 NOT NULL\n) PRIMARY KEY (`PK`, `PK_2`),\n    INTERLEAVE IN PARENT `C`",
+		"CREATE TABLE `D` (\n    `PK` INT64 NOT NULL,\n    `PK_2` INT64 NOT NULL\n) PRIMARY KEY (`PK`, `PK_2`)",
+		"ALTER TABLE `A` ADD COLUMN `C3` INT64",
+		"ALTER TABLE `A` ALTER COLUMN `C1` STRING(100) NOT NULL",
+		"CREATE UNIQUE INDEX `UQ_A_C1` ON `A` (`C1`)",
+		"ALTER TABLE `A` ADD CONSTRAINT `FK_A_C` FOREIGN KEY (`C3`) REFERENCES `C` (`PK`)",
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+
+	assert.Empty(t, diff.MigrationDDL(diff.Compare(after, after)))
+}
+
+func TestMigrationDDL_Indexes(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "STRING(MAX)", Nullable: true},
+				{Name: "C2", Type: "INT64", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{{Name: "UQ_A_C2", Key: []string{"C2"}}},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_A_C1", Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "UQ_A_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}}},
+			},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "STRING(MAX)", Nullable: true},
+				{Name: "C2", Type: "INT64", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_A_C1", Key: []schema.SchemaIndexKey{{Name: "C1", Desc: true}}, Storing: []string{"C2"}},
+			},
+		},
+		{
+			Name: "B",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "PK_2", Type: "INT64"},
+				{Name: "C1", Type: "STRING(MAX)", Nullable: true},
+			},
+			PrimaryKey: []string{"PK", "PK_2"},
+			Parent:     "A",
+			UniqueKeys: []schema.SchemaUniqueKey{{Name: "UQ_B_C1", Key: []string{"PK", "C1"}}},
+			Indexes: []schema.SchemaIndex{
+				{Name: "UQ_B_C1", Unique: true, NullFiltered: true, Key: []schema.SchemaIndexKey{{Name: "PK"}, {Name: "C1"}}, Interleave: "A"},
+			},
+		},
+	}
+	want := []string{
+		"DROP INDEX `IDX_A_C1`",
+		"DROP INDEX `UQ_A_C2`",
+		"CREATE TABLE `B` (\n    `PK` INT64 NOT NULL,\n    `PK_2` INT64 NOT NULL,\n    `C1` STRING(MAX)\n) PRIMARY KEY (`PK`, `PK_2`),\n    INTERLEAVE IN PARENT `A`",
+		"CREATE UNIQUE NULL_FILTERED INDEX `UQ_B_C1` ON `B` (`PK`, `C1`), INTERLEAVE IN `A`",
+		"CREATE INDEX `IDX_A_C1` ON `A` (`C1` DESC) STORING (`C2`)",
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+
+	assert.Empty(t, diff.MigrationDDL(diff.Compare(after, after)))
+}
+
+func TestMigrationDDL_Columns(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64", Default: "0"},
+				{Name: "C2", Type: "STRING(50)", Nullable: true},
+				{Name: "C3", Type: "INT64", Nullable: true, Generated: "C1 * 2", Stored: true},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "STRING(100)", Nullable: true, Default: "'abc'"},
+				{Name: "C3", Type: "INT64", Nullable: true, Generated: "C1 * 3", Stored: true},
+				{Name: "C4", Type: "TIMESTAMP", Default: "CURRENT_TIMESTAMP()"},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+	want := []string{
+		"ALTER TABLE `A` DROP COLUMN `C3`",
+		"ALTER TABLE `A` ADD COLUMN `C4` TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP())",
+		"ALTER TABLE `A` ALTER COLUMN `C1` DROP DEFAULT",
+		"ALTER TABLE `A` ALTER COLUMN `C2` STRING(100) DEFAULT ('abc')",
+		"ALTER TABLE `A` ADD COLUMN `C3` INT64 AS (C1 * 3) STORED",
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+	assert.Empty(t, diff.MigrationDDL(diff.Compare(after, after)))
+}
+
+func TestMigrationDDL_Checks(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name:       "A",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INT64"}, {Name: "C1", Type: "INT64", Nullable: true}},
+			PrimaryKey: []string{"PK"},
+			Checks: []schema.SchemaCheck{
+				{Name: "CK_A_C1", Expression: "C1 >= 0"},
+				{Name: "CK_A", Expression: "C1 < 10"},
+			},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name:       "A",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INT64"}, {Name: "C1", Type: "INT64", Nullable: true}},
+			PrimaryKey: []string{"PK"},
+			Checks: []schema.SchemaCheck{
+				{Name: "CK_A_C1", Expression: "C1 >= 0"},
+				{Name: "CK_A", Expression: "C1 < 100"},
+			},
+		},
+	}
+	want := []string{
+		"ALTER TABLE `A` DROP CONSTRAINT `CK_A`",
+		"ALTER TABLE `A` ADD CONSTRAINT `CK_A` CHECK (C1 < 100)",
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+}
+
+func TestMigrationDDL_ForeignKeys(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name:       "A",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INT64"}},
+			PrimaryKey: []string{"PK"},
+		},
+		{
+			Name:       "B",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INT64"}, {Name: "A", Type: "INT64"}},
+			PrimaryKey: []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_B_A", ReferencedTable: "A", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"A"}},
+			},
+		},
+	}
+	after := []schema.SchemaTable{
+		before[0],
+		{
+			Name:       "B",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INT64"}, {Name: "A", Type: "INT64"}},
+			PrimaryKey: []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_B_A", ReferencedTable: "A", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"A"}, OnDelete: "CASCADE"},
+				{Name: "FK_B_A_2", ReferencedTable: "A", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"PK"}, NotEnforced: true},
+			},
+		},
+	}
+	want := []string{
+		"ALTER TABLE `B` DROP CONSTRAINT `FK_B_A`",
+		"ALTER TABLE `B` ADD CONSTRAINT `FK_B_A` FOREIGN KEY (`A`) REFERENCES `A` (`PK`) ON DELETE CASCADE",
+		"ALTER TABLE `B` ADD CONSTRAINT `FK_B_A_2` FOREIGN KEY (`PK`) REFERENCES `A` (`PK`) NOT ENFORCED",
+	}
+
+	got := diff.MigrationDDL(diff.Compare(before, after))
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,152 @@
+package dump
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/Jumpaku/gotaface/dump"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	gf_spanner "github.com/Jumpaku/gotaface/spanner"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type dumper struct {
+	queryer           gf_spanner.Queryer
+	where             string
+	limit             int64
+	orderByPrimaryKey bool
+}
+
+// NewDumper returns a dumper that dumps all rows of tables in arbitrary order.
+func NewDumper(queryer gf_spanner.Queryer) dumper {
+	return dumper{queryer: queryer}
+}
+
+// WithWhere returns a dumper that dumps only rows satisfying the condition.
+func (d dumper) WithWhere(condition string) dumper {
+	d.where = condition
+	return d
+}
+
+// WithLimit returns a dumper that dumps at most limit rows of each table.
+func (d dumper) WithLimit(limit int64) dumper {
+	d.limit = limit
+	return d
+}
+
+// WithOrderByPrimaryKey returns a dumper that dumps rows in order of the primary key, which makes outputs stable.
+func (d dumper) WithOrderByPrimaryKey() dumper {
+	d.orderByPrimaryKey = true
+	return d
+}
+
+// Dump writes rows of the table, whose values are typed according to the Spanner types of the columns.
+func (d dumper) Dump(ctx context.Context, table schema.SchemaTable, writer dump.Writer) error {
+	columns := lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) string { return column.Name })
+	query := dump.Query{
+		Table:   quoteIdentifier(table.Name),
+		Columns: lo.Map(columns, func(column string, _ int) string { return quoteIdentifier(column) }),
+		Where:   d.where,
+		Limit:   d.limit,
+	}
+	if d.orderByPrimaryKey {
+		query.OrderBy = lo.Map(table.PrimaryKey, func(column string, _ int) string { return quoteIdentifier(column) })
+	}
+
+	if err := writer.WriteHeader(columns); err != nil {
+		return fmt.Errorf(`fail to write header of %s: %w`, table.Name, err)
+	}
+	err := d.queryer.Query(ctx, spanner.Statement{SQL: query.String()}).Do(func(r *spanner.Row) error {
+		values := make([]any, len(table.Columns))
+		for i, column := range table.Columns {
+			var value spanner.GenericColumnValue
+			if err := r.Column(i, &value); err != nil {
+				return fmt.Errorf(`fail to scan column %s: %w`, column.Name, err)
+			}
+			converted, err := ConvertValue(column, value.Value)
+			if err != nil {
+				return fmt.Errorf(`fail to convert value of %s: %w`, column.Name, err)
+			}
+			values[i] = converted
+		}
+		if err := writer.WriteRow(values); err != nil {
+			return fmt.Errorf(`fail to write row: %w`, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(`fail to dump rows of %s: %w`, table.Name, err)
+	}
+	return nil
+}
+
+// ConvertValue converts the value in the column into a value accepted by dump.Writer.
+// INT64 is converted into int64, NUMERIC into json.Number, BYTES into []byte, TIMESTAMP into time.Time, JSON into json.RawMessage, and ARRAY into []any.
+func ConvertValue(column schema.SchemaColumn, value *structpb.Value) (any, error) {
+	return convertValue(schema.ConvertType(column.Type), value)
+}
+
+func convertValue(t gf_schema.Type, value *structpb.Value) (any, error) {
+	if _, isNull := value.GetKind().(*structpb.Value_NullValue); value == nil || isNull {
+		return nil, nil
+	}
+	switch t.Kind {
+	case gf_schema.TypeKindInteger:
+		return strconv.ParseInt(value.GetStringValue(), 10, 64)
+	case gf_schema.TypeKindFloat:
+		// NaN and infinities are encoded as strings.
+		switch value.GetStringValue() {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+		return value.GetNumberValue(), nil
+	case gf_schema.TypeKindDecimal:
+		return json.Number(value.GetStringValue()), nil
+	case gf_schema.TypeKindString, gf_schema.TypeKindDate:
+		return value.GetStringValue(), nil
+	case gf_schema.TypeKindBytes:
+		return base64.StdEncoding.DecodeString(value.GetStringValue())
+	case gf_schema.TypeKindBool:
+		return value.GetBoolValue(), nil
+	case gf_schema.TypeKindTimestamp:
+		return time.Parse(time.RFC3339Nano, value.GetStringValue())
+	case gf_schema.TypeKindJSON:
+		return json.RawMessage(value.GetStringValue()), nil
+	case gf_schema.TypeKindArray:
+		elemType := gf_schema.Type{Kind: gf_schema.TypeKindOther}
+		if t.Elem != nil {
+			elemType = *t.Elem
+		}
+		values := []any{}
+		for _, elem := range value.GetListValue().GetValues() {
+			converted, err := convertValue(elemType, elem)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, converted)
+		}
+		return values, nil
+	default:
+		b, err := value.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(b), nil
+	}
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + identifier + "`"
+}
@@ -0,0 +1,47 @@
+package dump_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Jumpaku/gotaface/spanner/dump"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		columnType string
+		in         *structpb.Value
+		want       any
+	}{
+		{columnType: "INT64", in: structpb.NewStringValue("9007199254740993"), want: int64(9007199254740993)},
+		{columnType: "FLOAT64", in: structpb.NewNumberValue(0.5), want: 0.5},
+		{columnType: "FLOAT64", in: structpb.NewStringValue("-Infinity"), want: math.Inf(-1)},
+		{columnType: "NUMERIC", in: structpb.NewStringValue("12.5"), want: json.Number("12.5")},
+		{columnType: "STRING(MAX)", in: structpb.NewStringValue("abc"), want: "abc"},
+		{columnType: "BYTES(10)", in: structpb.NewStringValue("AQI="), want: []byte{1, 2}},
+		{columnType: "BOOL", in: structpb.NewBoolValue(true), want: true},
+		{columnType: "DATE", in: structpb.NewStringValue("2024-01-02"), want: "2024-01-02"},
+		{columnType: "TIMESTAMP", in: structpb.NewStringValue("2024-01-02T03:04:05.6Z"), want: time.Date(2024, 1, 2, 3, 4, 5, 600000000, time.UTC)},
+		{columnType: "JSON", in: structpb.NewStringValue(`{"k":1}`), want: json.RawMessage(`{"k":1}`)},
+		{
+			columnType: "ARRAY<NUMERIC>",
+			in:         structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("1.5"), structpb.NewNullValue()}}),
+			want:       []any{json.Number("1.5"), nil},
+		},
+		{columnType: "INT64", in: structpb.NewNullValue(), want: nil},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.columnType), func(t *testing.T) {
+			got, err := dump.ConvertValue(schema.SchemaColumn{Name: "C", Type: testcase.columnType}, testcase.in)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/fixture"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/Jumpaku/gotaface/spanner/dependency"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/samber/lo"
+)
+
+// Applier applies mutations atomically, which is implemented by *spanner.Client.
+type Applier interface {
+	Apply(ctx context.Context, ms []*spanner.Mutation, opts ...spanner.ApplyOption) (commitTimestamp time.Time, err error)
+}
+
+type loader struct {
+	applier Applier
+}
+
+// NewLoader returns a loader that inserts rows of fixtures by mutations in a transaction.
+func NewLoader(applier Applier) loader {
+	return loader{applier: applier}
+}
+
+// Load inserts rows of the fixture into the tables in insert order resolved from their foreign keys and interleaving, in which all the rows are inserted or none of them are.
+// Constraints are checked by Spanner at commit, whose violations are reported with the keys of the rows instead of their positions in the fixture.
+// The tables must include all the tables in the fixture.
+func (l loader) Load(ctx context.Context, tables []schema.SchemaTable, f fixture.Fixture) error {
+	tableMap := lo.SliceToMap(tables, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+	targets := []schema.SchemaTable{}
+	for _, name := range f.Tables() {
+		table, found := tableMap[name]
+		if !found {
+			return fmt.Errorf(`fail to load fixture: table %q is not found`, name)
+		}
+		targets = append(targets, table)
+	}
+	order, err := gf_dependency.Resolve(dependency.NewGraph(targets))
+	if err != nil {
+		return fmt.Errorf(`fail to resolve insert order: %w`, err)
+	}
+
+	mutations := []*spanner.Mutation{}
+	for _, name := range order.InsertOrder {
+		table := tableMap[name]
+		for index, row := range f[name] {
+			mutation, err := insertMutation(table, row)
+			if err != nil {
+				return &fixture.RowError{Table: name, Index: index, Err: err}
+			}
+			mutations = append(mutations, mutation)
+		}
+	}
+
+	if _, err := l.applier.Apply(ctx, mutations); err != nil {
+		return fmt.Errorf(`fail to apply fixture: %w`, err)
+	}
+	return nil
+}
+
+func insertMutation(table schema.SchemaTable, row fixture.Row) (*spanner.Mutation, error) {
+	columns, err := fixture.Columns(lo.Map(table.Columns, func(column schema.SchemaColumn, _ int) string { return column.Name }), row)
+	if err != nil {
+		return nil, err
+	}
+	values := []any{}
+	for _, column := range table.Columns {
+		if !lo.Contains(columns, column.Name) {
+			continue
+		}
+		value, err := ConvertValue(column, row[column.Name])
+		if err != nil {
+			return nil, fmt.Errorf(`fail to convert value of %s: %w`, column.Name, err)
+		}
+		values = append(values, value)
+	}
+	return spanner.Insert(table.Name, columns, values), nil
+}
+
+// ConvertValue converts the value parsed from a fixture into a value to be inserted into the column by a mutation.
+// NUMERIC is converted into *big.Rat, DATE into civil.Date, JSON into spanner.NullJSON, and ARRAY into a slice of the nullable type of the element type.
+func ConvertValue(column schema.SchemaColumn, value any) (any, error) {
+	return convertValue(schema.ConvertType(column.Type), value)
+}
+
+func convertValue(t gf_schema.Type, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch t.Kind {
+	case gf_schema.TypeKindDecimal:
+		converted, err := fixture.ConvertValue(t, value)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := new(big.Rat).SetString(converted.(string))
+		if !ok {
+			return nil, fmt.Errorf(`fail to convert %v into NUMERIC`, value)
+		}
+		return r, nil
+	case gf_schema.TypeKindDate:
+		converted, err := fixture.ConvertValue(t, value)
+		if err != nil {
+			return nil, err
+		}
+		return civil.ParseDate(converted.(string))
+	case gf_schema.TypeKindJSON:
+		converted, err := fixture.ConvertValue(t, value)
+		if err != nil {
+			return nil, err
+		}
+		return spanner.NullJSON{Value: json.RawMessage(converted.(string)), Valid: true}, nil
+	case gf_schema.TypeKindArray:
+		elems, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf(`fail to convert %v of %T into %s`, value, value, t)
+		}
+		elemType := gf_schema.Type{Kind: gf_schema.TypeKindOther}
+		if t.Elem != nil {
+			elemType = *t.Elem
+		}
+		values := []any{}
+		for _, elem := range elems {
+			converted, err := convertValue(elemType, elem)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, converted)
+		}
+		return convertArray(elemType, values), nil
+	default:
+		return fixture.ConvertValue(t, value)
+	}
+}
+
+// convertArray converts the converted elements into a slice of the nullable type, which is required by mutations.
+func convertArray(elemType gf_schema.Type, values []any) any {
+	switch elemType.Kind {
+	case gf_schema.TypeKindInteger:
+		return lo.Map(values, func(v any, _ int) spanner.NullInt64 {
+			i, ok := v.(int64)
+			return spanner.NullInt64{Int64: i, Valid: ok}
+		})
+	case gf_schema.TypeKindFloat:
+		return lo.Map(values, func(v any, _ int) spanner.NullFloat64 {
+			f, ok := v.(float64)
+			return spanner.NullFloat64{Float64: f, Valid: ok}
+		})
+	case gf_schema.TypeKindDecimal:
+		return lo.Map(values, func(v any, _ int) spanner.NullNumeric {
+			r, ok := v.(*big.Rat)
+			if !ok {
+				return spanner.NullNumeric{}
+			}
+			return spanner.NullNumeric{Numeric: *r, Valid: true}
+		})
+	case gf_schema.TypeKindBytes:
+		return lo.Map(values, func(v any, _ int) []byte {
+			b, _ := v.([]byte)
+			return b
+		})
+	case gf_schema.TypeKindBool:
+		return lo.Map(values, func(v any, _ int) spanner.NullBool {
+			b, ok := v.(bool)
+			return spanner.NullBool{Bool: b, Valid: ok}
+		})
+	case gf_schema.TypeKindDate:
+		return lo.Map(values, func(v any, _ int) spanner.NullDate {
+			d, ok := v.(civil.Date)
+			return spanner.NullDate{Date: d, Valid: ok}
+		})
+	case gf_schema.TypeKindTimestamp:
+		return lo.Map(values, func(v any, _ int) spanner.NullTime {
+			t, ok := v.(time.Time)
+			return spanner.NullTime{Time: t, Valid: ok}
+		})
+	case gf_schema.TypeKindJSON:
+		return lo.Map(values, func(v any, _ int) spanner.NullJSON {
+			j, _ := v.(spanner.NullJSON)
+			return j
+		})
+	default:
+		return lo.Map(values, func(v any, _ int) spanner.NullString {
+			s, ok := v.(string)
+			return spanner.NullString{StringVal: s, Valid: ok}
+		})
+	}
+}
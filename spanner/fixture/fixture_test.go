@@ -0,0 +1,63 @@
+package fixture_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/Jumpaku/gotaface/spanner/fixture"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		columnType string
+		in         any
+		want       any
+	}{
+		{columnType: "INT64", in: json.Number("1"), want: int64(1)},
+		{columnType: "FLOAT64", in: json.Number("0.5"), want: float64(0.5)},
+		{columnType: "NUMERIC", in: json.Number("12.50"), want: big.NewRat(25, 2)},
+		{columnType: "STRING(MAX)", in: "abc", want: "abc"},
+		{columnType: "BYTES(16)", in: "AQI=", want: []byte{1, 2}},
+		{columnType: "BOOL", in: true, want: true},
+		{columnType: "DATE", in: "2024-01-02", want: civil.Date{Year: 2024, Month: 1, Day: 2}},
+		{columnType: "TIMESTAMP", in: "2024-01-02T03:04:05Z", want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{columnType: "JSON", in: map[string]any{"k": "v"}, want: spanner.NullJSON{Value: json.RawMessage(`{"k":"v"}`), Valid: true}},
+		{columnType: "ARRAY<INT64>", in: []any{json.Number("1"), nil}, want: []spanner.NullInt64{{Int64: 1, Valid: true}, {}}},
+		{columnType: "ARRAY<STRING(MAX)>", in: []any{"x", nil}, want: []spanner.NullString{{StringVal: "x", Valid: true}, {}}},
+		{columnType: "ARRAY<NUMERIC>", in: []any{"1.5"}, want: []spanner.NullNumeric{{Numeric: *big.NewRat(3, 2), Valid: true}}},
+		{columnType: "STRING(MAX)", in: nil, want: nil},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.columnType), func(t *testing.T) {
+			got, err := fixture.ConvertValue(schema.SchemaColumn{Name: "C", Type: testcase.columnType}, testcase.in)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestConvertValue_Error(t *testing.T) {
+	testcases := []struct {
+		columnType string
+		in         any
+	}{
+		{columnType: "NUMERIC", in: "abc"},
+		{columnType: "DATE", in: "2024/01/02"},
+		{columnType: "ARRAY<INT64>", in: "1"},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.columnType), func(t *testing.T) {
+			_, err := fixture.ConvertValue(schema.SchemaColumn{Name: "C", Type: testcase.columnType}, testcase.in)
+			assert.NotNil(t, err)
+		})
+	}
+}
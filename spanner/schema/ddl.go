@@ -0,0 +1,293 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// GenerateDDL returns statements to create the given tables.
+// Named schemas of the tables and sequences used by the tables are created by CREATE SCHEMA and CREATE SEQUENCE statements preceding all CREATE TABLE statements.
+// CREATE TABLE statements are ordered so that each interleaved table follows its parent.
+// Unique keys, indexes, search indexes, and foreign keys are created by CREATE INDEX, CREATE SEARCH INDEX, and ALTER TABLE statements following all CREATE TABLE statements.
+// Unique keys having the same names as indexes are created as the indexes.
+// Change streams watching the tables are created by CREATE CHANGE STREAM statements at the end.
+func GenerateDDL(tables []SchemaTable) []string {
+	tables = orderByInterleave(tables)
+
+	stmts := []string{}
+	schemas := lo.Uniq(lo.FilterMap(tables, func(table SchemaTable, _ int) (string, bool) { return table.Schema, table.Schema != "" }))
+	slices.Sort(schemas)
+	for _, schemaName := range schemas {
+		stmts = append(stmts, "CREATE SCHEMA "+quoteIdentifier(schemaName))
+	}
+	sequences := lo.UniqBy(lo.FlatMap(tables, func(table SchemaTable, _ int) []SchemaSequence { return table.Sequences }), func(sequence SchemaSequence) string { return sequence.Name })
+	slices.SortStableFunc(sequences, func(a, b SchemaSequence) int { return strings.Compare(a.Name, b.Name) })
+	for _, sequence := range sequences {
+		stmts = append(stmts, CreateSequenceDDL(sequence))
+	}
+	for _, table := range tables {
+		stmts = append(stmts, CreateTableDDL(table))
+	}
+	for _, table := range tables {
+		for _, uniqueKey := range table.UniqueKeys {
+			if !HasIndex(table, uniqueKey.Name) {
+				stmts = append(stmts, CreateUniqueIndexDDL(table.QualifiedName(), uniqueKey))
+			}
+		}
+		for _, index := range table.Indexes {
+			stmts = append(stmts, CreateIndexDDL(table.QualifiedName(), index))
+		}
+		for _, searchIndex := range table.SearchIndexes {
+			stmts = append(stmts, CreateSearchIndexDDL(table.QualifiedName(), searchIndex))
+		}
+	}
+	for _, table := range tables {
+		for _, foreignKey := range table.ForeignKeys {
+			stmts = append(stmts, AddForeignKeyDDL(table.QualifiedName(), foreignKey))
+		}
+	}
+	stmts = append(stmts, createChangeStreamDDLs(tables)...)
+	return stmts
+}
+
+// CreateTableDDL returns a CREATE TABLE statement with columns, CHECK constraints, primary key, interleaving, and row deletion policy of the table.
+func CreateTableDDL(table SchemaTable) string {
+	definitions := []string{}
+	for _, column := range table.Columns {
+		definitions = append(definitions, ColumnDefinition(column))
+	}
+	for _, check := range table.Checks {
+		definitions = append(definitions, CheckDefinition(check))
+	}
+
+	stmt := fmt.Sprintf("CREATE TABLE %s (\n    %s\n) PRIMARY KEY (%s)",
+		quoteQualifiedName(table.QualifiedName()),
+		strings.Join(definitions, ",\n    "),
+		quoteIdentifiers(table.PrimaryKey),
+	)
+	if table.Parent != "" {
+		stmt += fmt.Sprintf(",\n    INTERLEAVE IN PARENT %s", quoteQualifiedName(qualifiedName(table.Schema, table.Parent)))
+		if table.ParentOnDelete != "" {
+			stmt += " ON DELETE " + table.ParentOnDelete
+		}
+	}
+	if policy := table.RowDeletionPolicy; policy != nil {
+		stmt += fmt.Sprintf(",\n    ROW DELETION POLICY (OLDER_THAN(%s, INTERVAL %d DAY))", quoteIdentifier(policy.Column), policy.Days)
+	}
+	return stmt
+}
+
+// ColumnDefinition returns the definition of the column with its type, nullability, default value, and generation expression.
+func ColumnDefinition(column SchemaColumn) string {
+	definition := quoteIdentifier(column.Name) + " " + column.Type
+	if !column.Nullable {
+		definition += " NOT NULL"
+	}
+	if column.Default != "" {
+		definition += fmt.Sprintf(" DEFAULT (%s)", column.Default)
+	}
+	if column.Generated != "" {
+		definition += fmt.Sprintf(" AS (%s)", column.Generated)
+		if column.Stored {
+			definition += " STORED"
+		}
+	}
+	return definition
+}
+
+// CheckDefinition returns a CHECK constraint definition used in CREATE TABLE and ALTER TABLE ADD statements.
+func CheckDefinition(check SchemaCheck) string {
+	definition := fmt.Sprintf(`CHECK (%s)`, check.Expression)
+	if check.Name != "" {
+		definition = fmt.Sprintf(`CONSTRAINT %s %s`, quoteIdentifier(check.Name), definition)
+	}
+	return definition
+}
+
+// CreateUniqueIndexDDL returns a CREATE UNIQUE INDEX statement for the unique key of the table.
+func CreateUniqueIndexDDL(table string, uniqueKey SchemaUniqueKey) string {
+	return fmt.Sprintf(`CREATE UNIQUE INDEX %s ON %s (%s)`,
+		quoteIdentifier(uniqueKey.Name),
+		quoteQualifiedName(table),
+		quoteIdentifiers(uniqueKey.Key),
+	)
+}
+
+// CreateIndexDDL returns a CREATE INDEX statement for the index of the table.
+func CreateIndexDDL(table string, index SchemaIndex) string {
+	stmt := fmt.Sprintf(`CREATE %s%sINDEX %s ON %s (%s)`,
+		lo.Ternary(index.Unique, "UNIQUE ", ""),
+		lo.Ternary(index.NullFiltered, "NULL_FILTERED ", ""),
+		quoteIdentifier(index.Name),
+		quoteQualifiedName(table),
+		quoteIndexKey(index.Key),
+	)
+	if len(index.Storing) > 0 {
+		stmt += fmt.Sprintf(` STORING (%s)`, quoteIdentifiers(index.Storing))
+	}
+	if index.Interleave != "" {
+		stmt += ", INTERLEAVE IN " + quoteQualifiedName(qualifiedName(tableSchema(table), index.Interleave))
+	}
+	return stmt
+}
+
+// CreateSearchIndexDDL returns a CREATE SEARCH INDEX statement for the search index of the table.
+func CreateSearchIndexDDL(table string, searchIndex SchemaSearchIndex) string {
+	stmt := fmt.Sprintf(`CREATE SEARCH INDEX %s ON %s (%s)`,
+		quoteIdentifier(searchIndex.Name),
+		quoteQualifiedName(table),
+		quoteIdentifiers(searchIndex.Key),
+	)
+	if len(searchIndex.Storing) > 0 {
+		stmt += fmt.Sprintf(` STORING (%s)`, quoteIdentifiers(searchIndex.Storing))
+	}
+	if searchIndex.Interleave != "" {
+		stmt += ", INTERLEAVE IN " + quoteQualifiedName(qualifiedName(tableSchema(table), searchIndex.Interleave))
+	}
+	return stmt
+}
+
+// CreateSequenceDDL returns a CREATE SEQUENCE statement with the options of the sequence.
+func CreateSequenceDDL(sequence SchemaSequence) string {
+	options := []string{}
+	if sequence.Kind != "" {
+		options = append(options, fmt.Sprintf(`sequence_kind = '%s'`, sequence.Kind))
+	}
+	if sequence.SkipRangeMin != nil {
+		options = append(options, fmt.Sprintf(`skip_range_min = %d`, *sequence.SkipRangeMin))
+	}
+	if sequence.SkipRangeMax != nil {
+		options = append(options, fmt.Sprintf(`skip_range_max = %d`, *sequence.SkipRangeMax))
+	}
+	if sequence.StartWithCounter != nil {
+		options = append(options, fmt.Sprintf(`start_with_counter = %d`, *sequence.StartWithCounter))
+	}
+	stmt := "CREATE SEQUENCE " + quoteQualifiedName(sequence.Name)
+	if len(options) > 0 {
+		stmt += fmt.Sprintf(` OPTIONS (%s)`, strings.Join(options, ", "))
+	}
+	return stmt
+}
+
+// createChangeStreamDDLs returns CREATE CHANGE STREAM statements for the change streams watching the tables, which are sorted by name.
+// A change stream watching some of the tables is created only for the given tables.
+func createChangeStreamDDLs(tables []SchemaTable) []string {
+	type watched struct {
+		table   string
+		columns []string
+	}
+	all := map[string]bool{}
+	targets := map[string][]watched{}
+	for _, table := range tables {
+		for _, changeStream := range table.ChangeStreams {
+			if changeStream.All {
+				all[changeStream.Name] = true
+				continue
+			}
+			targets[changeStream.Name] = append(targets[changeStream.Name], watched{table: table.QualifiedName(), columns: changeStream.Columns})
+		}
+	}
+
+	names := lo.Uniq(append(lo.Keys(all), lo.Keys(targets)...))
+	slices.Sort(names)
+	stmts := []string{}
+	for _, name := range names {
+		if all[name] {
+			stmts = append(stmts, fmt.Sprintf(`CREATE CHANGE STREAM %s FOR ALL`, quoteIdentifier(name)))
+			continue
+		}
+		stmts = append(stmts, fmt.Sprintf(`CREATE CHANGE STREAM %s FOR %s`, quoteIdentifier(name), strings.Join(lo.Map(targets[name], func(it watched, _ int) string {
+			if it.columns == nil {
+				return quoteQualifiedName(it.table)
+			}
+			return fmt.Sprintf(`%s(%s)`, quoteQualifiedName(it.table), quoteIdentifiers(it.columns))
+		}), ", ")))
+	}
+	return stmts
+}
+
+// HasIndex reports whether the table has the index of the name.
+func HasIndex(table SchemaTable, name string) bool {
+	return lo.ContainsBy(table.Indexes, func(index SchemaIndex) bool { return index.Name == name })
+}
+
+// AddForeignKeyDDL returns an ALTER TABLE statement adding the foreign key to the table.
+func AddForeignKeyDDL(table string, foreignKey SchemaForeignKey) string {
+	constraint := ""
+	if foreignKey.Name != "" {
+		constraint = "CONSTRAINT " + quoteIdentifier(foreignKey.Name) + " "
+	}
+	stmt := fmt.Sprintf(`ALTER TABLE %s ADD %sFOREIGN KEY (%s) REFERENCES %s (%s)`,
+		quoteQualifiedName(table),
+		constraint,
+		quoteIdentifiers(foreignKey.ReferencingKey),
+		quoteQualifiedName(foreignKey.ReferencedTable),
+		quoteIdentifiers(foreignKey.ReferencedKey),
+	)
+	if foreignKey.OnDelete != "" {
+		stmt += " ON DELETE " + foreignKey.OnDelete
+	}
+	if foreignKey.NotEnforced {
+		stmt += " NOT ENFORCED"
+	}
+	return stmt
+}
+
+func orderByInterleave(tables []SchemaTable) []SchemaTable {
+	names := lo.SliceToMap(tables, func(table SchemaTable) (string, bool) { return table.QualifiedName(), true })
+	created := map[string]bool{}
+	ordered := []SchemaTable{}
+	remaining := tables
+	for len(remaining) > 0 {
+		rest := []SchemaTable{}
+		for _, table := range remaining {
+			parent := qualifiedName(table.Schema, table.Parent)
+			if table.Parent == "" || !names[parent] || created[parent] {
+				ordered = append(ordered, table)
+			} else {
+				rest = append(rest, table)
+			}
+		}
+		for _, table := range ordered {
+			created[table.QualifiedName()] = true
+		}
+		if len(rest) == len(remaining) {
+			// interleaving must not be cyclic, but the rest are appended as they are to avoid an infinite loop.
+			return append(ordered, rest...)
+		}
+		remaining = rest
+	}
+	return ordered
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + identifier + "`"
+}
+
+// quoteQualifiedName quotes each part of the name qualified by a named schema.
+func quoteQualifiedName(name string) string {
+	schemaName, unqualified := splitQualifiedName(name)
+	if schemaName == "" {
+		return quoteIdentifier(unqualified)
+	}
+	return quoteIdentifier(schemaName) + "." + quoteIdentifier(unqualified)
+}
+
+// tableSchema returns the named schema of the table name, which is empty for the default schema.
+func tableSchema(table string) string {
+	schemaName, _ := splitQualifiedName(table)
+	return schemaName
+}
+
+func quoteIndexKey(key []SchemaIndexKey) string {
+	return strings.Join(lo.Map(key, func(column SchemaIndexKey, _ int) string {
+		return quoteIdentifier(column.Name) + lo.Ternary(column.Desc, " DESC", "")
+	}), ", ")
+}
+
+func quoteIdentifiers(identifiers []string) string {
+	return strings.Join(lo.Map(identifiers, func(identifier string, _ int) string { return quoteIdentifier(identifier) }), ", ")
+}
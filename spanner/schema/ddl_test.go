@@ -0,0 +1,91 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/Jumpaku/gotaface/spanner/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDDL(t *testing.T) {
+	testcases := []struct {
+		ddl    string
+		tables []string
+	}{
+		{ddl: "ddl_00_all_types", tables: []string{"A"}},
+		{ddl: "ddl_01_interleave", tables: []string{"B_4", "B_3", "B_2", "B_1"}},
+		{ddl: "ddl_02_foreign_keys", tables: []string{"C_1", "C_2", "C_3", "C_4", "C_5"}},
+		{ddl: "ddl_03_foreign_loop_1", tables: []string{"D_1"}},
+		{ddl: "ddl_04_foreign_loop_2", tables: []string{"E_1", "E_2"}},
+		{ddl: "ddl_05_foreign_loop_3", tables: []string{"F_1", "F_2", "F_3"}},
+		{ddl: "ddl_06_unique_keys", tables: []string{"G"}},
+		{ddl: "ddl_10_indexes", tables: []string{"L_1", "L_2"}},
+		{ddl: "ddl_11_column_defaults", tables: []string{"M"}},
+		{ddl: "ddl_12_checks", tables: []string{"N"}},
+		{ddl: "ddl_13_foreign_key_actions", tables: []string{"O_1", "O_2"}},
+		{ddl: "ddl_15_spanner_features", tables: []string{"Q_1", "Q_2", "S.Q_3"}},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			ctx := context.Background()
+
+			admin, client, teardown := test.Setup(t, fmt.Sprintf("ddl_%0d", number))
+			defer teardown()
+			test.InitDDLs(t, admin, client.DatabaseName(), ddls[testcase.ddl])
+			want := []schema.SchemaTable{}
+			for _, table := range testcase.tables {
+				got, err := schema.NewFetcher(client.ReadOnlyTransaction()).Fetch(ctx, table)
+				assert.Nil(t, err)
+				want = append(want, got)
+			}
+
+			generatedAdmin, generatedClient, generatedTeardown := test.Setup(t, fmt.Sprintf("ddl_%0d_generated", number))
+			defer generatedTeardown()
+			test.InitDDLs(t, generatedAdmin, generatedClient.DatabaseName(), schema.GenerateDDL(want))
+			for _, want := range want {
+				got, err := schema.NewFetcher(generatedClient.ReadOnlyTransaction()).Fetch(ctx, want.QualifiedName())
+				assert.Nil(t, err)
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateDDL_DDLFetcher(t *testing.T) {
+	testcases := []struct {
+		ddl    string
+		tables []string
+	}{
+		{ddl: "ddl_01_interleave", tables: []string{"B_4", "B_3", "B_2", "B_1"}},
+		{ddl: "ddl_10_indexes", tables: []string{"L_1", "L_2"}},
+		{ddl: "ddl_15_spanner_features", tables: []string{"Q_2", "Q_1", "S.Q_3"}},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			ctx := context.Background()
+
+			fetcher, err := schema.NewDDLFetcher(strings.Join(ddls[testcase.ddl], ";\n"))
+			assert.Nil(t, err)
+			want := []schema.SchemaTable{}
+			for _, table := range testcase.tables {
+				got, err := fetcher.Fetch(ctx, table)
+				assert.Nil(t, err)
+				want = append(want, got)
+			}
+
+			generated, err := schema.NewDDLFetcher(strings.Join(schema.GenerateDDL(want), ";\n"))
+			assert.Nil(t, err)
+			for _, want := range want {
+				got, err := generated.Fetch(ctx, want.QualifiedName())
+				assert.Nil(t, err)
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
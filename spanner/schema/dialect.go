@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	spanner_adminpb "cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	gf_spanner "github.com/Jumpaku/gotaface/spanner"
+)
+
+// dialectQueryer is a queryer of a database in GoogleSQL or PostgreSQL dialect.
+type dialectQueryer struct {
+	gf_spanner.Queryer
+	postgreSQL bool
+}
+
+// param is a parameter of a statement, which is referred to by name in GoogleSQL and by position in PostgreSQL.
+type param struct {
+	name  string
+	value any
+}
+
+// statement returns the statement written in the dialect of the database.
+// The parameters are referred to as @name in GoogleSQL and as $1, $2, ... in the given order in PostgreSQL.
+func (tx dialectQueryer) statement(googleSQL string, postgreSQL string, params ...param) spanner.Statement {
+	if !tx.postgreSQL {
+		stmt := spanner.Statement{SQL: googleSQL, Params: map[string]interface{}{}}
+		for _, p := range params {
+			stmt.Params[p.name] = p.value
+		}
+		return stmt
+	}
+	stmt := spanner.Statement{SQL: postgreSQL, Params: map[string]interface{}{}}
+	for i, p := range params {
+		stmt.Params[fmt.Sprintf("p%d", i+1)] = p.value
+	}
+	return stmt
+}
+
+// schemaParam returns the schema name as stored in INFORMATION_SCHEMA, in which the default schema is named public in PostgreSQL.
+func (tx dialectQueryer) schemaParam(schemaName string) string {
+	if tx.postgreSQL && schemaName == "" {
+		return "public"
+	}
+	return schemaName
+}
+
+// dialectQueryer returns the queryer with the dialect configured by WithDialect or detected by querying the database.
+func (fetcher fetcher) dialectQueryer(ctx context.Context) (dialectQueryer, error) {
+	dialect := fetcher.dialect
+	if dialect == spanner_adminpb.DatabaseDialect_DATABASE_DIALECT_UNSPECIFIED {
+		var err error
+		if dialect, err = gf_spanner.QueryDialect(ctx, fetcher.queryer); err != nil {
+			return dialectQueryer{}, err
+		}
+	}
+	return dialectQueryer{Queryer: fetcher.queryer, postgreSQL: dialect == spanner_adminpb.DatabaseDialect_POSTGRESQL}, nil
+}
@@ -3,8 +3,9 @@ package schema
 import (
 	"context"
 	"fmt"
+	"slices"
 
-	"cloud.google.com/go/spanner"
+	spanner_adminpb "cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
 	"github.com/Jumpaku/go-assert"
 	"github.com/Jumpaku/gotaface/schema"
 	gf_spanner "github.com/Jumpaku/gotaface/spanner"
@@ -15,35 +16,111 @@ type SchemaColumn struct {
 	Name     string `json:"name"`
 	Type     string `json:"type"`
 	Nullable bool   `json:"nullable"`
+	// Default is the expression of the default value without the enclosing parentheses, which is empty if the column has no default value.
+	Default string `json:"default"`
+	// Generated is the generation expression without the enclosing parentheses, which is empty if the column is not a generated column.
+	Generated string `json:"generated"`
+	// Stored is true if the column is a stored generated column.
+	Stored bool `json:"stored"`
 }
 type SchemaForeignKey struct {
 	Name            string   `json:"name"`
 	ReferencedTable string   `json:"referenced_table"`
 	ReferencedKey   []string `json:"referenced_key"`
 	ReferencingKey  []string `json:"referencing_key"`
+	// OnDelete is CASCADE or empty for NO ACTION.
+	OnDelete string `json:"on_delete"`
+	// NotEnforced reports whether the foreign key is an informational foreign key declared with NOT ENFORCED.
+	NotEnforced bool `json:"not_enforced"`
+}
+
+// SchemaReference is a foreign key of a table referencing the table, or an interleaved child table of the table.
+type SchemaReference struct {
+	// Name is the name of the foreign key, which is empty for an interleaved child table.
+	Name             string   `json:"name"`
+	ReferencingTable string   `json:"referencing_table"`
+	ReferencingKey   []string `json:"referencing_key"`
+	ReferencedKey    []string `json:"referenced_key"`
+	// Interleaved reports whether the referencing table is interleaved in the table, whose keys are the primary key of the table.
+	Interleaved bool `json:"interleaved"`
 }
 type SchemaUniqueKey struct {
 	Name string   `json:"name"`
 	Key  []string `json:"key"`
 }
+type SchemaIndexKey struct {
+	Name string `json:"name"`
+	Desc bool   `json:"desc"`
+}
+type SchemaIndex struct {
+	Name         string           `json:"name"`
+	Unique       bool             `json:"unique"`
+	Key          []SchemaIndexKey `json:"key"`
+	NullFiltered bool             `json:"null_filtered"`
+	// Storing is the columns in the STORING clause sorted by name.
+	Storing []string `json:"storing"`
+	// Interleave is the table in which the index is interleaved, which is empty if the index is not interleaved.
+	Interleave string `json:"interleave"`
+}
+type SchemaCheck struct {
+	// Name is the name of the CHECK constraint, which is generated by Spanner if the constraint is not named.
+	Name string `json:"name"`
+	// Expression is the condition of the CHECK constraint without the enclosing parentheses.
+	Expression string `json:"expression"`
+}
 type SchemaTable struct {
-	Name        string             `json:"name"`
-	Columns     []SchemaColumn     `json:"columns"`
-	PrimaryKey  []string           `json:"primary_key"`
-	Parent      string             `json:"parent"`
+	Name string `json:"name"`
+	// Schema is the named schema of the table, which is empty for the default schema.
+	Schema     string         `json:"schema"`
+	Columns    []SchemaColumn `json:"columns"`
+	PrimaryKey []string       `json:"primary_key"`
+	// Parent is the table in which the table is interleaved, which belongs to the same schema as the table.
+	Parent string `json:"parent"`
+	// ParentOnDelete is CASCADE or empty for NO ACTION, which is the action on the rows of the table when the parent rows are deleted.
+	ParentOnDelete string `json:"parent_on_delete"`
+	// RowDeletionPolicy is the TTL policy of the table, which is nil if the table has no row deletion policy.
+	RowDeletionPolicy *SchemaRowDeletionPolicy `json:"row_deletion_policy"`
+	// ForeignKeys reference tables whose names are qualified by the schemas if they belong to named schemas.
 	ForeignKeys []SchemaForeignKey `json:"foreign_key"`
 	UniqueKeys  []SchemaUniqueKey  `json:"unique_key"`
+	// Indexes are the indexes other than those managed by Spanner, which include the unique indexes also fetched as unique keys.
+	Indexes []SchemaIndex `json:"index"`
+	// SearchIndexes are the search indexes on TOKENLIST columns of the table.
+	SearchIndexes []SchemaSearchIndex `json:"search_index"`
+	Checks        []SchemaCheck       `json:"check"`
+	// ChangeStreams are the change streams watching the table sorted by name.
+	ChangeStreams []SchemaChangeStream `json:"change_stream"`
+	// Sequences are the sequences used by the default values of the columns sorted by name.
+	Sequences []SchemaSequence `json:"sequence"`
+	// ReferencedBy are the foreign keys referencing the table and the interleaved child tables, which are fetched only by fetchers configured with WithReferencedBy.
+	ReferencedBy []SchemaReference `json:"referenced_by"`
 }
 
 type fetcher struct {
-	queryer gf_spanner.Queryer
+	queryer      gf_spanner.Queryer
+	dialect      spanner_adminpb.DatabaseDialect
+	referencedBy bool
 }
 
 func NewFetcher(queryer gf_spanner.Queryer) fetcher {
 	return fetcher{queryer: queryer}
 }
 
+// WithReferencedBy returns a copy of the fetcher that also fetches foreign keys referencing the table and interleaved child tables into ReferencedBy.
+func (fetcher fetcher) WithReferencedBy() fetcher {
+	fetcher.referencedBy = true
+	return fetcher
+}
+
+// WithDialect returns a copy of the fetcher that assumes the dialect of the database instead of detecting it.
+// Types of columns in a PostgreSQL-dialect database are PostgreSQL type names such as character varying(50).
+func (fetcher fetcher) WithDialect(dialect spanner_adminpb.DatabaseDialect) fetcher {
+	fetcher.dialect = dialect
+	return fetcher
+}
+
 var _ schema.Fetcher[SchemaTable] = fetcher{}
+var _ schema.Lister = fetcher{}
 
 func (fetcher fetcher) Fetch(ctx context.Context, table string) (SchemaTable, error) {
 	wrapError := func(err error) (SchemaTable, error) {
@@ -51,159 +128,547 @@ func (fetcher fetcher) Fetch(ctx context.Context, table string) (SchemaTable, er
 		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: %w`, table, err)
 	}
 
-	schemaTable, err := getTable(ctx, fetcher.queryer, table)
+	tx, err := fetcher.dialectQueryer(ctx)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaName, tableName := splitQualifiedName(table)
+	schemaTable, err := getTable(ctx, tx, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
-	schemaTable.Columns, err = queryColumns(ctx, fetcher.queryer, table)
+	schemaTable.Columns, err = queryColumns(ctx, tx, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
-	schemaTable.PrimaryKey, err = queryPrimaryKey(ctx, fetcher.queryer, table)
+	schemaTable.PrimaryKey, err = queryPrimaryKey(ctx, tx, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
-	schemaTable.ForeignKeys, err = queryForeignKeys(ctx, fetcher.queryer, table)
+	schemaTable.ForeignKeys, err = queryForeignKeys(ctx, tx, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
-	schemaTable.UniqueKeys, err = queryUniqueKeys(ctx, fetcher.queryer, table)
+	schemaTable.UniqueKeys, err = queryUniqueKeys(ctx, tx, schemaName, tableName)
 	if err != nil {
 		return wrapError(err)
 	}
 
+	schemaTable.Indexes, err = queryIndexes(ctx, tx, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.SearchIndexes, err = querySearchIndexes(ctx, tx, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.Checks, err = queryChecks(ctx, tx, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.ChangeStreams, err = queryChangeStreams(ctx, tx, schemaName, tableName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	sequences, err := sequenceReferences(schemaTable.Columns)
+	if err != nil {
+		return wrapError(err)
+	}
+	schemaTable.Sequences, err = querySequences(ctx, tx, sequences)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if fetcher.referencedBy {
+		schemaTable.ReferencedBy, err = queryReferencedBy(ctx, tx, schemaName, tableName, schemaTable.PrimaryKey)
+		if err != nil {
+			return wrapError(err)
+		}
+	}
+
 	return schemaTable, nil
 }
 
-func getTable(ctx context.Context, tx gf_spanner.Queryer, table string) (SchemaTable, error) {
+// ListTables returns names of tables in the default schema and the named schemas, in which the names of tables in the named schemas are qualified by the schemas.
+func (fetcher fetcher) ListTables(ctx context.Context) ([]string, error) {
+	sql := `--sql query user table names
+SELECT
+	IF(TABLE_SCHEMA = '', TABLE_NAME, TABLE_SCHEMA || '.' || TABLE_NAME) AS Name
+FROM INFORMATION_SCHEMA.TABLES
+WHERE TABLE_CATALOG = '' AND TABLE_SCHEMA NOT IN ('INFORMATION_SCHEMA', 'SPANNER_SYS') AND TABLE_TYPE = 'BASE TABLE'
+ORDER BY Name`
+	postgreSQL := `--sql query user table names
+SELECT
+	CASE WHEN table_schema = 'public' THEN table_name ELSE table_schema || '.' || table_name END AS "Name"
+FROM information_schema.tables
+WHERE table_schema NOT IN ('information_schema', 'pg_catalog', 'spanner_sys') AND table_type = 'BASE TABLE'
+ORDER BY "Name"`
+	tx, err := fetcher.dialectQueryer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	type Table struct{ Name string }
+	tables, err := gf_spanner.ScanRowsStruct[Table](tx.Query(ctx, tx.statement(sql, postgreSQL)))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	return lo.Map(tables, func(it Table, i int) string { return it.Name }), nil
+}
+
+func getTable(ctx context.Context, tx dialectQueryer, schemaName string, table string) (SchemaTable, error) {
 	sql := `--sql query table name and parent information
 SELECT
+	TABLE_SCHEMA AS SchemaName,
 	TABLE_NAME AS Name,
 	IFNULL(PARENT_TABLE_NAME, "") AS Parent,
+	IF(ON_DELETE_ACTION = 'CASCADE', 'CASCADE', '') AS ParentOnDelete,
+	IFNULL(ROW_DELETION_POLICY_EXPRESSION, '') AS RowDeletionPolicy,
+	TABLE_TYPE AS TableType,
 FROM INFORMATION_SCHEMA.TABLES
-WHERE TABLE_NAME = @Table`
-	found, err := gf_spanner.ScanRowsStruct[SchemaTable](tx.Query(ctx, spanner.Statement{
-		SQL:    sql,
-		Params: map[string]interface{}{"Table": table},
-	}))
+WHERE TABLE_SCHEMA = @Schema AND TABLE_NAME = @Table`
+	postgreSQL := `--sql query table name and parent information
+SELECT
+	CASE WHEN table_schema = 'public' THEN '' ELSE table_schema END AS "SchemaName",
+	table_name AS "Name",
+	COALESCE(parent_table_name, '') AS "Parent",
+	CASE WHEN on_delete_action = 'CASCADE' THEN 'CASCADE' ELSE '' END AS "ParentOnDelete",
+	COALESCE(row_deletion_policy_expression, '') AS "RowDeletionPolicy",
+	table_type AS "TableType"
+FROM information_schema.tables
+WHERE table_schema = $1 AND table_name = $2`
+	type tableRow struct {
+		SchemaName        string
+		Name              string
+		Parent            string
+		ParentOnDelete    string
+		RowDeletionPolicy string
+		TableType         string
+	}
+	found, err := gf_spanner.ScanRowsStruct[tableRow](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
 	if err != nil {
 		return SchemaTable{}, fmt.Errorf(`fail to get table %s: %w`, table, err)
 	}
 	if len(found) == 0 {
 		return SchemaTable{}, fmt.Errorf("table %q not found", table)
 	}
-	return found[0], nil
+	if found[0].TableType == "VIEW" {
+		return SchemaTable{}, fmt.Errorf("%s is a view", table)
+	}
+	rowDeletionPolicy, err := parseRowDeletionPolicyExpression(found[0].RowDeletionPolicy)
+	if err != nil {
+		return SchemaTable{}, fmt.Errorf(`fail to get table %s: %w`, table, err)
+	}
+	return SchemaTable{
+		Name:              found[0].Name,
+		Schema:            found[0].SchemaName,
+		Parent:            found[0].Parent,
+		ParentOnDelete:    found[0].ParentOnDelete,
+		RowDeletionPolicy: rowDeletionPolicy,
+	}, nil
 }
 
-func queryColumns(ctx context.Context, tx gf_spanner.Queryer, table string) ([]SchemaColumn, error) {
+func queryColumns(ctx context.Context, tx dialectQueryer, schemaName string, table string) ([]SchemaColumn, error) {
 	sql := `--sql query column information
 SELECT
 	COLUMN_NAME AS Name,
 	SPANNER_TYPE AS Type,
 	(IS_NULLABLE = 'YES') AS Nullable,
+	IFNULL(COLUMN_DEFAULT, '') AS DefaultValue,
+	IFNULL(GENERATION_EXPRESSION, '') AS Generated,
+	IFNULL(IS_STORED = 'YES', FALSE) AS Stored,
 FROM INFORMATION_SCHEMA.COLUMNS
-WHERE TABLE_NAME = @Table
+WHERE TABLE_SCHEMA = @Schema AND TABLE_NAME = @Table
 ORDER BY ORDINAL_POSITION`
-	columns, err := gf_spanner.ScanRowsStruct[SchemaColumn](tx.Query(ctx, spanner.Statement{
-		SQL:    sql,
-		Params: map[string]interface{}{"Table": table},
-	}))
+	postgreSQL := `--sql query column information
+SELECT
+	column_name AS "Name",
+	spanner_type AS "Type",
+	(is_nullable = 'YES') AS "Nullable",
+	COALESCE(column_default, '') AS "DefaultValue",
+	COALESCE(generation_expression, '') AS "Generated",
+	COALESCE(is_stored = 'YES', FALSE) AS "Stored"
+FROM information_schema.columns
+WHERE table_schema = $1 AND table_name = $2
+ORDER BY ordinal_position`
+	// DEFAULT is a reserved keyword and cannot be used as an alias
+	type columnRow struct {
+		Name         string
+		Type         string
+		Nullable     bool
+		DefaultValue string
+		Generated    string
+		Stored       bool
+	}
+	columnRows, err := gf_spanner.ScanRowsStruct[columnRow](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get columns of %s: %w`, table, err)
 	}
 
-	return columns, nil
+	return lo.Map(columnRows, func(columnRow columnRow, _ int) SchemaColumn {
+		return SchemaColumn{
+			Name:      columnRow.Name,
+			Type:      columnRow.Type,
+			Nullable:  columnRow.Nullable,
+			Default:   columnRow.DefaultValue,
+			Generated: columnRow.Generated,
+			Stored:    columnRow.Stored,
+		}
+	}), nil
 }
 
-func queryPrimaryKey(ctx context.Context, tx gf_spanner.Queryer, table string) ([]string, error) {
+func queryPrimaryKey(ctx context.Context, tx dialectQueryer, schemaName string, table string) ([]string, error) {
 	sql := `--sql query primary key information
 SELECT
 	kcu.COLUMN_NAME AS Name
 FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS kcu
 	JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS tc
-	ON kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME 
-        AND kcu.TABLE_NAME = tc.TABLE_NAME
-WHERE kcu.TABLE_NAME = @Table AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+	ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+		AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		AND kcu.TABLE_NAME = tc.TABLE_NAME
+WHERE kcu.TABLE_SCHEMA = @Schema AND kcu.TABLE_NAME = @Table AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
 ORDER BY kcu.ORDINAL_POSITION`
+	postgreSQL := `--sql query primary key information
+SELECT
+	kcu.column_name AS "Name"
+FROM information_schema.key_column_usage AS kcu
+	JOIN information_schema.table_constraints AS tc
+	ON kcu.constraint_schema = tc.constraint_schema
+		AND kcu.constraint_name = tc.constraint_name
+		AND kcu.table_name = tc.table_name
+WHERE kcu.table_schema = $1 AND kcu.table_name = $2 AND tc.constraint_type = 'PRIMARY KEY'
+ORDER BY kcu.ordinal_position`
 	type PrimaryKey struct{ Name string }
-	primaryKey, err := gf_spanner.ScanRowsStruct[PrimaryKey](tx.Query(ctx, spanner.Statement{
-		SQL:    sql,
-		Params: map[string]interface{}{"Table": table},
-	}))
+	primaryKey, err := gf_spanner.ScanRowsStruct[PrimaryKey](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
 	}
 	return lo.Map(primaryKey, func(it PrimaryKey, i int) string { return it.Name }), nil
 }
 
-func queryForeignKeys(ctx context.Context, tx gf_spanner.Queryer, table string) ([]SchemaForeignKey, error) {
+func queryForeignKeys(ctx context.Context, tx dialectQueryer, schemaName string, table string) ([]SchemaForeignKey, error) {
 	sql := `--sql query foreign key information
 SELECT
 	tc.CONSTRAINT_NAME AS Name,
-	ctu.TABLE_NAME AS ReferencedTable,
+	IF(ctu.TABLE_SCHEMA = '', ctu.TABLE_NAME, ctu.TABLE_SCHEMA || '.' || ctu.TABLE_NAME) AS ReferencedTable,
 	ARRAY(
 		SELECT kcu.COLUMN_NAME
 		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu 
-		WHERE kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		WHERE kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
 		ORDER BY kcu.ORDINAL_POSITION
 	) AS ReferencingKey,
 	ARRAY(
 		SELECT kcu.COLUMN_NAME
 		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu 
-		WHERE kcu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
+		WHERE kcu.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
 		ORDER BY kcu.ORDINAL_POSITION
-	) AS ReferencedKey
+	) AS ReferencedKey,
+	IF(rc.DELETE_RULE = 'NO ACTION', '', rc.DELETE_RULE) AS OnDelete,
+	tc.ENFORCED = 'NO' AS NotEnforced
 FROM
 	INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
-	JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
-	JOIN INFORMATION_SCHEMA.CONSTRAINT_TABLE_USAGE ctu ON ctu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
-WHERE tc.CONSTRAINT_TYPE = 'FOREIGN KEY' AND tc.TABLE_NAME = @Table
+	JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+	JOIN INFORMATION_SCHEMA.CONSTRAINT_TABLE_USAGE ctu ON ctu.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND ctu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
+WHERE tc.CONSTRAINT_TYPE = 'FOREIGN KEY' AND tc.TABLE_SCHEMA = @Schema AND tc.TABLE_NAME = @Table
 ORDER BY Name`
-	foreignKeys, err := gf_spanner.ScanRowsStruct[SchemaForeignKey](tx.Query(ctx, spanner.Statement{
-		SQL:    sql,
-		Params: map[string]interface{}{"Table": table},
-	}))
+	postgreSQL := `--sql query foreign key information
+SELECT
+	tc.constraint_name AS "Name",
+	CASE WHEN ctu.table_schema = 'public' THEN ctu.table_name ELSE ctu.table_schema || '.' || ctu.table_name END AS "ReferencedTable",
+	ARRAY(
+		SELECT kcu.column_name
+		FROM information_schema.key_column_usage kcu
+		WHERE kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+		ORDER BY kcu.ordinal_position
+	) AS "ReferencingKey",
+	ARRAY(
+		SELECT kcu.column_name
+		FROM information_schema.key_column_usage kcu
+		WHERE kcu.constraint_schema = rc.unique_constraint_schema AND kcu.constraint_name = rc.unique_constraint_name
+		ORDER BY kcu.ordinal_position
+	) AS "ReferencedKey",
+	CASE WHEN rc.delete_rule = 'NO ACTION' THEN '' ELSE rc.delete_rule END AS "OnDelete",
+	tc.enforced = 'NO' AS "NotEnforced"
+FROM
+	information_schema.table_constraints tc
+	JOIN information_schema.referential_constraints rc ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+	JOIN information_schema.constraint_table_usage ctu ON ctu.constraint_schema = rc.unique_constraint_schema AND ctu.constraint_name = rc.unique_constraint_name
+WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = $1 AND tc.table_name = $2
+ORDER BY "Name"`
+	foreignKeys, err := gf_spanner.ScanRowsStruct[SchemaForeignKey](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get foreign keys of %s: %w`, table, err)
 	}
 	return foreignKeys, nil
 }
 
-func queryUniqueKeys(ctx context.Context, tx gf_spanner.Queryer, table string) ([]SchemaUniqueKey, error) {
+// queryReferencedBy returns foreign keys referencing the table and interleaved child tables, which are referencing the primary key of the table.
+func queryReferencedBy(ctx context.Context, tx dialectQueryer, schemaName string, table string, primaryKey []string) ([]SchemaReference, error) {
+	sql := `--sql query foreign keys and interleaved tables referencing the table
+SELECT
+	tc.CONSTRAINT_NAME AS Name,
+	IF(tc.TABLE_SCHEMA = '', tc.TABLE_NAME, tc.TABLE_SCHEMA || '.' || tc.TABLE_NAME) AS ReferencingTable,
+	ARRAY(
+		SELECT kcu.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+		WHERE kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		ORDER BY kcu.ORDINAL_POSITION
+	) AS ReferencingKey,
+	ARRAY(
+		SELECT kcu.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+		WHERE kcu.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
+		ORDER BY kcu.ORDINAL_POSITION
+	) AS ReferencedKey,
+	FALSE AS Interleaved
+FROM
+	INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+	JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+	JOIN INFORMATION_SCHEMA.CONSTRAINT_TABLE_USAGE ctu ON ctu.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND ctu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
+WHERE tc.CONSTRAINT_TYPE = 'FOREIGN KEY' AND ctu.TABLE_SCHEMA = @Schema AND ctu.TABLE_NAME = @Table
+UNION ALL
+SELECT
+	'' AS Name,
+	IF(t.TABLE_SCHEMA = '', t.TABLE_NAME, t.TABLE_SCHEMA || '.' || t.TABLE_NAME) AS ReferencingTable,
+	ARRAY<STRING>[] AS ReferencingKey,
+	ARRAY<STRING>[] AS ReferencedKey,
+	TRUE AS Interleaved
+FROM INFORMATION_SCHEMA.TABLES t
+WHERE t.TABLE_SCHEMA = @Schema AND t.PARENT_TABLE_NAME = @Table
+ORDER BY ReferencingTable, Interleaved DESC, Name`
+	postgreSQL := `--sql query foreign keys and interleaved tables referencing the table
+SELECT
+	tc.constraint_name AS "Name",
+	CASE WHEN tc.table_schema = 'public' THEN tc.table_name ELSE tc.table_schema || '.' || tc.table_name END AS "ReferencingTable",
+	ARRAY(
+		SELECT kcu.column_name
+		FROM information_schema.key_column_usage kcu
+		WHERE kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+		ORDER BY kcu.ordinal_position
+	) AS "ReferencingKey",
+	ARRAY(
+		SELECT kcu.column_name
+		FROM information_schema.key_column_usage kcu
+		WHERE kcu.constraint_schema = rc.unique_constraint_schema AND kcu.constraint_name = rc.unique_constraint_name
+		ORDER BY kcu.ordinal_position
+	) AS "ReferencedKey",
+	FALSE AS "Interleaved"
+FROM
+	information_schema.table_constraints tc
+	JOIN information_schema.referential_constraints rc ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+	JOIN information_schema.constraint_table_usage ctu ON ctu.constraint_schema = rc.unique_constraint_schema AND ctu.constraint_name = rc.unique_constraint_name
+WHERE tc.constraint_type = 'FOREIGN KEY' AND ctu.table_schema = $1 AND ctu.table_name = $2
+UNION ALL
+SELECT
+	'' AS "Name",
+	CASE WHEN t.table_schema = 'public' THEN t.table_name ELSE t.table_schema || '.' || t.table_name END AS "ReferencingTable",
+	ARRAY(SELECT kcu.column_name FROM information_schema.key_column_usage kcu WHERE FALSE) AS "ReferencingKey",
+	ARRAY(SELECT kcu.column_name FROM information_schema.key_column_usage kcu WHERE FALSE) AS "ReferencedKey",
+	TRUE AS "Interleaved"
+FROM information_schema.tables t
+WHERE t.table_schema = $1 AND t.parent_table_name = $2
+ORDER BY "ReferencingTable", "Interleaved" DESC, "Name"`
+	references, err := gf_spanner.ScanRowsStruct[SchemaReference](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys referencing %s: %w`, table, err)
+	}
+	for i, reference := range references {
+		if reference.Interleaved {
+			// the primary key of an interleaved table starts with the primary key of the parent table
+			references[i].ReferencingKey = slices.Clone(primaryKey)
+			references[i].ReferencedKey = slices.Clone(primaryKey)
+		}
+	}
+	return references, nil
+}
+
+func queryUniqueKeys(ctx context.Context, tx dialectQueryer, schemaName string, table string) ([]SchemaUniqueKey, error) {
 	sql := `--sql query unique key information
 WITH
 	EXCLUDE_FK_BACKING AS (
 		SELECT rc.UNIQUE_CONSTRAINT_NAME AS Name
 		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
-		JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
-		JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc2 ON tc2.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME AND tc2.CONSTRAINT_TYPE = 'UNIQUE'
-		WHERE tc.CONSTRAINT_TYPE = 'FOREIGN KEY'
+		JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc2 ON tc2.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND tc2.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME AND tc2.CONSTRAINT_TYPE = 'UNIQUE'
+		WHERE tc.CONSTRAINT_TYPE = 'FOREIGN KEY' AND rc.UNIQUE_CONSTRAINT_SCHEMA = @Schema
 	)
 SELECT
 	idx.INDEX_NAME AS Name,
 	ARRAY(
 		SELECT idxc.COLUMN_NAME
 		FROM INFORMATION_SCHEMA.INDEX_COLUMNS idxc
-		WHERE idx.INDEX_NAME = idxc.INDEX_NAME
+		WHERE idxc.TABLE_SCHEMA = idx.TABLE_SCHEMA AND idx.INDEX_NAME = idxc.INDEX_NAME
 		ORDER BY idxc.ORDINAL_POSITION
 	) AS Key
 FROM INFORMATION_SCHEMA.INDEXES idx
 WHERE
-	idx.TABLE_NAME = @Table
+	idx.TABLE_SCHEMA = @Schema
+	AND idx.TABLE_NAME = @Table
 	AND idx.IS_UNIQUE
 	AND INDEX_TYPE = "INDEX"
 	AND idx.INDEX_NAME NOT IN (SELECT Name FROM EXCLUDE_FK_BACKING)
 ORDER BY Name`
-	uniqueKeys, err := gf_spanner.ScanRowsStruct[SchemaUniqueKey](tx.Query(ctx, spanner.Statement{
-		SQL:    sql,
-		Params: map[string]interface{}{"Table": table},
-	}))
+	postgreSQL := `--sql query unique key information
+WITH
+	exclude_fk_backing AS (
+		SELECT rc.unique_constraint_name AS name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.referential_constraints rc ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+		JOIN information_schema.table_constraints tc2 ON tc2.constraint_schema = rc.unique_constraint_schema AND tc2.constraint_name = rc.unique_constraint_name AND tc2.constraint_type = 'UNIQUE'
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND rc.unique_constraint_schema = $1
+	)
+SELECT
+	idx.index_name AS "Name",
+	ARRAY(
+		SELECT idxc.column_name
+		FROM information_schema.index_columns idxc
+		WHERE idxc.table_schema = idx.table_schema AND idx.index_name = idxc.index_name
+		ORDER BY idxc.ordinal_position
+	) AS "Key"
+FROM information_schema.indexes idx
+WHERE
+	idx.table_schema = $1
+	AND idx.table_name = $2
+	AND idx.is_unique = 'YES'
+	AND idx.index_type = 'INDEX'
+	AND idx.index_name NOT IN (SELECT name FROM exclude_fk_backing)
+ORDER BY "Name"`
+	uniqueKeys, err := gf_spanner.ScanRowsStruct[SchemaUniqueKey](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get unique keys of %s: %w`, table, err)
 	}
 	return uniqueKeys, nil
 }
+
+func queryIndexes(ctx context.Context, tx dialectQueryer, schemaName string, table string) ([]SchemaIndex, error) {
+	sql := `--sql query index information
+SELECT
+	idx.INDEX_NAME AS Name,
+	idx.IS_UNIQUE AS Unique,
+	idx.IS_NULL_FILTERED AS NullFiltered,
+	IFNULL(idx.PARENT_TABLE_NAME, "") AS Interleave,
+	ARRAY(
+		SELECT idxc.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.INDEX_COLUMNS idxc
+		WHERE idxc.TABLE_SCHEMA = idx.TABLE_SCHEMA AND idxc.TABLE_NAME = idx.TABLE_NAME AND idxc.INDEX_NAME = idx.INDEX_NAME AND idxc.ORDINAL_POSITION IS NOT NULL
+		ORDER BY idxc.ORDINAL_POSITION
+	) AS KeyColumns,
+	ARRAY(
+		SELECT IFNULL(idxc.COLUMN_ORDERING, "")
+		FROM INFORMATION_SCHEMA.INDEX_COLUMNS idxc
+		WHERE idxc.TABLE_SCHEMA = idx.TABLE_SCHEMA AND idxc.TABLE_NAME = idx.TABLE_NAME AND idxc.INDEX_NAME = idx.INDEX_NAME AND idxc.ORDINAL_POSITION IS NOT NULL
+		ORDER BY idxc.ORDINAL_POSITION
+	) AS KeyOrderings,
+	ARRAY(
+		SELECT idxc.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.INDEX_COLUMNS idxc
+		WHERE idxc.TABLE_SCHEMA = idx.TABLE_SCHEMA AND idxc.TABLE_NAME = idx.TABLE_NAME AND idxc.INDEX_NAME = idx.INDEX_NAME AND idxc.ORDINAL_POSITION IS NULL
+		ORDER BY idxc.COLUMN_NAME
+	) AS Storing
+FROM INFORMATION_SCHEMA.INDEXES idx
+WHERE
+	idx.TABLE_SCHEMA = @Schema
+	AND idx.TABLE_NAME = @Table
+	AND idx.INDEX_TYPE = "INDEX"
+	AND NOT idx.SPANNER_IS_MANAGED
+ORDER BY Name`
+	postgreSQL := `--sql query index information
+SELECT
+	idx.index_name AS "Name",
+	idx.is_unique = 'YES' AS "Unique",
+	idx.is_null_filtered = 'YES' AS "NullFiltered",
+	COALESCE(idx.parent_table_name, '') AS "Interleave",
+	ARRAY(
+		SELECT idxc.column_name
+		FROM information_schema.index_columns idxc
+		WHERE idxc.table_schema = idx.table_schema AND idxc.table_name = idx.table_name AND idxc.index_name = idx.index_name AND idxc.ordinal_position IS NOT NULL
+		ORDER BY idxc.ordinal_position
+	) AS "KeyColumns",
+	ARRAY(
+		SELECT COALESCE(idxc.column_ordering, '')
+		FROM information_schema.index_columns idxc
+		WHERE idxc.table_schema = idx.table_schema AND idxc.table_name = idx.table_name AND idxc.index_name = idx.index_name AND idxc.ordinal_position IS NOT NULL
+		ORDER BY idxc.ordinal_position
+	) AS "KeyOrderings",
+	ARRAY(
+		SELECT idxc.column_name
+		FROM information_schema.index_columns idxc
+		WHERE idxc.table_schema = idx.table_schema AND idxc.table_name = idx.table_name AND idxc.index_name = idx.index_name AND idxc.ordinal_position IS NULL
+		ORDER BY idxc.column_name
+	) AS "Storing"
+FROM information_schema.indexes idx
+WHERE
+	idx.table_schema = $1
+	AND idx.table_name = $2
+	AND idx.index_type = 'INDEX'
+	AND idx.spanner_is_managed = 'NO'
+ORDER BY "Name"`
+	type indexRow struct {
+		Name         string
+		Unique       bool
+		NullFiltered bool
+		Interleave   string
+		KeyColumns   []string
+		KeyOrderings []string
+		Storing      []string
+	}
+	indexRows, err := gf_spanner.ScanRowsStruct[indexRow](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+	}
+
+	return lo.Map(indexRows, func(indexRow indexRow, _ int) SchemaIndex {
+		index := SchemaIndex{
+			Name:         indexRow.Name,
+			Unique:       indexRow.Unique,
+			NullFiltered: indexRow.NullFiltered,
+			Interleave:   indexRow.Interleave,
+		}
+		for i, column := range indexRow.KeyColumns {
+			index.Key = append(index.Key, SchemaIndexKey{Name: column, Desc: indexRow.KeyOrderings[i] == "DESC"})
+		}
+		if len(indexRow.Storing) > 0 {
+			index.Storing = indexRow.Storing
+		}
+		return index
+	}), nil
+}
+
+func queryChecks(ctx context.Context, tx dialectQueryer, schemaName string, table string) ([]SchemaCheck, error) {
+	// NOT NULL constraints are also listed as CHECK constraints named CK_IS_NOT_NULL_<table>_<column>
+	sql := `--sql query check constraint information
+SELECT
+	cc.CONSTRAINT_NAME AS Name,
+	cc.CHECK_CLAUSE AS Expression,
+FROM INFORMATION_SCHEMA.CHECK_CONSTRAINTS AS cc
+	JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS tc
+	ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+WHERE tc.TABLE_SCHEMA = @Schema AND tc.TABLE_NAME = @Table
+	AND tc.CONSTRAINT_TYPE = 'CHECK'
+	AND NOT STARTS_WITH(cc.CONSTRAINT_NAME, 'CK_IS_NOT_NULL_')
+ORDER BY Name`
+	postgreSQL := `--sql query check constraint information
+SELECT
+	cc.constraint_name AS "Name",
+	cc.check_clause AS "Expression"
+FROM information_schema.check_constraints AS cc
+	JOIN information_schema.table_constraints AS tc
+	ON cc.constraint_schema = tc.constraint_schema AND cc.constraint_name = tc.constraint_name
+WHERE tc.table_schema = $1 AND tc.table_name = $2
+	AND tc.constraint_type = 'CHECK'
+	AND substr(cc.constraint_name, 1, 15) <> 'CK_IS_NOT_NULL_'
+ORDER BY "Name"`
+	checks, err := gf_spanner.ScanRowsStruct[SchemaCheck](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get checks of %s: %w`, table, err)
+	}
+	return checks, nil
+}
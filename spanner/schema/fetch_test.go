@@ -6,361 +6,549 @@ import (
 	"fmt"
 	"testing"
 
+	spanner_adminpb "cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
 	"github.com/Jumpaku/gotaface/spanner/schema"
 	"github.com/Jumpaku/gotaface/spanner/schema/testdata"
 	"github.com/Jumpaku/gotaface/spanner/test"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
 var ddls = map[string][]string{
-	"ddl_00_all_types":      test.Split(testdata.DDL00AllTypesSQL),
-	"ddl_01_interleave":     test.Split(testdata.DDL01InterleaveSQL),
-	"ddl_02_foreign_keys":   test.Split(testdata.DDL02ForeignKeysSQL),
-	"ddl_03_foreign_loop_1": test.Split(testdata.DDL03ForeignLoop1SQL),
-	"ddl_04_foreign_loop_2": test.Split(testdata.DDL04ForeignLoop2SQL),
-	"ddl_05_foreign_loop_3": test.Split(testdata.DDL05ForeignLoop3SQL),
-	"ddl_06_unique_keys":    test.Split(testdata.DDL06UniqueKeysSQL),
+	"ddl_00_all_types":           test.Split(testdata.DDL00AllTypesSQL),
+	"ddl_01_interleave":          test.Split(testdata.DDL01InterleaveSQL),
+	"ddl_02_foreign_keys":        test.Split(testdata.DDL02ForeignKeysSQL),
+	"ddl_03_foreign_loop_1":      test.Split(testdata.DDL03ForeignLoop1SQL),
+	"ddl_04_foreign_loop_2":      test.Split(testdata.DDL04ForeignLoop2SQL),
+	"ddl_05_foreign_loop_3":      test.Split(testdata.DDL05ForeignLoop3SQL),
+	"ddl_06_unique_keys":         test.Split(testdata.DDL06UniqueKeysSQL),
+	"ddl_10_indexes":             test.Split(testdata.DDL10IndexesSQL),
+	"ddl_11_column_defaults":     test.Split(testdata.DDL11ColumnDefaultsSQL),
+	"ddl_12_checks":              test.Split(testdata.DDL12ChecksSQL),
+	"ddl_13_foreign_key_actions": test.Split(testdata.DDL13ForeignKeyActionsSQL),
+	"ddl_14_views":               test.Split(testdata.DDL14ViewsSQL),
+	"ddl_15_spanner_features":    test.Split(testdata.DDL15SpannerFeaturesSQL),
 }
 
-func TestFetcher(t *testing.T) {
-	testcases := []struct {
-		ddl   string
-		table string
-		want  schema.SchemaTable
-	}{
-		{
-			ddl:   "ddl_00_all_types",
-			table: "A",
-			want: schema.SchemaTable{
-				Name: "A",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "INT64", Nullable: false},
-					{Name: "Col_01", Type: "BOOL", Nullable: true},
-					{Name: "Col_02", Type: "BOOL", Nullable: false},
-					{Name: "Col_03", Type: "BYTES(50)", Nullable: true},
-					{Name: "Col_04", Type: "BYTES(50)", Nullable: false},
-					{Name: "Col_05", Type: "DATE", Nullable: true},
-					{Name: "Col_06", Type: "DATE", Nullable: false},
-					{Name: "Col_07", Type: "FLOAT64", Nullable: true},
-					{Name: "Col_08", Type: "FLOAT64", Nullable: false},
-					{Name: "Col_09", Type: "INT64", Nullable: true},
-					{Name: "Col_10", Type: "INT64", Nullable: false},
-					{Name: "Col_11", Type: "JSON", Nullable: true},
-					{Name: "Col_12", Type: "JSON", Nullable: false},
-					{Name: "Col_13", Type: "NUMERIC", Nullable: true},
-					{Name: "Col_14", Type: "NUMERIC", Nullable: false},
-					{Name: "Col_15", Type: "STRING(50)", Nullable: true},
-					{Name: "Col_16", Type: "STRING(50)", Nullable: false},
-					{Name: "Col_17", Type: "TIMESTAMP", Nullable: true},
-					{Name: "Col_18", Type: "TIMESTAMP", Nullable: false},
-				},
-				PrimaryKey: []string{"PK"},
+var fetcherTestcases = []struct {
+	ddl   string
+	table string
+	want  schema.SchemaTable
+}{
+	{
+		ddl:   "ddl_00_all_types",
+		table: "A",
+		want: schema.SchemaTable{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64", Nullable: false},
+				{Name: "Col_01", Type: "BOOL", Nullable: true},
+				{Name: "Col_02", Type: "BOOL", Nullable: false},
+				{Name: "Col_03", Type: "BYTES(50)", Nullable: true},
+				{Name: "Col_04", Type: "BYTES(50)", Nullable: false},
+				{Name: "Col_05", Type: "DATE", Nullable: true},
+				{Name: "Col_06", Type: "DATE", Nullable: false},
+				{Name: "Col_07", Type: "FLOAT64", Nullable: true},
+				{Name: "Col_08", Type: "FLOAT64", Nullable: false},
+				{Name: "Col_09", Type: "INT64", Nullable: true},
+				{Name: "Col_10", Type: "INT64", Nullable: false},
+				{Name: "Col_11", Type: "JSON", Nullable: true},
+				{Name: "Col_12", Type: "JSON", Nullable: false},
+				{Name: "Col_13", Type: "NUMERIC", Nullable: true},
+				{Name: "Col_14", Type: "NUMERIC", Nullable: false},
+				{Name: "Col_15", Type: "STRING(50)", Nullable: true},
+				{Name: "Col_16", Type: "STRING(50)", Nullable: false},
+				{Name: "Col_17", Type: "TIMESTAMP", Nullable: true},
+				{Name: "Col_18", Type: "TIMESTAMP", Nullable: false},
 			},
+			PrimaryKey: []string{"PK"},
 		},
-		{
-			ddl:   "ddl_01_interleave",
-			table: "B_1",
-			want: schema.SchemaTable{
-				Name: "B_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11"},
+	},
+	{
+		ddl:   "ddl_01_interleave",
+		table: "B_1",
+		want: schema.SchemaTable{
+			Name: "B_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
 			},
+			PrimaryKey: []string{"PK_11"},
 		},
-		{
-			ddl:   "ddl_01_interleave",
-			table: "B_2",
-			want: schema.SchemaTable{
-				Name: "B_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_21", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_21"},
-				Parent:     "B_1",
-			},
-		},
-		{
-			ddl:   "ddl_01_interleave",
-			table: "B_3",
-			want: schema.SchemaTable{
-				Name: "B_3",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_21", Type: "INT64"},
-					{Name: "PK_31", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_21", "PK_31"},
-				Parent:     "B_2",
-			},
-		},
-		{
-			ddl:   "ddl_01_interleave",
-			table: "B_4",
-			want: schema.SchemaTable{
-				Name: "B_4",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_21", Type: "INT64"},
-					{Name: "PK_41", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_21", "PK_41"},
-				Parent:     "B_2",
-			},
-		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_1",
-			want: schema.SchemaTable{
-				Name: "C_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_12", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
+	},
+	{
+		ddl:   "ddl_01_interleave",
+		table: "B_2",
+		want: schema.SchemaTable{
+			Name: "B_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_21", Type: "INT64"},
 			},
+			PrimaryKey:     []string{"PK_11", "PK_21"},
+			Parent:         "B_1",
+			ParentOnDelete: "CASCADE",
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_2",
-			want: schema.SchemaTable{
-				Name: "C_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "INT64"},
-					{Name: "PK_22", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_C_2_1",
-						ReferencedTable: "C_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
-				},
+	},
+	{
+		ddl:   "ddl_01_interleave",
+		table: "B_3",
+		want: schema.SchemaTable{
+			Name: "B_3",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "PK_31", Type: "INT64"},
 			},
+			PrimaryKey:     []string{"PK_11", "PK_21", "PK_31"},
+			Parent:         "B_2",
+			ParentOnDelete: "CASCADE",
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_3",
-			want: schema.SchemaTable{
-				Name: "C_3",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_31", Type: "INT64"},
-					{Name: "PK_32", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_31", "PK_32"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_C_3_2",
-						ReferencedTable: "C_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_31", "PK_32"},
-					},
-				},
+	},
+	{
+		ddl:   "ddl_01_interleave",
+		table: "B_4",
+		want: schema.SchemaTable{
+			Name: "B_4",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "PK_41", Type: "INT64"},
 			},
+			PrimaryKey:     []string{"PK_11", "PK_21", "PK_41"},
+			Parent:         "B_2",
+			ParentOnDelete: "CASCADE",
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_4",
-			want: schema.SchemaTable{
-				Name: "C_4",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_41", Type: "INT64"},
-					{Name: "PK_42", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_41", "PK_42"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_C_4_2",
-						ReferencedTable: "C_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_41", "PK_42"},
-					},
-				},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_1",
+		want: schema.SchemaTable{
+			Name: "C_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_12", Type: "INT64"},
 			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_5",
-			want: schema.SchemaTable{
-				Name: "C_5",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_51", Type: "INT64"},
-					{Name: "PK_52", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_51", "PK_52"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_C_5_3",
-						ReferencedTable: "C_3",
-						ReferencedKey:   []string{"PK_31", "PK_32"},
-						ReferencingKey:  []string{"PK_51", "PK_52"},
-					},
-					{
-						Name:            "FK_C_5_4",
-						ReferencedTable: "C_4",
-						ReferencedKey:   []string{"PK_41", "PK_42"},
-						ReferencingKey:  []string{"PK_51", "PK_52"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_2",
+		want: schema.SchemaTable{
+			Name: "C_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "PK_22", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_2_1",
+					ReferencedTable: "C_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_03_foreign_loop_1",
-			table: "D_1",
-			want: schema.SchemaTable{
-				Name: "D_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_12", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_D_1_1",
-						ReferencedTable: "D_1",
-						ReferencedKey:   []string{"PK_12"},
-						ReferencingKey:  []string{"PK_11"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_3",
+		want: schema.SchemaTable{
+			Name: "C_3",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_31", Type: "INT64"},
+				{Name: "PK_32", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_31", "PK_32"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_3_2",
+					ReferencedTable: "C_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_31", "PK_32"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_04_foreign_loop_2",
-			table: "E_1",
-			want: schema.SchemaTable{
-				Name: "E_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_12", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_E_1_2",
-						ReferencedTable: "E_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_11", "PK_12"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_4",
+		want: schema.SchemaTable{
+			Name: "C_4",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_41", Type: "INT64"},
+				{Name: "PK_42", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_41", "PK_42"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_4_2",
+					ReferencedTable: "C_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_41", "PK_42"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_04_foreign_loop_2",
-			table: "E_2",
-			want: schema.SchemaTable{
-				Name: "E_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "INT64"},
-					{Name: "PK_22", Type: "INT64"},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_5",
+		want: schema.SchemaTable{
+			Name: "C_5",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_51", Type: "INT64"},
+				{Name: "PK_52", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_51", "PK_52"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_5_3",
+					ReferencedTable: "C_3",
+					ReferencedKey:   []string{"PK_31", "PK_32"},
+					ReferencingKey:  []string{"PK_51", "PK_52"},
 				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_E_2_1",
-						ReferencedTable: "E_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
+				{
+					Name:            "FK_C_5_4",
+					ReferencedTable: "C_4",
+					ReferencedKey:   []string{"PK_41", "PK_42"},
+					ReferencingKey:  []string{"PK_51", "PK_52"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_1",
-			want: schema.SchemaTable{
-				Name: "F_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_12", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_F_1_3",
-						ReferencedTable: "F_3",
-						ReferencedKey:   []string{"PK_31", "PK_32"},
-						ReferencingKey:  []string{"PK_11", "PK_12"},
-					},
+	},
+	{
+		ddl:   "ddl_03_foreign_loop_1",
+		table: "D_1",
+		want: schema.SchemaTable{
+			Name: "D_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_12", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_D_1_1",
+					ReferencedTable: "D_1",
+					ReferencedKey:   []string{"PK_12"},
+					ReferencingKey:  []string{"PK_11"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_2",
-			want: schema.SchemaTable{
-				Name: "F_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "INT64"},
-					{Name: "PK_22", Type: "INT64"},
+	},
+	{
+		ddl:   "ddl_04_foreign_loop_2",
+		table: "E_1",
+		want: schema.SchemaTable{
+			Name: "E_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_12", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_E_1_2",
+					ReferencedTable: "E_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_11", "PK_12"},
 				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_F_2_1",
-						ReferencedTable: "F_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_04_foreign_loop_2",
+		table: "E_2",
+		want: schema.SchemaTable{
+			Name: "E_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "PK_22", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_E_2_1",
+					ReferencedTable: "E_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_3",
-			want: schema.SchemaTable{
-				Name: "F_3",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_31", Type: "INT64"},
-					{Name: "PK_32", Type: "INT64"},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_1",
+		want: schema.SchemaTable{
+			Name: "F_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_12", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_1_3",
+					ReferencedTable: "F_3",
+					ReferencedKey:   []string{"PK_31", "PK_32"},
+					ReferencingKey:  []string{"PK_11", "PK_12"},
 				},
-				PrimaryKey: []string{"PK_31", "PK_32"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						Name:            "FK_F_3_2",
-						ReferencedTable: "F_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_31", "PK_32"},
-					},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_2",
+		want: schema.SchemaTable{
+			Name: "F_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "PK_22", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_2_1",
+					ReferencedTable: "F_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_06_unique_keys",
-			table: "G",
-			want: schema.SchemaTable{
-				Name: "G",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "INT64"},
-					{Name: "C1", Type: "INT64"},
-					{Name: "C2", Type: "INT64"},
-					{Name: "C3", Type: "INT64"},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_3",
+		want: schema.SchemaTable{
+			Name: "F_3",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_31", Type: "INT64"},
+				{Name: "PK_32", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_31", "PK_32"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_3_2",
+					ReferencedTable: "F_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_31", "PK_32"},
 				},
-				PrimaryKey: []string{"PK"},
-				UniqueKeys: []schema.SchemaUniqueKey{
-					{Name: "UQ_G_C1", Key: []string{"C1"}},
-					{Name: "UQ_G_C1_C2", Key: []string{"C1", "C2"}},
-					{Name: "UQ_G_C1_C2_C3", Key: []string{"C1", "C2", "C3"}},
-					{Name: "UQ_G_C1_C3", Key: []string{"C1", "C3"}},
-					{Name: "UQ_G_C1_C3_C2", Key: []string{"C1", "C3", "C2"}},
-					{Name: "UQ_G_C2", Key: []string{"C2"}},
-					{Name: "UQ_G_C2_C1", Key: []string{"C2", "C1"}},
-					{Name: "UQ_G_C2_C1_C3", Key: []string{"C2", "C1", "C3"}},
-					{Name: "UQ_G_C2_C3", Key: []string{"C2", "C3"}},
-					{Name: "UQ_G_C2_C3_C1", Key: []string{"C2", "C3", "C1"}},
-					{Name: "UQ_G_C3", Key: []string{"C3"}},
-					{Name: "UQ_G_C3_C1", Key: []string{"C3", "C1"}},
-					{Name: "UQ_G_C3_C1_C2", Key: []string{"C3", "C1", "C2"}},
-					{Name: "UQ_G_C3_C2", Key: []string{"C3", "C2"}},
-					{Name: "UQ_G_C3_C2_C1", Key: []string{"C3", "C2", "C1"}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_06_unique_keys",
+		table: "G",
+		want: schema.SchemaTable{
+			Name: "G",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "INT64"},
+				{Name: "C3", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "UQ_G_C1", Key: []string{"C1"}},
+				{Name: "UQ_G_C1_C2", Key: []string{"C1", "C2"}},
+				{Name: "UQ_G_C1_C2_C3", Key: []string{"C1", "C2", "C3"}},
+				{Name: "UQ_G_C1_C3", Key: []string{"C1", "C3"}},
+				{Name: "UQ_G_C1_C3_C2", Key: []string{"C1", "C3", "C2"}},
+				{Name: "UQ_G_C2", Key: []string{"C2"}},
+				{Name: "UQ_G_C2_C1", Key: []string{"C2", "C1"}},
+				{Name: "UQ_G_C2_C1_C3", Key: []string{"C2", "C1", "C3"}},
+				{Name: "UQ_G_C2_C3", Key: []string{"C2", "C3"}},
+				{Name: "UQ_G_C2_C3_C1", Key: []string{"C2", "C3", "C1"}},
+				{Name: "UQ_G_C3", Key: []string{"C3"}},
+				{Name: "UQ_G_C3_C1", Key: []string{"C3", "C1"}},
+				{Name: "UQ_G_C3_C1_C2", Key: []string{"C3", "C1", "C2"}},
+				{Name: "UQ_G_C3_C2", Key: []string{"C3", "C2"}},
+				{Name: "UQ_G_C3_C2_C1", Key: []string{"C3", "C2", "C1"}},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "UQ_G_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "UQ_G_C1_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C2"}}},
+				{Name: "UQ_G_C1_C2_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C2"}, {Name: "C3"}}},
+				{Name: "UQ_G_C1_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C3"}}},
+				{Name: "UQ_G_C1_C3_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C3"}, {Name: "C2"}}},
+				{Name: "UQ_G_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}}},
+				{Name: "UQ_G_C2_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C1"}}},
+				{Name: "UQ_G_C2_C1_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C1"}, {Name: "C3"}}},
+				{Name: "UQ_G_C2_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C3"}}},
+				{Name: "UQ_G_C2_C3_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C3"}, {Name: "C1"}}},
+				{Name: "UQ_G_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}}},
+				{Name: "UQ_G_C3_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C1"}}},
+				{Name: "UQ_G_C3_C1_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C1"}, {Name: "C2"}}},
+				{Name: "UQ_G_C3_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C2"}}},
+				{Name: "UQ_G_C3_C2_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C2"}, {Name: "C1"}}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_10_indexes",
+		table: "L_1",
+		want: schema.SchemaTable{
+			Name: "L_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_1", Type: "INT64"},
+				{Name: "C1", Type: "INT64", Nullable: true},
+				{Name: "C2", Type: "STRING(50)", Nullable: true},
+			},
+			PrimaryKey: []string{"PK_1"},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_L_1_C1", Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "IDX_L_1_C2_C1", Key: []schema.SchemaIndexKey{{Name: "C2", Desc: true}, {Name: "C1"}}},
+				{Name: "IDX_L_1_C2_Storing", Key: []schema.SchemaIndexKey{{Name: "C2"}}, NullFiltered: true, Storing: []string{"C1"}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_10_indexes",
+		table: "L_2",
+		want: schema.SchemaTable{
+			Name: "L_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_1", Type: "INT64"},
+				{Name: "PK_2", Type: "INT64"},
+				{Name: "C3", Type: "FLOAT64", Nullable: true},
+				{Name: "C4", Type: "STRING(50)", Nullable: true},
+			},
+			PrimaryKey:     []string{"PK_1", "PK_2"},
+			Parent:         "L_1",
+			ParentOnDelete: "CASCADE",
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "UQ_L_2_C4", Key: []string{"C4"}},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_L_2_C3", Key: []schema.SchemaIndexKey{{Name: "PK_1"}, {Name: "C3", Desc: true}}, Storing: []string{"C4"}, Interleave: "L_1"},
+				{Name: "UQ_L_2_C4", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C4"}}, NullFiltered: true},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_11_column_defaults",
+		table: "M",
+		want: schema.SchemaTable{
+			Name: "M",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64", Default: "0"},
+				{Name: "C2", Type: "STRING(50)", Nullable: true, Default: "'abc'"},
+				{Name: "C3", Type: "TIMESTAMP", Nullable: true, Default: "CURRENT_TIMESTAMP()"},
+				{Name: "C4", Type: "STRING(36)", Default: "GENERATE_UUID()"},
+				{Name: "C5", Type: "INT64", Nullable: true, Generated: "C1 * 2", Stored: true},
+				{Name: "C6", Type: "STRING(50)", Nullable: true, Generated: "UPPER(C2)", Stored: true},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	},
+	{
+		ddl:   "ddl_12_checks",
+		table: "N",
+		want: schema.SchemaTable{
+			Name: "N",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "STRING(10)", Nullable: true},
+				{Name: "C3", Type: "INT64", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			Checks: []schema.SchemaCheck{
+				{Name: "CK_N_C1", Expression: "C1 >= 0"},
+				{Name: "CK_N_C1_C3", Expression: "C1 < C3"},
+				{Name: "CK_N_C2", Expression: "C2 IN ('a', 'b', 'c')"},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_13_foreign_key_actions",
+		table: "O_2",
+		want: schema.SchemaTable{
+			Name: "O_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "R1", Type: "INT64", Nullable: true},
+				{Name: "R2", Type: "INT64", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{Name: "FK_O_2_1", ReferencedTable: "O_1", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"R1"}, OnDelete: "CASCADE"},
+				{Name: "FK_O_2_2", ReferencedTable: "O_1", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"R2"}, NotEnforced: true},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_15_spanner_features",
+		table: "Q_1",
+		want: schema.SchemaTable{
+			Name: "Q_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64", Default: "GET_NEXT_SEQUENCE_VALUE(SEQUENCE Q_Seq)"},
+				{Name: "CreatedAt", Type: "TIMESTAMP"},
+				{Name: "Body", Type: "STRING(MAX)", Nullable: true},
+				{Name: "Body_Tokens", Type: "TOKENLIST", Nullable: true, Generated: "TOKENIZE_FULLTEXT(Body)"},
+			},
+			PrimaryKey:        []string{"PK"},
+			RowDeletionPolicy: &schema.SchemaRowDeletionPolicy{Column: "CreatedAt", Days: 30},
+			SearchIndexes: []schema.SchemaSearchIndex{
+				{Name: "SI_Q_1_Body", Key: []string{"Body_Tokens"}, Storing: []string{"CreatedAt"}},
+			},
+			ChangeStreams: []schema.SchemaChangeStream{
+				{Name: "CS_All", All: true},
+				{Name: "CS_Q"},
+			},
+			Sequences: []schema.SchemaSequence{
+				{
+					Name:             "Q_Seq",
+					Kind:             "bit_reversed_positive",
+					SkipRangeMin:     lo.ToPtr(int64(1)),
+					SkipRangeMax:     lo.ToPtr(int64(1000)),
+					StartWithCounter: lo.ToPtr(int64(10)),
 				},
 			},
 		},
-	}
+	},
+	{
+		ddl:   "ddl_15_spanner_features",
+		table: "Q_2",
+		want: schema.SchemaTable{
+			Name: "Q_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "STRING(MAX)", Nullable: true},
+				{Name: "C3", Type: "STRING(MAX)", Nullable: true},
+			},
+			PrimaryKey: []string{"PK", "C1"},
+			Parent:     "Q_1",
+			ChangeStreams: []schema.SchemaChangeStream{
+				{Name: "CS_All", All: true},
+				{Name: "CS_Q", Columns: []string{"C2"}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_15_spanner_features",
+		table: "S.Q_3",
+		want: schema.SchemaTable{
+			Name:   "Q_3",
+			Schema: "S",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "STRING(MAX)", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			ChangeStreams: []schema.SchemaChangeStream{
+				{Name: "CS_All", All: true},
+			},
+		},
+	},
+}
 
-	for number, testcase := range testcases {
+func TestFetcher(t *testing.T) {
+	for number, testcase := range fetcherTestcases {
 		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
 			database := fmt.Sprintf("fetcher_%0d", number)
 			admin, client, teardown := test.Setup(t, database)
@@ -375,3 +563,209 @@ func TestFetcher(t *testing.T) {
 		})
 	}
 }
+
+var listTablesTestcases = []struct {
+	ddl  string
+	want []string
+}{
+	{ddl: "ddl_00_all_types", want: []string{"A"}},
+	{ddl: "ddl_01_interleave", want: []string{"B_1", "B_2", "B_3", "B_4"}},
+	{ddl: "ddl_02_foreign_keys", want: []string{"C_1", "C_2", "C_3", "C_4", "C_5"}},
+	{ddl: "ddl_03_foreign_loop_1", want: []string{"D_1"}},
+	{ddl: "ddl_04_foreign_loop_2", want: []string{"E_1", "E_2"}},
+	{ddl: "ddl_05_foreign_loop_3", want: []string{"F_1", "F_2", "F_3"}},
+	{ddl: "ddl_06_unique_keys", want: []string{"G"}},
+	{ddl: "ddl_10_indexes", want: []string{"L_1", "L_2"}},
+	{ddl: "ddl_11_column_defaults", want: []string{"M"}},
+	{ddl: "ddl_12_checks", want: []string{"N"}},
+	{ddl: "ddl_13_foreign_key_actions", want: []string{"O_1", "O_2"}},
+	{ddl: "ddl_14_views", want: []string{"P_1", "P_2"}},
+	{ddl: "ddl_15_spanner_features", want: []string{"Q_1", "Q_2", "S.Q_3"}},
+}
+
+func TestListTables(t *testing.T) {
+	for number, testcase := range listTablesTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			database := fmt.Sprintf("lister_%0d", number)
+			admin, client, teardown := test.Setup(t, database)
+			defer teardown()
+			test.InitDDLs(t, admin, client.DatabaseName(), ddls[testcase.ddl])
+
+			ctx := context.Background()
+			sut := schema.NewFetcher(client.ReadOnlyTransaction())
+			got, err := sut.ListTables(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+var referencedByTestcases = []struct {
+	ddl   string
+	table string
+	want  []schema.SchemaReference
+}{
+	{ddl: "ddl_00_all_types", table: "A"},
+	{
+		ddl:   "ddl_01_interleave",
+		table: "B_2",
+		want: []schema.SchemaReference{
+			{ReferencingTable: "B_3", ReferencingKey: []string{"PK_11", "PK_21"}, ReferencedKey: []string{"PK_11", "PK_21"}, Interleaved: true},
+			{ReferencingTable: "B_4", ReferencingKey: []string{"PK_11", "PK_21"}, ReferencedKey: []string{"PK_11", "PK_21"}, Interleaved: true},
+		},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_2",
+		want: []schema.SchemaReference{
+			{Name: "FK_C_3_2", ReferencingTable: "C_3", ReferencingKey: []string{"PK_31", "PK_32"}, ReferencedKey: []string{"PK_21", "PK_22"}},
+			{Name: "FK_C_4_2", ReferencingTable: "C_4", ReferencingKey: []string{"PK_41", "PK_42"}, ReferencedKey: []string{"PK_21", "PK_22"}},
+		},
+	},
+	{
+		ddl:   "ddl_03_foreign_loop_1",
+		table: "D_1",
+		want: []schema.SchemaReference{
+			{Name: "FK_D_1_1", ReferencingTable: "D_1", ReferencingKey: []string{"PK_11"}, ReferencedKey: []string{"PK_12"}},
+		},
+	},
+	{
+		ddl:   "ddl_15_spanner_features",
+		table: "Q_1",
+		want: []schema.SchemaReference{
+			{ReferencingTable: "Q_2", ReferencingKey: []string{"PK"}, ReferencedKey: []string{"PK"}, Interleaved: true},
+		},
+	},
+}
+
+func TestFetcher_WithReferencedBy(t *testing.T) {
+	for number, testcase := range referencedByTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			database := fmt.Sprintf("referenced_by_%0d", number)
+			admin, client, teardown := test.Setup(t, database)
+			defer teardown()
+			test.InitDDLs(t, admin, client.DatabaseName(), ddls[testcase.ddl])
+
+			ctx := context.Background()
+			sut := schema.NewFetcher(client.ReadOnlyTransaction()).WithReferencedBy()
+			got, err := sut.Fetch(ctx, testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got.ReferencedBy)
+		})
+	}
+}
+
+var postgreSQLDDLs = map[string][]string{
+	"pg_ddl_00_all_types":  test.Split(testdata.PGDDL00AllTypesSQL),
+	"pg_ddl_01_interleave": test.Split(testdata.PGDDL01InterleaveSQL),
+}
+
+var postgreSQLFetcherTestcases = []struct {
+	ddl   string
+	table string
+	want  schema.SchemaTable
+}{
+	{
+		ddl:   "pg_ddl_00_all_types",
+		table: "pg_a",
+		want: schema.SchemaTable{
+			Name: "pg_a",
+			Columns: []schema.SchemaColumn{
+				{Name: "pk", Type: "bigint", Nullable: false},
+				{Name: "col_01", Type: "boolean", Nullable: true},
+				{Name: "col_02", Type: "boolean", Nullable: false},
+				{Name: "col_03", Type: "bytea", Nullable: true},
+				{Name: "col_04", Type: "bytea", Nullable: false},
+				{Name: "col_05", Type: "date", Nullable: true},
+				{Name: "col_06", Type: "date", Nullable: false},
+				{Name: "col_07", Type: "double precision", Nullable: true},
+				{Name: "col_08", Type: "double precision", Nullable: false},
+				{Name: "col_09", Type: "bigint", Nullable: true},
+				{Name: "col_10", Type: "bigint", Nullable: false},
+				{Name: "col_11", Type: "jsonb", Nullable: true},
+				{Name: "col_12", Type: "jsonb", Nullable: false},
+				{Name: "col_13", Type: "numeric", Nullable: true},
+				{Name: "col_14", Type: "numeric", Nullable: false},
+				{Name: "col_15", Type: "character varying(50)", Nullable: true},
+				{Name: "col_16", Type: "character varying(50)", Nullable: false},
+				{Name: "col_17", Type: "timestamp with time zone", Nullable: true},
+				{Name: "col_18", Type: "timestamp with time zone", Nullable: false},
+				{Name: "col_19", Type: "bigint[]", Nullable: true},
+				{Name: "col_20", Type: "character varying", Nullable: true},
+			},
+			PrimaryKey: []string{"pk"},
+		},
+	},
+	{
+		ddl:   "pg_ddl_01_interleave",
+		table: "pg_b_1",
+		want: schema.SchemaTable{
+			Name: "pg_b_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "pk_11", Type: "bigint"},
+			},
+			PrimaryKey: []string{"pk_11"},
+		},
+	},
+	{
+		ddl:   "pg_ddl_01_interleave",
+		table: "pg_b_2",
+		want: schema.SchemaTable{
+			Name: "pg_b_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "pk_11", Type: "bigint"},
+				{Name: "pk_21", Type: "bigint"},
+				{Name: "created_at", Type: "timestamp with time zone", Nullable: true},
+			},
+			PrimaryKey:        []string{"pk_11", "pk_21"},
+			Parent:            "pg_b_1",
+			ParentOnDelete:    "CASCADE",
+			RowDeletionPolicy: &schema.SchemaRowDeletionPolicy{Column: "created_at", Days: 30},
+			Indexes: []schema.SchemaIndex{
+				{Name: "idx_pg_b_2_created_at", Key: []schema.SchemaIndexKey{{Name: "created_at", Desc: true}}},
+			},
+		},
+	},
+}
+
+func TestFetcher_PostgreSQL(t *testing.T) {
+	for number, testcase := range postgreSQLFetcherTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			database := fmt.Sprintf("pg_fetcher_%0d", number)
+			admin, client, teardown := test.SetupDialect(t, database, spanner_adminpb.DatabaseDialect_POSTGRESQL)
+			defer teardown()
+			test.InitDDLs(t, admin, client.DatabaseName(), postgreSQLDDLs[testcase.ddl])
+
+			ctx := context.Background()
+			sut := schema.NewFetcher(client.ReadOnlyTransaction())
+			got, err := sut.Fetch(ctx, testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+var postgreSQLListTablesTestcases = []struct {
+	ddl  string
+	want []string
+}{
+	{ddl: "pg_ddl_00_all_types", want: []string{"pg_a"}},
+	{ddl: "pg_ddl_01_interleave", want: []string{"pg_b_1", "pg_b_2"}},
+}
+
+func TestListTables_PostgreSQL(t *testing.T) {
+	for number, testcase := range postgreSQLListTablesTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			database := fmt.Sprintf("pg_lister_%0d", number)
+			admin, client, teardown := test.SetupDialect(t, database, spanner_adminpb.DatabaseDialect_POSTGRESQL)
+			defer teardown()
+			test.InitDDLs(t, admin, client.DatabaseName(), postgreSQLDDLs[testcase.ddl])
+
+			ctx := context.Background()
+			sut := schema.NewFetcher(client.ReadOnlyTransaction()).WithDialect(spanner_adminpb.DatabaseDialect_POSTGRESQL)
+			got, err := sut.ListTables(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
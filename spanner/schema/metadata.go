@@ -0,0 +1,359 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/ddl"
+	gf_spanner "github.com/Jumpaku/gotaface/spanner"
+	"github.com/samber/lo"
+)
+
+// SchemaRowDeletionPolicy is a TTL policy declared by ROW DELETION POLICY (OLDER_THAN(column, INTERVAL days DAY)).
+type SchemaRowDeletionPolicy struct {
+	// Column is the TIMESTAMP column compared with the current time.
+	Column string `json:"column"`
+	// Days is the number of days after which rows become eligible for deletion.
+	Days int64 `json:"days"`
+}
+
+// SchemaChangeStream is a change stream watching the table.
+type SchemaChangeStream struct {
+	Name string `json:"name"`
+	// All reports whether the change stream is declared with FOR ALL to watch all the tables.
+	All bool `json:"all"`
+	// Columns are the non-key columns watched by the change stream sorted by name, which are nil if all the columns are watched.
+	Columns []string `json:"columns"`
+}
+
+// SchemaSearchIndex is a search index on TOKENLIST columns.
+// PARTITION BY and ORDER BY clauses of search indexes are not represented.
+type SchemaSearchIndex struct {
+	Name string `json:"name"`
+	// Key is the TOKENLIST columns indexed by the search index.
+	Key []string `json:"key"`
+	// Storing is the columns in the STORING clause sorted by name.
+	Storing []string `json:"storing"`
+	// Interleave is the table in which the search index is interleaved, which is empty if the search index is not interleaved.
+	Interleave string `json:"interleave"`
+}
+
+// SchemaSequence is a sequence used by the default values of columns through GET_NEXT_SEQUENCE_VALUE.
+type SchemaSequence struct {
+	// Name is qualified by the schema if the sequence belongs to a named schema.
+	Name string `json:"name"`
+	// Kind is the sequence kind such as bit_reversed_positive.
+	Kind string `json:"kind"`
+	// SkipRangeMin and SkipRangeMax are the range of values never generated, which are nil if the range is not specified.
+	SkipRangeMin *int64 `json:"skip_range_min"`
+	SkipRangeMax *int64 `json:"skip_range_max"`
+	// StartWithCounter is the initial value of the internal counter, which is nil if it is not specified.
+	StartWithCounter *int64 `json:"start_with_counter"`
+}
+
+// QualifiedName returns the name of the table qualified by the named schema, which is the name itself for the default schema.
+func (table SchemaTable) QualifiedName() string {
+	return qualifiedName(table.Schema, table.Name)
+}
+
+func qualifiedName(schemaName string, name string) string {
+	if schemaName == "" {
+		return name
+	}
+	return schemaName + "." + name
+}
+
+func splitQualifiedName(name string) (schemaName string, unqualified string) {
+	if schemaName, unqualified, found := strings.Cut(name, "."); found {
+		return schemaName, unqualified
+	}
+	return "", name
+}
+
+// parseRowDeletionPolicyExpression parses the expression shown in ROW_DELETION_POLICY_EXPRESSION of INFORMATION_SCHEMA.TABLES,
+// which is INTERVAL 'days DAYS' ON column in PostgreSQL-dialect databases.
+func parseRowDeletionPolicyExpression(expression string) (*SchemaRowDeletionPolicy, error) {
+	if expression == "" {
+		return nil, nil
+	}
+	tokens, err := ddl.Tokenize(expression)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to parse row deletion policy %q: %w`, expression, err)
+	}
+	p := ddl.NewParser(expression, tokens)
+	parse := parseRowDeletionPolicy
+	if p.PeekKeyword("INTERVAL") {
+		parse = parseTTL
+	}
+	policy, err := parse(p)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to parse row deletion policy %q: %w`, expression, err)
+	}
+	return policy, nil
+}
+
+// parseRowDeletionPolicy reads OLDER_THAN(column, INTERVAL days DAY).
+func parseRowDeletionPolicy(p *ddl.Parser) (*SchemaRowDeletionPolicy, error) {
+	if err := p.ExpectKeyword("OLDER_THAN"); err != nil {
+		return nil, err
+	}
+	if err := p.ExpectSymbol("("); err != nil {
+		return nil, err
+	}
+	column, err := parseIdentifier(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ExpectSymbol(","); err != nil {
+		return nil, err
+	}
+	if err := p.ExpectKeyword("INTERVAL"); err != nil {
+		return nil, err
+	}
+	days, err := parseInteger(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ExpectKeyword("DAY"); err != nil {
+		return nil, err
+	}
+	if err := p.ExpectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return &SchemaRowDeletionPolicy{Column: column, Days: days}, nil
+}
+
+// parseTTL reads INTERVAL 'days DAYS' ON column.
+func parseTTL(p *ddl.Parser) (*SchemaRowDeletionPolicy, error) {
+	if err := p.ExpectKeyword("INTERVAL"); err != nil {
+		return nil, err
+	}
+	token, err := p.Next()
+	if err != nil {
+		return nil, err
+	}
+	if token.Kind != ddl.TokenKindString {
+		return nil, fmt.Errorf(`interval is expected but %q appears`, token.Value)
+	}
+	value, unit, _ := strings.Cut(strings.TrimSpace(token.Value), " ")
+	if unit = strings.ToUpper(strings.TrimSpace(unit)); unit != "DAY" && unit != "DAYS" {
+		return nil, fmt.Errorf(`interval in days is expected but %q appears`, token.Value)
+	}
+	days, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to parse interval %q: %w`, token.Value, err)
+	}
+	if err := p.ExpectKeyword("ON"); err != nil {
+		return nil, err
+	}
+	column, err := parseIdentifier(p)
+	if err != nil {
+		return nil, err
+	}
+	return &SchemaRowDeletionPolicy{Column: column, Days: days}, nil
+}
+
+func parseInteger(p *ddl.Parser) (int64, error) {
+	negative := p.Symbol("-")
+	token, err := p.Next()
+	if err != nil {
+		return 0, err
+	}
+	if token.Kind != ddl.TokenKindNumber {
+		return 0, fmt.Errorf(`integer is expected but %q appears`, token.Value)
+	}
+	value, err := strconv.ParseInt(token.Value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf(`fail to parse integer %q: %w`, token.Value, err)
+	}
+	return lo.Ternary(negative, -value, value), nil
+}
+
+// sequenceReferences returns the names of the sequences referenced by GET_NEXT_SEQUENCE_VALUE(SEQUENCE name) in the default values of the columns.
+func sequenceReferences(columns []SchemaColumn) ([]string, error) {
+	var names []string
+	for _, column := range columns {
+		if column.Default == "" {
+			continue
+		}
+		tokens, err := ddl.Tokenize(column.Default)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to parse default value of %s: %w`, column.Name, err)
+		}
+		p := ddl.NewParser(column.Default, tokens)
+		for !p.EOF() {
+			if !p.Keyword("GET_NEXT_SEQUENCE_VALUE") {
+				_, _ = p.Next()
+				continue
+			}
+			if !p.Symbol("(") || !p.Keyword("SEQUENCE") {
+				continue
+			}
+			name, err := parseQualifiedName(p)
+			if err != nil {
+				return nil, fmt.Errorf(`fail to parse default value of %s: %w`, column.Name, err)
+			}
+			names = append(names, name)
+		}
+	}
+	names = lo.Uniq(names)
+	slices.Sort(names)
+	return names, nil
+}
+
+func queryChangeStreams(ctx context.Context, tx dialectQueryer, schemaName string, table string) ([]SchemaChangeStream, error) {
+	sql := `--sql query change streams watching the table
+SELECT
+	cs.CHANGE_STREAM_NAME AS Name,
+	cs.` + "`ALL`" + ` AS WatchesAll,
+	IFNULL(cst.ALL_COLUMNS, TRUE) AS AllColumns,
+	ARRAY(
+		SELECT csc.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.CHANGE_STREAM_COLUMNS csc
+		WHERE csc.CHANGE_STREAM_SCHEMA = cs.CHANGE_STREAM_SCHEMA AND csc.CHANGE_STREAM_NAME = cs.CHANGE_STREAM_NAME
+			AND csc.TABLE_SCHEMA = @Schema AND csc.TABLE_NAME = @Table
+		ORDER BY csc.COLUMN_NAME
+	) AS Columns
+FROM INFORMATION_SCHEMA.CHANGE_STREAMS cs
+	LEFT JOIN INFORMATION_SCHEMA.CHANGE_STREAM_TABLES cst
+	ON cst.CHANGE_STREAM_SCHEMA = cs.CHANGE_STREAM_SCHEMA AND cst.CHANGE_STREAM_NAME = cs.CHANGE_STREAM_NAME
+		AND cst.TABLE_SCHEMA = @Schema AND cst.TABLE_NAME = @Table
+WHERE cs.` + "`ALL`" + ` OR cst.TABLE_NAME IS NOT NULL
+ORDER BY Name`
+	postgreSQL := `--sql query change streams watching the table
+SELECT
+	cs.change_stream_name AS "Name",
+	cs."all" = 'YES' AS "WatchesAll",
+	COALESCE(cst.all_columns = 'YES', TRUE) AS "AllColumns",
+	ARRAY(
+		SELECT csc.column_name
+		FROM information_schema.change_stream_columns csc
+		WHERE csc.change_stream_schema = cs.change_stream_schema AND csc.change_stream_name = cs.change_stream_name
+			AND csc.table_schema = $1 AND csc.table_name = $2
+		ORDER BY csc.column_name
+	) AS "Columns"
+FROM information_schema.change_streams cs
+	LEFT JOIN information_schema.change_stream_tables cst
+	ON cst.change_stream_schema = cs.change_stream_schema AND cst.change_stream_name = cs.change_stream_name
+		AND cst.table_schema = $1 AND cst.table_name = $2
+WHERE cs."all" = 'YES' OR cst.table_name IS NOT NULL
+ORDER BY "Name"`
+	// ALL is a reserved keyword and cannot be used as an alias
+	type changeStreamRow struct {
+		Name       string
+		WatchesAll bool
+		AllColumns bool
+		Columns    []string
+	}
+	changeStreamRows, err := gf_spanner.ScanRowsStruct[changeStreamRow](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get change streams of %s: %w`, table, err)
+	}
+
+	return lo.Map(changeStreamRows, func(changeStreamRow changeStreamRow, _ int) SchemaChangeStream {
+		changeStream := SchemaChangeStream{Name: changeStreamRow.Name, All: changeStreamRow.WatchesAll}
+		if !changeStreamRow.WatchesAll && !changeStreamRow.AllColumns {
+			changeStream.Columns = append([]string{}, changeStreamRow.Columns...)
+		}
+		return changeStream
+	}), nil
+}
+
+func querySearchIndexes(ctx context.Context, tx dialectQueryer, schemaName string, table string) ([]SchemaSearchIndex, error) {
+	sql := `--sql query search index information
+SELECT
+	idx.INDEX_NAME AS Name,
+	IFNULL(idx.PARENT_TABLE_NAME, "") AS Interleave,
+	ARRAY(
+		SELECT idxc.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.INDEX_COLUMNS idxc
+		WHERE idxc.TABLE_SCHEMA = idx.TABLE_SCHEMA AND idxc.TABLE_NAME = idx.TABLE_NAME AND idxc.INDEX_NAME = idx.INDEX_NAME
+			AND idxc.SPANNER_TYPE = 'TOKENLIST'
+		ORDER BY idxc.ORDINAL_POSITION, idxc.COLUMN_NAME
+	) AS Key,
+	ARRAY(
+		SELECT idxc.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.INDEX_COLUMNS idxc
+		WHERE idxc.TABLE_SCHEMA = idx.TABLE_SCHEMA AND idxc.TABLE_NAME = idx.TABLE_NAME AND idxc.INDEX_NAME = idx.INDEX_NAME
+			AND idxc.SPANNER_TYPE <> 'TOKENLIST' AND idxc.ORDINAL_POSITION IS NULL
+		ORDER BY idxc.COLUMN_NAME
+	) AS Storing
+FROM INFORMATION_SCHEMA.INDEXES idx
+WHERE
+	idx.TABLE_SCHEMA = @Schema
+	AND idx.TABLE_NAME = @Table
+	AND idx.INDEX_TYPE = "SEARCH"
+ORDER BY Name`
+	postgreSQL := `--sql query search index information
+SELECT
+	idx.index_name AS "Name",
+	COALESCE(idx.parent_table_name, '') AS "Interleave",
+	ARRAY(
+		SELECT idxc.column_name
+		FROM information_schema.index_columns idxc
+		WHERE idxc.table_schema = idx.table_schema AND idxc.table_name = idx.table_name AND idxc.index_name = idx.index_name
+			AND idxc.spanner_type = 'spanner.tokenlist'
+		ORDER BY idxc.ordinal_position, idxc.column_name
+	) AS "Key",
+	ARRAY(
+		SELECT idxc.column_name
+		FROM information_schema.index_columns idxc
+		WHERE idxc.table_schema = idx.table_schema AND idxc.table_name = idx.table_name AND idxc.index_name = idx.index_name
+			AND idxc.spanner_type <> 'spanner.tokenlist' AND idxc.ordinal_position IS NULL
+		ORDER BY idxc.column_name
+	) AS "Storing"
+FROM information_schema.indexes idx
+WHERE
+	idx.table_schema = $1
+	AND idx.table_name = $2
+	AND idx.index_type = 'SEARCH'
+ORDER BY "Name"`
+	searchIndexes, err := gf_spanner.ScanRowsStruct[SchemaSearchIndex](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"Schema", tx.schemaParam(schemaName)}, param{"Table", table})))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get search indexes of %s: %w`, table, err)
+	}
+	for i, searchIndex := range searchIndexes {
+		if len(searchIndex.Storing) == 0 {
+			searchIndexes[i].Storing = nil
+		}
+	}
+	return searchIndexes, nil
+}
+
+// querySequences returns the sequences of the given names, which are qualified by the schemas if the sequences belong to named schemas.
+// Sequences are not fetched from PostgreSQL-dialect databases, in which default values use nextval instead of GET_NEXT_SEQUENCE_VALUE.
+func querySequences(ctx context.Context, tx dialectQueryer, names []string) ([]SchemaSequence, error) {
+	if len(names) == 0 || tx.postgreSQL {
+		return nil, nil
+	}
+	sql := `--sql query sequence information
+SELECT
+	IF(s.SCHEMA = '', s.NAME, s.SCHEMA || '.' || s.NAME) AS Name,
+	IFNULL((
+		SELECT o.OPTION_VALUE FROM INFORMATION_SCHEMA.SEQUENCE_OPTIONS o
+		WHERE o.SCHEMA = s.SCHEMA AND o.NAME = s.NAME AND o.OPTION_NAME = 'sequence_kind'
+	), '') AS Kind,
+	(
+		SELECT SAFE_CAST(o.OPTION_VALUE AS INT64) FROM INFORMATION_SCHEMA.SEQUENCE_OPTIONS o
+		WHERE o.SCHEMA = s.SCHEMA AND o.NAME = s.NAME AND o.OPTION_NAME = 'skip_range_min'
+	) AS SkipRangeMin,
+	(
+		SELECT SAFE_CAST(o.OPTION_VALUE AS INT64) FROM INFORMATION_SCHEMA.SEQUENCE_OPTIONS o
+		WHERE o.SCHEMA = s.SCHEMA AND o.NAME = s.NAME AND o.OPTION_NAME = 'skip_range_max'
+	) AS SkipRangeMax,
+	(
+		SELECT SAFE_CAST(o.OPTION_VALUE AS INT64) FROM INFORMATION_SCHEMA.SEQUENCE_OPTIONS o
+		WHERE o.SCHEMA = s.SCHEMA AND o.NAME = s.NAME AND o.OPTION_NAME = 'start_with_counter'
+	) AS StartWithCounter
+FROM INFORMATION_SCHEMA.SEQUENCES s
+WHERE IF(s.SCHEMA = '', s.NAME, s.SCHEMA || '.' || s.NAME) IN UNNEST(@Names)
+ORDER BY Name`
+	sequences, err := gf_spanner.ScanRowsStruct[SchemaSequence](tx.Query(ctx, tx.statement(sql, "", param{"Names", names})))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get sequences %v: %w`, names, err)
+	}
+	return sequences, nil
+}
@@ -0,0 +1,274 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+// ConvertTable converts the table into the dialect-neutral schema.
+func ConvertTable(table SchemaTable) schema.Table {
+	return schema.Table{
+		Name: table.Name,
+		Columns: lo.Map(table.Columns, func(column SchemaColumn, _ int) schema.Column {
+			return schema.Column{
+				Name:       column.Name,
+				Type:       ConvertType(column.Type),
+				NativeType: column.Type,
+				Nullable:   column.Nullable,
+				Default:    column.Default,
+				Generated:  column.Generated,
+				Stored:     column.Stored,
+			}
+		}),
+		PrimaryKey: table.PrimaryKey,
+		Parent:     table.Parent,
+		ForeignKeys: lo.Map(table.ForeignKeys, func(key SchemaForeignKey, _ int) schema.ForeignKey {
+			return schema.ForeignKey{
+				Name:            key.Name,
+				ReferencedTable: key.ReferencedTable,
+				ReferencedKey:   key.ReferencedKey,
+				ReferencingKey:  key.ReferencingKey,
+				OnDelete:        key.OnDelete,
+			}
+		}),
+		UniqueKeys: lo.Map(table.UniqueKeys, func(key SchemaUniqueKey, _ int) schema.UniqueKey {
+			return schema.UniqueKey{Name: key.Name, Key: key.Key}
+		}),
+		Indexes: lo.Map(table.Indexes, func(index SchemaIndex, _ int) schema.Index {
+			return schema.Index{
+				Name:   index.Name,
+				Unique: index.Unique,
+				Key: lo.Map(index.Key, func(key SchemaIndexKey, _ int) schema.IndexKey {
+					return schema.IndexKey{Name: key.Name, Desc: key.Desc}
+				}),
+			}
+		}),
+		Checks: lo.Map(table.Checks, func(check SchemaCheck, _ int) schema.Check {
+			return schema.Check{Name: check.Name, Expression: check.Expression}
+		}),
+	}
+}
+
+// ConvertType converts the Spanner type such as STRING(MAX) and ARRAY<INT64> into the logical type.
+// NUMERIC is converted into decimal(38,9) since its precision and scale are fixed in Spanner.
+// Types of PostgreSQL-dialect databases are converted after they are normalized by GoogleSQLType.
+func ConvertType(spannerType string) schema.Type {
+	spannerType = GoogleSQLType(spannerType)
+	if elementType, found := strings.CutPrefix(spannerType, "ARRAY<"); found {
+		elem := ConvertType(strings.TrimSuffix(elementType, ">"))
+		return schema.Type{Kind: schema.TypeKindArray, Elem: &elem}
+	}
+	typeName, length, _ := strings.Cut(spannerType, "(")
+	length = strings.TrimSuffix(length, ")")
+	switch typeName {
+	case "INT64":
+		return schema.Type{Kind: schema.TypeKindInteger}
+	case "FLOAT32", "FLOAT64":
+		return schema.Type{Kind: schema.TypeKindFloat}
+	case "NUMERIC":
+		return schema.Type{Kind: schema.TypeKindDecimal, Precision: 38, Scale: 9}
+	case "STRING":
+		return schema.Type{Kind: schema.TypeKindString, Length: maxLength(length)}
+	case "BYTES":
+		return schema.Type{Kind: schema.TypeKindBytes, Length: maxLength(length)}
+	case "BOOL":
+		return schema.Type{Kind: schema.TypeKindBool}
+	case "DATE":
+		return schema.Type{Kind: schema.TypeKindDate}
+	case "TIMESTAMP":
+		return schema.Type{Kind: schema.TypeKindTimestamp}
+	case "JSON":
+		return schema.Type{Kind: schema.TypeKindJSON}
+	default:
+		return schema.Type{Kind: schema.TypeKindOther}
+	}
+}
+
+// GoogleSQLType returns the GoogleSQL type corresponding to the type of a PostgreSQL-dialect database such as character varying(50) and bigint[].
+// Types that are not PostgreSQL type names are returned as they are.
+func GoogleSQLType(postgreSQLType string) string {
+	if elementType, found := strings.CutSuffix(postgreSQLType, "[]"); found {
+		return "ARRAY<" + GoogleSQLType(elementType) + ">"
+	}
+	typeName, length, found := strings.Cut(postgreSQLType, "(")
+	switch typeName {
+	case "bigint", "int8":
+		return "INT64"
+	case "boolean", "bool":
+		return "BOOL"
+	case "bytea":
+		return "BYTES(MAX)"
+	case "character varying", "varchar", "text":
+		if !found {
+			return "STRING(MAX)"
+		}
+		return "STRING(" + length
+	case "date":
+		return "DATE"
+	case "double precision", "float8":
+		return "FLOAT64"
+	case "real", "float4":
+		return "FLOAT32"
+	case "jsonb":
+		return "JSON"
+	case "numeric", "decimal":
+		return "NUMERIC"
+	case "timestamp with time zone", "timestamptz", "spanner.commit_timestamp":
+		return "TIMESTAMP"
+	case "spanner.tokenlist":
+		return "TOKENLIST"
+	default:
+		return postgreSQLType
+	}
+}
+
+// maxLength returns the length of STRING or BYTES, which is 0 for MAX.
+func maxLength(length string) int64 {
+	n, err := strconv.ParseInt(length, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// ConvertFromTables converts the dialect-neutral tables into tables in Spanner with warnings on parts that cannot be converted.
+// Unnamed unique keys are named after the table and the columns since they are created as unique indexes, and expressions are converted as written.
+func ConvertFromTables(tables []schema.Table) ([]SchemaTable, []schema.Warning) {
+	var warnings []schema.Warning
+	warn := func(table schema.Table, format string, args ...any) {
+		warnings = append(warnings, schema.Warning{Table: table.Name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	converted := []SchemaTable{}
+	for _, table := range tables {
+		if table.Schema != "" && table.Schema != "public" {
+			warn(table, "schema %s is ignored", table.Schema)
+		}
+		if len(table.PrimaryKey) == 0 {
+			warn(table, "table without primary key is converted into a table with the empty primary key, which has at most one row")
+		}
+		spannerTable := SchemaTable{
+			Name:       table.Name,
+			PrimaryKey: table.PrimaryKey,
+			Parent:     table.Parent,
+		}
+		for _, column := range table.Columns {
+			columnType, ok := ConvertFromType(column.Type)
+			if !ok {
+				warn(table, "type %s of column %s is converted into %s", column.NativeType, column.Name, columnType)
+			}
+			spannerColumn := SchemaColumn{
+				Name:      column.Name,
+				Type:      columnType,
+				Nullable:  column.Nullable,
+				Default:   column.Default,
+				Generated: column.Generated,
+				Stored:    column.Stored,
+			}
+			if column.AutoIncrement {
+				warn(table, "auto-increment of column %s is ignored", column.Name)
+				spannerColumn.Default = ""
+			}
+			spannerTable.Columns = append(spannerTable.Columns, spannerColumn)
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			spannerForeignKey := SchemaForeignKey{
+				Name:            foreignKey.Name,
+				ReferencedTable: foreignKey.ReferencedTable,
+				ReferencedKey:   foreignKey.ReferencedKey,
+				ReferencingKey:  foreignKey.ReferencingKey,
+			}
+			switch foreignKey.OnDelete {
+			case "", "CASCADE":
+				spannerForeignKey.OnDelete = foreignKey.OnDelete
+			default:
+				warn(table, "ON DELETE %s of foreign key %s is ignored", foreignKey.OnDelete, foreignKeyLabel(foreignKey))
+			}
+			if foreignKey.OnUpdate != "" {
+				warn(table, "ON UPDATE %s of foreign key %s is ignored", foreignKey.OnUpdate, foreignKeyLabel(foreignKey))
+			}
+			spannerTable.ForeignKeys = append(spannerTable.ForeignKeys, spannerForeignKey)
+		}
+		for _, uniqueKey := range table.UniqueKeys {
+			name := uniqueKey.Name
+			if name == "" {
+				name = strings.Join(append([]string{table.Name}, uniqueKey.Key...), "_") + "_key"
+			}
+			spannerTable.UniqueKeys = append(spannerTable.UniqueKeys, SchemaUniqueKey{Name: name, Key: uniqueKey.Key})
+		}
+		for _, index := range table.Indexes {
+			if lo.SomeBy(index.Key, func(key schema.IndexKey) bool { return key.Expression != "" }) {
+				warn(table, "index %s on expressions is ignored", index.Name)
+				continue
+			}
+			if index.Predicate != "" {
+				warn(table, "predicate of partial index %s is ignored", index.Name)
+			}
+			spannerTable.Indexes = append(spannerTable.Indexes, SchemaIndex{
+				Name:   index.Name,
+				Unique: index.Unique,
+				Key: lo.Map(index.Key, func(key schema.IndexKey, _ int) SchemaIndexKey {
+					return SchemaIndexKey{Name: key.Name, Desc: key.Desc}
+				}),
+			})
+		}
+		for _, check := range table.Checks {
+			spannerTable.Checks = append(spannerTable.Checks, SchemaCheck{Name: check.Name, Expression: check.Expression})
+		}
+		converted = append(converted, spannerTable)
+	}
+	return converted, warnings
+}
+
+// foreignKeyLabel returns the name of the foreign key, or its referencing columns if it is not named.
+func foreignKeyLabel(foreignKey schema.ForeignKey) string {
+	if foreignKey.Name != "" {
+		return foreignKey.Name
+	}
+	return "(" + strings.Join(foreignKey.ReferencingKey, ", ") + ")"
+}
+
+// ConvertFromType returns the Spanner type corresponding to the logical type.
+// It returns STRING(MAX) and false if the logical type has no counterpart such as arrays of arrays, and NUMERIC and false if the decimal exceeds the range of NUMERIC.
+func ConvertFromType(t schema.Type) (string, bool) {
+	switch t.Kind {
+	case schema.TypeKindInteger:
+		return "INT64", true
+	case schema.TypeKindFloat:
+		return "FLOAT64", true
+	case schema.TypeKindDecimal:
+		// NUMERIC has 29 digits before the decimal point and 9 digits after it.
+		return "NUMERIC", t.Scale <= 9 && t.Precision-t.Scale <= 29
+	case schema.TypeKindString:
+		return fmt.Sprintf("STRING(%s)", lengthOrMax(t.Length)), true
+	case schema.TypeKindBytes:
+		return fmt.Sprintf("BYTES(%s)", lengthOrMax(t.Length)), true
+	case schema.TypeKindBool:
+		return "BOOL", true
+	case schema.TypeKindDate:
+		return "DATE", true
+	case schema.TypeKindTimestamp:
+		return "TIMESTAMP", true
+	case schema.TypeKindJSON:
+		return "JSON", true
+	case schema.TypeKindArray:
+		if t.Elem == nil || t.Elem.Kind == schema.TypeKindArray {
+			return "STRING(MAX)", false
+		}
+		elemType, ok := ConvertFromType(*t.Elem)
+		return "ARRAY<" + elemType + ">", ok
+	default:
+		return "STRING(MAX)", false
+	}
+}
+
+func lengthOrMax(length int64) string {
+	if length > 0 {
+		return strconv.FormatInt(length, 10)
+	}
+	return "MAX"
+}
@@ -0,0 +1,103 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertType(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want gf_schema.Type
+	}{
+		{in: "INT64", want: gf_schema.Type{Kind: gf_schema.TypeKindInteger}},
+		{in: "FLOAT32", want: gf_schema.Type{Kind: gf_schema.TypeKindFloat}},
+		{in: "NUMERIC", want: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 38, Scale: 9}},
+		{in: "STRING(50)", want: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 50}},
+		{in: "STRING(MAX)", want: gf_schema.Type{Kind: gf_schema.TypeKindString}},
+		{in: "BYTES(16)", want: gf_schema.Type{Kind: gf_schema.TypeKindBytes, Length: 16}},
+		{in: "BOOL", want: gf_schema.Type{Kind: gf_schema.TypeKindBool}},
+		{in: "DATE", want: gf_schema.Type{Kind: gf_schema.TypeKindDate}},
+		{in: "TIMESTAMP", want: gf_schema.Type{Kind: gf_schema.TypeKindTimestamp}},
+		{in: "JSON", want: gf_schema.Type{Kind: gf_schema.TypeKindJSON}},
+		{in: "ARRAY<STRING(10)>", want: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}}},
+		{in: "TOKENLIST", want: gf_schema.Type{Kind: gf_schema.TypeKindOther}},
+		{in: "bigint", want: gf_schema.Type{Kind: gf_schema.TypeKindInteger}},
+		{in: "character varying(50)", want: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 50}},
+		{in: "character varying", want: gf_schema.Type{Kind: gf_schema.TypeKindString}},
+		{in: "timestamp with time zone", want: gf_schema.Type{Kind: gf_schema.TypeKindTimestamp}},
+		{in: "jsonb", want: gf_schema.Type{Kind: gf_schema.TypeKindJSON}},
+		{in: "double precision[]", want: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindFloat}}},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			got := schema.ConvertType(testcase.in)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestConvertFromType(t *testing.T) {
+	testcases := []struct {
+		in     gf_schema.Type
+		want   string
+		wantOk bool
+	}{
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, want: "INT64", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindString}, want: "STRING(MAX)", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindBytes, Length: 16}, want: "BYTES(16)", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 65, Scale: 30}, want: "NUMERIC", wantOk: false},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}}, want: "ARRAY<STRING(10)>", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindArray}}, want: "STRING(MAX)", wantOk: false},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			got, ok := schema.ConvertFromType(testcase.in)
+			assert.Equal(t, testcase.want, got)
+			assert.Equal(t, testcase.wantOk, ok)
+		})
+	}
+}
+
+func TestConvertTable(t *testing.T) {
+	sut, err := schema.NewDDLFetcher(`CREATE TABLE Parent (Id INT64 NOT NULL) PRIMARY KEY (Id);
+CREATE TABLE Child (
+	Id INT64 NOT NULL,
+	ChildId INT64 NOT NULL,
+	Tags ARRAY<STRING(MAX)>,
+	Price NUMERIC DEFAULT (0),
+	CONSTRAINT CK_Price CHECK (Price >= 0),
+) PRIMARY KEY (Id, ChildId), INTERLEAVE IN PARENT Parent ON DELETE CASCADE;
+CREATE INDEX IDX_Child_Price ON Child (Price DESC)`)
+	assert.Nil(t, err)
+	table, err := sut.Fetch(context.Background(), "Child")
+	assert.Nil(t, err)
+
+	got := schema.ConvertTable(table)
+
+	want := gf_schema.Table{
+		Name: "Child",
+		Columns: []gf_schema.Column{
+			{Name: "Id", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "INT64"},
+			{Name: "ChildId", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "INT64"},
+			{Name: "Tags", Type: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindString}}, NativeType: "ARRAY<STRING(MAX)>", Nullable: true},
+			{Name: "Price", Type: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 38, Scale: 9}, NativeType: "NUMERIC", Nullable: true, Default: "0"},
+		},
+		PrimaryKey:  []string{"Id", "ChildId"},
+		Parent:      "Parent",
+		ForeignKeys: []gf_schema.ForeignKey{},
+		UniqueKeys:  []gf_schema.UniqueKey{},
+		Indexes: []gf_schema.Index{
+			{Name: "IDX_Child_Price", Key: []gf_schema.IndexKey{{Name: "Price", Desc: true}}},
+		},
+		Checks: []gf_schema.Check{{Name: "CK_Price", Expression: "Price >= 0"}},
+	}
+	assert.Equal(t, want, got)
+}
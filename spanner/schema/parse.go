@@ -0,0 +1,1047 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/ddl"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+type ddlFetcher struct {
+	tables        map[string]*SchemaTable
+	views         map[string]*SchemaView
+	changeStreams map[string]*ddlChangeStream
+	sequences     map[string]*SchemaSequence
+	referencedBy  bool
+}
+
+// ddlChangeStream is a change stream declared by CREATE CHANGE STREAM.
+type ddlChangeStream struct {
+	all bool
+	// tables maps qualified names of the watched tables to the watched non-key columns, which are nil if all the columns are watched.
+	tables map[string][]string
+}
+
+// NewDDLFetcher parses DDL statements and returns a fetcher that provides schemas of the defined tables without querying a database.
+// CREATE, ALTER, and DROP statements for tables, indexes, search indexes, views, change streams, and sequences are interpreted in order and the other statements are ignored.
+// Tables in named schemas are referred to by names qualified by the schemas.
+// Names of foreign keys and CHECK constraints without CONSTRAINT clauses are empty because they are generated by Spanner.
+// Default values and generation expressions are kept as written in the DDL statements.
+// Columns of views are not provided because they are determined by the queries.
+func NewDDLFetcher(ddlStatements string) (ddlFetcher, error) {
+	tokens, err := ddl.Tokenize(ddlStatements)
+	if err != nil {
+		return ddlFetcher{}, fmt.Errorf(`fail to parse DDL: %w`, err)
+	}
+
+	parser := ddlParser{
+		tables:        map[string]*SchemaTable{},
+		views:         map[string]*SchemaView{},
+		changeStreams: map[string]*ddlChangeStream{},
+		sequences:     map[string]*SchemaSequence{},
+	}
+	for _, stmt := range ddl.Split(tokens) {
+		if err := parser.parseStatement(ddl.NewParser(ddlStatements, stmt)); err != nil {
+			return ddlFetcher{}, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+	}
+
+	return ddlFetcher{
+		tables:        parser.tables,
+		views:         parser.views,
+		changeStreams: parser.changeStreams,
+		sequences:     parser.sequences,
+	}, nil
+}
+
+// WithReferencedBy returns a copy of the fetcher that also provides foreign keys referencing the table and interleaved child tables in ReferencedBy.
+func (fetcher ddlFetcher) WithReferencedBy() ddlFetcher {
+	fetcher.referencedBy = true
+	return fetcher
+}
+
+var _ schema.Fetcher[SchemaTable] = ddlFetcher{}
+var _ schema.Lister = ddlFetcher{}
+
+func (fetcher ddlFetcher) Fetch(ctx context.Context, table string) (SchemaTable, error) {
+	t, found := fetcher.tables[table]
+	if _, isView := fetcher.views[table]; isView {
+		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: %s is a view`, table, table)
+	}
+	if !found {
+		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: table %q not found`, table, table)
+	}
+
+	schemaTable := *t
+	schemaTable.Columns = slices.Clone(t.Columns)
+	schemaTable.PrimaryKey = slices.Clone(t.PrimaryKey)
+	schemaTable.ForeignKeys = slices.Clone(t.ForeignKeys)
+	slices.SortStableFunc(schemaTable.ForeignKeys, func(a, b SchemaForeignKey) int { return strings.Compare(a.Name, b.Name) })
+	schemaTable.UniqueKeys = slices.Clone(t.UniqueKeys)
+	slices.SortStableFunc(schemaTable.UniqueKeys, func(a, b SchemaUniqueKey) int { return strings.Compare(a.Name, b.Name) })
+	schemaTable.Indexes = slices.Clone(t.Indexes)
+	slices.SortStableFunc(schemaTable.Indexes, func(a, b SchemaIndex) int { return strings.Compare(a.Name, b.Name) })
+	schemaTable.SearchIndexes = slices.Clone(t.SearchIndexes)
+	slices.SortStableFunc(schemaTable.SearchIndexes, func(a, b SchemaSearchIndex) int { return strings.Compare(a.Name, b.Name) })
+	schemaTable.Checks = slices.Clone(t.Checks)
+	slices.SortStableFunc(schemaTable.Checks, func(a, b SchemaCheck) int { return strings.Compare(a.Name, b.Name) })
+	if t.RowDeletionPolicy != nil {
+		policy := *t.RowDeletionPolicy
+		schemaTable.RowDeletionPolicy = &policy
+	}
+	schemaTable.ChangeStreams = fetcher.changeStreamsOf(t)
+	sequences, err := fetcher.sequencesOf(t)
+	if err != nil {
+		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: %w`, table, err)
+	}
+	schemaTable.Sequences = sequences
+
+	if fetcher.referencedBy {
+		schemaTable.ReferencedBy = fetcher.referencedByOf(t)
+	}
+
+	return schemaTable, nil
+}
+
+// changeStreamsOf returns the change streams watching the table sorted by name.
+func (fetcher ddlFetcher) changeStreamsOf(t *SchemaTable) []SchemaChangeStream {
+	names := lo.Keys(fetcher.changeStreams)
+	slices.Sort(names)
+	var changeStreams []SchemaChangeStream
+	for _, name := range names {
+		changeStream := fetcher.changeStreams[name]
+		if changeStream.all {
+			changeStreams = append(changeStreams, SchemaChangeStream{Name: name, All: true})
+			continue
+		}
+		if columns, found := changeStream.tables[t.QualifiedName()]; found {
+			changeStreams = append(changeStreams, SchemaChangeStream{Name: name, Columns: slices.Clone(columns)})
+		}
+	}
+	return changeStreams
+}
+
+// sequencesOf returns the declared sequences used by the default values of the columns of the table sorted by name.
+func (fetcher ddlFetcher) sequencesOf(t *SchemaTable) ([]SchemaSequence, error) {
+	names, err := sequenceReferences(t.Columns)
+	if err != nil {
+		return nil, err
+	}
+	var sequences []SchemaSequence
+	for _, name := range names {
+		if sequence, found := fetcher.sequences[name]; found {
+			sequences = append(sequences, *sequence)
+		}
+	}
+	return sequences, nil
+}
+
+// referencedByOf returns foreign keys referencing the table and interleaved child tables in the same order as the fetcher querying a database.
+func (fetcher ddlFetcher) referencedByOf(t *SchemaTable) []SchemaReference {
+	tables := lo.Keys(fetcher.tables)
+	slices.Sort(tables)
+	var references []SchemaReference
+	for _, name := range tables {
+		referencing := fetcher.tables[name]
+		if referencing.Schema == t.Schema && referencing.Parent == t.Name {
+			references = append(references, SchemaReference{
+				ReferencingTable: name,
+				ReferencingKey:   slices.Clone(t.PrimaryKey),
+				ReferencedKey:    slices.Clone(t.PrimaryKey),
+				Interleaved:      true,
+			})
+		}
+		foreignKeys := lo.Filter(referencing.ForeignKeys, func(it SchemaForeignKey, _ int) bool { return it.ReferencedTable == t.QualifiedName() })
+		slices.SortStableFunc(foreignKeys, func(a, b SchemaForeignKey) int { return strings.Compare(a.Name, b.Name) })
+		for _, foreignKey := range foreignKeys {
+			references = append(references, SchemaReference{
+				Name:             foreignKey.Name,
+				ReferencingTable: name,
+				ReferencingKey:   slices.Clone(foreignKey.ReferencingKey),
+				ReferencedKey:    slices.Clone(foreignKey.ReferencedKey),
+			})
+		}
+	}
+	return references
+}
+
+func (fetcher ddlFetcher) ListTables(ctx context.Context) ([]string, error) {
+	tables := lo.Keys(fetcher.tables)
+	slices.Sort(tables)
+	return tables, nil
+}
+
+var _ schema.ViewFetcher[SchemaView] = ddlFetcher{}
+var _ schema.ViewLister = ddlFetcher{}
+
+func (fetcher ddlFetcher) FetchView(ctx context.Context, view string) (SchemaView, error) {
+	v, found := fetcher.views[view]
+	if !found {
+		return SchemaView{}, fmt.Errorf(`fail to fetch view %s: view %q not found`, view, view)
+	}
+
+	schemaView := *v
+	names := append(lo.Keys(fetcher.tables), lo.Keys(fetcher.views)...)
+	names = lo.Reject(names, func(it string, _ int) bool { return it == view })
+	dependencies, err := viewDependencies(v.Definition, names)
+	if err != nil {
+		return SchemaView{}, fmt.Errorf(`fail to fetch view %s: %w`, view, err)
+	}
+	schemaView.Dependencies = dependencies
+
+	return schemaView, nil
+}
+
+func (fetcher ddlFetcher) ListViews(ctx context.Context) ([]string, error) {
+	views := lo.Keys(fetcher.views)
+	slices.Sort(views)
+	return views, nil
+}
+
+type ddlParser struct {
+	tables        map[string]*SchemaTable
+	views         map[string]*SchemaView
+	changeStreams map[string]*ddlChangeStream
+	sequences     map[string]*SchemaSequence
+}
+
+func (parser ddlParser) parseStatement(p *ddl.Parser) error {
+	switch {
+	case p.Keyword("CREATE", "TABLE"):
+		return parser.parseCreateTable(p)
+	case p.PeekKeyword("CREATE", "UNIQUE"), p.PeekKeyword("CREATE", "NULL_FILTERED"), p.PeekKeyword("CREATE", "INDEX"):
+		_ = p.Keyword("CREATE")
+		unique := p.Keyword("UNIQUE")
+		nullFiltered := p.Keyword("NULL_FILTERED")
+		if p.Keyword("INDEX") {
+			return parser.parseCreateIndex(p, unique, nullFiltered)
+		}
+	case p.Keyword("CREATE", "SEARCH", "INDEX"):
+		return parser.parseCreateSearchIndex(p)
+	case p.Keyword("CREATE", "CHANGE", "STREAM"):
+		return parser.parseCreateChangeStream(p)
+	case p.Keyword("CREATE", "SEQUENCE"):
+		return parser.parseCreateSequence(p)
+	case p.Keyword("CREATE", "VIEW"):
+		return parser.parseCreateView(p, false)
+	case p.Keyword("CREATE", "OR", "REPLACE", "VIEW"):
+		return parser.parseCreateView(p, true)
+	case p.Keyword("ALTER", "TABLE"):
+		return parser.parseAlterTable(p)
+	case p.Keyword("ALTER", "INDEX"):
+		return parser.parseAlterIndex(p)
+	case p.Keyword("ALTER", "SEARCH", "INDEX"):
+		return parser.parseAlterSearchIndex(p)
+	case p.Keyword("ALTER", "CHANGE", "STREAM"):
+		return parser.parseAlterChangeStream(p)
+	case p.Keyword("ALTER", "SEQUENCE"):
+		return parser.parseAlterSequence(p)
+	case p.Keyword("DROP", "TABLE"):
+		_ = p.Keyword("IF", "EXISTS")
+		name, err := parseQualifiedName(p)
+		if err != nil {
+			return err
+		}
+		delete(parser.tables, name)
+	case p.Keyword("DROP", "VIEW"):
+		_ = p.Keyword("IF", "EXISTS")
+		name, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		delete(parser.views, name)
+	case p.Keyword("DROP", "INDEX"):
+		_ = p.Keyword("IF", "EXISTS")
+		name, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		for _, table := range parser.tables {
+			table.UniqueKeys = lo.Reject(table.UniqueKeys, func(it SchemaUniqueKey, _ int) bool { return it.Name == name })
+			table.Indexes = lo.Reject(table.Indexes, func(it SchemaIndex, _ int) bool { return it.Name == name })
+		}
+	case p.Keyword("DROP", "SEARCH", "INDEX"):
+		_ = p.Keyword("IF", "EXISTS")
+		name, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		for _, table := range parser.tables {
+			table.SearchIndexes = lo.Reject(table.SearchIndexes, func(it SchemaSearchIndex, _ int) bool { return it.Name == name })
+		}
+	case p.Keyword("DROP", "CHANGE", "STREAM"):
+		name, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		delete(parser.changeStreams, name)
+	case p.Keyword("DROP", "SEQUENCE"):
+		_ = p.Keyword("IF", "EXISTS")
+		name, err := parseQualifiedName(p)
+		if err != nil {
+			return err
+		}
+		delete(parser.sequences, name)
+	}
+	return nil
+}
+
+func (parser ddlParser) parseCreateTable(p *ddl.Parser) error {
+	ifNotExists := p.Keyword("IF", "NOT", "EXISTS")
+	name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	if err := p.ExpectSymbol("("); err != nil {
+		return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+	}
+
+	schemaName, tableName := splitQualifiedName(name)
+	table := &SchemaTable{Name: tableName, Schema: schemaName}
+	// trailing commas are allowed in table elements
+	for !p.Symbol(")") {
+		if err := parser.parseTableElement(p, table); err != nil {
+			return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+		}
+		if !p.Symbol(",") {
+			if err := p.ExpectSymbol(")"); err != nil {
+				return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+			}
+			break
+		}
+	}
+
+	if err := p.ExpectKeyword("PRIMARY", "KEY"); err != nil {
+		return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+	}
+	if table.PrimaryKey, err = parseKeyList(p); err != nil {
+		return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+	}
+	for p.Symbol(",") {
+		switch {
+		case p.Keyword("INTERLEAVE", "IN", "PARENT"), p.Keyword("INTERLEAVE", "IN"):
+			parent, err := parseQualifiedName(p)
+			if err != nil {
+				return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+			}
+			_, table.Parent = splitQualifiedName(parent)
+			if p.Keyword("ON", "DELETE", "CASCADE") {
+				table.ParentOnDelete = "CASCADE"
+			}
+		case p.Keyword("ROW", "DELETION", "POLICY"):
+			if table.RowDeletionPolicy, err = parseRowDeletionPolicyClause(p); err != nil {
+				return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+			}
+		}
+		// skips ON DELETE NO ACTION clause
+		if err := p.SkipUntil(nil); err != nil {
+			return err
+		}
+	}
+
+	if _, found := parser.tables[name]; found {
+		if ifNotExists {
+			return nil
+		}
+		return fmt.Errorf(`table %s already exists`, name)
+	}
+	parser.tables[name] = table
+
+	return nil
+}
+
+func (parser ddlParser) parseCreateView(p *ddl.Parser, orReplace bool) error {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	if err := p.ExpectKeyword("SQL", "SECURITY"); err != nil {
+		return fmt.Errorf(`fail to parse CREATE VIEW %s: %w`, name, err)
+	}
+	view := &SchemaView{Name: name, SecurityInvoker: p.Keyword("INVOKER")}
+	if !view.SecurityInvoker {
+		if err := p.ExpectKeyword("DEFINER"); err != nil {
+			return fmt.Errorf(`fail to parse CREATE VIEW %s: %w`, name, err)
+		}
+	}
+	if err := p.ExpectKeyword("AS"); err != nil {
+		return fmt.Errorf(`fail to parse CREATE VIEW %s: %w`, name, err)
+	}
+	begin := p.Pos()
+	for !p.EOF() {
+		if err := p.Skip(); err != nil {
+			return fmt.Errorf(`fail to parse CREATE VIEW %s: %w`, name, err)
+		}
+	}
+	view.Definition = p.Text(begin, p.Pos())
+
+	if _, found := parser.views[name]; found && !orReplace {
+		return fmt.Errorf(`view %s already exists`, name)
+	}
+	parser.views[name] = view
+
+	return nil
+}
+
+func (parser ddlParser) parseCreateIndex(p *ddl.Parser, unique bool, nullFiltered bool) error {
+	_ = p.Keyword("IF", "NOT", "EXISTS")
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	if err := p.ExpectKeyword("ON"); err != nil {
+		return err
+	}
+	tableName, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	table, found := parser.tables[tableName]
+	if !found {
+		return fmt.Errorf(`fail to parse CREATE INDEX %s: table %s not found`, name, tableName)
+	}
+
+	index := SchemaIndex{Name: name, Unique: unique, NullFiltered: nullFiltered}
+	if index.Key, err = parseIndexKeyList(p); err != nil {
+		return fmt.Errorf(`fail to parse CREATE INDEX %s: %w`, name, err)
+	}
+	if p.Keyword("STORING") {
+		if index.Storing, err = parseKeyList(p); err != nil {
+			return fmt.Errorf(`fail to parse CREATE INDEX %s: %w`, name, err)
+		}
+		slices.Sort(index.Storing)
+	}
+	if p.Symbol(",") && p.Keyword("INTERLEAVE", "IN") {
+		interleave, err := parseQualifiedName(p)
+		if err != nil {
+			return fmt.Errorf(`fail to parse CREATE INDEX %s: %w`, name, err)
+		}
+		_, index.Interleave = splitQualifiedName(interleave)
+	}
+
+	table.Indexes = append(table.Indexes, index)
+	if unique {
+		table.UniqueKeys = append(table.UniqueKeys, SchemaUniqueKey{
+			Name: name,
+			Key:  lo.Map(index.Key, func(it SchemaIndexKey, _ int) string { return it.Name }),
+		})
+	}
+	return nil
+}
+
+func (parser ddlParser) parseAlterIndex(p *ddl.Parser) error {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	for _, table := range parser.tables {
+		i := slices.IndexFunc(table.Indexes, func(it SchemaIndex) bool { return it.Name == name })
+		if i < 0 {
+			continue
+		}
+		index := &table.Indexes[i]
+		switch {
+		case p.Keyword("ADD", "STORED", "COLUMN"):
+			column, err := parseIdentifier(p)
+			if err != nil {
+				return err
+			}
+			index.Storing = append(slices.Clone(index.Storing), column)
+			slices.Sort(index.Storing)
+		case p.Keyword("DROP", "STORED", "COLUMN"):
+			column, err := parseIdentifier(p)
+			if err != nil {
+				return err
+			}
+			index.Storing = lo.Without(index.Storing, column)
+			if len(index.Storing) == 0 {
+				index.Storing = nil
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf(`fail to parse ALTER INDEX %s: index not found`, name)
+}
+
+func (parser ddlParser) parseAlterTable(p *ddl.Parser) error {
+	name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	table, found := parser.tables[name]
+	if !found {
+		return fmt.Errorf(`fail to parse ALTER TABLE %s: table not found`, name)
+	}
+
+	switch {
+	case p.Keyword("ADD", "ROW", "DELETION", "POLICY"), p.Keyword("REPLACE", "ROW", "DELETION", "POLICY"):
+		if table.RowDeletionPolicy, err = parseRowDeletionPolicyClause(p); err != nil {
+			return fmt.Errorf(`fail to parse ALTER TABLE %s: %w`, name, err)
+		}
+	case p.Keyword("DROP", "ROW", "DELETION", "POLICY"):
+		table.RowDeletionPolicy = nil
+	case p.Keyword("SET", "ON", "DELETE", "CASCADE"):
+		table.ParentOnDelete = "CASCADE"
+	case p.Keyword("SET", "ON", "DELETE", "NO", "ACTION"):
+		table.ParentOnDelete = ""
+	case p.PeekKeyword("ADD", "CONSTRAINT"), p.PeekKeyword("ADD", "FOREIGN"), p.PeekKeyword("ADD", "CHECK"):
+		_ = p.Keyword("ADD")
+		if err := parser.parseTableElement(p, table); err != nil {
+			return fmt.Errorf(`fail to parse ALTER TABLE %s: %w`, name, err)
+		}
+	case p.Keyword("ADD"):
+		_ = p.Keyword("COLUMN")
+		_ = p.Keyword("IF", "NOT", "EXISTS")
+		if err := parser.parseColumnDefinition(p, table); err != nil {
+			return fmt.Errorf(`fail to parse ALTER TABLE %s: %w`, name, err)
+		}
+	case p.Keyword("DROP", "CONSTRAINT"):
+		constraint, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		table.ForeignKeys = lo.Reject(table.ForeignKeys, func(it SchemaForeignKey, _ int) bool { return it.Name == constraint })
+		table.Checks = lo.Reject(table.Checks, func(it SchemaCheck, _ int) bool { return it.Name == constraint })
+	case p.Keyword("DROP", "COLUMN"):
+		column, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		table.Columns = lo.Reject(table.Columns, func(it SchemaColumn, _ int) bool { return it.Name == column })
+	case p.Keyword("ALTER", "COLUMN"):
+		column, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		index := slices.IndexFunc(table.Columns, func(it SchemaColumn) bool { return it.Name == column })
+		if index < 0 {
+			return fmt.Errorf(`fail to parse ALTER TABLE %s: column %s not found`, name, column)
+		}
+		switch {
+		case p.Keyword("SET", "DEFAULT"):
+			begin := p.Pos()
+			if err := p.Skip(); err != nil {
+				return err
+			}
+			table.Columns[index].Default = p.Text(begin+1, p.Pos()-1)
+		case p.Keyword("DROP", "DEFAULT"):
+			table.Columns[index].Default = ""
+		case p.PeekKeyword("SET"):
+			// SET OPTIONS does not change the column
+		default:
+			altered, err := parseColumnType(p, column)
+			if err != nil {
+				return fmt.Errorf(`fail to parse ALTER TABLE %s: %w`, name, err)
+			}
+			table.Columns[index] = altered
+		}
+	}
+	return nil
+}
+
+func (parser ddlParser) parseCreateSearchIndex(p *ddl.Parser) error {
+	_ = p.Keyword("IF", "NOT", "EXISTS")
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	if err := p.ExpectKeyword("ON"); err != nil {
+		return err
+	}
+	tableName, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	table, found := parser.tables[tableName]
+	if !found {
+		return fmt.Errorf(`fail to parse CREATE SEARCH INDEX %s: table %s not found`, name, tableName)
+	}
+
+	searchIndex := SchemaSearchIndex{Name: name}
+	if searchIndex.Key, err = parseKeyList(p); err != nil {
+		return fmt.Errorf(`fail to parse CREATE SEARCH INDEX %s: %w`, name, err)
+	}
+	if p.Keyword("STORING") {
+		if searchIndex.Storing, err = parseKeyList(p); err != nil {
+			return fmt.Errorf(`fail to parse CREATE SEARCH INDEX %s: %w`, name, err)
+		}
+		slices.Sort(searchIndex.Storing)
+	}
+	// skips PARTITION BY clause, ORDER BY clause, and OPTIONS clause
+	for !p.EOF() {
+		if p.Keyword("INTERLEAVE", "IN") {
+			interleave, err := parseQualifiedName(p)
+			if err != nil {
+				return fmt.Errorf(`fail to parse CREATE SEARCH INDEX %s: %w`, name, err)
+			}
+			_, searchIndex.Interleave = splitQualifiedName(interleave)
+			continue
+		}
+		if err := p.Skip(); err != nil {
+			return fmt.Errorf(`fail to parse CREATE SEARCH INDEX %s: %w`, name, err)
+		}
+	}
+
+	table.SearchIndexes = append(table.SearchIndexes, searchIndex)
+	return nil
+}
+
+func (parser ddlParser) parseAlterSearchIndex(p *ddl.Parser) error {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	for _, table := range parser.tables {
+		i := slices.IndexFunc(table.SearchIndexes, func(it SchemaSearchIndex) bool { return it.Name == name })
+		if i < 0 {
+			continue
+		}
+		searchIndex := &table.SearchIndexes[i]
+		switch {
+		case p.Keyword("ADD", "STORED", "COLUMN"):
+			column, err := parseIdentifier(p)
+			if err != nil {
+				return err
+			}
+			searchIndex.Storing = append(slices.Clone(searchIndex.Storing), column)
+			slices.Sort(searchIndex.Storing)
+		case p.Keyword("DROP", "STORED", "COLUMN"):
+			column, err := parseIdentifier(p)
+			if err != nil {
+				return err
+			}
+			searchIndex.Storing = lo.Without(searchIndex.Storing, column)
+			if len(searchIndex.Storing) == 0 {
+				searchIndex.Storing = nil
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf(`fail to parse ALTER SEARCH INDEX %s: search index not found`, name)
+}
+
+func (parser ddlParser) parseCreateChangeStream(p *ddl.Parser) error {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	if _, found := parser.changeStreams[name]; found {
+		return fmt.Errorf(`change stream %s already exists`, name)
+	}
+
+	changeStream := &ddlChangeStream{tables: map[string][]string{}}
+	if p.Keyword("FOR") {
+		if err := parser.parseChangeStreamTargets(p, changeStream); err != nil {
+			return fmt.Errorf(`fail to parse CREATE CHANGE STREAM %s: %w`, name, err)
+		}
+	}
+	// OPTIONS clause does not change the watched tables
+	parser.changeStreams[name] = changeStream
+	return nil
+}
+
+func (parser ddlParser) parseAlterChangeStream(p *ddl.Parser) error {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	changeStream, found := parser.changeStreams[name]
+	if !found {
+		return fmt.Errorf(`fail to parse ALTER CHANGE STREAM %s: change stream not found`, name)
+	}
+
+	switch {
+	case p.Keyword("SET", "FOR"):
+		*changeStream = ddlChangeStream{tables: map[string][]string{}}
+		if err := parser.parseChangeStreamTargets(p, changeStream); err != nil {
+			return fmt.Errorf(`fail to parse ALTER CHANGE STREAM %s: %w`, name, err)
+		}
+	case p.Keyword("DROP", "FOR", "ALL"):
+		*changeStream = ddlChangeStream{tables: map[string][]string{}}
+	}
+	return nil
+}
+
+// parseChangeStreamTargets reads ALL or the list of tables optionally followed by the watched columns in a FOR clause.
+func (parser ddlParser) parseChangeStreamTargets(p *ddl.Parser, changeStream *ddlChangeStream) error {
+	if p.Keyword("ALL") {
+		changeStream.all = true
+		return nil
+	}
+	for {
+		tableName, err := parseQualifiedName(p)
+		if err != nil {
+			return err
+		}
+		if _, found := parser.tables[tableName]; !found {
+			return fmt.Errorf(`table %s not found`, tableName)
+		}
+		var columns []string
+		if p.PeekSymbol("(") {
+			if columns, err = parseKeyList(p); err != nil {
+				return err
+			}
+			slices.Sort(columns)
+		}
+		changeStream.tables[tableName] = columns
+		if !p.Symbol(",") {
+			return nil
+		}
+	}
+}
+
+func (parser ddlParser) parseCreateSequence(p *ddl.Parser) error {
+	ifNotExists := p.Keyword("IF", "NOT", "EXISTS")
+	name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+
+	sequence := &SchemaSequence{Name: name}
+	if err := parseSequenceOptions(p, sequence); err != nil {
+		return fmt.Errorf(`fail to parse CREATE SEQUENCE %s: %w`, name, err)
+	}
+
+	if _, found := parser.sequences[name]; found {
+		if ifNotExists {
+			return nil
+		}
+		return fmt.Errorf(`sequence %s already exists`, name)
+	}
+	parser.sequences[name] = sequence
+	return nil
+}
+
+func (parser ddlParser) parseAlterSequence(p *ddl.Parser) error {
+	name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	sequence, found := parser.sequences[name]
+	if !found {
+		return fmt.Errorf(`fail to parse ALTER SEQUENCE %s: sequence not found`, name)
+	}
+	_ = p.Keyword("SET")
+	if err := parseSequenceOptions(p, sequence); err != nil {
+		return fmt.Errorf(`fail to parse ALTER SEQUENCE %s: %w`, name, err)
+	}
+	return nil
+}
+
+// parseSequenceOptions reads the OPTIONS clause and the clauses such as BIT_REVERSED_POSITIVE, SKIP RANGE, and START COUNTER WITH into the sequence.
+func parseSequenceOptions(p *ddl.Parser, sequence *SchemaSequence) error {
+	for !p.EOF() {
+		switch {
+		case p.Keyword("BIT_REVERSED_POSITIVE"):
+			sequence.Kind = "bit_reversed_positive"
+		case p.Keyword("NO", "SKIP", "RANGE"):
+			sequence.SkipRangeMin, sequence.SkipRangeMax = nil, nil
+		case p.Keyword("SKIP", "RANGE"):
+			skipRangeMin, err := parseInteger(p)
+			if err != nil {
+				return err
+			}
+			if err := p.ExpectSymbol(","); err != nil {
+				return err
+			}
+			skipRangeMax, err := parseInteger(p)
+			if err != nil {
+				return err
+			}
+			sequence.SkipRangeMin, sequence.SkipRangeMax = &skipRangeMin, &skipRangeMax
+		case p.Keyword("START", "COUNTER"), p.Keyword("RESTART", "COUNTER"):
+			_ = p.Keyword("WITH")
+			startWithCounter, err := parseInteger(p)
+			if err != nil {
+				return err
+			}
+			sequence.StartWithCounter = &startWithCounter
+		case p.Keyword("OPTIONS"):
+			if err := p.ExpectSymbol("("); err != nil {
+				return err
+			}
+			for !p.Symbol(")") {
+				if err := parseSequenceOption(p, sequence); err != nil {
+					return err
+				}
+				if !p.Symbol(",") {
+					if err := p.ExpectSymbol(")"); err != nil {
+						return err
+					}
+					break
+				}
+			}
+		default:
+			token, _ := p.Peek()
+			return fmt.Errorf(`unexpected %q in sequence options`, token.Value)
+		}
+	}
+	return nil
+}
+
+// parseSequenceOption reads an option in the form of name = value, in which a NULL value resets the option.
+func parseSequenceOption(p *ddl.Parser, sequence *SchemaSequence) error {
+	option, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	if err := p.ExpectSymbol("="); err != nil {
+		return err
+	}
+	if option = strings.ToLower(option); option == "sequence_kind" {
+		if p.Keyword("NULL") {
+			sequence.Kind = ""
+			return nil
+		}
+		token, err := p.Next()
+		if err != nil {
+			return err
+		}
+		if token.Kind != ddl.TokenKindString {
+			return fmt.Errorf(`string is expected for %s but %q appears`, option, token.Value)
+		}
+		sequence.Kind = token.Value
+		return nil
+	}
+
+	var value *int64
+	if !p.Keyword("NULL") {
+		integer, err := parseInteger(p)
+		if err != nil {
+			return err
+		}
+		value = &integer
+	}
+	switch option {
+	case "skip_range_min":
+		sequence.SkipRangeMin = value
+	case "skip_range_max":
+		sequence.SkipRangeMax = value
+	case "start_with_counter":
+		sequence.StartWithCounter = value
+	default:
+		return fmt.Errorf(`unknown sequence option %s`, option)
+	}
+	return nil
+}
+
+// parseRowDeletionPolicyClause reads (OLDER_THAN(column, INTERVAL days DAY)) following ROW DELETION POLICY.
+func parseRowDeletionPolicyClause(p *ddl.Parser) (*SchemaRowDeletionPolicy, error) {
+	if err := p.ExpectSymbol("("); err != nil {
+		return nil, err
+	}
+	policy, err := parseRowDeletionPolicy(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ExpectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (parser ddlParser) parseTableElement(p *ddl.Parser, table *SchemaTable) error {
+	if !p.PeekKeyword("CONSTRAINT") && !p.PeekKeyword("FOREIGN", "KEY") && !p.PeekKeyword("CHECK") && !p.PeekKeyword("SYNONYM") {
+		return parser.parseColumnDefinition(p, table)
+	}
+
+	name := ""
+	if p.Keyword("CONSTRAINT") {
+		var err error
+		if name, err = parseIdentifier(p); err != nil {
+			return err
+		}
+	}
+	if p.Keyword("FOREIGN", "KEY") {
+		key, err := parseKeyList(p)
+		if err != nil {
+			return err
+		}
+		if err := p.ExpectKeyword("REFERENCES"); err != nil {
+			return err
+		}
+		referencedTable, err := parseQualifiedName(p)
+		if err != nil {
+			return err
+		}
+		referencedKey, err := parseKeyList(p)
+		if err != nil {
+			return err
+		}
+		foreignKey := SchemaForeignKey{
+			Name:            name,
+			ReferencedTable: referencedTable,
+			ReferencedKey:   referencedKey,
+			ReferencingKey:  key,
+		}
+		for {
+			if p.Keyword("ON", "DELETE") {
+				switch {
+				case p.Keyword("CASCADE"):
+					foreignKey.OnDelete = "CASCADE"
+				case p.Keyword("NO", "ACTION"):
+					foreignKey.OnDelete = ""
+				default:
+					return fmt.Errorf(`unexpected referential action`)
+				}
+				continue
+			}
+			if p.Keyword("NOT", "ENFORCED") {
+				foreignKey.NotEnforced = true
+				continue
+			}
+			if p.Keyword("ENFORCED") {
+				foreignKey.NotEnforced = false
+				continue
+			}
+			break
+		}
+		table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+	}
+
+	if p.Keyword("CHECK") {
+		if !p.PeekSymbol("(") {
+			return fmt.Errorf(`CHECK constraint without parentheses`)
+		}
+		begin := p.Pos()
+		if err := p.Skip(); err != nil {
+			return err
+		}
+		table.Checks = append(table.Checks, SchemaCheck{Name: name, Expression: p.Text(begin+1, p.Pos()-1)})
+	}
+
+	// skips SYNONYM clauses
+	return p.SkipUntil(nil)
+}
+
+func (parser ddlParser) parseColumnDefinition(p *ddl.Parser, table *SchemaTable) error {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	column, err := parseColumnType(p, name)
+	if err != nil {
+		return err
+	}
+	table.Columns = append(table.Columns, column)
+	return nil
+}
+
+// parseColumnType reads a type and following options of a column, in which the type is normalized in the same format as SPANNER_TYPE.
+func parseColumnType(p *ddl.Parser, name string) (SchemaColumn, error) {
+	column := SchemaColumn{Name: name, Nullable: true}
+
+	depth, afterWord := 0, false
+	for !p.EOF() && !(depth == 0 && (p.PeekSymbol(",") || p.PeekSymbol(")") || p.PeekKeyword("NOT") || p.PeekKeyword("DEFAULT") || p.PeekKeyword("AS") || p.PeekKeyword("OPTIONS") || p.PeekKeyword("HIDDEN"))) {
+		token, _ := p.Next()
+		if token.Kind == ddl.TokenKindSymbol {
+			switch token.Value {
+			case "(", "<":
+				depth++
+			case ")", ">":
+				depth--
+			}
+			column.Type += token.Value
+			afterWord = false
+			continue
+		}
+		if afterWord {
+			column.Type += " "
+		}
+		column.Type += strings.ToUpper(token.Value)
+		afterWord = true
+	}
+	if column.Type == "" {
+		return SchemaColumn{}, fmt.Errorf(`type of column %s is not specified`, name)
+	}
+
+	for !p.EOF() && !p.PeekSymbol(",") && !p.PeekSymbol(")") {
+		switch {
+		case p.Keyword("NOT", "NULL"):
+			column.Nullable = false
+		case p.Keyword("DEFAULT"):
+			begin := p.Pos()
+			if err := p.Skip(); err != nil {
+				return SchemaColumn{}, err
+			}
+			column.Default = p.Text(begin+1, p.Pos()-1)
+		case p.Keyword("AS"):
+			begin := p.Pos()
+			if err := p.Skip(); err != nil {
+				return SchemaColumn{}, err
+			}
+			column.Generated = p.Text(begin+1, p.Pos()-1)
+		case p.Keyword("STORED"):
+			column.Stored = true
+		case p.Keyword("OPTIONS"):
+			if err := p.Skip(); err != nil {
+				return SchemaColumn{}, err
+			}
+		default:
+			// skips HIDDEN
+			if err := p.Skip(); err != nil {
+				return SchemaColumn{}, err
+			}
+		}
+	}
+
+	return column, nil
+}
+
+func parseIdentifier(p *ddl.Parser) (string, error) {
+	token, err := p.Identifier()
+	if err != nil {
+		return "", err
+	}
+	return token.Value, nil
+}
+
+// parseQualifiedName reads an identifier optionally qualified by a named schema and returns it in the form of schema.name.
+func parseQualifiedName(p *ddl.Parser) (string, error) {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return "", err
+	}
+	if !p.Symbol(".") {
+		return name, nil
+	}
+	unqualified, err := parseIdentifier(p)
+	if err != nil {
+		return "", err
+	}
+	return qualifiedName(name, unqualified), nil
+}
+
+func parseKeyList(p *ddl.Parser) ([]string, error) {
+	key, err := parseIndexKeyList(p)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(key, func(it SchemaIndexKey, _ int) string { return it.Name }), nil
+}
+
+func parseIndexKeyList(p *ddl.Parser) ([]SchemaIndexKey, error) {
+	if err := p.ExpectSymbol("("); err != nil {
+		return nil, err
+	}
+	columns := []SchemaIndexKey{}
+	for !p.Symbol(")") {
+		column, err := parseIdentifier(p)
+		if err != nil {
+			return nil, err
+		}
+		desc := !p.Keyword("ASC") && p.Keyword("DESC")
+		columns = append(columns, SchemaIndexKey{Name: column, Desc: desc})
+		if !p.Symbol(",") {
+			if err := p.ExpectSymbol(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return columns, nil
+}
@@ -0,0 +1,224 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDDLFetcher(t *testing.T) {
+	for number, testcase := range fetcherTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(strings.Join(ddls[testcase.ddl], ";\n"))
+			assert.Nil(t, err)
+
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_ListTables(t *testing.T) {
+	for number, testcase := range listTablesTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(strings.Join(ddls[testcase.ddl], ";\n"))
+			assert.Nil(t, err)
+
+			got, err := sut.ListTables(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_WithReferencedBy(t *testing.T) {
+	for number, testcase := range referencedByTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(strings.Join(ddls[testcase.ddl], ";\n"))
+			assert.Nil(t, err)
+
+			got, err := sut.WithReferencedBy().Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got.ReferencedBy)
+		})
+	}
+}
+
+func TestDDLFetcher_Statements(t *testing.T) {
+	testcases := []struct {
+		name  string
+		ddl   string
+		table string
+		want  schema.SchemaTable
+	}{
+		{
+			name: "alter_table",
+			ddl: `CREATE TABLE Parent (
+	Id INT64 NOT NULL,
+) PRIMARY KEY (Id);
+CREATE TABLE Child (
+	Id INT64 NOT NULL,
+	ChildId STRING(36) NOT NULL DEFAULT (GENERATE_UUID()),
+	Tags array<string(max)>,
+	Upper STRING(MAX) AS (UPPER(ChildId)) STORED,
+	Dropped BOOL OPTIONS (allow_commit_timestamp = true),
+	FOREIGN KEY (Id) REFERENCES Parent (Id) NOT ENFORCED,
+) PRIMARY KEY (Id, ChildId DESC),
+	INTERLEAVE IN PARENT Parent ON DELETE CASCADE,
+	ROW DELETION POLICY (OLDER_THAN(CreatedAt, INTERVAL 30 DAY));
+CREATE UNIQUE NULL_FILTERED INDEX UQ_Child_Upper ON Child (Upper DESC) STORING (Tags), INTERLEAVE IN Parent;
+CREATE INDEX IX_Child_Tags ON Child (Upper);
+ALTER TABLE Child DROP COLUMN Dropped;
+ALTER TABLE Child ALTER COLUMN Upper STRING(100) NOT NULL;
+ALTER TABLE Child ADD CONSTRAINT FK_Child_Parent FOREIGN KEY (Id) REFERENCES Parent (Id) ON DELETE CASCADE;`,
+			table: "Child",
+			want: schema.SchemaTable{
+				Name: "Child",
+				Columns: []schema.SchemaColumn{
+					{Name: "Id", Type: "INT64"},
+					{Name: "ChildId", Type: "STRING(36)", Default: "GENERATE_UUID()"},
+					{Name: "Tags", Type: "ARRAY<STRING(MAX)>", Nullable: true},
+					{Name: "Upper", Type: "STRING(100)"},
+				},
+				PrimaryKey:        []string{"Id", "ChildId"},
+				Parent:            "Parent",
+				ParentOnDelete:    "CASCADE",
+				RowDeletionPolicy: &schema.SchemaRowDeletionPolicy{Column: "CreatedAt", Days: 30},
+				ForeignKeys: []schema.SchemaForeignKey{
+					{ReferencedTable: "Parent", ReferencedKey: []string{"Id"}, ReferencingKey: []string{"Id"}, NotEnforced: true},
+					{Name: "FK_Child_Parent", ReferencedTable: "Parent", ReferencedKey: []string{"Id"}, ReferencingKey: []string{"Id"}, OnDelete: "CASCADE"},
+				},
+				UniqueKeys: []schema.SchemaUniqueKey{{Name: "UQ_Child_Upper", Key: []string{"Upper"}}},
+				Indexes: []schema.SchemaIndex{
+					{Name: "IX_Child_Tags", Key: []schema.SchemaIndexKey{{Name: "Upper"}}},
+					{Name: "UQ_Child_Upper", Unique: true, Key: []schema.SchemaIndexKey{{Name: "Upper", Desc: true}}, NullFiltered: true, Storing: []string{"Tags"}, Interleave: "Parent"},
+				},
+			},
+		},
+		{
+			name: "spanner_features",
+			ddl: `CREATE SCHEMA S;
+CREATE SEQUENCE S.Seq BIT_REVERSED_POSITIVE SKIP RANGE 1, 100;
+CREATE SEQUENCE Unused OPTIONS (sequence_kind = 'bit_reversed_positive');
+ALTER SEQUENCE S.Seq SET OPTIONS (skip_range_min = NULL, skip_range_max = NULL, start_with_counter = 5);
+CREATE TABLE S.Parent (
+	Id INT64 NOT NULL,
+) PRIMARY KEY (Id);
+CREATE TABLE S.Child (
+	Id INT64 NOT NULL,
+	ChildId INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE S.Seq)),
+	UpdatedAt TIMESTAMP,
+	Text STRING(MAX),
+	Text_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(Text)) HIDDEN,
+	Title STRING(MAX),
+	Title_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(Title)) HIDDEN,
+	FOREIGN KEY (Id) REFERENCES S.Parent (Id),
+) PRIMARY KEY (Id, ChildId),
+	INTERLEAVE IN PARENT S.Parent ON DELETE CASCADE;
+ALTER TABLE S.Child ADD ROW DELETION POLICY (OLDER_THAN(UpdatedAt, INTERVAL 7 DAY));
+ALTER TABLE S.Child REPLACE ROW DELETION POLICY (OLDER_THAN(UpdatedAt, INTERVAL 14 DAY));
+ALTER TABLE S.Child SET ON DELETE NO ACTION;
+CREATE SEARCH INDEX SI_Text ON S.Child (Text_Tokens, Title_Tokens) STORING (Title) PARTITION BY Id ORDER BY UpdatedAt DESC, INTERLEAVE IN S.Parent OPTIONS (sort_order_sharding = true);
+CREATE SEARCH INDEX SI_Title ON S.Child (Title_Tokens);
+ALTER SEARCH INDEX SI_Text ADD STORED COLUMN Text;
+DROP SEARCH INDEX SI_Title;
+CREATE CHANGE STREAM CS_1 FOR S.Child (Text, Title) OPTIONS (retention_period = '1d');
+CREATE CHANGE STREAM CS_2 FOR S.Parent;
+ALTER CHANGE STREAM CS_2 SET FOR S.Parent, S.Child ();
+CREATE CHANGE STREAM CS_3 FOR ALL;
+ALTER CHANGE STREAM CS_3 DROP FOR ALL;
+CREATE CHANGE STREAM CS_4 FOR S.Child;
+DROP CHANGE STREAM CS_4;`,
+			table: "S.Child",
+			want: schema.SchemaTable{
+				Name:   "Child",
+				Schema: "S",
+				Columns: []schema.SchemaColumn{
+					{Name: "Id", Type: "INT64"},
+					{Name: "ChildId", Type: "INT64", Default: "GET_NEXT_SEQUENCE_VALUE(SEQUENCE S.Seq)"},
+					{Name: "UpdatedAt", Type: "TIMESTAMP", Nullable: true},
+					{Name: "Text", Type: "STRING(MAX)", Nullable: true},
+					{Name: "Text_Tokens", Type: "TOKENLIST", Nullable: true, Generated: "TOKENIZE_FULLTEXT(Text)"},
+					{Name: "Title", Type: "STRING(MAX)", Nullable: true},
+					{Name: "Title_Tokens", Type: "TOKENLIST", Nullable: true, Generated: "TOKENIZE_FULLTEXT(Title)"},
+				},
+				PrimaryKey:        []string{"Id", "ChildId"},
+				Parent:            "Parent",
+				RowDeletionPolicy: &schema.SchemaRowDeletionPolicy{Column: "UpdatedAt", Days: 14},
+				ForeignKeys: []schema.SchemaForeignKey{
+					{ReferencedTable: "S.Parent", ReferencedKey: []string{"Id"}, ReferencingKey: []string{"Id"}},
+				},
+				SearchIndexes: []schema.SchemaSearchIndex{
+					{Name: "SI_Text", Key: []string{"Text_Tokens", "Title_Tokens"}, Storing: []string{"Text", "Title"}, Interleave: "Parent"},
+				},
+				ChangeStreams: []schema.SchemaChangeStream{
+					{Name: "CS_1", Columns: []string{"Text", "Title"}},
+					{Name: "CS_2", Columns: []string{}},
+				},
+				Sequences: []schema.SchemaSequence{
+					{Name: "S.Seq", Kind: "bit_reversed_positive", StartWithCounter: lo.ToPtr(int64(5))},
+				},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(testcase.ddl)
+			assert.Nil(t, err)
+
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_WithReferencedBy_NamedSchema(t *testing.T) {
+	sut, err := schema.NewDDLFetcher(`CREATE SCHEMA S;
+CREATE TABLE S.Parent (Id INT64 NOT NULL) PRIMARY KEY (Id);
+CREATE TABLE S.Child (Id INT64 NOT NULL, ChildId INT64 NOT NULL) PRIMARY KEY (Id, ChildId), INTERLEAVE IN PARENT S.Parent;
+CREATE TABLE Other (Id INT64 NOT NULL, ParentId INT64, FOREIGN KEY (ParentId) REFERENCES S.Parent (Id)) PRIMARY KEY (Id);`)
+	assert.Nil(t, err)
+
+	tables, err := sut.ListTables(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Other", "S.Child", "S.Parent"}, tables)
+
+	got, err := sut.WithReferencedBy().Fetch(context.Background(), "S.Parent")
+	assert.Nil(t, err)
+	assert.Equal(t, []schema.SchemaReference{
+		{ReferencingTable: "Other", ReferencingKey: []string{"ParentId"}, ReferencedKey: []string{"Id"}},
+		{ReferencingTable: "S.Child", ReferencingKey: []string{"Id"}, ReferencedKey: []string{"Id"}, Interleaved: true},
+	}, got.ReferencedBy)
+}
+
+func TestDDLFetcher_SpannerFeatures_Error(t *testing.T) {
+	testcases := []struct {
+		name string
+		ddl  string
+	}{
+		{name: "row_deletion_policy_invalid", ddl: `CREATE TABLE T (Id INT64, At TIMESTAMP) PRIMARY KEY (Id), ROW DELETION POLICY (OLDER_THAN(At, INTERVAL 1 HOUR))`},
+		{name: "search_index_table_missing", ddl: `CREATE SEARCH INDEX SI ON T (Tokens)`},
+		{name: "search_index_missing", ddl: `ALTER SEARCH INDEX SI ADD STORED COLUMN C`},
+		{name: "change_stream_table_missing", ddl: `CREATE CHANGE STREAM CS FOR T`},
+		{name: "change_stream_duplicated", ddl: `CREATE CHANGE STREAM CS FOR ALL; CREATE CHANGE STREAM CS FOR ALL`},
+		{name: "change_stream_missing", ddl: `ALTER CHANGE STREAM CS DROP FOR ALL`},
+		{name: "sequence_duplicated", ddl: `CREATE SEQUENCE Seq BIT_REVERSED_POSITIVE; CREATE SEQUENCE Seq BIT_REVERSED_POSITIVE`},
+		{name: "sequence_missing", ddl: `ALTER SEQUENCE Seq SET OPTIONS (start_with_counter = 1)`},
+		{name: "sequence_option_unknown", ddl: `CREATE SEQUENCE Seq OPTIONS (sequence_kind = 'bit_reversed_positive', unknown = 1)`},
+		{name: "sequence_option_invalid", ddl: `CREATE SEQUENCE Seq OPTIONS (skip_range_min = 'a')`},
+	}
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			_, err := schema.NewDDLFetcher(testcase.ddl)
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+CREATE TABLE L_1 (
+    PK_1 INT64 NOT NULL,
+    C1 INT64,
+    C2 STRING(50),
+) PRIMARY KEY (PK_1);
+
+CREATE TABLE L_2 (
+    PK_1 INT64 NOT NULL,
+    PK_2 INT64 NOT NULL,
+    C3 FLOAT64,
+    C4 STRING(50),
+) PRIMARY KEY (PK_1, PK_2),
+    INTERLEAVE IN PARENT L_1 ON DELETE CASCADE;
+
+CREATE INDEX IDX_L_1_C1 ON L_1 (C1);
+CREATE INDEX IDX_L_1_C2_C1 ON L_1 (C2 DESC, C1 ASC);
+CREATE NULL_FILTERED INDEX IDX_L_1_C2_Storing ON L_1 (C2) STORING (C1);
+CREATE INDEX IDX_L_2_C3 ON L_2 (PK_1, C3 DESC) STORING (C4), INTERLEAVE IN L_1;
+CREATE UNIQUE NULL_FILTERED INDEX UQ_L_2_C4 ON L_2 (C4);
@@ -0,0 +1,9 @@
+CREATE TABLE M (
+    PK INT64 NOT NULL,
+    C1 INT64 NOT NULL DEFAULT (0),
+    C2 STRING(50) DEFAULT ('abc'),
+    C3 TIMESTAMP DEFAULT (CURRENT_TIMESTAMP()),
+    C4 STRING(36) NOT NULL DEFAULT (GENERATE_UUID()),
+    C5 INT64 AS (C1 * 2) STORED,
+    C6 STRING(50) AS (UPPER(C2)) STORED,
+) PRIMARY KEY (PK);
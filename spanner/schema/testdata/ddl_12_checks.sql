@@ -0,0 +1,10 @@
+CREATE TABLE N (
+    PK INT64 NOT NULL,
+    C1 INT64 NOT NULL,
+    C2 STRING(10),
+    C3 INT64,
+    CONSTRAINT CK_N_C1 CHECK (C1 >= 0),
+    CONSTRAINT CK_N_C2 CHECK (C2 IN ('a', 'b', 'c')),
+) PRIMARY KEY (PK);
+
+ALTER TABLE N ADD CONSTRAINT CK_N_C1_C3 CHECK (C1 < C3);
@@ -0,0 +1,11 @@
+CREATE TABLE O_1 (
+    PK INT64 NOT NULL,
+) PRIMARY KEY (PK);
+
+CREATE TABLE O_2 (
+    PK INT64 NOT NULL,
+    R1 INT64,
+    R2 INT64,
+    CONSTRAINT FK_O_2_1 FOREIGN KEY (R1) REFERENCES O_1 (PK) ON DELETE CASCADE,
+    CONSTRAINT FK_O_2_2 FOREIGN KEY (R2) REFERENCES O_1 (PK) NOT ENFORCED,
+) PRIMARY KEY (PK);
@@ -0,0 +1,14 @@
+CREATE TABLE P_1 (
+    PK INT64 NOT NULL,
+    C1 STRING(MAX) NOT NULL,
+) PRIMARY KEY (PK);
+
+CREATE TABLE P_2 (
+    PK INT64 NOT NULL,
+    R1 INT64,
+    C2 FLOAT64,
+) PRIMARY KEY (PK);
+
+CREATE VIEW V_1 SQL SECURITY INVOKER AS SELECT P_1.PK, P_1.C1 FROM P_1 WHERE P_1.C1 <> '';
+
+CREATE VIEW V_2 SQL SECURITY DEFINER AS SELECT v.PK AS K, SUM(p.C2) AS Total FROM V_1 AS v JOIN P_2 AS p ON v.PK = p.R1 GROUP BY v.PK;
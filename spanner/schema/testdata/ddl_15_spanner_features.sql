@@ -0,0 +1,30 @@
+CREATE SCHEMA S;
+
+CREATE SEQUENCE Q_Seq OPTIONS (sequence_kind = 'bit_reversed_positive', skip_range_min = 1, skip_range_max = 1000, start_with_counter = 10);
+
+CREATE TABLE Q_1 (
+    PK INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE Q_Seq)),
+    CreatedAt TIMESTAMP NOT NULL,
+    Body STRING(MAX),
+    Body_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(Body)) HIDDEN,
+) PRIMARY KEY (PK),
+    ROW DELETION POLICY (OLDER_THAN(CreatedAt, INTERVAL 30 DAY));
+
+CREATE TABLE Q_2 (
+    PK INT64 NOT NULL,
+    C1 INT64 NOT NULL,
+    C2 STRING(MAX),
+    C3 STRING(MAX),
+) PRIMARY KEY (PK, C1),
+    INTERLEAVE IN PARENT Q_1 ON DELETE NO ACTION;
+
+CREATE TABLE S.Q_3 (
+    PK INT64 NOT NULL,
+    C1 STRING(MAX),
+) PRIMARY KEY (PK);
+
+CREATE SEARCH INDEX SI_Q_1_Body ON Q_1 (Body_Tokens) STORING (CreatedAt);
+
+CREATE CHANGE STREAM CS_All FOR ALL;
+
+CREATE CHANGE STREAM CS_Q FOR Q_1, Q_2 (C2);
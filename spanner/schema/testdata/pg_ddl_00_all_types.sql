@@ -0,0 +1,24 @@
+CREATE TABLE pg_a (
+    pk bigint NOT NULL,
+    col_01 boolean,
+    col_02 boolean NOT NULL,
+    col_03 bytea,
+    col_04 bytea NOT NULL,
+    col_05 date,
+    col_06 date NOT NULL,
+    col_07 double precision,
+    col_08 double precision NOT NULL,
+    col_09 bigint,
+    col_10 bigint NOT NULL,
+    col_11 jsonb,
+    col_12 jsonb NOT NULL,
+    col_13 numeric,
+    col_14 numeric NOT NULL,
+    col_15 varchar(50),
+    col_16 varchar(50) NOT NULL,
+    col_17 timestamptz,
+    col_18 timestamptz NOT NULL,
+    col_19 bigint[],
+    col_20 varchar,
+    PRIMARY KEY (pk)
+);
@@ -0,0 +1,16 @@
+-- classDiagram
+--     pg_b_1 <|-- pg_b_2
+CREATE TABLE pg_b_1 (
+    pk_11 bigint NOT NULL,
+    PRIMARY KEY (pk_11)
+);
+
+CREATE TABLE pg_b_2 (
+    pk_11 bigint NOT NULL,
+    pk_21 bigint NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (pk_11, pk_21)
+) INTERLEAVE IN PARENT pg_b_1 ON DELETE CASCADE
+    TTL INTERVAL '30 days' ON created_at;
+
+CREATE INDEX idx_pg_b_2_created_at ON pg_b_2 (created_at DESC);
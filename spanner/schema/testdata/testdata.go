@@ -22,3 +22,27 @@ var DDL05ForeignLoop3SQL string
 
 //go:embed ddl_06_unique_keys.sql
 var DDL06UniqueKeysSQL string
+
+//go:embed ddl_10_indexes.sql
+var DDL10IndexesSQL string
+
+//go:embed ddl_11_column_defaults.sql
+var DDL11ColumnDefaultsSQL string
+
+//go:embed ddl_12_checks.sql
+var DDL12ChecksSQL string
+
+//go:embed ddl_13_foreign_key_actions.sql
+var DDL13ForeignKeyActionsSQL string
+
+//go:embed ddl_14_views.sql
+var DDL14ViewsSQL string
+
+//go:embed ddl_15_spanner_features.sql
+var DDL15SpannerFeaturesSQL string
+
+//go:embed pg_ddl_00_all_types.sql
+var PGDDL00AllTypesSQL string
+
+//go:embed pg_ddl_01_interleave.sql
+var PGDDL01InterleaveSQL string
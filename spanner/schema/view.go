@@ -0,0 +1,151 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Jumpaku/go-assert"
+	"github.com/Jumpaku/gotaface/ddl"
+	"github.com/Jumpaku/gotaface/schema"
+	gf_spanner "github.com/Jumpaku/gotaface/spanner"
+	"github.com/samber/lo"
+)
+
+type SchemaView struct {
+	Name string `json:"name"`
+	// Columns are the columns of the view, which are always nullable.
+	Columns []SchemaColumn `json:"columns"`
+	// Definition is the query of the view as written.
+	Definition string `json:"definition"`
+	// SecurityInvoker reports whether the view is declared with SQL SECURITY INVOKER rather than SQL SECURITY DEFINER.
+	SecurityInvoker bool `json:"security_invoker"`
+	// Dependencies are the names of the tables and views referenced by the view.
+	Dependencies []string `json:"dependencies"`
+}
+
+var _ schema.ViewFetcher[SchemaView] = fetcher{}
+var _ schema.ViewLister = fetcher{}
+
+func (fetcher fetcher) FetchView(ctx context.Context, view string) (SchemaView, error) {
+	wrapError := func(err error) (SchemaView, error) {
+		assert.Params(err != nil, "wrapped error must be not nil")
+		return SchemaView{}, fmt.Errorf(`fail to fetch view %s: %w`, view, err)
+	}
+
+	tx, err := fetcher.dialectQueryer(ctx)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView, err := getView(ctx, tx, view)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView.Columns, err = queryColumns(ctx, tx, "", view)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView.Dependencies, err = queryViewDependencies(ctx, tx, view, schemaView.Definition)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return schemaView, nil
+}
+
+func (fetcher fetcher) ListViews(ctx context.Context) ([]string, error) {
+	sql := `--sql query user view names
+SELECT
+	TABLE_NAME AS Name
+FROM INFORMATION_SCHEMA.VIEWS
+WHERE TABLE_CATALOG = '' AND TABLE_SCHEMA = ''
+ORDER BY TABLE_NAME`
+	postgreSQL := `--sql query user view names
+SELECT
+	table_name AS "Name"
+FROM information_schema.views
+WHERE table_schema = 'public'
+ORDER BY table_name`
+	tx, err := fetcher.dialectQueryer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list views: %w`, err)
+	}
+	type View struct{ Name string }
+	views, err := gf_spanner.ScanRowsStruct[View](tx.Query(ctx, tx.statement(sql, postgreSQL)))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list views: %w`, err)
+	}
+	return lo.Map(views, func(it View, i int) string { return it.Name }), nil
+}
+
+func getView(ctx context.Context, tx dialectQueryer, view string) (SchemaView, error) {
+	sql := `--sql query view definition
+SELECT
+	TABLE_NAME AS Name,
+	VIEW_DEFINITION AS Definition,
+	IFNULL(SECURITY_TYPE = 'INVOKER', FALSE) AS SecurityInvoker,
+FROM INFORMATION_SCHEMA.VIEWS
+WHERE TABLE_CATALOG = '' AND TABLE_SCHEMA = '' AND TABLE_NAME = @View`
+	postgreSQL := `--sql query view definition
+SELECT
+	table_name AS "Name",
+	view_definition AS "Definition",
+	COALESCE(security_type = 'INVOKER', FALSE) AS "SecurityInvoker"
+FROM information_schema.views
+WHERE table_schema = 'public' AND table_name = $1`
+	found, err := gf_spanner.ScanRowsStruct[SchemaView](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"View", view})))
+	if err != nil {
+		return SchemaView{}, fmt.Errorf(`fail to get view %s: %w`, view, err)
+	}
+	if len(found) == 0 {
+		return SchemaView{}, fmt.Errorf("view %q not found", view)
+	}
+	return found[0], nil
+}
+
+func queryViewDependencies(ctx context.Context, tx dialectQueryer, view string, definition string) ([]string, error) {
+	sql := `--sql query table and view names
+SELECT
+	TABLE_NAME AS Name
+FROM INFORMATION_SCHEMA.TABLES
+WHERE TABLE_CATALOG = '' AND TABLE_SCHEMA = '' AND TABLE_NAME <> @View
+ORDER BY TABLE_NAME`
+	postgreSQL := `--sql query table and view names
+SELECT
+	table_name AS "Name"
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name <> $1
+ORDER BY table_name`
+	type Table struct{ Name string }
+	tables, err := gf_spanner.ScanRowsStruct[Table](tx.Query(ctx, tx.statement(sql, postgreSQL, param{"View", view})))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+	dependencies, err := viewDependencies(definition, lo.Map(tables, func(it Table, _ int) string { return it.Name }))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+	return dependencies, nil
+}
+
+// viewDependencies returns the names appearing in the query of the view, which are compared case-insensitively as Spanner does.
+func viewDependencies(definition string, names []string) ([]string, error) {
+	tokens, err := ddl.Tokenize(definition)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to parse %q: %w`, definition, err)
+	}
+	identifiers := ddl.Identifiers(tokens)
+
+	var dependencies []string
+	for _, name := range names {
+		if lo.SomeBy(identifiers, func(it string) bool { return strings.EqualFold(it, name) }) {
+			dependencies = append(dependencies, name)
+		}
+	}
+	slices.Sort(dependencies)
+	return dependencies, nil
+}
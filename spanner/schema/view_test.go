@@ -0,0 +1,135 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/spanner/schema"
+	"github.com/Jumpaku/gotaface/spanner/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var fetchViewTestcases = []struct {
+	ddl  string
+	view string
+	want schema.SchemaView
+}{
+	{
+		ddl:  "ddl_14_views",
+		view: "V_1",
+		want: schema.SchemaView{
+			Name: "V_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64", Nullable: true},
+				{Name: "C1", Type: "STRING(MAX)", Nullable: true},
+			},
+			Definition:      `SELECT P_1.PK, P_1.C1 FROM P_1 WHERE P_1.C1 <> ''`,
+			SecurityInvoker: true,
+			Dependencies:    []string{"P_1"},
+		},
+	},
+	{
+		ddl:  "ddl_14_views",
+		view: "V_2",
+		want: schema.SchemaView{
+			Name: "V_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "K", Type: "INT64", Nullable: true},
+				{Name: "Total", Type: "FLOAT64", Nullable: true},
+			},
+			Definition:   `SELECT v.PK AS K, SUM(p.C2) AS Total FROM V_1 AS v JOIN P_2 AS p ON v.PK = p.R1 GROUP BY v.PK`,
+			Dependencies: []string{"P_2", "V_1"},
+		},
+	},
+}
+
+func TestFetcher_FetchView(t *testing.T) {
+	for number, testcase := range fetchViewTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.view), func(t *testing.T) {
+			database := fmt.Sprintf("fetch_view_%0d", number)
+			admin, client, teardown := test.Setup(t, database)
+			defer teardown()
+			test.InitDDLs(t, admin, client.DatabaseName(), ddls[testcase.ddl])
+
+			ctx := context.Background()
+			sut := schema.NewFetcher(client.ReadOnlyTransaction())
+			got, err := sut.FetchView(ctx, testcase.view)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+
+			_, err = schema.NewFetcher(client.Single()).Fetch(ctx, testcase.view)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestListViews(t *testing.T) {
+	admin, client, teardown := test.Setup(t, "list_views")
+	defer teardown()
+	test.InitDDLs(t, admin, client.DatabaseName(), ddls["ddl_14_views"])
+
+	sut := schema.NewFetcher(client.ReadOnlyTransaction())
+	got, err := sut.ListViews(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"V_1", "V_2"}, got)
+}
+
+func TestDDLFetcher_FetchView(t *testing.T) {
+	for number, testcase := range fetchViewTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.view), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(strings.Join(ddls[testcase.ddl], ";\n"))
+			assert.Nil(t, err)
+
+			got, err := sut.FetchView(context.Background(), testcase.view)
+			assert.Nil(t, err)
+			want := testcase.want
+			// columns of views are not provided by the DDL fetcher
+			want.Columns = nil
+			assert.Equal(t, want, got)
+
+			_, err = sut.Fetch(context.Background(), testcase.view)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestDDLFetcher_ListViews(t *testing.T) {
+	sut, err := schema.NewDDLFetcher(`CREATE TABLE T (ID INT64) PRIMARY KEY (ID);
+CREATE VIEW V_1 SQL SECURITY INVOKER AS SELECT T.ID FROM T;
+CREATE VIEW V_2 SQL SECURITY INVOKER AS SELECT T.ID FROM T;
+CREATE OR REPLACE VIEW V_1 SQL SECURITY DEFINER AS SELECT V_2.ID FROM V_2;
+CREATE VIEW V_3 SQL SECURITY INVOKER AS SELECT T.ID FROM T;
+DROP VIEW V_3;`)
+	assert.Nil(t, err)
+
+	got, err := sut.ListViews(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"V_1", "V_2"}, got)
+
+	view, err := sut.FetchView(context.Background(), "V_1")
+	assert.Nil(t, err)
+	assert.Equal(t, schema.SchemaView{Name: "V_1", Definition: "SELECT V_2.ID FROM V_2", Dependencies: []string{"V_2"}}, view)
+
+	tables, err := sut.ListTables(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"T"}, tables)
+}
+
+func TestDDLFetcher_CreateView_Error(t *testing.T) {
+	testcases := []struct {
+		name string
+		ddl  string
+	}{
+		{name: "security_missing", ddl: `CREATE VIEW V AS SELECT 1 AS X`},
+		{name: "security_invalid", ddl: `CREATE VIEW V SQL SECURITY NONE AS SELECT 1 AS X`},
+		{name: "duplicated", ddl: `CREATE VIEW V SQL SECURITY INVOKER AS SELECT 1 AS X; CREATE VIEW V SQL SECURITY INVOKER AS SELECT 2 AS X`},
+	}
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			_, err := schema.NewDDLFetcher(testcase.ddl)
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -23,9 +23,17 @@ func SkipIfNoEnv(t *testing.T) {
 	}
 }
 
+// Setup creates a GoogleSQL-dialect database and returns clients connected to it.
 func Setup(t *testing.T, database string) (adminClient *spanner_admin.DatabaseAdminClient, client *spanner.Client, teardown func()) {
 	t.Helper()
 
+	return SetupDialect(t, database, spanner_adminpb.DatabaseDialect_GOOGLE_STANDARD_SQL)
+}
+
+// SetupDialect creates a database of the dialect and returns clients connected to it.
+func SetupDialect(t *testing.T, database string, dialect spanner_adminpb.DatabaseDialect) (adminClient *spanner_admin.DatabaseAdminClient, client *spanner.Client, teardown func()) {
+	t.Helper()
+
 	SkipIfNoEnv(t)
 
 	ctx := context.Background()
@@ -38,7 +46,8 @@ func Setup(t *testing.T, database string) (adminClient *spanner_admin.DatabaseAd
 	parent := fmt.Sprintf(`projects/%s/instances/%s`, project, instance)
 	op, err := adminClient.CreateDatabase(ctx, &spanner_adminpb.CreateDatabaseRequest{
 		Parent:          parent,
-		CreateStatement: fmt.Sprintf("CREATE DATABASE %s", database),
+		CreateStatement: createDatabaseStatement(database, dialect),
+		DatabaseDialect: dialect,
 	})
 	if err != nil {
 		adminClient.Close()
@@ -68,6 +77,14 @@ func Setup(t *testing.T, database string) (adminClient *spanner_admin.DatabaseAd
 	return adminClient, client, teardown
 }
 
+// createDatabaseStatement returns a CREATE DATABASE statement, in which the name is quoted by double quotes only in PostgreSQL.
+func createDatabaseStatement(database string, dialect spanner_adminpb.DatabaseDialect) string {
+	if dialect == spanner_adminpb.DatabaseDialect_POSTGRESQL {
+		return fmt.Sprintf(`CREATE DATABASE "%s"`, database)
+	}
+	return fmt.Sprintf("CREATE DATABASE %s", database)
+}
+
 func InitDDLs(t *testing.T, adminClient *spanner_admin.DatabaseAdminClient, database string, stmts []string) {
 	t.Helper()
 	removeComment := func(stmt string, i int) string {
@@ -93,6 +110,8 @@ func InitDDLs(t *testing.T, adminClient *spanner_admin.DatabaseAdminClient, data
 }
 
 func InitDMLs(t *testing.T, client *spanner.Client, stmt []spanner.Statement) {
+	t.Helper()
+
 	_, err := client.ReadWriteTransaction(context.Background(), func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
 		for _, stmt := range stmt {
 			_, err := tx.Update(ctx, stmt)
@@ -103,7 +122,7 @@ func InitDMLs(t *testing.T, client *spanner.Client, stmt []spanner.Statement) {
 		return nil
 	})
 	if err != nil {
-		t.Fatalf(`fail to wait create tables: %v`, err)
+		t.Fatalf(`fail to execute dmls: %v`, err)
 	}
 }
 
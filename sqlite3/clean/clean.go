@@ -0,0 +1,80 @@
+package clean
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/sqlite3/dependency"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+)
+
+type cleaner struct {
+	db   *sqlx.DB
+	keep []string
+}
+
+// NewCleaner returns a cleaner that deletes all rows of tables in a transaction.
+func NewCleaner(db *sqlx.DB) cleaner {
+	return cleaner{db: db}
+}
+
+// WithKeep returns a cleaner that keeps rows of the tables, such as reference tables holding master data.
+func (c cleaner) WithKeep(tables ...string) cleaner {
+	c.keep = append(slices.Clone(c.keep), tables...)
+	return c
+}
+
+// Clean deletes all rows of the tables except the kept tables in delete order resolved from their foreign keys, and resets their AUTOINCREMENT sequences in sqlite_sequence.
+// Foreign keys are deferred if the tables reference each other.
+func (c cleaner) Clean(ctx context.Context, tables []schema.SchemaTable) error {
+	targets := lo.Reject(tables, func(table schema.SchemaTable, _ int) bool { return lo.Contains(c.keep, table.Name) })
+	order, err := gf_dependency.Resolve(dependency.NewGraph(targets))
+	if err != nil {
+		return fmt.Errorf(`fail to resolve delete order: %w`, err)
+	}
+
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(`fail to begin transaction: %w`, err)
+	}
+	defer tx.Rollback()
+
+	if len(order.Cycles) > 0 {
+		if _, err := tx.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return fmt.Errorf(`fail to defer foreign keys: %w`, err)
+		}
+	}
+
+	for _, name := range order.DeleteOrder {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s`, quoteIdentifier(name))); err != nil {
+			return fmt.Errorf(`fail to delete rows of %s: %w`, name, err)
+		}
+	}
+
+	// sqlite_sequence exists only if a table with AUTOINCREMENT has been created.
+	var sequences int
+	if err := tx.GetContext(ctx, &sequences, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sqlite_sequence'`); err != nil {
+		return fmt.Errorf(`fail to find sqlite_sequence: %w`, err)
+	}
+	if sequences > 0 {
+		for _, name := range order.DeleteOrder {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM sqlite_sequence WHERE name = ?`, name); err != nil {
+				return fmt.Errorf(`fail to reset sequence of %s: %w`, name, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`fail to commit deletion: %w`, err)
+	}
+	return nil
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
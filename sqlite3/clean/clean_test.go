@@ -0,0 +1,101 @@
+package clean_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/sqlite3/clean"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/Jumpaku/gotaface/sqlite3/test"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var ddls = []string{
+	`CREATE TABLE Kind (ID INTEGER PRIMARY KEY, Name TEXT NOT NULL)`,
+	`CREATE TABLE Parent (ID INTEGER PRIMARY KEY AUTOINCREMENT, KindID INTEGER NOT NULL REFERENCES Kind (ID))`,
+	`CREATE TABLE Child (ID INTEGER PRIMARY KEY, ParentID INTEGER NOT NULL REFERENCES Parent (ID))`,
+	`CREATE TABLE X (ID INTEGER PRIMARY KEY, YID INTEGER REFERENCES Y (ID))`,
+	`CREATE TABLE Y (ID INTEGER PRIMARY KEY, XID INTEGER REFERENCES X (ID))`,
+}
+
+var dmls = []string{
+	`INSERT INTO Kind (ID, Name) VALUES (1, 'a')`,
+	`INSERT INTO Parent (KindID) VALUES (1), (1)`,
+	`INSERT INTO Child (ID, ParentID) VALUES (1, 1), (2, 2)`,
+	`INSERT INTO X (ID, YID) VALUES (1, NULL)`,
+	`INSERT INTO Y (ID, XID) VALUES (1, 1)`,
+	`UPDATE X SET YID = 1`,
+}
+
+type parentRow struct {
+	ID     int64 `db:"ID"`
+	KindID int64 `db:"KindID"`
+}
+
+var cleanerTestcases = []struct {
+	name     string
+	tables   []string
+	keep     []string
+	wantRows map[string]int
+}{
+	{
+		name:     "all",
+		tables:   []string{"Kind", "Parent", "Child", "X", "Y"},
+		wantRows: map[string]int{"Kind": 0, "Parent": 0, "Child": 0, "X": 0, "Y": 0},
+	},
+	{
+		name:     "keep",
+		tables:   []string{"Kind", "Parent", "Child", "X", "Y"},
+		keep:     []string{"Kind"},
+		wantRows: map[string]int{"Kind": 1, "Parent": 0, "Child": 0, "X": 0, "Y": 0},
+	},
+	{
+		name:     "selected",
+		tables:   []string{"X", "Y"},
+		wantRows: map[string]int{"Kind": 1, "Parent": 2, "Child": 2, "X": 0, "Y": 0},
+	},
+}
+
+func TestCleaner(t *testing.T) {
+	for number, testcase := range cleanerTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			db, teardown := test.Setup(t, fmt.Sprintf("cleaner_%0d.sqlite", number))
+			defer teardown()
+
+			// PRAGMA foreign_keys is a setting of each connection.
+			db.SetMaxOpenConns(1)
+			if _, err := db.Exec(`PRAGMA foreign_keys = ON`); err != nil {
+				t.Fatalf(`fail to enable foreign keys: %v`, err)
+			}
+			test.InitDDLs(t, db, ddls)
+			test.InitDMLs(t, db, dmls)
+
+			fetcher := schema.NewFetcher(db)
+			tables := lo.Map(testcase.tables, func(name string, _ int) schema.SchemaTable {
+				table, err := fetcher.Fetch(context.Background(), name)
+				assert.Nil(t, err)
+				return table
+			})
+
+			err := clean.NewCleaner(db).WithKeep(testcase.keep...).Clean(context.Background(), tables)
+			assert.Nil(t, err)
+
+			for table, want := range testcase.wantRows {
+				var got int
+				assert.Nil(t, db.Get(&got, fmt.Sprintf(`SELECT COUNT(*) FROM %s`, table)))
+				assert.Equal(t, want, got, table)
+			}
+			if testcase.wantRows["Parent"] == 0 {
+				// AUTOINCREMENT restarts from 1 after its sequence is reset.
+				_, err := db.Exec(`INSERT OR IGNORE INTO Kind (ID, Name) VALUES (1, 'a')`)
+				assert.Nil(t, err)
+				_, err = db.Exec(`INSERT INTO Parent (KindID) VALUES (1)`)
+				assert.Nil(t, err)
+				assert.Equal(t, []*parentRow{{ID: 1, KindID: 1}}, test.ListRows[parentRow](t, db, "Parent"))
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Jumpaku/gotaface/sqlite3/diff"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+)
+
+func diffSnapshots(subcommand []string, input CLI_Diff_Input, inputErr error) (err error) {
+	if inputErr != nil {
+		fmt.Fprintln(os.Stderr, cli.Sub_Diff.DESC_Simple())
+		return fmt.Errorf("fail to resolve command line arguments: %w", inputErr)
+	}
+	if input.Opt_Help {
+		fmt.Fprintln(os.Stderr, cli.Sub_Diff.DESC_Detail())
+		return nil
+	}
+
+	before, err := readSnapshot(input.Arg_BeforeSnapshot)
+	if err != nil {
+		return fmt.Errorf("fail to read before snapshot: %w", err)
+	}
+	after, err := readSnapshot(input.Arg_AfterSnapshot)
+	if err != nil {
+		return fmt.Errorf("fail to read after snapshot: %w", err)
+	}
+
+	result := diff.Compare(before, after)
+
+	var out io.Writer = os.Stdout
+	if input.Opt_Output != "" {
+		f, err := os.Create(input.Opt_Output)
+		if err != nil {
+			return fmt.Errorf("fail to open output file %q: %w", input.Opt_Output, err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	switch input.Opt_Format {
+	default:
+		return fmt.Errorf("invalid option value for format, which must be one of json, sql")
+	case "json":
+		if err := json.NewEncoder(out).Encode(result); err != nil {
+			return fmt.Errorf("fail to encode differences into JSON: %w", err)
+		}
+	case "sql":
+		for _, stmt := range diff.MigrationDDL(result) {
+			if _, err := fmt.Fprintf(out, "%s;\n\n", stmt); err != nil {
+				return fmt.Errorf("fail to write DDL: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func readSnapshot(path string) ([]schema.SchemaTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("fail to open snapshot file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	tables := []schema.SchemaTable{}
+	decoder := json.NewDecoder(f)
+	for {
+		var table schema.SchemaTable
+		if err := decoder.Decode(&table); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("fail to decode schema from JSON in %q: %w", path, err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
@@ -0,0 +1,78 @@
+package codegen
+
+import (
+	"strings"
+
+	gf_codegen "github.com/Jumpaku/gotaface/codegen"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/samber/lo"
+)
+
+// Generate returns source code of a Go package that defines a struct for each table, whose fields are tagged with db and json.
+// Hidden columns of virtual tables are omitted.
+func Generate(packageName string, tables []schema.SchemaTable) ([]byte, error) {
+	imports := []string{}
+	codegenTables := lo.Map(tables, func(table schema.SchemaTable, _ int) gf_codegen.Table {
+		return gf_codegen.Table{
+			Name: table.Name,
+			Columns: lo.FilterMap(table.Columns, func(column schema.SchemaColumn, _ int) (gf_codegen.Column, bool) {
+				if column.Hidden {
+					return gf_codegen.Column{}, false
+				}
+				goType, importPath := GoType(column)
+				if importPath != "" {
+					imports = append(imports, importPath)
+				}
+				return gf_codegen.Column{Name: column.Name, GoType: goType}, true
+			}),
+			PrimaryKey: table.PrimaryKey,
+			UniqueKeys: lo.Map(table.UniqueKeys, func(key schema.SchemaUniqueKey, _ int) []string { return key.Key }),
+			ForeignKeys: lo.Map(table.ForeignKeys, func(key schema.SchemaForeignKey, _ int) gf_codegen.ForeignKey {
+				return gf_codegen.ForeignKey{ReferencedTable: key.ReferencedTable, ReferencedKey: key.ReferencedKey, ReferencingKey: key.ReferencingKey}
+			}),
+		}
+	})
+
+	return gf_codegen.Generate(gf_codegen.Config{
+		Package: packageName,
+		Imports: imports,
+		Tags:    []string{"db", "json"},
+		KeyType: "[]any",
+	}, codegenTables)
+}
+
+// GoType returns the Go type of the field for the column and the import path of the package that the type requires.
+// The type is determined by the type affinity of the declared type, in which boolean and date types with NUMERIC affinity are mapped to bool and time.Time.
+// Nullable columns are mapped to sql.Null* types.
+func GoType(column schema.SchemaColumn) (goType string, importPath string) {
+	nullable := func(notNullType, nullType string) (string, string) {
+		if column.Nullable {
+			return nullType, "database/sql"
+		}
+		return notNullType, ""
+	}
+
+	declared := strings.ToUpper(column.Type)
+	contains := func(substrings ...string) bool {
+		return lo.SomeBy(substrings, func(s string) bool { return strings.Contains(declared, s) })
+	}
+	switch {
+	case contains("INT"):
+		return nullable("int64", "sql.NullInt64")
+	case contains("CHAR", "CLOB", "TEXT"):
+		return nullable("string", "sql.NullString")
+	case contains("BLOB") || declared == "":
+		return "[]byte", ""
+	case contains("REAL", "FLOA", "DOUB"):
+		return nullable("float64", "sql.NullFloat64")
+	case contains("BOOL"):
+		return nullable("bool", "sql.NullBool")
+	case contains("DATE", "TIME"):
+		if column.Nullable {
+			return "sql.NullTime", "database/sql"
+		}
+		return "time.Time", "time"
+	default:
+		return nullable("float64", "sql.NullFloat64")
+	}
+}
@@ -0,0 +1,63 @@
+package codegen_test
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/sqlite3/codegen"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoType(t *testing.T) {
+	testcases := []struct {
+		column         schema.SchemaColumn
+		wantGoType     string
+		wantImportPath string
+	}{
+		{column: schema.SchemaColumn{Type: "INTEGER"}, wantGoType: "int64", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "BIGINT", Nullable: true}, wantGoType: "sql.NullInt64", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "VARCHAR(10)"}, wantGoType: "string", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "TEXT", Nullable: true}, wantGoType: "sql.NullString", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "BLOB", Nullable: true}, wantGoType: "[]byte", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: ""}, wantGoType: "[]byte", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "DOUBLE PRECISION"}, wantGoType: "float64", wantImportPath: ""},
+		{column: schema.SchemaColumn{Type: "BOOLEAN", Nullable: true}, wantGoType: "sql.NullBool", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "DATETIME"}, wantGoType: "time.Time", wantImportPath: "time"},
+		{column: schema.SchemaColumn{Type: "DATE", Nullable: true}, wantGoType: "sql.NullTime", wantImportPath: "database/sql"},
+		{column: schema.SchemaColumn{Type: "NUMERIC"}, wantGoType: "float64", wantImportPath: ""},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.column.Type), func(t *testing.T) {
+			gotGoType, gotImportPath := codegen.GoType(testcase.column)
+			assert.Equal(t, testcase.wantGoType, gotGoType)
+			assert.Equal(t, testcase.wantImportPath, gotImportPath)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tables := []schema.SchemaTable{
+		{
+			Name:       "t",
+			Columns:    []schema.SchemaColumn{{Name: "id", Type: "INTEGER"}, {Name: "at", Type: "DATETIME"}, {Name: "name", Type: "TEXT", Nullable: true}},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	got, err := codegen.Generate("model", tables)
+	assert.Nil(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "model.go", got, parser.ImportsOnly)
+	assert.Nil(t, err)
+	assert.Equal(t, "model", file.Name.Name)
+	imports := []string{}
+	for _, spec := range file.Imports {
+		imports = append(imports, spec.Path.Value)
+	}
+	assert.Equal(t, []string{`"database/sql"`, `"time"`}, imports)
+	assert.Contains(t, string(got), "func (row T) PrimaryKey() []any {")
+}
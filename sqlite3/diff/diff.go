@@ -0,0 +1,267 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/samber/lo"
+)
+
+type ColumnDiff struct {
+	Name   string              `json:"name"`
+	Before schema.SchemaColumn `json:"before"`
+	After  schema.SchemaColumn `json:"after"`
+}
+
+type TableDiff struct {
+	Name              string                `json:"name"`
+	Before            schema.SchemaTable    `json:"before"`
+	After             schema.SchemaTable    `json:"after"`
+	AddedColumns      []schema.SchemaColumn `json:"added_columns"`
+	DroppedColumns    []schema.SchemaColumn `json:"dropped_columns"`
+	ModifiedColumns   []ColumnDiff          `json:"modified_columns"`
+	PrimaryKeyChanged bool                  `json:"primary_key_changed"`
+	// OptionsChanged reports whether STRICT or WITHOUT ROWID of the table is changed.
+	OptionsChanged     bool                      `json:"options_changed"`
+	AddedForeignKeys   []schema.SchemaForeignKey `json:"added_foreign_keys"`
+	DroppedForeignKeys []schema.SchemaForeignKey `json:"dropped_foreign_keys"`
+	AddedUniqueKeys    []schema.SchemaUniqueKey  `json:"added_unique_keys"`
+	DroppedUniqueKeys  []schema.SchemaUniqueKey  `json:"dropped_unique_keys"`
+	AddedIndexes       []schema.SchemaIndex      `json:"added_indexes"`
+	DroppedIndexes     []schema.SchemaIndex      `json:"dropped_indexes"`
+	AddedChecks        []schema.SchemaCheck      `json:"added_checks"`
+	DroppedChecks      []schema.SchemaCheck      `json:"dropped_checks"`
+}
+
+type Diff struct {
+	AddedTables    []schema.SchemaTable `json:"added_tables"`
+	DroppedTables  []schema.SchemaTable `json:"dropped_tables"`
+	ModifiedTables []TableDiff          `json:"modified_tables"`
+}
+
+// Compare returns differences to migrate tables from before to after.
+func Compare(before, after []schema.SchemaTable) Diff {
+	beforeTables := lo.SliceToMap(before, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+	afterTables := lo.SliceToMap(after, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+
+	diff := Diff{}
+	for _, table := range before {
+		if _, found := afterTables[table.Name]; !found {
+			diff.DroppedTables = append(diff.DroppedTables, table)
+		}
+	}
+	for _, table := range after {
+		beforeTable, found := beforeTables[table.Name]
+		if !found {
+			diff.AddedTables = append(diff.AddedTables, table)
+			continue
+		}
+		if tableDiff := compareTable(beforeTable, table); !tableDiff.IsEmpty() {
+			diff.ModifiedTables = append(diff.ModifiedTables, tableDiff)
+		}
+	}
+
+	return diff
+}
+
+func (diff Diff) IsEmpty() bool {
+	return len(diff.AddedTables) == 0 && len(diff.DroppedTables) == 0 && len(diff.ModifiedTables) == 0
+}
+
+func (diff TableDiff) IsEmpty() bool {
+	return len(diff.AddedColumns) == 0 &&
+		len(diff.DroppedColumns) == 0 &&
+		len(diff.ModifiedColumns) == 0 &&
+		!diff.PrimaryKeyChanged &&
+		!diff.OptionsChanged &&
+		len(diff.AddedForeignKeys) == 0 &&
+		len(diff.DroppedForeignKeys) == 0 &&
+		len(diff.AddedUniqueKeys) == 0 &&
+		len(diff.DroppedUniqueKeys) == 0 &&
+		len(diff.AddedIndexes) == 0 &&
+		len(diff.DroppedIndexes) == 0 &&
+		len(diff.AddedChecks) == 0 &&
+		len(diff.DroppedChecks) == 0
+}
+
+func compareTable(before, after schema.SchemaTable) TableDiff {
+	diff := TableDiff{Name: after.Name, Before: before, After: after}
+
+	beforeColumns := lo.SliceToMap(before.Columns, func(column schema.SchemaColumn) (string, schema.SchemaColumn) { return column.Name, column })
+	afterColumns := lo.SliceToMap(after.Columns, func(column schema.SchemaColumn) (string, schema.SchemaColumn) { return column.Name, column })
+	for _, column := range before.Columns {
+		if _, found := afterColumns[column.Name]; !found {
+			diff.DroppedColumns = append(diff.DroppedColumns, column)
+		}
+	}
+	for _, column := range after.Columns {
+		beforeColumn, found := beforeColumns[column.Name]
+		switch {
+		case !found:
+			diff.AddedColumns = append(diff.AddedColumns, column)
+		case beforeColumn != column:
+			diff.ModifiedColumns = append(diff.ModifiedColumns, ColumnDiff{Name: column.Name, Before: beforeColumn, After: column})
+		}
+	}
+
+	diff.PrimaryKeyChanged = !slices.Equal(before.PrimaryKey, after.PrimaryKey)
+
+	diff.OptionsChanged = before.Strict != after.Strict || before.WithoutRowID != after.WithoutRowID
+
+	diff.DroppedForeignKeys = lo.Filter(before.ForeignKeys, func(foreignKey schema.SchemaForeignKey, _ int) bool {
+		return !lo.ContainsBy(after.ForeignKeys, func(it schema.SchemaForeignKey) bool { return equalForeignKey(it, foreignKey) })
+	})
+	diff.AddedForeignKeys = lo.Filter(after.ForeignKeys, func(foreignKey schema.SchemaForeignKey, _ int) bool {
+		return !lo.ContainsBy(before.ForeignKeys, func(it schema.SchemaForeignKey) bool { return equalForeignKey(it, foreignKey) })
+	})
+
+	diff.DroppedUniqueKeys = lo.Filter(before.UniqueKeys, func(uniqueKey schema.SchemaUniqueKey, _ int) bool {
+		return !lo.ContainsBy(after.UniqueKeys, func(it schema.SchemaUniqueKey) bool { return equalUniqueKey(it, uniqueKey) })
+	})
+	diff.AddedUniqueKeys = lo.Filter(after.UniqueKeys, func(uniqueKey schema.SchemaUniqueKey, _ int) bool {
+		return !lo.ContainsBy(before.UniqueKeys, func(it schema.SchemaUniqueKey) bool { return equalUniqueKey(it, uniqueKey) })
+	})
+
+	diff.DroppedIndexes = lo.Filter(before.Indexes, func(index schema.SchemaIndex, _ int) bool {
+		return !lo.ContainsBy(after.Indexes, func(it schema.SchemaIndex) bool { return equalIndex(it, index) })
+	})
+	diff.AddedIndexes = lo.Filter(after.Indexes, func(index schema.SchemaIndex, _ int) bool {
+		return !lo.ContainsBy(before.Indexes, func(it schema.SchemaIndex) bool { return equalIndex(it, index) })
+	})
+
+	diff.DroppedChecks = lo.Filter(before.Checks, func(check schema.SchemaCheck, _ int) bool {
+		return !lo.Contains(after.Checks, check)
+	})
+	diff.AddedChecks = lo.Filter(after.Checks, func(check schema.SchemaCheck, _ int) bool {
+		return !lo.Contains(before.Checks, check)
+	})
+
+	return diff
+}
+
+func equalForeignKey(a, b schema.SchemaForeignKey) bool {
+	return a.Name == b.Name &&
+		a.ReferencedTable == b.ReferencedTable &&
+		slices.Equal(a.ReferencedKey, b.ReferencedKey) &&
+		slices.Equal(a.ReferencingKey, b.ReferencingKey) &&
+		a.OnDelete == b.OnDelete &&
+		a.OnUpdate == b.OnUpdate &&
+		a.Deferrable == b.Deferrable &&
+		a.InitiallyDeferred == b.InitiallyDeferred
+}
+
+func equalUniqueKey(a, b schema.SchemaUniqueKey) bool {
+	return a.Name == b.Name && slices.Equal(a.Key, b.Key)
+}
+
+func equalIndex(a, b schema.SchemaIndex) bool {
+	return a.Name == b.Name &&
+		a.Unique == b.Unique &&
+		slices.Equal(a.Key, b.Key) &&
+		a.Predicate == b.Predicate
+}
+
+// MigrationDDL returns statements to migrate tables according to the diff.
+// Since SQLite cannot alter most constraints, each modified table is rebuilt by creating a new table, copying rows of the columns remaining, dropping the old table, and renaming the new table, except that only nullable columns without default values, virtual generated columns, named unique keys, or indexes are added or dropped.
+// Named unique keys having the same names as indexes are created and dropped as the indexes.
+// Foreign key enforcement is disabled during the migration and checked at the end by PRAGMA foreign_key_check, which does not fail but returns the rows violating foreign keys.
+// The caller must check that the pragma returns no rows and otherwise roll back, so the statements other than PRAGMA foreign_keys should be run in a transaction since PRAGMA foreign_keys has no effect in a transaction.
+func MigrationDDL(diff Diff) []string {
+	if diff.IsEmpty() {
+		return []string{}
+	}
+
+	stmts := []string{`PRAGMA foreign_keys = OFF`}
+
+	for _, table := range diff.DroppedTables {
+		stmts = append(stmts, fmt.Sprintf(`DROP TABLE %s`, quoteIdentifier(table.Name)))
+	}
+
+	for _, table := range diff.AddedTables {
+		stmts = append(stmts, schema.CreateTableDDL(table))
+		stmts = append(stmts, createIndexesDDL(table)...)
+	}
+
+	for _, table := range diff.ModifiedTables {
+		if !requiresRebuild(table) {
+			for _, uniqueKey := range table.DroppedUniqueKeys {
+				if !schema.HasIndex(table.Before, uniqueKey.Name) {
+					stmts = append(stmts, fmt.Sprintf(`DROP INDEX %s`, quoteIdentifier(uniqueKey.Name)))
+				}
+			}
+			for _, index := range table.DroppedIndexes {
+				stmts = append(stmts, fmt.Sprintf(`DROP INDEX %s`, quoteIdentifier(index.Name)))
+			}
+			for _, column := range table.AddedColumns {
+				stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s`, quoteIdentifier(table.Name), schema.ColumnDefinition(column)))
+			}
+			for _, uniqueKey := range table.AddedUniqueKeys {
+				if !schema.HasIndex(table.After, uniqueKey.Name) {
+					stmts = append(stmts, schema.CreateUniqueIndexDDL(table.Name, uniqueKey))
+				}
+			}
+			for _, index := range table.AddedIndexes {
+				stmts = append(stmts, schema.CreateIndexDDL(table.Name, index))
+			}
+			continue
+		}
+
+		newTable := table.After
+		newTable.Name = "_new_" + table.Name
+		// generated columns are not copied since their values cannot be inserted
+		columns := lo.FilterMap(table.After.Columns, func(column schema.SchemaColumn, _ int) (string, bool) {
+			return quoteIdentifier(column.Name), column.Generated == "" && lo.ContainsBy(table.Before.Columns, func(it schema.SchemaColumn) bool { return it.Name == column.Name && it.Generated == "" })
+		})
+		stmts = append(stmts,
+			schema.CreateTableDDL(newTable),
+			fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM %s`,
+				quoteIdentifier(newTable.Name),
+				strings.Join(columns, ", "),
+				strings.Join(columns, ", "),
+				quoteIdentifier(table.Name),
+			),
+			fmt.Sprintf(`DROP TABLE %s`, quoteIdentifier(table.Name)),
+			fmt.Sprintf(`ALTER TABLE %s RENAME TO %s`, quoteIdentifier(newTable.Name), quoteIdentifier(table.Name)),
+		)
+		stmts = append(stmts, createIndexesDDL(table.After)...)
+	}
+
+	return append(stmts, `PRAGMA foreign_key_check`, `PRAGMA foreign_keys = ON`)
+}
+
+// createIndexesDDL returns statements to create named unique keys and indexes of the table.
+func createIndexesDDL(table schema.SchemaTable) []string {
+	stmts := []string{}
+	for _, uniqueKey := range table.UniqueKeys {
+		if uniqueKey.Name != "" && !schema.HasIndex(table, uniqueKey.Name) {
+			stmts = append(stmts, schema.CreateUniqueIndexDDL(table.Name, uniqueKey))
+		}
+	}
+	for _, index := range table.Indexes {
+		stmts = append(stmts, schema.CreateIndexDDL(table.Name, index))
+	}
+	return stmts
+}
+
+// requiresRebuild returns false if the table can be migrated by ALTER TABLE ADD COLUMN, CREATE INDEX, and DROP INDEX.
+func requiresRebuild(table TableDiff) bool {
+	return len(table.DroppedColumns) > 0 ||
+		len(table.ModifiedColumns) > 0 ||
+		table.PrimaryKeyChanged ||
+		table.OptionsChanged ||
+		len(table.AddedForeignKeys) > 0 ||
+		len(table.DroppedForeignKeys) > 0 ||
+		len(table.AddedChecks) > 0 ||
+		len(table.DroppedChecks) > 0 ||
+		lo.SomeBy(table.AddedColumns, func(column schema.SchemaColumn) bool {
+			return !column.Nullable || column.Default != "" || column.Stored
+		}) ||
+		lo.SomeBy(table.AddedUniqueKeys, func(uniqueKey schema.SchemaUniqueKey) bool { return uniqueKey.Name == "" }) ||
+		lo.SomeBy(table.DroppedUniqueKeys, func(uniqueKey schema.SchemaUniqueKey) bool { return uniqueKey.Name == "" })
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
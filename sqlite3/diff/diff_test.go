@@ -0,0 +1,248 @@
+package diff_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/Jumpaku/gotaface/sqlite3/diff"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/Jumpaku/gotaface/sqlite3/test"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	before := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INTEGER"},
+				{Name: "C1", Type: "TEXT", Nullable: true},
+				{Name: "C2", Type: "TEXT"},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{{Key: []string{"C1"}}},
+		},
+		{
+			Name:       "B",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INTEGER"}},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+	after := []schema.SchemaTable{
+		{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INTEGER"},
+				{Name: "C1", Type: "TEXT"},
+				{Name: "C3", Type: "INTEGER", Nullable: true},
+			},
+			PrimaryKey:  []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{{ReferencedTable: "C", ReferencedKey: []string{"PK"}, ReferencingKey: []string{"C3"}}},
+			UniqueKeys:  []schema.SchemaUniqueKey{{Name: "UQ_A_C1", Key: []string{"C1"}}},
+		},
+		{
+			Name:       "C",
+			Columns:    []schema.SchemaColumn{{Name: "PK", Type: "INTEGER"}},
+			PrimaryKey: []string{"PK"},
+		},
+	}
+
+	got := diff.Compare(before, after)
+
+	assert.Equal(t, []schema.SchemaTable{after[1]}, got.AddedTables)
+	assert.Equal(t, []schema.SchemaTable{before[1]}, got.DroppedTables)
+	assert.Len(t, got.ModifiedTables, 1)
+	a := got.ModifiedTables[0]
+	assert.Equal(t, "A", a.Name)
+	assert.Equal(t, []schema.SchemaColumn{{Name: "C3", Type: "INTEGER", Nullable: true}}, a.AddedColumns)
+	assert.Equal(t, []schema.SchemaColumn{{Name: "C2", Type: "TEXT"}}, a.DroppedColumns)
+	assert.Equal(t, []diff.ColumnDiff{{
+		Name:   "C1",
+		Before: schema.SchemaColumn{Name: "C1", Type: "TEXT", Nullable: true},
+		After:  schema.SchemaColumn{Name: "C1", Type: "TEXT"},
+	}}, a.ModifiedColumns)
+	assert.False(t, a.PrimaryKeyChanged)
+	assert.Equal(t, after[0].ForeignKeys, a.AddedForeignKeys)
+	assert.Empty(t, a.DroppedForeignKeys)
+	assert.Equal(t, after[0].UniqueKeys, a.AddedUniqueKeys)
+	assert.Equal(t, before[0].UniqueKeys, a.DroppedUniqueKeys)
+
+	assert.True(t, diff.Compare(before, before).IsEmpty())
+}
+
+func TestMigrationDDL(t *testing.T) {
+	testcases := []struct {
+		name   string
+		before string
+		after  string
+	}{
+		{
+			name:   "add_nullable_column_and_index",
+			before: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT NOT NULL, PRIMARY KEY (PK));`,
+			after: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT NOT NULL, C2 TEXT, PRIMARY KEY (PK));
+CREATE UNIQUE INDEX UQ_A_C1 ON A (C1);`,
+		},
+		{
+			name: "rebuild_table",
+			before: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT, C2 TEXT NOT NULL, PRIMARY KEY (PK));
+CREATE UNIQUE INDEX UQ_A_C2 ON A (C2);
+CREATE TABLE B (PK INTEGER NOT NULL, PRIMARY KEY (PK));`,
+			after: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT NOT NULL, C3 INTEGER NOT NULL, PRIMARY KEY (PK, C1), UNIQUE (C1), FOREIGN KEY (C3) REFERENCES C (PK));
+CREATE TABLE C (PK INTEGER NOT NULL, PRIMARY KEY (PK));`,
+		},
+		{
+			name: "modify_indexes",
+			before: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT NOT NULL, C2 TEXT, PRIMARY KEY (PK));
+CREATE INDEX IDX_A_C1 ON A (C1);
+CREATE UNIQUE INDEX UQ_A_C2 ON A (C2);`,
+			after: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT NOT NULL, C2 TEXT, PRIMARY KEY (PK));
+CREATE INDEX IDX_A_C1 ON A (C1 DESC);
+CREATE INDEX IDX_A_C2 ON A (C2, C1) WHERE C2 IS NOT NULL;`,
+		},
+		{
+			name: "rebuild_table_with_indexes",
+			before: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT, PRIMARY KEY (PK));
+CREATE INDEX IDX_A_C1 ON A (C1);`,
+			after: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT NOT NULL, PRIMARY KEY (PK));
+CREATE INDEX IDX_A_C1 ON A (C1);
+CREATE UNIQUE INDEX UQ_A_C1 ON A (C1 DESC);`,
+		},
+		{
+			name:   "add_virtual_generated_column",
+			before: `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT, PRIMARY KEY (PK));`,
+			after:  `CREATE TABLE A (PK INTEGER NOT NULL, C1 TEXT, C2 TEXT AS (upper(C1)), PRIMARY KEY (PK));`,
+		},
+		{
+			name:   "rebuild_table_with_defaults",
+			before: `CREATE TABLE A (PK INTEGER PRIMARY KEY AUTOINCREMENT, C1 TEXT DEFAULT 'a', C2 TEXT AS (C1) STORED);`,
+			after:  `CREATE TABLE A (PK INTEGER PRIMARY KEY AUTOINCREMENT, C1 TEXT DEFAULT 'b', C2 TEXT AS (C1 || 'x') STORED, C3 INTEGER NOT NULL DEFAULT (0));`,
+		},
+		{
+			name:   "drop_foreign_key",
+			before: `CREATE TABLE A (PK INTEGER NOT NULL, PRIMARY KEY (PK), FOREIGN KEY (PK) REFERENCES A (PK));`,
+			after:  `CREATE TABLE A (PK INTEGER NOT NULL, PRIMARY KEY (PK));`,
+		},
+		{
+			name:   "modify_checks",
+			before: `CREATE TABLE A (PK INTEGER NOT NULL, C1 INTEGER CHECK (C1 > 0), PRIMARY KEY (PK), CONSTRAINT CK_A CHECK (C1 < 10));`,
+			after:  `CREATE TABLE A (PK INTEGER NOT NULL, C1 INTEGER CHECK (C1 > 0), PRIMARY KEY (PK), CONSTRAINT CK_A CHECK (C1 < 100), CHECK (PK <> C1));`,
+		},
+		{
+			name:   "modify_foreign_key_actions",
+			before: `CREATE TABLE A (PK INTEGER NOT NULL, PRIMARY KEY (PK)); CREATE TABLE B (PK INTEGER NOT NULL, A INTEGER, PRIMARY KEY (PK), CONSTRAINT FK_B_A FOREIGN KEY (A) REFERENCES A (PK));`,
+			after:  `CREATE TABLE A (PK INTEGER NOT NULL, PRIMARY KEY (PK)); CREATE TABLE B (PK INTEGER NOT NULL, A INTEGER, PRIMARY KEY (PK), CONSTRAINT FK_B_A FOREIGN KEY (A) REFERENCES A (PK) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED);`,
+		},
+		{
+			name:   "modify_table_options_and_collation",
+			before: `CREATE TABLE A (PK TEXT NOT NULL, C1 TEXT, PRIMARY KEY (PK));`,
+			after:  `CREATE TABLE A (PK TEXT NOT NULL, C1 TEXT COLLATE NOCASE, PRIMARY KEY (PK)) WITHOUT ROWID, STRICT;`,
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			ctx := context.Background()
+			fetchAll := func(db *sqlx.DB) []schema.SchemaTable {
+				fetcher := schema.NewFetcher(db)
+				tables, err := gf_schema.FetchAll[schema.SchemaTable](ctx, fetcher, fetcher)
+				assert.Nil(t, err)
+				return tables
+			}
+
+			afterDB, afterTeardown := test.Setup(t, fmt.Sprintf("diff_%0d_after.sqlite", number))
+			defer afterTeardown()
+			test.InitDDLs(t, afterDB, []string{testcase.after})
+			after := fetchAll(afterDB)
+
+			db, teardown := test.Setup(t, fmt.Sprintf("diff_%0d.sqlite", number))
+			defer teardown()
+			test.InitDDLs(t, db, []string{testcase.before})
+			before := fetchAll(db)
+
+			err := migrate(ctx, db, diff.MigrationDDL(diff.Compare(before, after)))
+			assert.Nil(t, err)
+
+			migrated := fetchAll(db)
+			assert.True(t, diff.Compare(migrated, after).IsEmpty(), "%+v", diff.Compare(migrated, after))
+		})
+	}
+}
+
+func TestMigrationDDL_ForeignKeyViolation(t *testing.T) {
+	ctx := context.Background()
+	before := `CREATE TABLE A (PK INTEGER NOT NULL, C1 INTEGER, PRIMARY KEY (PK));
+CREATE TABLE B (PK INTEGER NOT NULL, PRIMARY KEY (PK));
+INSERT INTO A (PK, C1) VALUES (1, 1);`
+	after := `CREATE TABLE A (PK INTEGER NOT NULL, C1 INTEGER, PRIMARY KEY (PK), FOREIGN KEY (C1) REFERENCES B (PK));
+CREATE TABLE B (PK INTEGER NOT NULL, PRIMARY KEY (PK));`
+
+	afterDB, afterTeardown := test.Setup(t, "diff_violation_after.sqlite")
+	defer afterTeardown()
+	test.InitDDLs(t, afterDB, []string{after})
+	afterFetcher := schema.NewFetcher(afterDB)
+	afterTables, err := gf_schema.FetchAll[schema.SchemaTable](ctx, afterFetcher, afterFetcher)
+	assert.Nil(t, err)
+
+	db, teardown := test.Setup(t, "diff_violation.sqlite")
+	defer teardown()
+	test.InitDDLs(t, db, []string{before})
+	fetcher := schema.NewFetcher(db)
+	beforeTables, err := gf_schema.FetchAll[schema.SchemaTable](ctx, fetcher, fetcher)
+	assert.Nil(t, err)
+
+	err = migrate(ctx, db, diff.MigrationDDL(diff.Compare(beforeTables, afterTables)))
+	assert.ErrorContains(t, err, "foreign keys are violated")
+
+	rolledBack, err := gf_schema.FetchAll[schema.SchemaTable](ctx, fetcher, fetcher)
+	assert.Nil(t, err)
+	assert.True(t, diff.Compare(rolledBack, beforeTables).IsEmpty(), "%+v", diff.Compare(rolledBack, beforeTables))
+}
+
+// migrate runs the statements in a transaction between the pragmas enabling and disabling foreign keys and rolls back if PRAGMA foreign_key_check returns rows.
+func migrate(ctx context.Context, db *sqlx.DB, stmts []string) error {
+	if len(stmts) == 0 {
+		return nil
+	}
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, stmts[0]); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, stmts[len(stmts)-1])
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range stmts[1 : len(stmts)-1] {
+		if stmt == `PRAGMA foreign_key_check` {
+			var violations []struct {
+				Table  string `db:"table"`
+				RowID  *int64 `db:"rowid"`
+				Parent string `db:"parent"`
+				FKID   int64  `db:"fkid"`
+			}
+			if err := tx.SelectContext(ctx, &violations, stmt); err != nil {
+				return err
+			}
+			if len(violations) > 0 {
+				return fmt.Errorf(`foreign keys are violated: %+v`, violations)
+			}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf(`fail to execute %q: %w`, stmt, err)
+		}
+	}
+	return tx.Commit()
+}
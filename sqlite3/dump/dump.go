@@ -0,0 +1,169 @@
+package dump
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jumpaku/gotaface/dump"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	gf_sqlite3 "github.com/Jumpaku/gotaface/sqlite3"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/samber/lo"
+)
+
+type dumper struct {
+	queryer           gf_sqlite3.Queryer
+	where             string
+	limit             int64
+	orderByPrimaryKey bool
+}
+
+// NewDumper returns a dumper that dumps all rows of tables in arbitrary order.
+func NewDumper(queryer gf_sqlite3.Queryer) dumper {
+	return dumper{queryer: queryer}
+}
+
+// WithWhere returns a dumper that dumps only rows satisfying the condition.
+func (d dumper) WithWhere(condition string) dumper {
+	d.where = condition
+	return d
+}
+
+// WithLimit returns a dumper that dumps at most limit rows of each table.
+func (d dumper) WithLimit(limit int64) dumper {
+	d.limit = limit
+	return d
+}
+
+// WithOrderByPrimaryKey returns a dumper that dumps rows in order of the primary key, which makes outputs stable.
+func (d dumper) WithOrderByPrimaryKey() dumper {
+	d.orderByPrimaryKey = true
+	return d
+}
+
+// Dump writes rows of the table, whose values are typed according to the affinity of the declared types of the columns.
+// Hidden columns of virtual tables are not dumped.
+func (d dumper) Dump(ctx context.Context, table schema.SchemaTable, writer dump.Writer) error {
+	tableColumns := lo.Reject(table.Columns, func(column schema.SchemaColumn, _ int) bool { return column.Hidden })
+	columns := lo.Map(tableColumns, func(column schema.SchemaColumn, _ int) string { return column.Name })
+	query := dump.Query{
+		Table:   quoteIdentifier(table.Name),
+		Columns: lo.Map(columns, func(column string, _ int) string { return quoteIdentifier(column) }),
+		Where:   d.where,
+		Limit:   d.limit,
+	}
+	if d.orderByPrimaryKey {
+		query.OrderBy = lo.Map(table.PrimaryKey, func(column string, _ int) string { return quoteIdentifier(column) })
+	}
+
+	itr, err := d.queryer.QueryxContext(ctx, query.String())
+	if err != nil {
+		return fmt.Errorf(`fail to query rows of %s: %w`, table.Name, err)
+	}
+	defer itr.Close()
+
+	if err := writer.WriteHeader(columns); err != nil {
+		return fmt.Errorf(`fail to write header of %s: %w`, table.Name, err)
+	}
+	for itr.Next() {
+		values, err := itr.SliceScan()
+		if err != nil {
+			return fmt.Errorf(`fail to scan row of %s: %w`, table.Name, err)
+		}
+		for i, column := range tableColumns {
+			values[i] = ConvertValue(column, values[i])
+		}
+		if err := writer.WriteRow(values); err != nil {
+			return fmt.Errorf(`fail to write row of %s: %w`, table.Name, err)
+		}
+	}
+	if err := itr.Err(); err != nil {
+		return fmt.Errorf(`fail to iterate rows of %s: %w`, table.Name, err)
+	}
+	return nil
+}
+
+// ConvertValue converts the value scanned from the column into a value accepted by dump.Writer.
+// Values in columns without declared types are not converted since they can be of any storage class.
+func ConvertValue(column schema.SchemaColumn, value any) any {
+	if value == nil {
+		return nil
+	}
+	if column.Type == "" {
+		return normalize(value)
+	}
+	switch t := schema.ConvertType(column.Type); t.Kind {
+	case gf_schema.TypeKindString:
+		if b, ok := value.([]byte); ok {
+			return string(b)
+		}
+	case gf_schema.TypeKindBytes:
+		if s, ok := value.(string); ok {
+			return []byte(s)
+		}
+	case gf_schema.TypeKindFloat:
+		if i, ok := value.(int64); ok {
+			return float64(i)
+		}
+	case gf_schema.TypeKindDecimal:
+		switch value := value.(type) {
+		case int64:
+			return json.Number(strconv.FormatInt(value, 10))
+		case float64:
+			return json.Number(strconv.FormatFloat(value, 'g', -1, 64))
+		case string:
+			if isNumber(value) {
+				return json.Number(value)
+			}
+		}
+	case gf_schema.TypeKindBool:
+		if i, ok := value.(int64); ok {
+			return i != 0
+		}
+	case gf_schema.TypeKindDate:
+		if v, ok := value.(time.Time); ok {
+			return v.Format(time.DateOnly)
+		}
+	case gf_schema.TypeKindJSON:
+		if s, ok := text(value); ok && json.Valid([]byte(s)) {
+			return json.RawMessage(s)
+		}
+	}
+	return normalize(value)
+}
+
+// isNumber returns true if the string is a number literal in JSON.
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil && json.Valid([]byte(s))
+}
+
+func text(value any) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case []byte:
+		return string(value), true
+	default:
+		return "", false
+	}
+}
+
+func normalize(value any) any {
+	switch value := value.(type) {
+	case []byte:
+		return value
+	case string, int64, float64, bool, time.Time:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
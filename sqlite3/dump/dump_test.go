@@ -0,0 +1,90 @@
+package dump_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	gf_dump "github.com/Jumpaku/gotaface/dump"
+	"github.com/Jumpaku/gotaface/sqlite3/dump"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/Jumpaku/gotaface/sqlite3/test"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+const ddl = `CREATE TABLE T (
+    PK INTEGER NOT NULL,
+    Name TEXT,
+    Data BLOB,
+    Flag BOOLEAN,
+    Price DECIMAL(10, 2),
+    Doc JSON,
+    At DATETIME,
+    Day DATE,
+    PRIMARY KEY (PK)
+)`
+
+var dmls = []string{
+	`INSERT INTO T VALUES (3, 'c', X'0102', 1, 1.5, '{"k":1}', '2024-01-02 03:04:05', '2024-01-02')`,
+	`INSERT INTO T VALUES (1, 'a', NULL, 0, 3, '[1,2]', NULL, NULL)`,
+	`INSERT INTO T VALUES (2, 'b', NULL, NULL, NULL, 'not json', NULL, NULL)`,
+}
+
+var dumperTestcases = []struct {
+	name    string
+	where   string
+	limit   int64
+	ordered bool
+	want    string
+}{
+	{
+		name:    "ordered",
+		ordered: true,
+		want: `{"PK":1,"Name":"a","Data":null,"Flag":false,"Price":3,"Doc":[1,2],"At":null,"Day":null}
+{"PK":2,"Name":"b","Data":null,"Flag":null,"Price":null,"Doc":"not json","At":null,"Day":null}
+{"PK":3,"Name":"c","Data":"AQI=","Flag":true,"Price":1.5,"Doc":{"k":1},"At":"2024-01-02T03:04:05Z","Day":"2024-01-02"}
+`,
+	},
+	{
+		name:    "where",
+		where:   `"PK" <> 2`,
+		ordered: true,
+		want: `{"PK":1,"Name":"a","Data":null,"Flag":false,"Price":3,"Doc":[1,2],"At":null,"Day":null}
+{"PK":3,"Name":"c","Data":"AQI=","Flag":true,"Price":1.5,"Doc":{"k":1},"At":"2024-01-02T03:04:05Z","Day":"2024-01-02"}
+`,
+	},
+	{
+		name:    "limit",
+		limit:   1,
+		ordered: true,
+		want: `{"PK":1,"Name":"a","Data":null,"Flag":false,"Price":3,"Doc":[1,2],"At":null,"Day":null}
+`,
+	},
+}
+
+func TestDumper(t *testing.T) {
+	for number, testcase := range dumperTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			db, teardown := test.Setup(t, fmt.Sprintf("dumper_%0d.sqlite", number))
+			defer teardown()
+
+			test.InitDDLs(t, db, append([]string{ddl}, dmls...))
+
+			table, err := schema.NewFetcher(db).Fetch(context.Background(), "T")
+			assert.Nil(t, err)
+
+			sut := dump.NewDumper(db).WithWhere(testcase.where).WithLimit(testcase.limit)
+			if testcase.ordered {
+				sut = sut.WithOrderByPrimaryKey()
+			}
+			var got bytes.Buffer
+			writer := gf_dump.NewJSONLWriter(&got)
+			err = sut.Dump(context.Background(), table, writer)
+			assert.Nil(t, err)
+			assert.Nil(t, writer.Flush())
+			assert.Equal(t, testcase.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gf_dependency "github.com/Jumpaku/gotaface/dependency"
+	"github.com/Jumpaku/gotaface/fixture"
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/Jumpaku/gotaface/sqlite3/dependency"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+	"github.com/samber/lo"
+)
+
+type loader struct {
+	db *sqlx.DB
+}
+
+// NewLoader returns a loader that inserts rows of fixtures in a transaction.
+func NewLoader(db *sqlx.DB) loader {
+	return loader{db: db}
+}
+
+// Load inserts rows of the fixture into the tables in insert order resolved from their foreign keys, in which all the rows are inserted or none of them are.
+// Foreign keys are deferred if the tables reference each other, and violations of them are checked before commit.
+// The tables must include all the tables in the fixture.
+func (l loader) Load(ctx context.Context, tables []schema.SchemaTable, f fixture.Fixture) error {
+	tableMap := lo.SliceToMap(tables, func(table schema.SchemaTable) (string, schema.SchemaTable) { return table.Name, table })
+	targets := []schema.SchemaTable{}
+	for _, name := range f.Tables() {
+		table, found := tableMap[name]
+		if !found {
+			return fmt.Errorf(`fail to load fixture: table %q is not found`, name)
+		}
+		targets = append(targets, table)
+	}
+	order, err := gf_dependency.Resolve(dependency.NewGraph(targets))
+	if err != nil {
+		return fmt.Errorf(`fail to resolve insert order: %w`, err)
+	}
+
+	tx, err := l.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(`fail to begin transaction: %w`, err)
+	}
+	defer tx.Rollback()
+
+	deferred := len(order.Cycles) > 0
+	if deferred {
+		if _, err := tx.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return fmt.Errorf(`fail to defer foreign keys: %w`, err)
+		}
+	}
+
+	// rowIndexes maps rowids of inserted rows to their positions in the fixture for each table.
+	rowIndexes := map[string]map[int64]int{}
+	for _, name := range order.InsertOrder {
+		table := tableMap[name]
+		rowIndexes[name] = map[int64]int{}
+		for index, row := range f[name] {
+			rowid, err := insertRow(ctx, tx, table, row)
+			if err != nil {
+				return &fixture.RowError{Table: name, Index: index, Constraint: constraint(err), Err: err}
+			}
+			rowIndexes[name][rowid] = index
+		}
+	}
+
+	if deferred {
+		if err := checkForeignKeys(ctx, tx, rowIndexes); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`fail to commit fixture: %w`, err)
+	}
+	return nil
+}
+
+func insertRow(ctx context.Context, tx *sqlx.Tx, table schema.SchemaTable, row fixture.Row) (rowid int64, err error) {
+	// hidden columns of virtual tables cannot be inserted as ordinary columns
+	columns, err := fixture.Columns(lo.FilterMap(table.Columns, func(column schema.SchemaColumn, _ int) (string, bool) { return column.Name, !column.Hidden }), row)
+	if err != nil {
+		return 0, err
+	}
+	values := []any{}
+	for _, column := range table.Columns {
+		if !lo.Contains(columns, column.Name) {
+			continue
+		}
+		value, err := ConvertValue(column, row[column.Name])
+		if err != nil {
+			return 0, fmt.Errorf(`fail to convert value of %s: %w`, column.Name, err)
+		}
+		values = append(values, value)
+	}
+
+	stmt := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`,
+		quoteIdentifier(table.Name),
+		strings.Join(lo.Map(columns, func(column string, _ int) string { return quoteIdentifier(column) }), ", "),
+		strings.Join(lo.Map(columns, func(string, int) string { return "?" }), ", "))
+	if len(columns) == 0 {
+		stmt = fmt.Sprintf(`INSERT INTO %s DEFAULT VALUES`, quoteIdentifier(table.Name))
+	}
+	result, err := tx.ExecContext(ctx, stmt, values...)
+	if err != nil {
+		return 0, err
+	}
+	// WITHOUT ROWID tables do not have rowids.
+	rowid, _ = result.LastInsertId()
+	return rowid, nil
+}
+
+// checkForeignKeys returns an error on the first row violating a foreign key, which is reported by PRAGMA foreign_key_check.
+func checkForeignKeys(ctx context.Context, tx *sqlx.Tx, rowIndexes map[string]map[int64]int) error {
+	itr, err := tx.QueryxContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return fmt.Errorf(`fail to check foreign keys: %w`, err)
+	}
+	defer itr.Close()
+
+	if itr.Next() {
+		var table, parent string
+		var rowid *int64
+		var fkid int
+		if err := itr.Scan(&table, &rowid, &parent, &fkid); err != nil {
+			return fmt.Errorf(`fail to scan foreign key violation: %w`, err)
+		}
+		index := -1
+		if rowid != nil {
+			if i, found := rowIndexes[table][*rowid]; found {
+				index = i
+			}
+		}
+		return &fixture.RowError{
+			Table:      table,
+			Index:      index,
+			Constraint: fmt.Sprintf(`FOREIGN KEY constraint referencing %s`, parent),
+			Err:        fmt.Errorf(`referenced row is not found`),
+		}
+	}
+	return itr.Err()
+}
+
+// constraint returns the kind of the constraint violated by the error if available.
+func constraint(err error) string {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code != sqlite3.ErrConstraint {
+		return ""
+	}
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintCheck:
+		return "CHECK constraint"
+	case sqlite3.ErrConstraintForeignKey:
+		return "FOREIGN KEY constraint"
+	case sqlite3.ErrConstraintNotNull:
+		return "NOT NULL constraint"
+	case sqlite3.ErrConstraintPrimaryKey:
+		return "PRIMARY KEY constraint"
+	case sqlite3.ErrConstraintUnique:
+		return "UNIQUE constraint"
+	default:
+		return "constraint"
+	}
+}
+
+// ConvertValue converts the value parsed from a fixture into a value to be inserted into the column according to the affinity of the declared type.
+// Values for columns without declared types are not converted except for numbers.
+func ConvertValue(column schema.SchemaColumn, value any) (any, error) {
+	if column.Type == "" {
+		return fixture.ConvertValue(gf_schema.Type{Kind: gf_schema.TypeKindOther}, value)
+	}
+	return fixture.ConvertValue(schema.ConvertType(column.Type), value)
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
@@ -0,0 +1,110 @@
+package fixture_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gf_fixture "github.com/Jumpaku/gotaface/fixture"
+	"github.com/Jumpaku/gotaface/sqlite3/fixture"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/Jumpaku/gotaface/sqlite3/test"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var ddls = []string{
+	`CREATE TABLE Parent (ID INTEGER PRIMARY KEY, Name TEXT NOT NULL UNIQUE)`,
+	`CREATE TABLE Child (ID INTEGER PRIMARY KEY, ParentID INTEGER NOT NULL REFERENCES Parent (ID), Data BLOB, Price DECIMAL(10, 2))`,
+	`CREATE TABLE X (ID INTEGER PRIMARY KEY, YID INTEGER REFERENCES Y (ID))`,
+	`CREATE TABLE Y (ID INTEGER PRIMARY KEY, XID INTEGER REFERENCES X (ID))`,
+}
+
+type childRow struct {
+	ID       int64    `db:"ID"`
+	ParentID int64    `db:"ParentID"`
+	Data     []byte   `db:"Data"`
+	Price    *float64 `db:"Price"`
+}
+
+var loaderTestcases = []struct {
+	name      string
+	fixture   string
+	wantErr   *gf_fixture.RowError
+	wantChild []*childRow
+}{
+	{
+		name: "foreign_key_order",
+		fixture: `{
+  "Child": [{"ID": 1, "ParentID": 2, "Data": "AQI=", "Price": 12.50}, {"ID": 2, "ParentID": 1}],
+  "Parent": [{"ID": 1, "Name": "a"}, {"ID": 2, "Name": "b"}]
+}`,
+		wantChild: []*childRow{{ID: 1, ParentID: 2, Data: []byte{1, 2}, Price: lo.ToPtr(12.5)}, {ID: 2, ParentID: 1}},
+	},
+	{
+		name:    "cycle",
+		fixture: `{"X": [{"ID": 1, "YID": 1}], "Y": [{"ID": 1, "XID": 1}]}`,
+	},
+	{
+		name:    "unique_violation",
+		fixture: `{"Parent": [{"ID": 1, "Name": "a"}, {"ID": 2, "Name": "a"}]}`,
+		wantErr: &gf_fixture.RowError{Table: "Parent", Index: 1, Constraint: "UNIQUE constraint"},
+	},
+	{
+		name:    "foreign_key_violation",
+		fixture: `{"Child": [{"ID": 1, "ParentID": 1}, {"ID": 2, "ParentID": 3}], "Parent": [{"ID": 1, "Name": "a"}]}`,
+		wantErr: &gf_fixture.RowError{Table: "Child", Index: 1, Constraint: "FOREIGN KEY constraint"},
+	},
+	{
+		name:    "deferred_foreign_key_violation",
+		fixture: `{"X": [{"ID": 1, "YID": 1}, {"ID": 2, "YID": 2}], "Y": [{"ID": 1, "XID": 1}]}`,
+		wantErr: &gf_fixture.RowError{Table: "X", Index: 1, Constraint: "FOREIGN KEY constraint referencing Y"},
+	},
+	{
+		name:    "unknown_column",
+		fixture: `{"Parent": [{"ID": 1, "Name": "a", "Unknown": 1}]}`,
+		wantErr: &gf_fixture.RowError{Table: "Parent", Index: 0},
+	},
+}
+
+func TestLoader(t *testing.T) {
+	for number, testcase := range loaderTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			db, teardown := test.Setup(t, fmt.Sprintf("loader_%0d.sqlite", number))
+			defer teardown()
+
+			// PRAGMA foreign_keys is a setting of each connection.
+			db.SetMaxOpenConns(1)
+			if _, err := db.Exec(`PRAGMA foreign_keys = ON`); err != nil {
+				t.Fatalf(`fail to enable foreign keys: %v`, err)
+			}
+			test.InitDDLs(t, db, ddls)
+
+			f, err := gf_fixture.Parse(gf_fixture.FormatJSON, []byte(testcase.fixture))
+			assert.Nil(t, err)
+
+			fetcher := schema.NewFetcher(db)
+			tables := lo.Map([]string{"Parent", "Child", "X", "Y"}, func(name string, _ int) schema.SchemaTable {
+				table, err := fetcher.Fetch(context.Background(), name)
+				assert.Nil(t, err)
+				return table
+			})
+
+			err = fixture.NewLoader(db).Load(context.Background(), tables, f)
+			if testcase.wantErr != nil {
+				var rowErr *gf_fixture.RowError
+				assert.ErrorAs(t, err, &rowErr)
+				assert.Equal(t, testcase.wantErr.Table, rowErr.Table)
+				assert.Equal(t, testcase.wantErr.Index, rowErr.Index)
+				assert.Equal(t, testcase.wantErr.Constraint, rowErr.Constraint)
+				assert.Empty(t, test.ListRows[childRow](t, db, "Child"))
+				return
+			}
+			assert.Nil(t, err)
+			if testcase.wantChild != nil {
+				assert.Equal(t, testcase.wantChild, test.ListRows[childRow](t, db, "Child"))
+			}
+		})
+	}
+}
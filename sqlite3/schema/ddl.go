@@ -0,0 +1,173 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// GenerateDDL returns statements to create the given tables.
+// Named unique keys, which are fetched from unique indexes, and indexes are created by CREATE INDEX statements following all CREATE TABLE statements.
+// Named unique keys having the same names as indexes are created as the indexes.
+func GenerateDDL(tables []SchemaTable) []string {
+	stmts := []string{}
+	for _, table := range tables {
+		stmts = append(stmts, CreateTableDDL(table))
+	}
+	for _, table := range tables {
+		for _, uniqueKey := range table.UniqueKeys {
+			if uniqueKey.Name != "" && !HasIndex(table, uniqueKey.Name) {
+				stmts = append(stmts, CreateUniqueIndexDDL(table.Name, uniqueKey))
+			}
+		}
+		for _, index := range table.Indexes {
+			stmts = append(stmts, CreateIndexDDL(table.Name, index))
+		}
+	}
+	return stmts
+}
+
+// CreateTableDDL returns a CREATE TABLE statement with columns, primary key, foreign keys, unnamed unique keys, CHECK constraints, and options of the table.
+// The primary key is declared in the column definition if the column has AUTOINCREMENT.
+// A CREATE VIRTUAL TABLE statement with the module arguments is returned for a virtual table.
+func CreateTableDDL(table SchemaTable) string {
+	if table.Module != "" {
+		return fmt.Sprintf("CREATE VIRTUAL TABLE %s USING %s(%s)", quoteIdentifier(table.Name), table.Module, strings.Join(table.ModuleArguments, ", "))
+	}
+
+	definitions := []string{}
+	for _, column := range table.Columns {
+		definitions = append(definitions, ColumnDefinition(column))
+	}
+	autoIncrement := lo.SomeBy(table.Columns, func(column SchemaColumn) bool { return column.AutoIncrement })
+	if len(table.PrimaryKey) > 0 && !autoIncrement {
+		definitions = append(definitions, fmt.Sprintf(`PRIMARY KEY (%s)`, quoteIdentifiers(table.PrimaryKey)))
+	}
+	for _, foreignKey := range table.ForeignKeys {
+		definitions = append(definitions, ForeignKeyDefinition(foreignKey))
+	}
+	for _, uniqueKey := range table.UniqueKeys {
+		if uniqueKey.Name == "" {
+			definitions = append(definitions, fmt.Sprintf(`UNIQUE (%s)`, quoteIdentifiers(uniqueKey.Key)))
+		}
+	}
+	for _, check := range table.Checks {
+		definitions = append(definitions, CheckDefinition(check))
+	}
+
+	options := []string{}
+	if table.WithoutRowID {
+		options = append(options, "WITHOUT ROWID")
+	}
+	if table.Strict {
+		options = append(options, "STRICT")
+	}
+
+	stmt := fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", quoteIdentifier(table.Name), strings.Join(definitions, ",\n    "))
+	if len(options) > 0 {
+		stmt += " " + strings.Join(options, ", ")
+	}
+	return stmt
+}
+
+// CheckDefinition returns a CHECK constraint definition used in CREATE TABLE statements.
+func CheckDefinition(check SchemaCheck) string {
+	definition := fmt.Sprintf(`CHECK (%s)`, check.Expression)
+	if check.Name != "" {
+		definition = fmt.Sprintf(`CONSTRAINT %s %s`, quoteIdentifier(check.Name), definition)
+	}
+	return definition
+}
+
+// ForeignKeyDefinition returns a FOREIGN KEY constraint definition used in CREATE TABLE statements.
+func ForeignKeyDefinition(foreignKey SchemaForeignKey) string {
+	definition := fmt.Sprintf(`FOREIGN KEY (%s) REFERENCES %s (%s)`,
+		quoteIdentifiers(foreignKey.ReferencingKey),
+		quoteIdentifier(foreignKey.ReferencedTable),
+		quoteIdentifiers(foreignKey.ReferencedKey),
+	)
+	if foreignKey.Name != "" {
+		definition = fmt.Sprintf(`CONSTRAINT %s %s`, quoteIdentifier(foreignKey.Name), definition)
+	}
+	if foreignKey.OnDelete != "" {
+		definition += " ON DELETE " + foreignKey.OnDelete
+	}
+	if foreignKey.OnUpdate != "" {
+		definition += " ON UPDATE " + foreignKey.OnUpdate
+	}
+	if foreignKey.Deferrable {
+		definition += " DEFERRABLE" + lo.Ternary(foreignKey.InitiallyDeferred, " INITIALLY DEFERRED", "")
+	}
+	return definition
+}
+
+// ColumnDefinition returns a column definition used in CREATE TABLE and ALTER TABLE ADD COLUMN statements.
+// Default values and generation expressions are enclosed in parentheses so that any expressions are accepted.
+func ColumnDefinition(column SchemaColumn) string {
+	definition := quoteIdentifier(column.Name)
+	if column.Type != "" {
+		definition += " " + column.Type
+	}
+	if !column.Nullable {
+		definition += " NOT NULL"
+	}
+	if column.AutoIncrement {
+		definition += " PRIMARY KEY AUTOINCREMENT"
+	}
+	if column.Default != "" {
+		definition += " DEFAULT (" + column.Default + ")"
+	}
+	if column.Collation != "" {
+		definition += " COLLATE " + column.Collation
+	}
+	if column.Generated != "" {
+		definition += " GENERATED ALWAYS AS (" + column.Generated + ")" + lo.Ternary(column.Stored, " STORED", " VIRTUAL")
+	}
+	return definition
+}
+
+// CreateUniqueIndexDDL returns a CREATE UNIQUE INDEX statement for the named unique key of the table.
+func CreateUniqueIndexDDL(table string, uniqueKey SchemaUniqueKey) string {
+	return fmt.Sprintf(`CREATE UNIQUE INDEX %s ON %s (%s)`,
+		quoteIdentifier(uniqueKey.Name),
+		quoteIdentifier(table),
+		quoteIdentifiers(uniqueKey.Key),
+	)
+}
+
+// CreateIndexDDL returns a CREATE INDEX statement for the index of the table.
+func CreateIndexDDL(table string, index SchemaIndex) string {
+	stmt := fmt.Sprintf(`CREATE %sINDEX %s ON %s (%s)`,
+		lo.Ternary(index.Unique, "UNIQUE ", ""),
+		quoteIdentifier(index.Name),
+		quoteIdentifier(table),
+		quoteIndexKey(index.Key),
+	)
+	if index.Predicate != "" {
+		stmt += " WHERE " + index.Predicate
+	}
+	return stmt
+}
+
+// HasIndex reports whether the table has the index of the name.
+func HasIndex(table SchemaTable, name string) bool {
+	return lo.ContainsBy(table.Indexes, func(index SchemaIndex) bool { return strings.EqualFold(index.Name, name) })
+}
+
+func quoteIndexKey(key []SchemaIndexKey) string {
+	return strings.Join(lo.Map(key, func(column SchemaIndexKey, _ int) string {
+		if column.Expression != "" {
+			return column.Expression + lo.Ternary(column.Desc, " DESC", "")
+		}
+		return quoteIdentifier(column.Name) + lo.Ternary(column.Desc, " DESC", "")
+	}), ", ")
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func quoteIdentifiers(identifiers []string) string {
+	return strings.Join(lo.Map(identifiers, func(identifier string, _ int) string { return quoteIdentifier(identifier) }), ", ")
+}
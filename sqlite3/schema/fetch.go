@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/Jumpaku/go-assert"
+	"github.com/Jumpaku/gotaface/ddl"
 	"github.com/Jumpaku/gotaface/schema"
 	gf_sqlite3 "github.com/Jumpaku/gotaface/sqlite3"
 	"github.com/jmoiron/sqlx"
@@ -16,33 +18,114 @@ type SchemaColumn struct {
 	Name     string `json:"name"`
 	Type     string `json:"type"`
 	Nullable bool   `json:"nullable"`
+	// Default is the expression of the default value as written, which is empty if the column has no default value.
+	Default string `json:"default"`
+	// Generated is the expression of the generated column as written, which is empty if the column is not generated.
+	Generated string `json:"generated"`
+	// Stored reports whether the generated column is STORED rather than VIRTUAL.
+	Stored bool `json:"stored"`
+	// AutoIncrement reports whether the column is the INTEGER PRIMARY KEY with AUTOINCREMENT.
+	AutoIncrement bool `json:"auto_increment"`
+	// Collation is the collating sequence declared by COLLATE as written, which is empty if the column is declared without COLLATE.
+	Collation string `json:"collation"`
+	// Hidden reports whether the column is a hidden column of a virtual table, such as the column named after an FTS table.
+	Hidden bool `json:"hidden"`
 }
 type SchemaForeignKey struct {
+	Name            string   `json:"name"`
 	ReferencedTable string   `json:"referenced_table"`
 	ReferencedKey   []string `json:"referenced_key"`
 	ReferencingKey  []string `json:"referencing_key"`
+	// OnDelete and OnUpdate are the referential actions CASCADE, SET NULL, SET DEFAULT, or RESTRICT, which are empty for NO ACTION.
+	OnDelete string `json:"on_delete"`
+	OnUpdate string `json:"on_update"`
+	// Deferrable and InitiallyDeferred report DEFERRABLE INITIALLY DEFERRED, which is the only clause making the foreign key deferred in SQLite.
+	Deferrable        bool `json:"deferrable"`
+	InitiallyDeferred bool `json:"initially_deferred"`
+}
+
+// SchemaReference is a foreign key of a table referencing the table that has the reference.
+type SchemaReference struct {
+	// Name is the name of the foreign key, which is empty if the foreign key is not named.
+	Name             string   `json:"name"`
+	ReferencingTable string   `json:"referencing_table"`
+	ReferencingKey   []string `json:"referencing_key"`
+	ReferencedKey    []string `json:"referenced_key"`
 }
 type SchemaUniqueKey struct {
 	Name string   `json:"name"`
 	Key  []string `json:"key"`
 }
+type SchemaIndexKey struct {
+	// Name is the column of the key part, which is empty if the key part is an expression.
+	Name string `json:"name"`
+	Desc bool   `json:"desc"`
+	// Expression is the expression of the key part as written, which is empty if the key part is a column.
+	Expression string `json:"expression"`
+}
+type SchemaIndex struct {
+	Name   string           `json:"name"`
+	Unique bool             `json:"unique"`
+	Key    []SchemaIndexKey `json:"key"`
+	// Predicate is the condition of a partial index, which is empty if the index is not partial.
+	Predicate string `json:"predicate"`
+}
+type SchemaCheck struct {
+	// Name is the name of the CHECK constraint, which is empty if the constraint is not named.
+	Name string `json:"name"`
+	// Expression is the condition of the CHECK constraint as written.
+	Expression string `json:"expression"`
+}
 type SchemaTable struct {
-	Name        string             `json:"name"`
+	Name string `json:"name"`
+	// Strict reports whether the table is declared with STRICT.
+	Strict bool `json:"strict"`
+	// WithoutRowID reports whether the table is declared with WITHOUT ROWID.
+	WithoutRowID bool `json:"without_rowid"`
+	// Module is the module of the virtual table in lower case such as fts5 and rtree, which is empty if the table is not a virtual table.
+	Module string `json:"module"`
+	// ModuleArguments are the arguments of the module of the virtual table as written.
+	ModuleArguments []string `json:"module_arguments"`
+	// Columns include the hidden columns of the virtual table.
 	Columns     []SchemaColumn     `json:"columns"`
 	PrimaryKey  []string           `json:"primary_key"`
 	ForeignKeys []SchemaForeignKey `json:"foreign_key"`
 	UniqueKeys  []SchemaUniqueKey  `json:"unique_key"`
+	// Indexes are the indexes created by CREATE INDEX statements, which include the unique indexes also fetched as named unique keys.
+	Indexes []SchemaIndex `json:"index"`
+	// Checks are the CHECK constraints of the table and its columns in the order they are written.
+	Checks []SchemaCheck `json:"check"`
+	// ReferencedBy are the foreign keys referencing the table, which are fetched only by fetchers configured with WithReferencedBy.
+	ReferencedBy []SchemaReference `json:"referenced_by"`
+}
+
+// IsFTS5 reports whether the table is a virtual table of the FTS5 full-text search module.
+func (table SchemaTable) IsFTS5() bool {
+	return table.Module == "fts5"
+}
+
+// IsRTree reports whether the table is a virtual table of the R*Tree module.
+func (table SchemaTable) IsRTree() bool {
+	return table.Module == "rtree" || table.Module == "rtree_i32"
 }
 
 type fetcher struct {
-	queryer sqlx.QueryerContext
+	queryer      sqlx.QueryerContext
+	referencedBy bool
 }
 
 func NewFetcher(queryer gf_sqlite3.Queryer) fetcher {
 	return fetcher{queryer: queryer}
 }
 
+// WithReferencedBy returns a copy of the fetcher that also fetches foreign keys referencing the table into ReferencedBy.
+func (fetcher fetcher) WithReferencedBy() fetcher {
+	fetcher.referencedBy = true
+	return fetcher
+}
+
 var _ schema.Fetcher[SchemaTable] = fetcher{}
+var _ schema.Lister = fetcher{}
 
 func (fetcher fetcher) Fetch(ctx context.Context, table string) (SchemaTable, error) {
 	wrapError := func(err error) (SchemaTable, error) {
@@ -50,10 +133,26 @@ func (fetcher fetcher) Fetch(ctx context.Context, table string) (SchemaTable, er
 		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: %w`, table, err)
 	}
 
-	schemaTable := SchemaTable{Name: table}
+	isView, err := queryIsView(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+	if isView {
+		return wrapError(fmt.Errorf(`%s is a view`, table))
+	}
 
-	var err error
-	schemaTable.Columns, err = queryColumns(ctx, fetcher.queryer, table)
+	// details written only in the CREATE TABLE statement are taken from the statement parsed once
+	definition, err := queryTableDefinition(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable, err := getTable(ctx, fetcher.queryer, table, definition)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaTable.Columns, err = queryColumns(ctx, fetcher.queryer, table, definition)
 	if err != nil {
 		return wrapError(err)
 	}
@@ -63,7 +162,7 @@ func (fetcher fetcher) Fetch(ctx context.Context, table string) (SchemaTable, er
 		return wrapError(err)
 	}
 
-	schemaTable.ForeignKeys, err = queryForeignKeys(ctx, fetcher.queryer, table)
+	schemaTable.ForeignKeys, err = queryForeignKeys(ctx, fetcher.queryer, table, definition)
 	if err != nil {
 		return wrapError(err)
 	}
@@ -73,16 +172,92 @@ func (fetcher fetcher) Fetch(ctx context.Context, table string) (SchemaTable, er
 		return wrapError(err)
 	}
 
+	schemaTable.Indexes, err = queryIndexes(ctx, fetcher.queryer, table)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	// CHECK constraints are available only in the CREATE TABLE statement, which are omitted if the statement cannot be parsed
+	schemaTable.Checks = definition.Checks
+
+	if fetcher.referencedBy {
+		schemaTable.ReferencedBy, err = queryReferencedBy(ctx, fetcher.queryer, table, schemaTable.PrimaryKey)
+		if err != nil {
+			return wrapError(err)
+		}
+	}
+
 	return schemaTable, nil
 }
 
-func queryColumns(ctx context.Context, tx gf_sqlite3.Queryer, table string) ([]SchemaColumn, error) {
+// ListTables returns names of tables including virtual tables, in which shadow tables storing contents of virtual tables are excluded.
+func (fetcher fetcher) ListTables(ctx context.Context) ([]string, error) {
+	sql := `--sql query user table names
+SELECT
+	"name" AS Name
+FROM pragma_table_list
+WHERE "schema" = 'main' AND "type" IN ('table', 'virtual') AND "name" NOT LIKE 'sqlite\_%' ESCAPE '\'
+ORDER BY "name"`
+	rows, err := fetcher.queryer.QueryxContext(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	type table struct {
+		Name string `db:"Name"`
+	}
+	tables, err := gf_sqlite3.ScanRowsStruct[table](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	return lo.Map(tables, func(it table, i int) string { return it.Name }), nil
+}
+
+// getTable returns the table with the options, which are available in pragma_table_list, and the module of the virtual table taken from the definition.
+func getTable(ctx context.Context, tx gf_sqlite3.Queryer, table string, definition SchemaTable) (SchemaTable, error) {
+	sql := `--sql query table options
+SELECT
+	"name" AS Name,
+	"type" AS Type,
+	"wr" AS WithoutRowID,
+	"strict" AS Strict
+FROM pragma_table_list(?)
+WHERE "schema" = 'main'`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return SchemaTable{}, fmt.Errorf(`fail to get table %s: %w`, table, err)
+	}
+	type tableRow struct {
+		Name         string `db:"Name"`
+		Type         string `db:"Type"`
+		WithoutRowID bool   `db:"WithoutRowID"`
+		Strict       bool   `db:"Strict"`
+	}
+	tableRows, err := gf_sqlite3.ScanRowsStruct[tableRow](rows)
+	if err != nil {
+		return SchemaTable{}, fmt.Errorf(`fail to get table %s: %w`, table, err)
+	}
+	if len(tableRows) == 0 {
+		return SchemaTable{}, fmt.Errorf(`table %q not found`, table)
+	}
+	schemaTable := SchemaTable{Name: table, WithoutRowID: tableRows[0].WithoutRowID, Strict: tableRows[0].Strict}
+	if tableRows[0].Type == "virtual" {
+		// modules of virtual tables are available only in the CREATE VIRTUAL TABLE statement
+		schemaTable.Module = definition.Module
+		schemaTable.ModuleArguments = definition.ModuleArguments
+	}
+	return schemaTable, nil
+}
+
+// queryColumns returns the columns in pragma_table_xinfo, in which generation expressions, AUTOINCREMENT, and collations are taken from the definition.
+func queryColumns(ctx context.Context, tx gf_sqlite3.Queryer, table string, definition SchemaTable) ([]SchemaColumn, error) {
 	sql := `--sql query column information
 SELECT 
 	"name" AS Name,
 	"type" AS Type,
-	"notnull" = 0 AS Nullable
-FROM pragma_table_info(?)
+	"notnull" = 0 AS Nullable,
+	IFNULL("dflt_value", '') AS "Default",
+	"hidden" AS Hidden
+FROM pragma_table_xinfo(?)
 ORDER BY "cid"`
 	rows, err := tx.QueryxContext(ctx, sql, table)
 	if err != nil {
@@ -92,6 +267,9 @@ ORDER BY "cid"`
 		Name     string `db:"Name"`
 		Type     string `db:"Type"`
 		Nullable bool   `db:"Nullable"`
+		Default  string `db:"Default"`
+		// Hidden is 1 for hidden columns of virtual tables, 2 for virtual generated columns, and 3 for stored generated columns.
+		Hidden int `db:"Hidden"`
 	}
 	columns, err := gf_sqlite3.ScanRowsStruct[column](rows)
 	if err != nil {
@@ -99,14 +277,58 @@ ORDER BY "cid"`
 	}
 
 	return lo.Map(columns, func(column column, index int) SchemaColumn {
-		return SchemaColumn{
+		schemaColumn := SchemaColumn{
 			Name:     column.Name,
 			Type:     column.Type,
 			Nullable: column.Nullable,
+			Default:  column.Default,
+			Stored:   column.Hidden == 3,
+			Hidden:   column.Hidden == 1,
 		}
+		if defined, found := lo.Find(definition.Columns, func(it SchemaColumn) bool { return strings.EqualFold(it.Name, column.Name) }); found {
+			schemaColumn.Generated = defined.Generated
+			schemaColumn.AutoIncrement = defined.AutoIncrement
+			schemaColumn.Collation = defined.Collation
+		}
+		return schemaColumn
 	}), nil
 }
 
+// queryTableDefinition parses the CREATE TABLE or CREATE VIRTUAL TABLE statement of the table stored in sqlite_master.
+// It returns an empty table if the statement is not found or cannot be parsed, since SQLite accepts statements the parser does not support,
+// in which case the details available only in the statement are omitted and the others are fetched from the pragmas.
+func queryTableDefinition(ctx context.Context, tx gf_sqlite3.Queryer, table string) (SchemaTable, error) {
+	sql := `--sql query table definition
+SELECT 
+	"sql" AS SQL
+FROM sqlite_master
+WHERE "type" = 'table' AND "name" = ? COLLATE NOCASE`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return SchemaTable{}, fmt.Errorf(`fail to get definition of %s: %w`, table, err)
+	}
+	type definitionRow struct {
+		SQL string `db:"SQL"`
+	}
+	definitions, err := gf_sqlite3.ScanRowsStruct[definitionRow](rows)
+	if err != nil {
+		return SchemaTable{}, fmt.Errorf(`fail to get definition of %s: %w`, table, err)
+	}
+	if len(definitions) == 0 {
+		return SchemaTable{}, nil
+	}
+
+	fetcher, err := NewDDLFetcher(definitions[0].SQL)
+	if err != nil {
+		return SchemaTable{}, nil
+	}
+	parsed, err := fetcher.Fetch(ctx, table)
+	if err != nil {
+		return SchemaTable{}, nil
+	}
+	return parsed, nil
+}
+
 func queryPrimaryKey(ctx context.Context, tx gf_sqlite3.Queryer, table string) ([]string, error) {
 	sql := `--sql query primary key information
 SELECT
@@ -125,17 +347,24 @@ ORDER BY "pk"`
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
 	}
+	if len(primaryKey) == 0 {
+		return nil, nil
+	}
 	return lo.Map(primaryKey, func(it key, i int) string { return it.Name }), nil
 }
 
-func queryForeignKeys(ctx context.Context, tx gf_sqlite3.Queryer, table string) ([]SchemaForeignKey, error) {
+// queryForeignKeys returns the foreign keys in pragma_foreign_key_list, in which names and deferrability are taken from the definition.
+// The omitted referenced columns are regarded as the primary key of the referenced table.
+func queryForeignKeys(ctx context.Context, tx gf_sqlite3.Queryer, table string, definition SchemaTable) ([]SchemaForeignKey, error) {
 	sql := `--sql query foreign key information
 SELECT
 	"id" AS Id,
 	"seq" AS Seq,
 	"table" AS ReferencedTable,
 	"from" AS ReferencingKey,
-	"to" AS ReferencedKey
+	IFNULL("to", '') AS ReferencedKey,
+	"on_delete" AS OnDelete,
+	"on_update" AS OnUpdate
 FROM pragma_foreign_key_list(?)
 ORDER BY "id", "seq"`
 	rows, err := tx.QueryxContext(ctx, sql, table)
@@ -148,11 +377,16 @@ ORDER BY "id", "seq"`
 		ReferencedTable string `db:"ReferencedTable"`
 		ReferencingKey  string `db:"ReferencingKey"`
 		ReferencedKey   string `db:"ReferencedKey"`
+		OnDelete        string `db:"OnDelete"`
+		OnUpdate        string `db:"OnUpdate"`
 	}
 	fkRows, err := gf_sqlite3.ScanRowsStruct[fkRow](rows)
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get foreign keys of %s: %w`, table, err)
 	}
+	if len(fkRows) == 0 {
+		return nil, nil
+	}
 
 	group := lo.GroupBy(fkRows, func(fkRow fkRow) int64 { return fkRow.Id })
 	groupIDs := lo.MapToSlice(group, func(id int64, _ []fkRow) int64 { return id })
@@ -161,16 +395,89 @@ ORDER BY "id", "seq"`
 	var foreignKeys []SchemaForeignKey
 	for _, id := range groupIDs {
 		g := group[id]
-		foreignKeys = append(foreignKeys, SchemaForeignKey{
+		foreignKey := SchemaForeignKey{
 			ReferencedTable: g[0].ReferencedTable,
 			ReferencedKey:   lo.Map(g, func(fkRow fkRow, _ int) string { return fkRow.ReferencedKey }),
 			ReferencingKey:  lo.Map(g, func(fkRow fkRow, _ int) string { return fkRow.ReferencingKey }),
-		})
+			OnDelete:        lo.Ternary(g[0].OnDelete == "NO ACTION", "", g[0].OnDelete),
+			OnUpdate:        lo.Ternary(g[0].OnUpdate == "NO ACTION", "", g[0].OnUpdate),
+		}
+		if g[0].ReferencedKey == "" {
+			if foreignKey.ReferencedKey, err = queryPrimaryKey(ctx, tx, foreignKey.ReferencedTable); err != nil {
+				return nil, fmt.Errorf(`fail to get foreign keys of %s: %w`, table, err)
+			}
+		}
+		// names and deferrability of foreign keys are available only in the CREATE TABLE statement
+		if defined, found := lo.Find(definition.ForeignKeys, func(it SchemaForeignKey) bool {
+			return strings.EqualFold(it.ReferencedTable, foreignKey.ReferencedTable) && slices.Equal(it.ReferencingKey, foreignKey.ReferencingKey)
+		}); found {
+			foreignKey.Name = defined.Name
+			foreignKey.Deferrable = defined.Deferrable
+			foreignKey.InitiallyDeferred = defined.InitiallyDeferred
+		}
+		foreignKeys = append(foreignKeys, foreignKey)
 	}
 
 	return foreignKeys, nil
 }
 
+// queryReferencedBy returns foreign keys referencing the table, in which the omitted referenced columns are regarded as the primary key.
+func queryReferencedBy(ctx context.Context, tx gf_sqlite3.Queryer, table string, primaryKey []string) ([]SchemaReference, error) {
+	// foreign keys are listed in the reverse order of the declaration by pragma_foreign_key_list
+	sql := `--sql query foreign keys referencing the table
+SELECT
+	m."name" AS ReferencingTable,
+	m."sql" AS SQL,
+	fk."id" AS Id,
+	fk."from" AS ReferencingKey,
+	IFNULL(fk."to", '') AS ReferencedKey
+FROM sqlite_master AS m, pragma_foreign_key_list(m."name") AS fk
+WHERE m."type" = 'table' AND fk."table" = ? COLLATE NOCASE
+ORDER BY m."name", fk."id" DESC, fk."seq"`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys referencing %s: %w`, table, err)
+	}
+	type referenceRow struct {
+		ReferencingTable string `db:"ReferencingTable"`
+		SQL              string `db:"SQL"`
+		Id               int64  `db:"Id"`
+		ReferencingKey   string `db:"ReferencingKey"`
+		ReferencedKey    string `db:"ReferencedKey"`
+	}
+	referenceRows, err := gf_sqlite3.ScanRowsStruct[referenceRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get foreign keys referencing %s: %w`, table, err)
+	}
+
+	var references []SchemaReference
+	for _, g := range lo.PartitionBy(referenceRows, func(row referenceRow) lo.Tuple2[string, int64] {
+		return lo.T2(row.ReferencingTable, row.Id)
+	}) {
+		reference := SchemaReference{
+			ReferencingTable: g[0].ReferencingTable,
+			ReferencingKey:   lo.Map(g, func(row referenceRow, _ int) string { return row.ReferencingKey }),
+			ReferencedKey:    lo.Map(g, func(row referenceRow, _ int) string { return row.ReferencedKey }),
+		}
+		if g[0].ReferencedKey == "" {
+			reference.ReferencedKey = slices.Clone(primaryKey)
+		}
+		// names of foreign keys are available only in the CREATE TABLE statement, which are omitted if the statement cannot be parsed
+		referencing := SchemaTable{}
+		if definition, err := NewDDLFetcher(g[0].SQL); err == nil {
+			referencing, _ = definition.Fetch(ctx, g[0].ReferencingTable)
+		}
+		if defined, found := lo.Find(referencing.ForeignKeys, func(it SchemaForeignKey) bool {
+			return strings.EqualFold(it.ReferencedTable, table) && slices.Equal(it.ReferencingKey, reference.ReferencingKey)
+		}); found {
+			reference.Name = defined.Name
+		}
+		references = append(references, reference)
+	}
+
+	return references, nil
+}
+
 func queryUniqueKeys(ctx context.Context, tx gf_sqlite3.Queryer, table string) ([]SchemaUniqueKey, error) {
 	sql := `--sql query unique key information
 SELECT 
@@ -187,10 +494,11 @@ ORDER BY pil."seq", pii."seqno"`
 		return nil, fmt.Errorf(`fail to get unique keys of %s: %w`, table, err)
 	}
 	type ukRow struct {
-		Seq     int64  `db:"Seq"`
-		Name    string `db:"Name"`
-		Named   bool   `db:"Named"`
-		ColName string `db:"ColName"`
+		Seq   int64  `db:"Seq"`
+		Name  string `db:"Name"`
+		Named bool   `db:"Named"`
+		// ColName is NULL if the key part is an expression.
+		ColName *string `db:"ColName"`
 	}
 	ukRows, err := gf_sqlite3.ScanRowsStruct[ukRow](rows)
 	if err != nil {
@@ -203,8 +511,12 @@ ORDER BY pil."seq", pii."seqno"`
 	var uniqueKeys []SchemaUniqueKey
 	for _, id := range groupIDs {
 		g := group[id]
+		if lo.SomeBy(g, func(ukRow ukRow) bool { return ukRow.ColName == nil }) {
+			// unique indexes on expressions are not unique keys since they have no columns
+			continue
+		}
 		uk := SchemaUniqueKey{
-			Key: lo.Map(g, func(ukRow ukRow, _ int) string { return ukRow.ColName }),
+			Key: lo.Map(g, func(ukRow ukRow, _ int) string { return *ukRow.ColName }),
 		}
 		if g[0].Named {
 			uk.Name = g[0].Name
@@ -215,3 +527,100 @@ ORDER BY pil."seq", pii."seqno"`
 
 	return uniqueKeys, nil
 }
+
+func queryIndexes(ctx context.Context, tx gf_sqlite3.Queryer, table string) ([]SchemaIndex, error) {
+	// indexes are ordered in the order of creation, which is the reverse of pragma_index_list in general
+	sql := `--sql query index information
+SELECT
+	sm."rowid" AS Seq,
+	pil."name" AS Name,
+	pil."unique" AS "Unique",
+	pil."partial" AS Partial,
+	sm."sql" AS Definition,
+	pix."name" AS ColName,
+	pix."desc" AS "Desc"
+FROM pragma_index_list(?) AS pil
+	JOIN pragma_index_xinfo(pil."name") AS pix
+	JOIN sqlite_master AS sm ON sm."type" = 'index' AND sm."name" = pil."name"
+WHERE pil."origin" = 'c' AND pix."key"
+ORDER BY sm."rowid", pix."seqno"`
+	rows, err := tx.QueryxContext(ctx, sql, table)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+	}
+	type indexRow struct {
+		Seq        int64  `db:"Seq"`
+		Name       string `db:"Name"`
+		Unique     bool   `db:"Unique"`
+		Partial    bool   `db:"Partial"`
+		Definition string `db:"Definition"`
+		// ColName is NULL if the key part is an expression.
+		ColName *string `db:"ColName"`
+		Desc    bool    `db:"Desc"`
+	}
+	indexRows, err := gf_sqlite3.ScanRowsStruct[indexRow](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+	}
+	group := lo.GroupBy(indexRows, func(indexRow indexRow) int64 { return indexRow.Seq })
+	groupIDs := lo.MapToSlice(group, func(id int64, _ []indexRow) int64 { return id })
+	slices.Sort(groupIDs)
+
+	var indexes []SchemaIndex
+	for _, id := range groupIDs {
+		g := group[id]
+		index := SchemaIndex{
+			Name:   g[0].Name,
+			Unique: g[0].Unique,
+			Key: lo.Map(g, func(indexRow indexRow, _ int) SchemaIndexKey {
+				return SchemaIndexKey{Name: lo.FromPtr(indexRow.ColName), Desc: indexRow.Desc}
+			}),
+		}
+		hasExpression := lo.SomeBy(g, func(indexRow indexRow) bool { return indexRow.ColName == nil })
+		if g[0].Partial || hasExpression {
+			// expressions are taken from the CREATE INDEX statement, which is kept as written in sqlite_master
+			key, predicate, err := parseIndexDefinition(g[0].Definition)
+			if err != nil {
+				return nil, fmt.Errorf(`fail to get indexes of %s: %w`, table, err)
+			}
+			if len(key) != len(index.Key) {
+				return nil, fmt.Errorf(`fail to get indexes of %s: key of index %s does not match %q`, table, index.Name, g[0].Definition)
+			}
+			for i, indexRow := range g {
+				if indexRow.ColName == nil {
+					index.Key[i].Expression = key[i].Expression
+				}
+			}
+			index.Predicate = predicate
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// parseIndexDefinition returns the key and the condition written in the WHERE clause of the CREATE INDEX statement.
+func parseIndexDefinition(createIndex string) ([]SchemaIndexKey, string, error) {
+	tokens, err := ddl.Tokenize(createIndex)
+	if err != nil {
+		return nil, "", fmt.Errorf(`fail to parse %q: %w`, createIndex, err)
+	}
+	p := ddl.NewParser(createIndex, tokens)
+	for !p.Keyword("ON") {
+		if err := p.Skip(); err != nil {
+			return nil, "", fmt.Errorf(`fail to parse %q: %w`, createIndex, err)
+		}
+	}
+	if _, err := parseIdentifier(p); err != nil {
+		return nil, "", fmt.Errorf(`fail to parse %q: %w`, createIndex, err)
+	}
+	key, err := parseIndexKeyList(p)
+	if err != nil {
+		return nil, "", fmt.Errorf(`fail to parse %q: %w`, createIndex, err)
+	}
+	if !p.Keyword("WHERE") {
+		return key, "", nil
+	}
+	return key, p.Text(p.Pos(), len(tokens)), nil
+}
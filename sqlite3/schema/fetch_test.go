@@ -22,334 +22,549 @@ var ddls = map[string]string{
 	"ddl_06_unique_keys_index":      testdata.DDL06UniqueKeysIndexSQL,
 	"ddl_07_unique_keys_constraint": testdata.DDL07UniqueKeysConstraintSQL,
 	"ddl_08_unique_keys_column":     testdata.DDL08UniqueKeysColumnSQL,
+	"ddl_10_indexes":                testdata.DDL10IndexesSQL,
+	"ddl_11_column_defaults":        testdata.DDL11ColumnDefaultsSQL,
+	"ddl_12_checks":                 testdata.DDL12ChecksSQL,
+	"ddl_13_foreign_key_actions":    testdata.DDL13ForeignKeyActionsSQL,
+	"ddl_14_views":                  testdata.DDL14ViewsSQL,
+	"ddl_15_table_features":         testdata.DDL15TableFeaturesSQL,
 }
 
-func TestFetcher(t *testing.T) {
-	testcases := []struct {
-		ddl   string
-		table string
-		want  schema.SchemaTable
-	}{
-		{
-			ddl:   "ddl_00_all_types",
-			table: "A",
-			want: schema.SchemaTable{
-				Name: "A",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "INT64", Nullable: false},
-					{Name: "Col_01", Type: "BOOL", Nullable: true},
-					{Name: "Col_02", Type: "BOOL", Nullable: false},
-					{Name: "Col_03", Type: "BYTES(50)", Nullable: true},
-					{Name: "Col_04", Type: "BYTES(50)", Nullable: false},
-					{Name: "Col_05", Type: "DATE", Nullable: true},
-					{Name: "Col_06", Type: "DATE", Nullable: false},
-					{Name: "Col_07", Type: "FLOAT64", Nullable: true},
-					{Name: "Col_08", Type: "FLOAT64", Nullable: false},
-					{Name: "Col_09", Type: "INT64", Nullable: true},
-					{Name: "Col_10", Type: "INT64", Nullable: false},
-					{Name: "Col_11", Type: "JSON", Nullable: true},
-					{Name: "Col_12", Type: "JSON", Nullable: false},
-					{Name: "Col_13", Type: "NUMERIC", Nullable: true},
-					{Name: "Col_14", Type: "NUMERIC", Nullable: false},
-					{Name: "Col_15", Type: "STRING(50)", Nullable: true},
-					{Name: "Col_16", Type: "STRING(50)", Nullable: false},
-					{Name: "Col_17", Type: "TIMESTAMP", Nullable: true},
-					{Name: "Col_18", Type: "TIMESTAMP", Nullable: false},
-				},
-				PrimaryKey: []string{"PK"},
+var fetcherTestcases = []struct {
+	ddl   string
+	table string
+	want  schema.SchemaTable
+}{
+	{
+		ddl:   "ddl_00_all_types",
+		table: "A",
+		want: schema.SchemaTable{
+			Name: "A",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64", Nullable: false},
+				{Name: "Col_01", Type: "BOOL", Nullable: true},
+				{Name: "Col_02", Type: "BOOL", Nullable: false},
+				{Name: "Col_03", Type: "BYTES(50)", Nullable: true},
+				{Name: "Col_04", Type: "BYTES(50)", Nullable: false},
+				{Name: "Col_05", Type: "DATE", Nullable: true},
+				{Name: "Col_06", Type: "DATE", Nullable: false},
+				{Name: "Col_07", Type: "FLOAT64", Nullable: true},
+				{Name: "Col_08", Type: "FLOAT64", Nullable: false},
+				{Name: "Col_09", Type: "INT64", Nullable: true},
+				{Name: "Col_10", Type: "INT64", Nullable: false},
+				{Name: "Col_11", Type: "JSON", Nullable: true},
+				{Name: "Col_12", Type: "JSON", Nullable: false},
+				{Name: "Col_13", Type: "NUMERIC", Nullable: true},
+				{Name: "Col_14", Type: "NUMERIC", Nullable: false},
+				{Name: "Col_15", Type: "STRING(50)", Nullable: true},
+				{Name: "Col_16", Type: "STRING(50)", Nullable: false},
+				{Name: "Col_17", Type: "TIMESTAMP", Nullable: true},
+				{Name: "Col_18", Type: "TIMESTAMP", Nullable: false},
 			},
+			PrimaryKey: []string{"PK"},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_1",
-			want: schema.SchemaTable{
-				Name: "C_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_12", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_1",
+		want: schema.SchemaTable{
+			Name: "C_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_12", Type: "INT64"},
 			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_2",
-			want: schema.SchemaTable{
-				Name: "C_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "INT64"},
-					{Name: "PK_22", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "C_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_2",
+		want: schema.SchemaTable{
+			Name: "C_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "PK_22", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_2_1",
+					ReferencedTable: "C_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_3",
-			want: schema.SchemaTable{
-				Name: "C_3",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_31", Type: "INT64"},
-					{Name: "PK_32", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_31", "PK_32"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "C_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_31", "PK_32"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_3",
+		want: schema.SchemaTable{
+			Name: "C_3",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_31", Type: "INT64"},
+				{Name: "PK_32", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_31", "PK_32"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_3_2",
+					ReferencedTable: "C_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_31", "PK_32"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_4",
-			want: schema.SchemaTable{
-				Name: "C_4",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_41", Type: "INT64"},
-					{Name: "PK_42", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_41", "PK_42"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "C_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_41", "PK_42"},
-					},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_4",
+		want: schema.SchemaTable{
+			Name: "C_4",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_41", Type: "INT64"},
+				{Name: "PK_42", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_41", "PK_42"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_4_2",
+					ReferencedTable: "C_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_41", "PK_42"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_02_foreign_keys",
-			table: "C_5",
-			want: schema.SchemaTable{
-				Name: "C_5",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_51", Type: "INT64"},
-					{Name: "PK_52", Type: "INT64"},
+	},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_5",
+		want: schema.SchemaTable{
+			Name: "C_5",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_51", Type: "INT64"},
+				{Name: "PK_52", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_51", "PK_52"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_C_5_4",
+					ReferencedTable: "C_4",
+					ReferencedKey:   []string{"PK_41", "PK_42"},
+					ReferencingKey:  []string{"PK_51", "PK_52"},
 				},
-				PrimaryKey: []string{"PK_51", "PK_52"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "C_4",
-						ReferencedKey:   []string{"PK_41", "PK_42"},
-						ReferencingKey:  []string{"PK_51", "PK_52"},
-					},
-					{
-						ReferencedTable: "C_3",
-						ReferencedKey:   []string{"PK_31", "PK_32"},
-						ReferencingKey:  []string{"PK_51", "PK_52"},
-					},
+				{
+					Name:            "FK_C_5_3",
+					ReferencedTable: "C_3",
+					ReferencedKey:   []string{"PK_31", "PK_32"},
+					ReferencingKey:  []string{"PK_51", "PK_52"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_03_foreign_loop_1",
-			table: "D_1",
-			want: schema.SchemaTable{
-				Name: "D_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_12", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "D_1",
-						ReferencedKey:   []string{"PK_12"},
-						ReferencingKey:  []string{"PK_11"},
-					},
+	},
+	{
+		ddl:   "ddl_03_foreign_loop_1",
+		table: "D_1",
+		want: schema.SchemaTable{
+			Name: "D_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_12", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_D_1_1",
+					ReferencedTable: "D_1",
+					ReferencedKey:   []string{"PK_12"},
+					ReferencingKey:  []string{"PK_11"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_04_foreign_loop_2",
-			table: "E_1",
-			want: schema.SchemaTable{
-				Name: "E_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_12", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "E_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_11", "PK_12"},
-					},
+	},
+	{
+		ddl:   "ddl_04_foreign_loop_2",
+		table: "E_1",
+		want: schema.SchemaTable{
+			Name: "E_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_12", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_E_1_2",
+					ReferencedTable: "E_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_11", "PK_12"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_04_foreign_loop_2",
-			table: "E_2",
-			want: schema.SchemaTable{
-				Name: "E_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "INT64"},
-					{Name: "PK_22", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "E_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
+	},
+	{
+		ddl:   "ddl_04_foreign_loop_2",
+		table: "E_2",
+		want: schema.SchemaTable{
+			Name: "E_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "PK_22", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_E_2_1",
+					ReferencedTable: "E_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_1",
-			want: schema.SchemaTable{
-				Name: "F_1",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_11", Type: "INT64"},
-					{Name: "PK_12", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_11", "PK_12"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "F_3",
-						ReferencedKey:   []string{"PK_31", "PK_32"},
-						ReferencingKey:  []string{"PK_11", "PK_12"},
-					},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_1",
+		want: schema.SchemaTable{
+			Name: "F_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_11", Type: "INT64"},
+				{Name: "PK_12", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_11", "PK_12"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_1_3",
+					ReferencedTable: "F_3",
+					ReferencedKey:   []string{"PK_31", "PK_32"},
+					ReferencingKey:  []string{"PK_11", "PK_12"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_2",
-			want: schema.SchemaTable{
-				Name: "F_2",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_21", Type: "INT64"},
-					{Name: "PK_22", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_21", "PK_22"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "F_1",
-						ReferencedKey:   []string{"PK_11", "PK_12"},
-						ReferencingKey:  []string{"PK_21", "PK_22"},
-					},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_2",
+		want: schema.SchemaTable{
+			Name: "F_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_21", Type: "INT64"},
+				{Name: "PK_22", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_21", "PK_22"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_2_1",
+					ReferencedTable: "F_1",
+					ReferencedKey:   []string{"PK_11", "PK_12"},
+					ReferencingKey:  []string{"PK_21", "PK_22"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_05_foreign_loop_3",
-			table: "F_3",
-			want: schema.SchemaTable{
-				Name: "F_3",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK_31", Type: "INT64"},
-					{Name: "PK_32", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK_31", "PK_32"},
-				ForeignKeys: []schema.SchemaForeignKey{
-					{
-						ReferencedTable: "F_2",
-						ReferencedKey:   []string{"PK_21", "PK_22"},
-						ReferencingKey:  []string{"PK_31", "PK_32"},
-					},
+	},
+	{
+		ddl:   "ddl_05_foreign_loop_3",
+		table: "F_3",
+		want: schema.SchemaTable{
+			Name: "F_3",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK_31", Type: "INT64"},
+				{Name: "PK_32", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK_31", "PK_32"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					Name:            "FK_F_3_2",
+					ReferencedTable: "F_2",
+					ReferencedKey:   []string{"PK_21", "PK_22"},
+					ReferencingKey:  []string{"PK_31", "PK_32"},
 				},
 			},
 		},
-		{
-			ddl:   "ddl_06_unique_keys_index",
-			table: "G",
-			want: schema.SchemaTable{
-				Name: "G",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "INT64"},
-					{Name: "C1", Type: "INT64"},
-					{Name: "C2", Type: "INT64"},
-					{Name: "C3", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK"},
-				UniqueKeys: []schema.SchemaUniqueKey{
-					{Name: "UQ_G_C1", Key: []string{"C1"}},
-					{Name: "UQ_G_C1_C2", Key: []string{"C1", "C2"}},
-					{Name: "UQ_G_C1_C2_C3", Key: []string{"C1", "C2", "C3"}},
-					{Name: "UQ_G_C1_C3", Key: []string{"C1", "C3"}},
-					{Name: "UQ_G_C1_C3_C2", Key: []string{"C1", "C3", "C2"}},
-					{Name: "UQ_G_C2", Key: []string{"C2"}},
-					{Name: "UQ_G_C2_C1", Key: []string{"C2", "C1"}},
-					{Name: "UQ_G_C2_C1_C3", Key: []string{"C2", "C1", "C3"}},
-					{Name: "UQ_G_C2_C3", Key: []string{"C2", "C3"}},
-					{Name: "UQ_G_C2_C3_C1", Key: []string{"C2", "C3", "C1"}},
-					{Name: "UQ_G_C3", Key: []string{"C3"}},
-					{Name: "UQ_G_C3_C1", Key: []string{"C3", "C1"}},
-					{Name: "UQ_G_C3_C1_C2", Key: []string{"C3", "C1", "C2"}},
-					{Name: "UQ_G_C3_C2", Key: []string{"C3", "C2"}},
-					{Name: "UQ_G_C3_C2_C1", Key: []string{"C3", "C2", "C1"}},
-				},
+	},
+	{
+		ddl:   "ddl_06_unique_keys_index",
+		table: "G",
+		want: schema.SchemaTable{
+			Name: "G",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "INT64"},
+				{Name: "C3", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "UQ_G_C1", Key: []string{"C1"}},
+				{Name: "UQ_G_C1_C2", Key: []string{"C1", "C2"}},
+				{Name: "UQ_G_C1_C2_C3", Key: []string{"C1", "C2", "C3"}},
+				{Name: "UQ_G_C1_C3", Key: []string{"C1", "C3"}},
+				{Name: "UQ_G_C1_C3_C2", Key: []string{"C1", "C3", "C2"}},
+				{Name: "UQ_G_C2", Key: []string{"C2"}},
+				{Name: "UQ_G_C2_C1", Key: []string{"C2", "C1"}},
+				{Name: "UQ_G_C2_C1_C3", Key: []string{"C2", "C1", "C3"}},
+				{Name: "UQ_G_C2_C3", Key: []string{"C2", "C3"}},
+				{Name: "UQ_G_C2_C3_C1", Key: []string{"C2", "C3", "C1"}},
+				{Name: "UQ_G_C3", Key: []string{"C3"}},
+				{Name: "UQ_G_C3_C1", Key: []string{"C3", "C1"}},
+				{Name: "UQ_G_C3_C1_C2", Key: []string{"C3", "C1", "C2"}},
+				{Name: "UQ_G_C3_C2", Key: []string{"C3", "C2"}},
+				{Name: "UQ_G_C3_C2_C1", Key: []string{"C3", "C2", "C1"}},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "UQ_G_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "UQ_G_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}}},
+				{Name: "UQ_G_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}}},
+				{Name: "UQ_G_C1_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C2"}}},
+				{Name: "UQ_G_C2_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C1"}}},
+				{Name: "UQ_G_C2_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C3"}}},
+				{Name: "UQ_G_C3_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C2"}}},
+				{Name: "UQ_G_C3_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C1"}}},
+				{Name: "UQ_G_C1_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C3"}}},
+				{Name: "UQ_G_C1_C2_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C2"}, {Name: "C3"}}},
+				{Name: "UQ_G_C1_C3_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Name: "C3"}, {Name: "C2"}}},
+				{Name: "UQ_G_C2_C3_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C3"}, {Name: "C1"}}},
+				{Name: "UQ_G_C2_C1_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C2"}, {Name: "C1"}, {Name: "C3"}}},
+				{Name: "UQ_G_C3_C1_C2", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C1"}, {Name: "C2"}}},
+				{Name: "UQ_G_C3_C2_C1", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3"}, {Name: "C2"}, {Name: "C1"}}},
 			},
 		},
-		{
-			ddl:   "ddl_07_unique_keys_constraint",
-			table: "H",
-			want: schema.SchemaTable{
-				Name: "H",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "INT64"},
-					{Name: "C1", Type: "INT64"},
-					{Name: "C2", Type: "INT64"},
-					{Name: "C3", Type: "INT64"},
-				},
-				PrimaryKey: []string{"PK"},
-				UniqueKeys: []schema.SchemaUniqueKey{
-					{Name: "", Key: []string{"C1"}},
-					{Name: "", Key: []string{"C1", "C2"}},
-					{Name: "", Key: []string{"C1", "C2", "C3"}},
-					{Name: "", Key: []string{"C1", "C3"}},
-					{Name: "", Key: []string{"C1", "C3", "C2"}},
-					{Name: "", Key: []string{"C2"}},
-					{Name: "", Key: []string{"C2", "C1"}},
-					{Name: "", Key: []string{"C2", "C1", "C3"}},
-					{Name: "", Key: []string{"C2", "C3"}},
-					{Name: "", Key: []string{"C2", "C3", "C1"}},
-					{Name: "", Key: []string{"C3"}},
-					{Name: "", Key: []string{"C3", "C1"}},
-					{Name: "", Key: []string{"C3", "C1", "C2"}},
-					{Name: "", Key: []string{"C3", "C2"}},
-					{Name: "", Key: []string{"C3", "C2", "C1"}},
-				},
+	},
+	{
+		ddl:   "ddl_07_unique_keys_constraint",
+		table: "H",
+		want: schema.SchemaTable{
+			Name: "H",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "INT64"},
+				{Name: "C3", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "", Key: []string{"C1"}},
+				{Name: "", Key: []string{"C1", "C2"}},
+				{Name: "", Key: []string{"C1", "C2", "C3"}},
+				{Name: "", Key: []string{"C1", "C3"}},
+				{Name: "", Key: []string{"C1", "C3", "C2"}},
+				{Name: "", Key: []string{"C2"}},
+				{Name: "", Key: []string{"C2", "C1"}},
+				{Name: "", Key: []string{"C2", "C1", "C3"}},
+				{Name: "", Key: []string{"C2", "C3"}},
+				{Name: "", Key: []string{"C2", "C3", "C1"}},
+				{Name: "", Key: []string{"C3"}},
+				{Name: "", Key: []string{"C3", "C1"}},
+				{Name: "", Key: []string{"C3", "C1", "C2"}},
+				{Name: "", Key: []string{"C3", "C2"}},
+				{Name: "", Key: []string{"C3", "C2", "C1"}},
 			},
 		},
-		{
-			ddl:   "ddl_08_unique_keys_column",
-			table: "I",
-			want: schema.SchemaTable{
-				Name: "I",
-				Columns: []schema.SchemaColumn{
-					{Name: "PK", Type: "INT64"},
-					{Name: "C1", Type: "INT64"},
-					{Name: "C2", Type: "INT64"},
-					{Name: "C3", Type: "INT64"},
+	},
+	{
+		ddl:   "ddl_08_unique_keys_column",
+		table: "I",
+		want: schema.SchemaTable{
+			Name: "I",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "INT64"},
+				{Name: "C3", Type: "INT64"},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "", Key: []string{"C1"}},
+				{Name: "", Key: []string{"C2"}},
+				{Name: "", Key: []string{"C3"}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_10_indexes",
+		table: "L",
+		want: schema.SchemaTable{
+			Name: "L",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT64"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "STRING(50)", Nullable: true},
+				{Name: "C3", Type: "FLOAT64", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.SchemaUniqueKey{
+				{Name: "UQ_L_C3", Key: []string{"C3"}},
+			},
+			Indexes: []schema.SchemaIndex{
+				{Name: "IDX_L_C1", Key: []schema.SchemaIndexKey{{Name: "C1"}}},
+				{Name: "IDX_L_C2_C1", Key: []schema.SchemaIndexKey{{Name: "C2", Desc: true}, {Name: "C1"}}},
+				{Name: "UQ_L_C3", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C3", Desc: true}}},
+				{Name: "IDX_L_C3_Partial", Key: []schema.SchemaIndexKey{{Name: "C3"}}, Predicate: "C3 > 0 AND C2 IS NOT NULL"},
+				{Name: "IDX_L_Expression", Key: []schema.SchemaIndexKey{{Expression: "lower(C2)"}}},
+				{Name: "UQ_L_Expression", Unique: true, Key: []schema.SchemaIndexKey{{Name: "C1"}, {Expression: "abs(C3)", Desc: true}, {Name: "C2"}}},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_11_column_defaults",
+		table: "M",
+		want: schema.SchemaTable{
+			Name: "M",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INTEGER", Nullable: true, AutoIncrement: true},
+				{Name: "C1", Type: "INT64", Default: "0"},
+				{Name: "C2", Type: "STRING(50)", Nullable: true, Default: "'abc'"},
+				{Name: "C3", Type: "FLOAT64", Nullable: true, Default: "-1.5"},
+				{Name: "C4", Type: "TIMESTAMP", Nullable: true, Default: "CURRENT_TIMESTAMP"},
+				{Name: "C5", Type: "INT64", Nullable: true, Default: "1 + 2"},
+				{Name: "C6", Type: "INT64", Nullable: true, Generated: "C1 * 2"},
+				{Name: "C7", Type: "STRING(50)", Nullable: true, Generated: "upper(C2)", Stored: true},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	},
+	{
+		ddl:   "ddl_12_checks",
+		table: "N",
+		want: schema.SchemaTable{
+			Name: "N",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INTEGER"},
+				{Name: "C1", Type: "INT64"},
+				{Name: "C2", Type: "STRING(10)", Nullable: true},
+				{Name: "C3", Type: "FLOAT64", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			Checks: []schema.SchemaCheck{
+				{Expression: "C1 >= 0"},
+				{Name: "CK_N_C2", Expression: "C2 IN ('a', 'b', 'c')"},
+				{Name: "CK_N_C1_C3", Expression: "C1 < C3"},
+				{Expression: "C3 <> 0"},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_13_foreign_key_actions",
+		table: "O_2",
+		want: schema.SchemaTable{
+			Name: "O_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INTEGER"},
+				{Name: "R1", Type: "INTEGER", Nullable: true},
+				{Name: "R2", Type: "INTEGER", Nullable: true},
+				{Name: "R3", Type: "INTEGER", Nullable: true},
+				{Name: "R4", Type: "INTEGER", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			ForeignKeys: []schema.SchemaForeignKey{
+				{
+					ReferencedTable: "O_1",
+					ReferencedKey:   []string{"PK"},
+					ReferencingKey:  []string{"R4"},
+				},
+				{
+					ReferencedTable:   "O_1",
+					ReferencedKey:     []string{"C1"},
+					ReferencingKey:    []string{"R2"},
+					OnDelete:          "SET NULL",
+					Deferrable:        true,
+					InitiallyDeferred: true,
+				},
+				{
+					Name:            "FK_O_2_1",
+					ReferencedTable: "O_1",
+					ReferencedKey:   []string{"PK"},
+					ReferencingKey:  []string{"R1"},
+					OnDelete:        "CASCADE",
+					OnUpdate:        "RESTRICT",
 				},
-				PrimaryKey: []string{"PK"},
-				UniqueKeys: []schema.SchemaUniqueKey{
-					{Name: "", Key: []string{"C1"}},
-					{Name: "", Key: []string{"C2"}},
-					{Name: "", Key: []string{"C3"}},
+				{
+					Name:            "FK_O_2_3",
+					ReferencedTable: "O_1",
+					ReferencedKey:   []string{"PK"},
+					ReferencingKey:  []string{"R3"},
+					OnUpdate:        "SET DEFAULT",
 				},
 			},
 		},
-	}
+	},
+	{
+		ddl:   "ddl_15_table_features",
+		table: "R_1",
+		want: schema.SchemaTable{
+			Name:   "R_1",
+			Strict: true,
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INTEGER", Nullable: true, AutoIncrement: true},
+				{Name: "C1", Type: "TEXT", Collation: "NOCASE"},
+				{Name: "C2", Type: "TEXT", Nullable: true, Collation: "RTRIM"},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	},
+	{
+		ddl:   "ddl_15_table_features",
+		table: "R_2",
+		want: schema.SchemaTable{
+			Name:         "R_2",
+			Strict:       true,
+			WithoutRowID: true,
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "TEXT"},
+				{Name: "C1", Type: "ANY", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+		},
+	},
+	{
+		ddl:   "ddl_15_table_features",
+		table: "R_5",
+		want: schema.SchemaTable{
+			Name:         "R_5",
+			WithoutRowID: true,
+			Columns: []schema.SchemaColumn{
+				{Name: "PK1", Type: "TEXT"},
+				{Name: "PK2", Type: "INTEGER"},
+				{Name: "C1", Type: "TEXT", Nullable: true},
+			},
+			PrimaryKey: []string{"PK1", "PK2"},
+		},
+	},
+	{
+		ddl:   "ddl_15_table_features",
+		table: "R_3",
+		want: schema.SchemaTable{
+			Name:            "R_3",
+			Module:          "fts4",
+			ModuleArguments: []string{"Title", "Body", "tokenize=porter"},
+			Columns: []schema.SchemaColumn{
+				{Name: "Title", Nullable: true},
+				{Name: "Body", Nullable: true},
+				{Name: "R_3", Nullable: true, Hidden: true},
+				{Name: "docid", Nullable: true, Hidden: true},
+				{Name: "__langid", Nullable: true, Hidden: true},
+			},
+		},
+	},
+	{
+		ddl:   "ddl_15_table_features",
+		table: "R_4",
+		want: schema.SchemaTable{
+			Name:            "R_4",
+			Module:          "rtree",
+			ModuleArguments: []string{"PK", "MinX", "MaxX", "+Label TEXT"},
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INT", Nullable: true},
+				{Name: "MinX", Type: "REAL", Nullable: true},
+				{Name: "MaxX", Type: "REAL", Nullable: true},
+				{Name: "Label", Nullable: true},
+			},
+		},
+	},
+}
 
-	for number, testcase := range testcases {
+func TestFetcher(t *testing.T) {
+	for number, testcase := range fetcherTestcases {
 		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
 			db, teardown := test.Setup(t, fmt.Sprintf("fetcher_%0d.sqlite", number))
 			defer teardown()
@@ -363,11 +578,127 @@ func TestFetcher(t *testing.T) {
 		})
 	}
 }
+
+func TestFetcher_UnparsedDefinition(t *testing.T) {
+	db, teardown := test.Setup(t, "fetcher_unparsed_definition.sqlite")
+	defer teardown()
+
+	// SQLite accepts string literals as column names, which the DDL parser does not support
+	test.InitDDLs(t, db, []string{
+		`CREATE TABLE T_1 ('C1' TEXT, "PK" INTEGER PRIMARY KEY, CHECK ("PK" > 0))`,
+		`CREATE TABLE T_2 ('R' INTEGER CONSTRAINT FK_T_2 REFERENCES T_1)`,
+	})
+
+	sut := schema.NewFetcher(db).WithReferencedBy()
+	got, err := sut.Fetch(context.Background(), "T_1")
+	assert.Nil(t, err)
+	assertEqualSchemaTable(t, schema.SchemaTable{
+		Name: "T_1",
+		Columns: []schema.SchemaColumn{
+			{Name: "C1", Type: "TEXT", Nullable: true},
+			{Name: "PK", Type: "INTEGER", Nullable: true},
+		},
+		PrimaryKey: []string{"PK"},
+	}, got)
+	assert.Equal(t, []schema.SchemaReference{{ReferencingTable: "T_2", ReferencingKey: []string{"R"}, ReferencedKey: []string{"PK"}}}, got.ReferencedBy)
+}
+
+var listTablesTestcases = []struct {
+	ddl  string
+	want []string
+}{
+	{ddl: "ddl_00_all_types", want: []string{"A"}},
+	{ddl: "ddl_02_foreign_keys", want: []string{"C_1", "C_2", "C_3", "C_4", "C_5"}},
+	{ddl: "ddl_03_foreign_loop_1", want: []string{"D_1"}},
+	{ddl: "ddl_04_foreign_loop_2", want: []string{"E_1", "E_2"}},
+	{ddl: "ddl_05_foreign_loop_3", want: []string{"F_1", "F_2", "F_3"}},
+	{ddl: "ddl_06_unique_keys_index", want: []string{"G"}},
+	{ddl: "ddl_07_unique_keys_constraint", want: []string{"H"}},
+	{ddl: "ddl_08_unique_keys_column", want: []string{"I"}},
+	{ddl: "ddl_10_indexes", want: []string{"L"}},
+	{ddl: "ddl_11_column_defaults", want: []string{"M"}},
+	{ddl: "ddl_12_checks", want: []string{"N"}},
+	{ddl: "ddl_13_foreign_key_actions", want: []string{"O_1", "O_2"}},
+	{ddl: "ddl_14_views", want: []string{"P_1", "P_2"}},
+	{ddl: "ddl_15_table_features", want: []string{"R_1", "R_2", "R_3", "R_4", "R_5"}},
+}
+
+func TestListTables(t *testing.T) {
+	for number, testcase := range listTablesTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			db, teardown := test.Setup(t, fmt.Sprintf("lister_%0d.sqlite", number))
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db)
+			got, err := sut.ListTables(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+var referencedByTestcases = []struct {
+	ddl   string
+	table string
+	want  []schema.SchemaReference
+}{
+	{ddl: "ddl_00_all_types", table: "A"},
+	{
+		ddl:   "ddl_02_foreign_keys",
+		table: "C_2",
+		want: []schema.SchemaReference{
+			{Name: "FK_C_3_2", ReferencingTable: "C_3", ReferencingKey: []string{"PK_31", "PK_32"}, ReferencedKey: []string{"PK_21", "PK_22"}},
+			{Name: "FK_C_4_2", ReferencingTable: "C_4", ReferencingKey: []string{"PK_41", "PK_42"}, ReferencedKey: []string{"PK_21", "PK_22"}},
+		},
+	},
+	{
+		ddl:   "ddl_03_foreign_loop_1",
+		table: "D_1",
+		want: []schema.SchemaReference{
+			{Name: "FK_D_1_1", ReferencingTable: "D_1", ReferencingKey: []string{"PK_11"}, ReferencedKey: []string{"PK_12"}},
+		},
+	},
+	{
+		ddl:   "ddl_13_foreign_key_actions",
+		table: "O_1",
+		want: []schema.SchemaReference{
+			{ReferencingTable: "O_2", ReferencingKey: []string{"R2"}, ReferencedKey: []string{"C1"}},
+			{ReferencingTable: "O_2", ReferencingKey: []string{"R4"}, ReferencedKey: []string{"PK"}},
+			{Name: "FK_O_2_1", ReferencingTable: "O_2", ReferencingKey: []string{"R1"}, ReferencedKey: []string{"PK"}},
+			{Name: "FK_O_2_3", ReferencingTable: "O_2", ReferencingKey: []string{"R3"}, ReferencedKey: []string{"PK"}},
+		},
+	},
+}
+
+func TestFetcher_WithReferencedBy(t *testing.T) {
+	for number, testcase := range referencedByTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			db, teardown := test.Setup(t, fmt.Sprintf("referenced_by_%0d.sqlite", number))
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db).WithReferencedBy()
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got.ReferencedBy)
+		})
+	}
+}
+
 func assertEqualSchemaTable(t *testing.T, want schema.SchemaTable, got schema.SchemaTable) {
 	t.Helper()
 	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.Strict, got.Strict)
+	assert.Equal(t, want.WithoutRowID, got.WithoutRowID)
+	assert.Equal(t, want.Module, got.Module)
+	assert.Equal(t, want.ModuleArguments, got.ModuleArguments)
 	assert.Equal(t, want.PrimaryKey, got.PrimaryKey)
 	assert.Equal(t, want.Columns, got.Columns)
 	assert.ElementsMatch(t, want.ForeignKeys, got.ForeignKeys)
 	assert.ElementsMatch(t, want.UniqueKeys, got.UniqueKeys)
+	assert.Equal(t, want.Indexes, got.Indexes)
+	assert.Equal(t, want.Checks, got.Checks)
 }
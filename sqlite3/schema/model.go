@@ -0,0 +1,229 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+// ConvertTable converts the table into the dialect-neutral schema, in which hidden columns of virtual tables are omitted.
+func ConvertTable(table SchemaTable) schema.Table {
+	return schema.Table{
+		Name: table.Name,
+		Columns: lo.FilterMap(table.Columns, func(column SchemaColumn, _ int) (schema.Column, bool) {
+			return schema.Column{
+				Name:          column.Name,
+				Type:          ConvertType(column.Type),
+				NativeType:    column.Type,
+				Nullable:      column.Nullable,
+				Default:       column.Default,
+				Generated:     column.Generated,
+				Stored:        column.Stored,
+				AutoIncrement: column.AutoIncrement,
+			}, !column.Hidden
+		}),
+		PrimaryKey: table.PrimaryKey,
+		ForeignKeys: lo.Map(table.ForeignKeys, func(key SchemaForeignKey, _ int) schema.ForeignKey {
+			return schema.ForeignKey{
+				Name:            key.Name,
+				ReferencedTable: key.ReferencedTable,
+				ReferencedKey:   key.ReferencedKey,
+				ReferencingKey:  key.ReferencingKey,
+				OnDelete:        key.OnDelete,
+				OnUpdate:        key.OnUpdate,
+			}
+		}),
+		UniqueKeys: lo.Map(table.UniqueKeys, func(key SchemaUniqueKey, _ int) schema.UniqueKey {
+			return schema.UniqueKey{Name: key.Name, Key: key.Key}
+		}),
+		Indexes: lo.Map(table.Indexes, func(index SchemaIndex, _ int) schema.Index {
+			return schema.Index{
+				Name:   index.Name,
+				Unique: index.Unique,
+				Key: lo.Map(index.Key, func(key SchemaIndexKey, _ int) schema.IndexKey {
+					return schema.IndexKey{Name: key.Name, Desc: key.Desc, Expression: key.Expression}
+				}),
+				Predicate: index.Predicate,
+			}
+		}),
+		Checks: lo.Map(table.Checks, func(check SchemaCheck, _ int) schema.Check {
+			return schema.Check{Name: check.Name, Expression: check.Expression}
+		}),
+	}
+}
+
+// ConvertType converts the declared type into the logical type.
+// The type is determined by the rules of type affinity, in which declared types meaning booleans, dates, timestamps, JSON, and decimals are distinguished.
+func ConvertType(declaredType string) schema.Type {
+	declared := strings.ToUpper(declaredType)
+	name, params := typeParameters(declared)
+	contains := func(substrings ...string) bool {
+		return lo.SomeBy(substrings, func(s string) bool { return strings.Contains(name, s) })
+	}
+	switch {
+	case contains("INT"):
+		return schema.Type{Kind: schema.TypeKindInteger}
+	case contains("CHAR", "CLOB", "TEXT"):
+		t := schema.Type{Kind: schema.TypeKindString}
+		if len(params) > 0 {
+			t.Length = params[0]
+		}
+		return t
+	case contains("BLOB") || name == "":
+		return schema.Type{Kind: schema.TypeKindBytes}
+	case contains("REAL", "FLOA", "DOUB"):
+		return schema.Type{Kind: schema.TypeKindFloat}
+	case contains("BOOL"):
+		return schema.Type{Kind: schema.TypeKindBool}
+	case contains("TIME"):
+		return schema.Type{Kind: schema.TypeKindTimestamp}
+	case contains("DATE"):
+		return schema.Type{Kind: schema.TypeKindDate}
+	case contains("JSON"):
+		return schema.Type{Kind: schema.TypeKindJSON}
+	default:
+		t := schema.Type{Kind: schema.TypeKindDecimal}
+		if len(params) > 0 {
+			t.Precision = params[0]
+		}
+		if len(params) > 1 {
+			t.Scale = params[1]
+		}
+		return t
+	}
+}
+
+// typeParameters splits the declared type such as DECIMAL(10, 2) into the name and the numeric parameters.
+func typeParameters(declaredType string) (name string, params []int64) {
+	name, rest, found := strings.Cut(declaredType, "(")
+	name = strings.TrimSpace(name)
+	if !found {
+		return name, nil
+	}
+	rest, _, _ = strings.Cut(rest, ")")
+	for _, param := range strings.Split(rest, ",") {
+		value, err := strconv.ParseInt(strings.TrimSpace(param), 10, 64)
+		if err != nil {
+			return name, nil
+		}
+		params = append(params, value)
+	}
+	return name, params
+}
+
+// ConvertFromTables converts the dialect-neutral tables into tables in SQLite with warnings on parts that cannot be converted.
+// Interleaved tables are converted into tables having foreign keys referencing their parents, and expressions are converted as written.
+// Auto-increment is available only for the single-column primary key of integer.
+func ConvertFromTables(tables []schema.Table) ([]SchemaTable, []schema.Warning) {
+	var warnings []schema.Warning
+	warn := func(table schema.Table, format string, args ...any) {
+		warnings = append(warnings, schema.Warning{Table: table.Name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	converted := []SchemaTable{}
+	for _, table := range tables {
+		if table.Schema != "" && table.Schema != "public" {
+			warn(table, "schema %s is ignored", table.Schema)
+		}
+		sqliteTable := SchemaTable{
+			Name:       table.Name,
+			PrimaryKey: table.PrimaryKey,
+		}
+		for _, column := range table.Columns {
+			columnType, ok := ConvertFromType(column.Type)
+			if !ok {
+				warn(table, "type %s of column %s is converted into %s", column.NativeType, column.Name, columnType)
+			}
+			sqliteColumn := SchemaColumn{
+				Name:      column.Name,
+				Type:      columnType,
+				Nullable:  column.Nullable,
+				Default:   column.Default,
+				Generated: column.Generated,
+				Stored:    column.Stored,
+			}
+			if column.AutoIncrement {
+				sqliteColumn.Default = ""
+				if column.Type.Kind == schema.TypeKindInteger && slices.Equal(table.PrimaryKey, []string{column.Name}) {
+					sqliteColumn.AutoIncrement = true
+				} else {
+					warn(table, "auto-increment of column %s is ignored since the column is not the single-column primary key of integer", column.Name)
+				}
+			}
+			sqliteTable.Columns = append(sqliteTable.Columns, sqliteColumn)
+		}
+		if table.Parent != "" {
+			if foreignKey, found := schema.ParentForeignKey(table, tables); found {
+				warn(table, "interleaving in %s is converted into a foreign key", table.Parent)
+				table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+			} else {
+				warn(table, "interleaving in %s is ignored", table.Parent)
+			}
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			sqliteTable.ForeignKeys = append(sqliteTable.ForeignKeys, SchemaForeignKey{
+				Name:            foreignKey.Name,
+				ReferencedTable: foreignKey.ReferencedTable,
+				ReferencedKey:   foreignKey.ReferencedKey,
+				ReferencingKey:  foreignKey.ReferencingKey,
+				OnDelete:        foreignKey.OnDelete,
+				OnUpdate:        foreignKey.OnUpdate,
+			})
+		}
+		for _, uniqueKey := range table.UniqueKeys {
+			sqliteTable.UniqueKeys = append(sqliteTable.UniqueKeys, SchemaUniqueKey{Name: uniqueKey.Name, Key: uniqueKey.Key})
+		}
+		for _, index := range table.Indexes {
+			sqliteTable.Indexes = append(sqliteTable.Indexes, SchemaIndex{
+				Name:   index.Name,
+				Unique: index.Unique,
+				Key: lo.Map(index.Key, func(key schema.IndexKey, _ int) SchemaIndexKey {
+					return SchemaIndexKey{Name: key.Name, Desc: key.Desc, Expression: key.Expression}
+				}),
+				Predicate: index.Predicate,
+			})
+		}
+		for _, check := range table.Checks {
+			sqliteTable.Checks = append(sqliteTable.Checks, SchemaCheck{Name: check.Name, Expression: check.Expression})
+		}
+		converted = append(converted, sqliteTable)
+	}
+	return converted, warnings
+}
+
+// ConvertFromType returns the declared type in SQLite corresponding to the logical type, which ConvertType converts back into the logical type.
+// It returns TEXT and false if the logical type has no counterpart such as arrays.
+func ConvertFromType(t schema.Type) (string, bool) {
+	switch t.Kind {
+	case schema.TypeKindInteger:
+		return "INTEGER", true
+	case schema.TypeKindFloat:
+		return "REAL", true
+	case schema.TypeKindDecimal:
+		if t.Precision > 0 {
+			return fmt.Sprintf("DECIMAL(%d, %d)", t.Precision, t.Scale), true
+		}
+		return "NUMERIC", true
+	case schema.TypeKindString:
+		if t.Length > 0 {
+			return fmt.Sprintf("VARCHAR(%d)", t.Length), true
+		}
+		return "TEXT", true
+	case schema.TypeKindBytes:
+		return "BLOB", true
+	case schema.TypeKindBool:
+		return "BOOLEAN", true
+	case schema.TypeKindDate:
+		return "DATE", true
+	case schema.TypeKindTimestamp:
+		return "TIMESTAMP", true
+	case schema.TypeKindJSON:
+		return "JSON", true
+	default:
+		return "TEXT", false
+	}
+}
@@ -0,0 +1,97 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gf_schema "github.com/Jumpaku/gotaface/schema"
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertType(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want gf_schema.Type
+	}{
+		{in: "INTEGER", want: gf_schema.Type{Kind: gf_schema.TypeKindInteger}},
+		{in: "bigint", want: gf_schema.Type{Kind: gf_schema.TypeKindInteger}},
+		{in: "VARCHAR(50)", want: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 50}},
+		{in: "TEXT", want: gf_schema.Type{Kind: gf_schema.TypeKindString}},
+		{in: "BLOB", want: gf_schema.Type{Kind: gf_schema.TypeKindBytes}},
+		{in: "", want: gf_schema.Type{Kind: gf_schema.TypeKindBytes}},
+		{in: "DOUBLE PRECISION", want: gf_schema.Type{Kind: gf_schema.TypeKindFloat}},
+		{in: "BOOLEAN", want: gf_schema.Type{Kind: gf_schema.TypeKindBool}},
+		{in: "DATE", want: gf_schema.Type{Kind: gf_schema.TypeKindDate}},
+		{in: "DATETIME", want: gf_schema.Type{Kind: gf_schema.TypeKindTimestamp}},
+		{in: "JSON", want: gf_schema.Type{Kind: gf_schema.TypeKindJSON}},
+		{in: "NUMERIC", want: gf_schema.Type{Kind: gf_schema.TypeKindDecimal}},
+		{in: "DECIMAL(10, 2)", want: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 10, Scale: 2}},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			got := schema.ConvertType(testcase.in)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestConvertFromType(t *testing.T) {
+	testcases := []struct {
+		in     gf_schema.Type
+		want   string
+		wantOk bool
+	}{
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, want: "INTEGER", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}, want: "VARCHAR(10)", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 10, Scale: 2}, want: "DECIMAL(10, 2)", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindTimestamp}, want: "TIMESTAMP", wantOk: true},
+		{in: gf_schema.Type{Kind: gf_schema.TypeKindArray, Elem: &gf_schema.Type{Kind: gf_schema.TypeKindBool}}, want: "TEXT", wantOk: false},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.in), func(t *testing.T) {
+			got, ok := schema.ConvertFromType(testcase.in)
+			assert.Equal(t, testcase.want, got)
+			assert.Equal(t, testcase.wantOk, ok)
+		})
+	}
+}
+
+func TestConvertTable(t *testing.T) {
+	sut, err := schema.NewDDLFetcher(`CREATE TABLE parent (id INTEGER PRIMARY KEY);
+CREATE TABLE child (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	parent_id INTEGER NOT NULL CONSTRAINT fk_parent REFERENCES parent ON DELETE CASCADE,
+	code VARCHAR(10) UNIQUE,
+	price DECIMAL(10, 2) DEFAULT 0 CONSTRAINT ck_price CHECK (price >= 0)
+);
+CREATE INDEX child_code_idx ON child (code DESC);`)
+	assert.Nil(t, err)
+	table, err := sut.Fetch(context.Background(), "child")
+	assert.Nil(t, err)
+
+	got := schema.ConvertTable(table)
+
+	want := gf_schema.Table{
+		Name: "child",
+		Columns: []gf_schema.Column{
+			{Name: "id", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "INTEGER", Nullable: true, AutoIncrement: true},
+			{Name: "parent_id", Type: gf_schema.Type{Kind: gf_schema.TypeKindInteger}, NativeType: "INTEGER"},
+			{Name: "code", Type: gf_schema.Type{Kind: gf_schema.TypeKindString, Length: 10}, NativeType: "VARCHAR(10)", Nullable: true},
+			{Name: "price", Type: gf_schema.Type{Kind: gf_schema.TypeKindDecimal, Precision: 10, Scale: 2}, NativeType: "DECIMAL(10, 2)", Nullable: true, Default: "0"},
+		},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []gf_schema.ForeignKey{
+			{Name: "fk_parent", ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"parent_id"}, OnDelete: "CASCADE"},
+		},
+		UniqueKeys: []gf_schema.UniqueKey{{Key: []string{"code"}}},
+		Indexes: []gf_schema.Index{
+			{Name: "child_code_idx", Key: []gf_schema.IndexKey{{Name: "code", Desc: true}}},
+		},
+		Checks: []gf_schema.Check{{Name: "ck_price", Expression: "price >= 0"}},
+	}
+	assert.Equal(t, want, got)
+}
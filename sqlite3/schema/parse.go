@@ -0,0 +1,822 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Jumpaku/gotaface/ddl"
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/samber/lo"
+)
+
+type ddlUniqueKey struct {
+	// index is the name of the index created by CREATE UNIQUE INDEX, which is empty for UNIQUE constraints.
+	index string
+	key   []string
+}
+
+type ddlCheck struct {
+	// column is the name of the column whose definition has the CHECK constraint, which is empty for table constraints.
+	column string
+	check  SchemaCheck
+}
+
+type ddlTable struct {
+	name            string
+	strict          bool
+	withoutRowID    bool
+	module          string
+	moduleArguments []string
+	columns         []SchemaColumn
+	primaryKey      []string
+	foreignKeys     []SchemaForeignKey
+	uniqueKeys      []ddlUniqueKey
+	indexes         []SchemaIndex
+	checks          []ddlCheck
+}
+
+type ddlFetcher struct {
+	tables       map[string]*ddlTable
+	referencedBy bool
+}
+
+// NewDDLFetcher parses DDL statements and returns a fetcher that provides schemas of the defined tables without querying a database.
+// CREATE TABLE, CREATE VIRTUAL TABLE, CREATE INDEX, ALTER TABLE, DROP TABLE, and DROP INDEX statements are interpreted in order and the other statements are ignored.
+// Table names are case-insensitive, and default values and generated columns are kept as written as pragma_table_xinfo does.
+// Columns of virtual tables are provided only for the FTS3, FTS4, FTS5, and R*Tree modules.
+func NewDDLFetcher(ddlStatements string) (ddlFetcher, error) {
+	tokens, err := ddl.Tokenize(ddlStatements)
+	if err != nil {
+		return ddlFetcher{}, fmt.Errorf(`fail to parse DDL: %w`, err)
+	}
+
+	parser := ddlParser{tables: map[string]*ddlTable{}}
+	for _, stmt := range ddl.Split(tokens) {
+		if err := parser.parseStatement(ddl.NewParser(ddlStatements, stmt)); err != nil {
+			return ddlFetcher{}, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+	}
+
+	return ddlFetcher{tables: parser.tables}, nil
+}
+
+// WithReferencedBy returns a copy of the fetcher that also provides foreign keys referencing the table in ReferencedBy.
+func (fetcher ddlFetcher) WithReferencedBy() ddlFetcher {
+	fetcher.referencedBy = true
+	return fetcher
+}
+
+var _ schema.Fetcher[SchemaTable] = ddlFetcher{}
+var _ schema.Lister = ddlFetcher{}
+
+func (fetcher ddlFetcher) Fetch(ctx context.Context, table string) (SchemaTable, error) {
+	t, found := fetcher.tables[strings.ToLower(table)]
+	if !found {
+		return SchemaTable{}, fmt.Errorf(`fail to fetch schema of %s: table %q not found`, table, table)
+	}
+
+	schemaTable := SchemaTable{
+		Name:            t.name,
+		Strict:          t.strict,
+		WithoutRowID:    t.withoutRowID,
+		Module:          t.module,
+		ModuleArguments: slices.Clone(t.moduleArguments),
+		Columns:         slices.Clone(t.columns),
+		PrimaryKey:      slices.Clone(t.primaryKey),
+	}
+	if t.withoutRowID {
+		// columns of the primary key of a WITHOUT ROWID table are NOT NULL in the same way as pragma_table_info reports
+		for i, column := range schemaTable.Columns {
+			if lo.ContainsBy(t.primaryKey, func(it string) bool { return strings.EqualFold(it, column.Name) }) {
+				schemaTable.Columns[i].Nullable = false
+			}
+		}
+	}
+
+	for _, foreignKey := range t.foreignKeys {
+		if len(foreignKey.ReferencedKey) == 0 {
+			if referenced, found := fetcher.tables[strings.ToLower(foreignKey.ReferencedTable)]; found {
+				foreignKey.ReferencedKey = slices.Clone(referenced.primaryKey)
+			}
+		}
+		schemaTable.ForeignKeys = append(schemaTable.ForeignKeys, foreignKey)
+	}
+
+	for _, uniqueKey := range t.uniqueKeys {
+		schemaTable.UniqueKeys = append(schemaTable.UniqueKeys, SchemaUniqueKey{Name: uniqueKey.index, Key: slices.Clone(uniqueKey.key)})
+	}
+
+	for _, index := range t.indexes {
+		index.Key = slices.Clone(index.Key)
+		schemaTable.Indexes = append(schemaTable.Indexes, index)
+	}
+
+	for _, check := range t.checks {
+		schemaTable.Checks = append(schemaTable.Checks, check.check)
+	}
+
+	if fetcher.referencedBy {
+		schemaTable.ReferencedBy = fetcher.referencedByOf(t)
+	}
+
+	return schemaTable, nil
+}
+
+// referencedByOf returns foreign keys referencing the table in the same order as the fetcher querying a database.
+func (fetcher ddlFetcher) referencedByOf(t *ddlTable) []SchemaReference {
+	tables := lo.Values(fetcher.tables)
+	slices.SortFunc(tables, func(a, b *ddlTable) int { return strings.Compare(a.name, b.name) })
+	var references []SchemaReference
+	for _, referencing := range tables {
+		for _, foreignKey := range referencing.foreignKeys {
+			if !strings.EqualFold(foreignKey.ReferencedTable, t.name) {
+				continue
+			}
+			referencedKey := foreignKey.ReferencedKey
+			if len(referencedKey) == 0 {
+				referencedKey = t.primaryKey
+			}
+			references = append(references, SchemaReference{
+				Name:             foreignKey.Name,
+				ReferencingTable: referencing.name,
+				ReferencingKey:   slices.Clone(foreignKey.ReferencingKey),
+				ReferencedKey:    slices.Clone(referencedKey),
+			})
+		}
+	}
+	return references
+}
+
+func (fetcher ddlFetcher) ListTables(ctx context.Context) ([]string, error) {
+	tables := lo.MapToSlice(fetcher.tables, func(_ string, table *ddlTable) string { return table.name })
+	slices.Sort(tables)
+	return tables, nil
+}
+
+type ddlParser struct {
+	tables map[string]*ddlTable
+}
+
+func (parser ddlParser) parseStatement(p *ddl.Parser) error {
+	switch {
+	case p.Keyword("CREATE"):
+		_ = p.Keyword("TEMPORARY") || p.Keyword("TEMP")
+		switch {
+		case p.Keyword("TABLE"):
+			return parser.parseCreateTable(p)
+		case p.Keyword("VIRTUAL", "TABLE"):
+			return parser.parseCreateVirtualTable(p)
+		case p.Keyword("UNIQUE", "INDEX"):
+			return parser.parseCreateIndex(p, true)
+		case p.Keyword("INDEX"):
+			return parser.parseCreateIndex(p, false)
+		}
+	case p.Keyword("ALTER", "TABLE"):
+		return parser.parseAlterTable(p)
+	case p.Keyword("DROP", "TABLE"):
+		_ = p.Keyword("IF", "EXISTS")
+		name, err := parseQualifiedName(p)
+		if err != nil {
+			return err
+		}
+		delete(parser.tables, strings.ToLower(name))
+	case p.Keyword("DROP", "INDEX"):
+		_ = p.Keyword("IF", "EXISTS")
+		name, err := parseQualifiedName(p)
+		if err != nil {
+			return err
+		}
+		for _, table := range parser.tables {
+			table.uniqueKeys = lo.Reject(table.uniqueKeys, func(it ddlUniqueKey, _ int) bool { return strings.EqualFold(it.index, name) })
+			table.indexes = lo.Reject(table.indexes, func(it SchemaIndex, _ int) bool { return strings.EqualFold(it.Name, name) })
+		}
+	}
+	return nil
+}
+
+func (parser ddlParser) parseCreateTable(p *ddl.Parser) error {
+	ifNotExists := p.Keyword("IF", "NOT", "EXISTS")
+	name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	if !p.Symbol("(") {
+		return fmt.Errorf(`CREATE TABLE %s without column definitions is not supported`, name)
+	}
+
+	table := &ddlTable{name: name}
+	for !p.Symbol(")") {
+		if err := parser.parseTableElement(p, table); err != nil {
+			return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+		}
+		if !p.Symbol(",") {
+			if err := p.ExpectSymbol(")"); err != nil {
+				return fmt.Errorf(`fail to parse CREATE TABLE %s: %w`, name, err)
+			}
+			break
+		}
+	}
+	for !p.EOF() {
+		switch {
+		case p.Keyword("WITHOUT", "ROWID"):
+			table.withoutRowID = true
+		case p.Keyword("STRICT"):
+			table.strict = true
+		case p.Symbol(","):
+		default:
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return parser.addTable(table, ifNotExists)
+}
+
+func (parser ddlParser) addTable(table *ddlTable, ifNotExists bool) error {
+	if _, found := parser.tables[strings.ToLower(table.name)]; found {
+		if ifNotExists {
+			return nil
+		}
+		return fmt.Errorf(`table %s already exists`, table.name)
+	}
+	parser.tables[strings.ToLower(table.name)] = table
+
+	return nil
+}
+
+func (parser ddlParser) parseCreateVirtualTable(p *ddl.Parser) error {
+	ifNotExists := p.Keyword("IF", "NOT", "EXISTS")
+	name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	if err := p.ExpectKeyword("USING"); err != nil {
+		return err
+	}
+	module, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+
+	table := &ddlTable{name: name, module: strings.ToLower(module)}
+	if p.Symbol("(") {
+		for !p.Symbol(")") {
+			if p.EOF() {
+				return fmt.Errorf(`fail to parse CREATE VIRTUAL TABLE %s: %w`, name, p.ExpectSymbol(")"))
+			}
+			begin := p.Pos()
+			if err := p.SkipUntil(func() bool { return p.PeekSymbol(",") || p.PeekSymbol(")") }); err != nil {
+				return fmt.Errorf(`fail to parse CREATE VIRTUAL TABLE %s: %w`, name, err)
+			}
+			// the arguments are kept as written in the same way as sqlite_master
+			table.moduleArguments = append(table.moduleArguments, p.Text(begin, p.Pos()))
+			_ = p.Symbol(",")
+		}
+	}
+	table.columns = virtualTableColumns(table.name, table.module, table.moduleArguments)
+
+	return parser.addTable(table, ifNotExists)
+}
+
+// virtualTableColumns returns the columns of the virtual table including the hidden columns in the same way as pragma_table_xinfo.
+// Columns are not provided for modules other than fts3, fts4, fts5, rtree, and rtree_i32.
+func virtualTableColumns(name string, module string, arguments []string) []SchemaColumn {
+	var columns []SchemaColumn
+	languageID := "__langid"
+	for i, argument := range arguments {
+		tokens, err := ddl.Tokenize(argument)
+		if err != nil || len(tokens) == 0 {
+			continue
+		}
+		p := ddl.NewParser(argument, tokens)
+		switch module {
+		case "fts3", "fts4", "fts5":
+			column, err := parseIdentifier(p)
+			if err != nil {
+				continue
+			}
+			if p.Symbol("=") {
+				// options such as tokenize=porter are not columns
+				if token, err := p.Next(); err == nil && strings.EqualFold(column, "languageid") {
+					languageID = token.Value
+				}
+				continue
+			}
+			columns = append(columns, SchemaColumn{Name: column, Nullable: true})
+		case "rtree", "rtree_i32":
+			auxiliary := p.Symbol("+")
+			column, err := parseIdentifier(p)
+			if err != nil {
+				continue
+			}
+			columnType := lo.Ternary(i == 0 || module == "rtree_i32", "INT", "REAL")
+			columns = append(columns, SchemaColumn{Name: column, Type: lo.Ternary(auxiliary, "", columnType), Nullable: true})
+		}
+	}
+	switch module {
+	case "fts3", "fts4":
+		columns = append(columns,
+			SchemaColumn{Name: name, Nullable: true, Hidden: true},
+			SchemaColumn{Name: "docid", Nullable: true, Hidden: true},
+			SchemaColumn{Name: languageID, Nullable: true, Hidden: true},
+		)
+	case "fts5":
+		columns = append(columns,
+			SchemaColumn{Name: name, Nullable: true, Hidden: true},
+			SchemaColumn{Name: "rank", Nullable: true, Hidden: true},
+		)
+	}
+	return columns
+}
+
+func (parser ddlParser) parseCreateIndex(p *ddl.Parser, unique bool) error {
+	_ = p.Keyword("IF", "NOT", "EXISTS")
+	name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	if err := p.ExpectKeyword("ON"); err != nil {
+		return err
+	}
+	tableName, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	table, found := parser.tables[strings.ToLower(tableName)]
+	if !found {
+		return fmt.Errorf(`fail to parse CREATE INDEX %s: table %s not found`, name, tableName)
+	}
+	key, err := parseIndexKeyList(p)
+	if err != nil {
+		return err
+	}
+	index := SchemaIndex{Name: name, Unique: unique, Key: key}
+	if p.Keyword("WHERE") {
+		begin := p.Pos()
+		for !p.EOF() {
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		}
+		// the condition is kept as written in the same way as sqlite_master
+		index.Predicate = p.Text(begin, p.Pos())
+	}
+	table.indexes = append(table.indexes, index)
+	// unique indexes on expressions are not unique keys since they have no columns
+	if unique && !lo.SomeBy(key, func(column SchemaIndexKey) bool { return column.Expression != "" }) {
+		table.uniqueKeys = append(table.uniqueKeys, ddlUniqueKey{index: name, key: lo.Map(key, func(column SchemaIndexKey, _ int) string { return column.Name })})
+	}
+	return nil
+}
+
+func (parser ddlParser) parseAlterTable(p *ddl.Parser) error {
+	name, err := parseQualifiedName(p)
+	if err != nil {
+		return err
+	}
+	table, found := parser.tables[strings.ToLower(name)]
+	if !found {
+		return fmt.Errorf(`fail to parse ALTER TABLE %s: table not found`, name)
+	}
+
+	switch {
+	case p.Keyword("RENAME", "TO"):
+		after, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		delete(parser.tables, strings.ToLower(table.name))
+		for _, t := range parser.tables {
+			for i := range t.foreignKeys {
+				if strings.EqualFold(t.foreignKeys[i].ReferencedTable, table.name) {
+					t.foreignKeys[i].ReferencedTable = after
+				}
+			}
+		}
+		table.name = after
+		parser.tables[strings.ToLower(after)] = table
+	case p.Keyword("RENAME"):
+		_ = p.Keyword("COLUMN")
+		before, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		if err := p.ExpectKeyword("TO"); err != nil {
+			return err
+		}
+		after, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		table.renameColumn(before, after)
+		for _, t := range parser.tables {
+			for i := range t.foreignKeys {
+				if strings.EqualFold(t.foreignKeys[i].ReferencedTable, table.name) {
+					t.foreignKeys[i].ReferencedKey = renameKey(t.foreignKeys[i].ReferencedKey, before, after)
+				}
+			}
+		}
+	case p.Keyword("ADD"):
+		_ = p.Keyword("COLUMN")
+		if err := parser.parseColumnDefinition(p, table); err != nil {
+			return fmt.Errorf(`fail to parse ALTER TABLE %s: %w`, name, err)
+		}
+	case p.Keyword("DROP"):
+		_ = p.Keyword("COLUMN")
+		column, err := parseIdentifier(p)
+		if err != nil {
+			return err
+		}
+		table.columns = lo.Reject(table.columns, func(it SchemaColumn, _ int) bool { return strings.EqualFold(it.Name, column) })
+		table.checks = lo.Reject(table.checks, func(it ddlCheck, _ int) bool { return strings.EqualFold(it.column, column) })
+	}
+	return nil
+}
+
+func (parser ddlParser) parseTableElement(p *ddl.Parser, table *ddlTable) error {
+	if !p.PeekKeyword("CONSTRAINT") && !p.PeekKeyword("PRIMARY", "KEY") && !p.PeekKeyword("UNIQUE") && !p.PeekKeyword("FOREIGN", "KEY") && !p.PeekKeyword("CHECK") {
+		return parser.parseColumnDefinition(p, table)
+	}
+
+	constraint := ""
+	if p.Keyword("CONSTRAINT") {
+		var err error
+		if constraint, err = parseIdentifier(p); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.Keyword("PRIMARY", "KEY"):
+		key, err := parseColumnList(p)
+		if err != nil {
+			return err
+		}
+		table.primaryKey = key
+	case p.Keyword("UNIQUE"):
+		key, err := parseColumnList(p)
+		if err != nil {
+			return err
+		}
+		table.uniqueKeys = append(table.uniqueKeys, ddlUniqueKey{key: key})
+	case p.Keyword("FOREIGN", "KEY"):
+		key, err := parseColumnList(p)
+		if err != nil {
+			return err
+		}
+		if err := p.ExpectKeyword("REFERENCES"); err != nil {
+			return err
+		}
+		if err := parseReferences(p, table, constraint, key); err != nil {
+			return err
+		}
+	case p.Keyword("CHECK"):
+		expression, err := parseCheckExpression(p)
+		if err != nil {
+			return err
+		}
+		table.checks = append(table.checks, ddlCheck{check: SchemaCheck{Name: constraint, Expression: expression}})
+	}
+
+	return p.SkipUntil(nil)
+}
+
+func parseReferences(p *ddl.Parser, table *ddlTable, name string, key []string) error {
+	referencedTable, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+	var referencedKey []string
+	if p.PeekSymbol("(") {
+		if referencedKey, err = parseColumnList(p); err != nil {
+			return err
+		}
+	}
+	foreignKey := SchemaForeignKey{Name: name, ReferencedTable: referencedTable, ReferencedKey: referencedKey, ReferencingKey: key}
+
+	for {
+		switch {
+		case p.Keyword("MATCH"):
+			// MATCH clauses are parsed but ignored by SQLite
+			if _, err := p.Next(); err != nil {
+				return err
+			}
+		case p.Keyword("ON", "DELETE"):
+			if foreignKey.OnDelete, err = parseReferentialAction(p); err != nil {
+				return err
+			}
+		case p.Keyword("ON", "UPDATE"):
+			if foreignKey.OnUpdate, err = parseReferentialAction(p); err != nil {
+				return err
+			}
+		case p.Keyword("NOT", "DEFERRABLE"):
+			_ = p.Keyword("INITIALLY", "DEFERRED") || p.Keyword("INITIALLY", "IMMEDIATE")
+		case p.Keyword("DEFERRABLE"):
+			if p.Keyword("INITIALLY", "DEFERRED") {
+				foreignKey.Deferrable, foreignKey.InitiallyDeferred = true, true
+			}
+			_ = p.Keyword("INITIALLY", "IMMEDIATE")
+		default:
+			table.foreignKeys = append(table.foreignKeys, foreignKey)
+			return nil
+		}
+	}
+}
+
+// parseReferentialAction reads the action of ON DELETE or ON UPDATE and returns it in upper case, which is empty for NO ACTION.
+func parseReferentialAction(p *ddl.Parser) (string, error) {
+	switch {
+	case p.Keyword("SET", "NULL"):
+		return "SET NULL", nil
+	case p.Keyword("SET", "DEFAULT"):
+		return "SET DEFAULT", nil
+	case p.Keyword("CASCADE"):
+		return "CASCADE", nil
+	case p.Keyword("RESTRICT"):
+		return "RESTRICT", nil
+	case p.Keyword("NO", "ACTION"):
+		return "", nil
+	default:
+		return "", fmt.Errorf(`unexpected referential action`)
+	}
+}
+
+var columnConstraintKeywords = []string{"CONSTRAINT", "PRIMARY", "NOT", "NULL", "UNIQUE", "CHECK", "DEFAULT", "COLLATE", "REFERENCES", "GENERATED", "AS"}
+
+func peekColumnConstraint(p *ddl.Parser) bool {
+	return lo.SomeBy(columnConstraintKeywords, func(keyword string) bool { return p.PeekKeyword(keyword) })
+}
+
+func (parser ddlParser) parseColumnDefinition(p *ddl.Parser, table *ddlTable) error {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return err
+	}
+
+	begin := p.Pos()
+	if err := p.SkipUntil(func() bool { return peekColumnConstraint(p) }); err != nil {
+		return err
+	}
+	// the declared type is kept as written in the same way as pragma_table_info
+	column := SchemaColumn{Name: name, Type: p.Text(begin, p.Pos()), Nullable: true}
+	if tokens := p.Tokens(begin, p.Pos()); len(tokens) == 1 && tokens[0].Kind == ddl.TokenKindQuotedIdentifier {
+		column.Type = tokens[0].Value
+	}
+	for !p.EOF() && !p.PeekSymbol(",") && !p.PeekSymbol(")") {
+		constraint := ""
+		if p.Keyword("CONSTRAINT") {
+			if constraint, err = parseIdentifier(p); err != nil {
+				return err
+			}
+		}
+		switch {
+		case p.Keyword("NOT", "NULL"):
+			column.Nullable = false
+		case p.Keyword("NULL"):
+		case p.Keyword("PRIMARY", "KEY"):
+			table.primaryKey = []string{name}
+		case p.Keyword("UNIQUE"):
+			table.uniqueKeys = append(table.uniqueKeys, ddlUniqueKey{key: []string{name}})
+		case p.Keyword("REFERENCES"):
+			if err := parseReferences(p, table, constraint, []string{name}); err != nil {
+				return err
+			}
+		case p.Keyword("AUTOINCREMENT"):
+			column.AutoIncrement = true
+		case p.Keyword("GENERATED", "ALWAYS", "AS"), p.Keyword("AS"):
+			begin := p.Pos()
+			if err := p.Skip(); err != nil {
+				return err
+			}
+			column.Generated = p.Text(begin+1, p.Pos()-1)
+		case p.Keyword("STORED"):
+			column.Stored = true
+		case p.Keyword("DEFAULT"):
+			begin := p.Pos()
+			if p.PeekSymbol("(") {
+				if err := p.Skip(); err != nil {
+					return err
+				}
+				// the default value in parentheses is kept without the parentheses in the same way as pragma_table_xinfo
+				column.Default = p.Text(begin+1, p.Pos()-1)
+				break
+			}
+			if err := p.Skip(); err != nil {
+				return err
+			}
+			if err := p.SkipUntil(func() bool { return peekColumnConstraint(p) }); err != nil {
+				return err
+			}
+			column.Default = p.Text(begin, p.Pos())
+		case p.Keyword("CHECK"):
+			expression, err := parseCheckExpression(p)
+			if err != nil {
+				return err
+			}
+			table.checks = append(table.checks, ddlCheck{column: name, check: SchemaCheck{Name: constraint, Expression: expression}})
+		case p.Keyword("COLLATE"):
+			if column.Collation, err = parseIdentifier(p); err != nil {
+				return err
+			}
+		default:
+			// skips conflict clauses, ASC, DESC, and VIRTUAL
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+
+	table.columns = append(table.columns, column)
+	return nil
+}
+
+func (table *ddlTable) renameColumn(before, after string) {
+	for i := range table.columns {
+		if strings.EqualFold(table.columns[i].Name, before) {
+			table.columns[i].Name = after
+		}
+		table.columns[i].Generated = renameInExpression(table.columns[i].Generated, before, after)
+	}
+	table.primaryKey = renameKey(table.primaryKey, before, after)
+	for i := range table.foreignKeys {
+		table.foreignKeys[i].ReferencingKey = renameKey(table.foreignKeys[i].ReferencingKey, before, after)
+	}
+	for i := range table.uniqueKeys {
+		table.uniqueKeys[i].key = renameKey(table.uniqueKeys[i].key, before, after)
+	}
+	for i := range table.indexes {
+		for j := range table.indexes[i].Key {
+			if strings.EqualFold(table.indexes[i].Key[j].Name, before) {
+				table.indexes[i].Key[j].Name = after
+			}
+			table.indexes[i].Key[j].Expression = renameInExpression(table.indexes[i].Key[j].Expression, before, after)
+		}
+		table.indexes[i].Predicate = renameInExpression(table.indexes[i].Predicate, before, after)
+	}
+	for i := range table.checks {
+		if strings.EqualFold(table.checks[i].column, before) {
+			table.checks[i].column = after
+		}
+		table.checks[i].check.Expression = renameInExpression(table.checks[i].check.Expression, before, after)
+	}
+}
+
+// renameInExpression replaces references to the column in the expression in the same way as SQLite rewrites the schema on RENAME COLUMN.
+func renameInExpression(expression string, before, after string) string {
+	tokens, err := ddl.Tokenize(expression)
+	if err != nil {
+		return expression
+	}
+	renamed, end := "", 0
+	for _, token := range tokens {
+		if (token.Kind == ddl.TokenKindWord || token.Kind == ddl.TokenKindQuotedIdentifier) && strings.EqualFold(token.Value, before) {
+			renamed += expression[end:token.Begin] + quoteIdentifierIfNeeded(after)
+			end = token.End
+		}
+	}
+	return renamed + expression[end:]
+}
+
+func quoteIdentifierIfNeeded(identifier string) string {
+	tokens, err := ddl.Tokenize(identifier)
+	if err == nil && len(tokens) == 1 && tokens[0].Kind == ddl.TokenKindWord && tokens[0].Value == identifier {
+		return identifier
+	}
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func renameKey(key []string, before, after string) []string {
+	return lo.Map(key, func(it string, _ int) string { return lo.Ternary(strings.EqualFold(it, before), after, it) })
+}
+
+// parseIdentifier reads an identifier, which can be quoted by brackets in addition to double quotes and backquotes.
+// parseCheckExpression returns the condition of a CHECK constraint without the enclosing parentheses.
+func parseCheckExpression(p *ddl.Parser) (string, error) {
+	if !p.PeekSymbol("(") {
+		return "", fmt.Errorf(`CHECK constraint without parentheses`)
+	}
+	begin := p.Pos()
+	if err := p.Skip(); err != nil {
+		return "", err
+	}
+	return p.Text(begin+1, p.Pos()-1), nil
+}
+
+func parseIdentifier(p *ddl.Parser) (string, error) {
+	if p.Symbol("[") {
+		begin := p.Pos()
+		for !p.PeekSymbol("]") {
+			if _, err := p.Next(); err != nil {
+				return "", err
+			}
+		}
+		name := p.Text(begin, p.Pos())
+		_ = p.Symbol("]")
+		return name, nil
+	}
+	token, err := p.Identifier()
+	if err != nil {
+		return "", err
+	}
+	return token.Value, nil
+}
+
+// parseQualifiedName reads a name possibly qualified by a schema and returns the name without the qualifier.
+func parseQualifiedName(p *ddl.Parser) (string, error) {
+	name, err := parseIdentifier(p)
+	if err != nil {
+		return "", err
+	}
+	if p.Symbol(".") {
+		return parseIdentifier(p)
+	}
+	return name, nil
+}
+
+func parseColumnList(p *ddl.Parser) ([]string, error) {
+	key, err := parseIndexedColumnList(p)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(key, func(column SchemaIndexKey, _ int) string { return column.Name }), nil
+}
+
+// parseIndexedColumnList reads a list of columns with their sort orders.
+func parseIndexedColumnList(p *ddl.Parser) ([]SchemaIndexKey, error) {
+	if err := p.ExpectSymbol("("); err != nil {
+		return nil, err
+	}
+	key := []SchemaIndexKey{}
+	for {
+		column, err := parseIdentifier(p)
+		if err != nil {
+			return nil, err
+		}
+		if token, ok := p.Peek(); ok && token.Kind != ddl.TokenKindWord && !p.PeekSymbol(",") && !p.PeekSymbol(")") {
+			return nil, fmt.Errorf(`expressions in column list are not supported`)
+		}
+		// skips COLLATE and ASC
+		if err := p.SkipUntil(func() bool { return p.PeekKeyword("DESC") }); err != nil {
+			return nil, err
+		}
+		desc := p.Keyword("DESC")
+		if err := p.SkipUntil(nil); err != nil {
+			return nil, err
+		}
+		key = append(key, SchemaIndexKey{Name: column, Desc: desc})
+		if !p.Symbol(",") {
+			break
+		}
+	}
+	if err := p.ExpectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// parseIndexKeyList reads a list of columns or expressions with their sort orders, in which expressions are kept as written in the same way as sqlite_master.
+func parseIndexKeyList(p *ddl.Parser) ([]SchemaIndexKey, error) {
+	if err := p.ExpectSymbol("("); err != nil {
+		return nil, err
+	}
+	key := []SchemaIndexKey{}
+	for {
+		begin := p.Pos()
+		if err := p.SkipUntil(func() bool { return p.PeekKeyword("COLLATE") || p.PeekKeyword("ASC") || p.PeekKeyword("DESC") }); err != nil {
+			return nil, err
+		}
+		end := p.Pos()
+		if begin == end {
+			return nil, fmt.Errorf(`key part of index is expected`)
+		}
+		keyPart := SchemaIndexKey{}
+		tokens := p.Tokens(begin, end)
+		switch {
+		case len(tokens) == 1 && tokens[0].Kind != ddl.TokenKindNumber && tokens[0].Kind != ddl.TokenKindSymbol:
+			// a string is also a column in the same way as SQLite for backward compatibility
+			keyPart.Name = tokens[0].Value
+		case len(tokens) >= 3 && tokens[0].Kind == ddl.TokenKindSymbol && tokens[0].Value == "[" && tokens[len(tokens)-1].Kind == ddl.TokenKindSymbol && tokens[len(tokens)-1].Value == "]":
+			keyPart.Name = p.Text(begin+1, end-1)
+		default:
+			keyPart.Expression = p.Text(begin, end)
+		}
+		// skips COLLATE and ASC
+		if err := p.SkipUntil(func() bool { return p.PeekKeyword("DESC") }); err != nil {
+			return nil, err
+		}
+		keyPart.Desc = p.Keyword("DESC")
+		if err := p.SkipUntil(nil); err != nil {
+			return nil, err
+		}
+		key = append(key, keyPart)
+		if !p.Symbol(",") {
+			break
+		}
+	}
+	if err := p.ExpectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
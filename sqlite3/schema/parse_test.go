@@ -0,0 +1,158 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDDLFetcher(t *testing.T) {
+	for number, testcase := range fetcherTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(ddls[testcase.ddl])
+			assert.Nil(t, err)
+
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assertEqualSchemaTable(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_ListTables(t *testing.T) {
+	for number, testcase := range listTablesTestcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.ddl), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(ddls[testcase.ddl])
+			assert.Nil(t, err)
+
+			got, err := sut.ListTables(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_WithReferencedBy(t *testing.T) {
+	for number, testcase := range referencedByTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.table), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(ddls[testcase.ddl])
+			assert.Nil(t, err)
+
+			got, err := sut.WithReferencedBy().Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got.ReferencedBy)
+		})
+	}
+}
+
+func TestDDLFetcher_Statements(t *testing.T) {
+	testcases := []struct {
+		name  string
+		ddl   string
+		table string
+		want  schema.SchemaTable
+	}{
+		{
+			name: "alter_table",
+			ddl: `CREATE TABLE Parent (id INTEGER PRIMARY KEY AUTOINCREMENT, code VARCHAR ( 10 ) UNIQUE ON CONFLICT REPLACE);
+CREATE TABLE [child] (
+	id INTEGER NOT NULL,
+	parent_id REFERENCES parent ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED,
+	note TEXT DEFAULT NULL NOT NULL COLLATE NOCASE,
+	upper_note TEXT GENERATED ALWAYS AS (upper(note)) VIRTUAL,
+	dropped INTEGER CHECK (dropped > 0),
+	PRIMARY KEY (id DESC),
+	CONSTRAINT CK_child_note CHECK (length(note) < 100)
+) WITHOUT ROWID;
+CREATE UNIQUE INDEX UQ_child_note ON child (note COLLATE NOCASE);
+CREATE UNIQUE INDEX UQ_child_expr ON child (lower(note));
+ALTER TABLE child DROP COLUMN dropped;
+ALTER TABLE child RENAME COLUMN note TO memo;
+CREATE TRIGGER tr AFTER INSERT ON child BEGIN SELECT 1; END;`,
+			table: "CHILD",
+			want: schema.SchemaTable{
+				Name:         "child",
+				WithoutRowID: true,
+				Columns: []schema.SchemaColumn{
+					{Name: "id", Type: "INTEGER"},
+					{Name: "parent_id", Type: "", Nullable: true},
+					{Name: "memo", Type: "TEXT", Default: "NULL", Collation: "NOCASE"},
+					{Name: "upper_note", Type: "TEXT", Nullable: true, Generated: "upper(memo)"},
+				},
+				PrimaryKey: []string{"id"},
+				ForeignKeys: []schema.SchemaForeignKey{
+					{ReferencedTable: "parent", ReferencedKey: []string{"id"}, ReferencingKey: []string{"parent_id"}, OnDelete: "SET NULL", Deferrable: true, InitiallyDeferred: true},
+				},
+				UniqueKeys: []schema.SchemaUniqueKey{{Name: "UQ_child_note", Key: []string{"memo"}}},
+				Indexes: []schema.SchemaIndex{
+					{Name: "UQ_child_note", Unique: true, Key: []schema.SchemaIndexKey{{Name: "memo"}}},
+					{Name: "UQ_child_expr", Unique: true, Key: []schema.SchemaIndexKey{{Expression: "lower(memo)"}}},
+				},
+				Checks: []schema.SchemaCheck{{Name: "CK_child_note", Expression: "length(memo) < 100"}},
+			},
+		},
+		{
+			name: "fts5",
+			ddl: `CREATE VIRTUAL TABLE main.Docs USING FTS5(Title, Body UNINDEXED, tokenize = 'porter unicode61', prefix = '2 3');
+CREATE VIRTUAL TABLE IF NOT EXISTS Docs USING fts5(Other);`,
+			table: "docs",
+			want: schema.SchemaTable{
+				Name:            "Docs",
+				Module:          "fts5",
+				ModuleArguments: []string{"Title", "Body UNINDEXED", "tokenize = 'porter unicode61'", "prefix = '2 3'"},
+				Columns: []schema.SchemaColumn{
+					{Name: "Title", Nullable: true},
+					{Name: "Body", Nullable: true},
+					{Name: "Docs", Nullable: true, Hidden: true},
+					{Name: "rank", Nullable: true, Hidden: true},
+				},
+			},
+		},
+		{
+			name:  "rtree_i32",
+			ddl:   `CREATE VIRTUAL TABLE Boxes USING rtree_i32(Id, MinX, MaxX, +Label)`,
+			table: "Boxes",
+			want: schema.SchemaTable{
+				Name:            "Boxes",
+				Module:          "rtree_i32",
+				ModuleArguments: []string{"Id", "MinX", "MaxX", "+Label"},
+				Columns: []schema.SchemaColumn{
+					{Name: "Id", Type: "INT", Nullable: true},
+					{Name: "MinX", Type: "INT", Nullable: true},
+					{Name: "MaxX", Type: "INT", Nullable: true},
+					{Name: "Label", Nullable: true},
+				},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			sut, err := schema.NewDDLFetcher(testcase.ddl)
+			assert.Nil(t, err)
+
+			got, err := sut.Fetch(context.Background(), testcase.table)
+			assert.Nil(t, err)
+			assertEqualSchemaTable(t, testcase.want, got)
+		})
+	}
+}
+
+func TestDDLFetcher_Statements_Error(t *testing.T) {
+	testcases := []struct {
+		name string
+		ddl  string
+	}{
+		{name: "virtual_table_arguments_unterminated", ddl: `CREATE VIRTUAL TABLE R_3 USING fts4(Title, Body, tokenize`},
+		{name: "virtual_table_arguments_empty_unterminated", ddl: `CREATE VIRTUAL TABLE R_3 USING fts4(`},
+	}
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			_, err := schema.NewDDLFetcher(testcase.ddl)
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+CREATE TABLE L (
+    PK INT64 NOT NULL,
+    C1 INT64 NOT NULL,
+    C2 STRING(50),
+    C3 FLOAT64,
+    PRIMARY KEY (PK)
+);
+
+CREATE INDEX IDX_L_C1 ON L (C1);
+CREATE INDEX IDX_L_C2_C1 ON L (C2 DESC, C1 ASC);
+CREATE UNIQUE INDEX UQ_L_C3 ON L (C3 DESC);
+CREATE INDEX IDX_L_C3_Partial ON L (C3) WHERE C3 > 0 AND C2 IS NOT NULL;
+CREATE INDEX IDX_L_Expression ON L (lower(C2));
+CREATE UNIQUE INDEX UQ_L_Expression ON L (C1, abs(C3) DESC, 'C2');
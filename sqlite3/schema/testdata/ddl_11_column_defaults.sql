@@ -0,0 +1,10 @@
+CREATE TABLE M (
+    PK INTEGER PRIMARY KEY AUTOINCREMENT,
+    C1 INT64 NOT NULL DEFAULT 0,
+    C2 STRING(50) DEFAULT 'abc',
+    C3 FLOAT64 DEFAULT -1.5,
+    C4 TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    C5 INT64 DEFAULT (1 + 2),
+    C6 INT64 GENERATED ALWAYS AS (C1 * 2) VIRTUAL,
+    C7 STRING(50) AS (upper(C2)) STORED
+);
@@ -0,0 +1,9 @@
+CREATE TABLE N (
+    PK INTEGER NOT NULL,
+    C1 INT64 NOT NULL CHECK (C1 >= 0),
+    C2 STRING(10) CONSTRAINT CK_N_C2 CHECK (C2 IN ('a', 'b', 'c')),
+    C3 FLOAT64,
+    PRIMARY KEY (PK),
+    CONSTRAINT CK_N_C1_C3 CHECK (C1 < C3),
+    CHECK (C3 <> 0)
+);
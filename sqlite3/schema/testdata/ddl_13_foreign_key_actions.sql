@@ -0,0 +1,16 @@
+CREATE TABLE O_1 (
+    PK INTEGER NOT NULL,
+    C1 INTEGER NOT NULL UNIQUE,
+    PRIMARY KEY (PK)
+);
+
+CREATE TABLE O_2 (
+    PK INTEGER NOT NULL,
+    R1 INTEGER,
+    R2 INTEGER REFERENCES O_1 (C1) ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED,
+    R3 INTEGER,
+    R4 INTEGER REFERENCES O_1,
+    PRIMARY KEY (PK),
+    CONSTRAINT FK_O_2_1 FOREIGN KEY (R1) REFERENCES O_1 (PK) ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT FK_O_2_3 FOREIGN KEY (R3) REFERENCES O_1 (PK) ON UPDATE SET DEFAULT DEFERRABLE INITIALLY IMMEDIATE
+);
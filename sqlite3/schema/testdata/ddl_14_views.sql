@@ -0,0 +1,19 @@
+CREATE TABLE P_1 (
+    PK INTEGER NOT NULL,
+    C1 TEXT NOT NULL,
+    PRIMARY KEY (PK)
+);
+
+CREATE TABLE P_2 (
+    PK INTEGER NOT NULL,
+    R1 INTEGER REFERENCES P_1 (PK),
+    C2 REAL,
+    PRIMARY KEY (PK)
+);
+
+CREATE VIEW V_1 AS SELECT PK, C1 FROM P_1 WHERE C1 <> '';
+
+CREATE VIEW V_2 (K, C, Total) AS
+    SELECT v.PK, v.C1, SUM(p.C2)
+    FROM V_1 AS v JOIN "P_2" AS p ON v.PK = p.R1
+    GROUP BY v.PK, v.C1;
@@ -0,0 +1,21 @@
+CREATE TABLE R_1 (
+    PK INTEGER PRIMARY KEY AUTOINCREMENT,
+    C1 TEXT COLLATE NOCASE NOT NULL,
+    C2 TEXT COLLATE RTRIM
+) STRICT;
+
+CREATE TABLE R_2 (
+    PK TEXT NOT NULL PRIMARY KEY,
+    C1 ANY
+) WITHOUT ROWID, STRICT;
+
+CREATE TABLE R_5 (
+    PK1 TEXT,
+    PK2 INTEGER,
+    C1 TEXT,
+    PRIMARY KEY (PK1, PK2)
+) WITHOUT ROWID;
+
+CREATE VIRTUAL TABLE R_3 USING fts4(Title, Body, tokenize=porter);
+
+CREATE VIRTUAL TABLE R_4 USING rtree(PK, MinX, MaxX, +Label TEXT);
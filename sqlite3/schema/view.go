@@ -0,0 +1,200 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Jumpaku/go-assert"
+	"github.com/Jumpaku/gotaface/ddl"
+	"github.com/Jumpaku/gotaface/schema"
+	gf_sqlite3 "github.com/Jumpaku/gotaface/sqlite3"
+	"github.com/samber/lo"
+)
+
+type SchemaView struct {
+	Name string `json:"name"`
+	// Columns are the columns of the view, whose types are the declared types of the underlying columns or empty for expressions.
+	Columns []SchemaColumn `json:"columns"`
+	// Definition is the SELECT statement of the view as written.
+	Definition string `json:"definition"`
+	// Dependencies are the names of the tables and views referenced by the view.
+	Dependencies []string `json:"dependencies"`
+}
+
+var _ schema.ViewFetcher[SchemaView] = fetcher{}
+var _ schema.ViewLister = fetcher{}
+
+func (fetcher fetcher) FetchView(ctx context.Context, view string) (SchemaView, error) {
+	wrapError := func(err error) (SchemaView, error) {
+		assert.Params(err != nil, "wrapped error must be not nil")
+		return SchemaView{}, fmt.Errorf(`fail to fetch view %s: %w`, view, err)
+	}
+
+	createView, err := queryViewDefinition(ctx, fetcher.queryer, view)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView := SchemaView{Name: view}
+	if schemaView.Definition, err = viewDefinition(createView); err != nil {
+		return wrapError(err)
+	}
+
+	schemaView.Columns, err = queryViewColumns(ctx, fetcher.queryer, view)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schemaView.Dependencies, err = queryViewDependencies(ctx, fetcher.queryer, view, schemaView.Definition)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return schemaView, nil
+}
+
+func (fetcher fetcher) ListViews(ctx context.Context) ([]string, error) {
+	sql := `--sql query user view names
+SELECT
+	"name" AS Name
+FROM sqlite_master
+WHERE "type" = 'view'
+ORDER BY "name"`
+	rows, err := fetcher.queryer.QueryxContext(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list views: %w`, err)
+	}
+	type view struct {
+		Name string `db:"Name"`
+	}
+	views, err := gf_sqlite3.ScanRowsStruct[view](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list views: %w`, err)
+	}
+	return lo.Map(views, func(it view, i int) string { return it.Name }), nil
+}
+
+// queryViewDefinition returns the CREATE VIEW statement of the view stored in sqlite_master.
+func queryViewDefinition(ctx context.Context, tx gf_sqlite3.Queryer, view string) (string, error) {
+	sql := `--sql query view definition
+SELECT
+	"sql" AS SQL
+FROM sqlite_master
+WHERE "type" = 'view' AND "name" = ?`
+	rows, err := tx.QueryxContext(ctx, sql, view)
+	if err != nil {
+		return "", fmt.Errorf(`fail to get definition of %s: %w`, view, err)
+	}
+	type definition struct {
+		SQL string `db:"SQL"`
+	}
+	definitions, err := gf_sqlite3.ScanRowsStruct[definition](rows)
+	if err != nil {
+		return "", fmt.Errorf(`fail to get definition of %s: %w`, view, err)
+	}
+	if len(definitions) == 0 {
+		return "", fmt.Errorf(`fail to get definition of %s: view not found`, view)
+	}
+	return definitions[0].SQL, nil
+}
+
+// queryIsView reports whether the name is of a view rather than a table.
+func queryIsView(ctx context.Context, tx gf_sqlite3.Queryer, name string) (bool, error) {
+	sql := `--sql query whether the name is of a view
+SELECT
+	COUNT(*) > 0 AS IsView
+FROM sqlite_master
+WHERE "type" = 'view' AND "name" = ?`
+	rows, err := tx.QueryxContext(ctx, sql, name)
+	if err != nil {
+		return false, fmt.Errorf(`fail to get type of %s: %w`, name, err)
+	}
+	type result struct {
+		IsView bool `db:"IsView"`
+	}
+	results, err := gf_sqlite3.ScanRowsStruct[result](rows)
+	if err != nil {
+		return false, fmt.Errorf(`fail to get type of %s: %w`, name, err)
+	}
+	return len(results) > 0 && results[0].IsView, nil
+}
+
+func queryViewColumns(ctx context.Context, tx gf_sqlite3.Queryer, view string) ([]SchemaColumn, error) {
+	sql := `--sql query view column information
+SELECT
+	"name" AS Name,
+	"type" AS Type,
+	"notnull" = 0 AS Nullable
+FROM pragma_table_info(?)
+ORDER BY "cid"`
+	rows, err := tx.QueryxContext(ctx, sql, view)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get columns of %s: %w`, view, err)
+	}
+	type column struct {
+		Name     string `db:"Name"`
+		Type     string `db:"Type"`
+		Nullable bool   `db:"Nullable"`
+	}
+	columns, err := gf_sqlite3.ScanRowsStruct[column](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get columns of %s: %w`, view, err)
+	}
+	return lo.Map(columns, func(column column, _ int) SchemaColumn {
+		return SchemaColumn{Name: column.Name, Type: column.Type, Nullable: column.Nullable}
+	}), nil
+}
+
+// queryViewDependencies returns the tables and views whose names appear in the definition of the view.
+func queryViewDependencies(ctx context.Context, tx gf_sqlite3.Queryer, view string, definition string) ([]string, error) {
+	sql := `--sql query table and view names
+SELECT
+	"name" AS Name
+FROM sqlite_master
+WHERE "type" IN ('table', 'view') AND "name" NOT LIKE 'sqlite\_%' ESCAPE '\' AND "name" <> ? COLLATE NOCASE
+ORDER BY "name"`
+	rows, err := tx.QueryxContext(ctx, sql, view)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+	type object struct {
+		Name string `db:"Name"`
+	}
+	objects, err := gf_sqlite3.ScanRowsStruct[object](rows)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+
+	tokens, err := ddl.Tokenize(definition)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get dependencies of %s: %w`, view, err)
+	}
+	identifiers := ddl.Identifiers(tokens)
+
+	var dependencies []string
+	for _, object := range objects {
+		if lo.SomeBy(identifiers, func(it string) bool { return strings.EqualFold(it, object.Name) }) {
+			dependencies = append(dependencies, object.Name)
+		}
+	}
+	return dependencies, nil
+}
+
+// viewDefinition returns the SELECT statement following AS in the CREATE VIEW statement, which is kept as written in sqlite_master.
+func viewDefinition(createView string) (string, error) {
+	tokens, err := ddl.Tokenize(createView)
+	if err != nil {
+		return "", fmt.Errorf(`fail to parse %q: %w`, createView, err)
+	}
+	p := ddl.NewParser(createView, tokens)
+	for !p.EOF() {
+		if p.Keyword("AS") {
+			return p.Text(p.Pos(), len(tokens)), nil
+		}
+		if err := p.Skip(); err != nil {
+			return "", fmt.Errorf(`fail to parse %q: %w`, createView, err)
+		}
+	}
+	return "", fmt.Errorf(`fail to parse %q: AS is not found`, createView)
+}
@@ -0,0 +1,89 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/Jumpaku/gotaface/sqlite3/test"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+var fetchViewTestcases = []struct {
+	ddl  string
+	view string
+	want schema.SchemaView
+}{
+	{
+		ddl:  "ddl_14_views",
+		view: "V_1",
+		want: schema.SchemaView{
+			Name: "V_1",
+			Columns: []schema.SchemaColumn{
+				{Name: "PK", Type: "INTEGER", Nullable: true},
+				{Name: "C1", Type: "TEXT", Nullable: true},
+			},
+			Definition:   `SELECT PK, C1 FROM P_1 WHERE C1 <> ''`,
+			Dependencies: []string{"P_1"},
+		},
+	},
+	{
+		ddl:  "ddl_14_views",
+		view: "V_2",
+		want: schema.SchemaView{
+			Name: "V_2",
+			Columns: []schema.SchemaColumn{
+				{Name: "K", Type: "INTEGER", Nullable: true},
+				{Name: "C", Type: "TEXT", Nullable: true},
+				{Name: "Total", Type: "", Nullable: true},
+			},
+			Definition: `SELECT v.PK, v.C1, SUM(p.C2)
+    FROM V_1 AS v JOIN "P_2" AS p ON v.PK = p.R1
+    GROUP BY v.PK, v.C1`,
+			Dependencies: []string{"P_2", "V_1"},
+		},
+	},
+}
+
+func TestFetcher_FetchView(t *testing.T) {
+	for number, testcase := range fetchViewTestcases {
+		t.Run(fmt.Sprintf("%03d:%s[%s]", number, testcase.ddl, testcase.view), func(t *testing.T) {
+			db, teardown := test.Setup(t, fmt.Sprintf("fetch_view_%0d.sqlite", number))
+			defer teardown()
+
+			test.InitDDLs(t, db, []string{ddls[testcase.ddl]})
+
+			sut := schema.NewFetcher(db)
+			got, err := sut.FetchView(context.Background(), testcase.view)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestFetcher_FetchView_Error(t *testing.T) {
+	db, teardown := test.Setup(t, "fetch_view_error.sqlite")
+	defer teardown()
+
+	test.InitDDLs(t, db, []string{ddls["ddl_14_views"]})
+
+	sut := schema.NewFetcher(db)
+	_, err := sut.FetchView(context.Background(), "P_1")
+	assert.NotNil(t, err)
+	_, err = sut.Fetch(context.Background(), "V_1")
+	assert.NotNil(t, err)
+}
+
+func TestListViews(t *testing.T) {
+	db, teardown := test.Setup(t, "list_views.sqlite")
+	defer teardown()
+
+	test.InitDDLs(t, db, []string{ddls["ddl_14_views"]})
+
+	sut := schema.NewFetcher(db)
+	got, err := sut.ListViews(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"V_1", "V_2"}, got)
+}
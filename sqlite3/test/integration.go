@@ -66,11 +66,14 @@ func InitDMLs(t *testing.T, db *sqlx.DB, stmts []string) {
 	defer tx.Rollback()
 
 	for _, stmt := range stmts {
-		_, err := tx.ExecContext(ctx, stmt)
-		t.Fatalf(`fail to execute ddl: %v`, err)
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf(`fail to execute dml: %v`, err)
+		}
 	}
 
-	tx.Commit()
+	if err := tx.Commit(); err != nil {
+		t.Fatalf(`fail to commit dmls: %v`, err)
+	}
 }
 
 func ListRows[Row any](t *testing.T, tx gf_sqlite3.Queryer, from string) []*Row {
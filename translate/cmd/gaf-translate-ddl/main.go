@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"cloud.google.com/go/spanner"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/samber/lo"
+
+	mysql_schema "github.com/Jumpaku/gotaface/mysql/schema"
+	postgres_schema "github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/Jumpaku/gotaface/schema"
+	spanner_schema "github.com/Jumpaku/gotaface/spanner/schema"
+	sqlite3_schema "github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/Jumpaku/gotaface/translate"
+)
+
+//go:generate go run "github.com/Jumpaku/cyamli/cmd/cyamli@latest" golang -schema-path=cli.yaml -out-path=cli.gen.go
+var cli = NewCLI()
+
+func main() {
+	cli.FUNC = translateDDL
+	if err := Run(cli, os.Args); err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+}
+
+func translateDDL(subcommand []string, input CLI_Input, inputErr error) (err error) {
+	if inputErr != nil {
+		fmt.Fprintln(os.Stderr, cli.DESC_Simple())
+		return fmt.Errorf("fail to resolve command line arguments: %w", inputErr)
+	}
+	if input.Opt_Help {
+		fmt.Fprintln(os.Stderr, cli.DESC_Detail())
+		return nil
+	}
+	ctx := context.Background()
+	source := translate.Dialect(input.Arg_SourceDialect)
+	target := translate.Dialect(input.Arg_TargetDialect)
+
+	var tables []schema.Table
+	if input.Opt_DataSource != "" {
+		tables, err = fetchTables(ctx, source, input.Opt_DataSource)
+		if err != nil {
+			return fmt.Errorf("fail to fetch schemas from %s database: %w", source, err)
+		}
+	} else {
+		var in io.Reader = os.Stdin
+		if input.Opt_Input != "" {
+			f, err := os.Open(input.Opt_Input)
+			if err != nil {
+				return fmt.Errorf("fail to open input file %q: %w", input.Opt_Input, err)
+			}
+			defer f.Close()
+
+			in = f
+		}
+
+		inBytes, err := io.ReadAll(in)
+		if err != nil {
+			return fmt.Errorf("fail to read DDL: %w", err)
+		}
+		tables, err = translate.ParseDDL(ctx, source, string(inBytes))
+		if err != nil {
+			return fmt.Errorf("fail to parse DDL in %s: %w", source, err)
+		}
+	}
+
+	stmts, warnings, err := translate.Translate(target, tables)
+	if err != nil {
+		return fmt.Errorf("fail to translate schemas into %s: %w", target, err)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+
+	var out io.Writer = os.Stdout
+	if input.Opt_Output != "" {
+		f, err := os.Create(input.Opt_Output)
+		if err != nil {
+			return fmt.Errorf("fail to open output file %q: %w", input.Opt_Output, err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	for _, stmt := range stmts {
+		if _, err := fmt.Fprintf(out, "%s;\n\n", stmt); err != nil {
+			return fmt.Errorf("fail to write DDL: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func fetchTables(ctx context.Context, source translate.Dialect, dataSource string) ([]schema.Table, error) {
+	switch source {
+	default:
+		return nil, fmt.Errorf("unsupported dialect %q", source)
+	case translate.DialectPostgres:
+		conn, err := pgx.Connect(ctx, dataSource)
+		if err != nil {
+			return nil, fmt.Errorf("fail to connect PostgreSQL database: %w", err)
+		}
+		defer conn.Close(ctx)
+
+		fetcher := postgres_schema.NewFetcher(conn)
+		tables, err := schema.FetchAll[postgres_schema.SchemaTable](ctx, fetcher, fetcher)
+		if err != nil {
+			return nil, err
+		}
+		return lo.Map(tables, func(table postgres_schema.SchemaTable, _ int) schema.Table {
+			return postgres_schema.ConvertTable(table)
+		}), nil
+	case translate.DialectSQLite3:
+		dbx, err := sqlx.Open("sqlite3", dataSource)
+		if err != nil {
+			return nil, fmt.Errorf("fail to open SQLite3 database: %w", err)
+		}
+		defer dbx.Close()
+
+		fetcher := sqlite3_schema.NewFetcher(dbx)
+		tables, err := schema.FetchAll[sqlite3_schema.SchemaTable](ctx, fetcher, fetcher)
+		if err != nil {
+			return nil, err
+		}
+		return lo.Map(tables, func(table sqlite3_schema.SchemaTable, _ int) schema.Table { return sqlite3_schema.ConvertTable(table) }), nil
+	case translate.DialectSpanner:
+		client, err := spanner.NewClient(ctx, dataSource)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create Spanner client: %w", err)
+		}
+		defer client.Close()
+
+		tx := client.ReadOnlyTransaction()
+		defer tx.Close()
+
+		fetcher := spanner_schema.NewFetcher(tx)
+		tables, err := schema.FetchAll[spanner_schema.SchemaTable](ctx, fetcher, fetcher)
+		if err != nil {
+			return nil, err
+		}
+		return lo.Map(tables, func(table spanner_schema.SchemaTable, _ int) schema.Table { return spanner_schema.ConvertTable(table) }), nil
+	case translate.DialectMySQL:
+		dbx, err := sqlx.Open("mysql", dataSource)
+		if err != nil {
+			return nil, fmt.Errorf("fail to open MySQL database: %w", err)
+		}
+		defer dbx.Close()
+
+		fetcher := mysql_schema.NewFetcher(dbx)
+		tables, err := schema.FetchAll[mysql_schema.SchemaTable](ctx, fetcher, fetcher)
+		if err != nil {
+			return nil, err
+		}
+		return lo.Map(tables, func(table mysql_schema.SchemaTable, _ int) schema.Table { return mysql_schema.ConvertTable(table) }), nil
+	}
+}
@@ -0,0 +1,84 @@
+package translate
+
+import (
+	"context"
+	"fmt"
+
+	mysql_schema "github.com/Jumpaku/gotaface/mysql/schema"
+	postgres_schema "github.com/Jumpaku/gotaface/postgres/schema"
+	"github.com/Jumpaku/gotaface/schema"
+	spanner_schema "github.com/Jumpaku/gotaface/spanner/schema"
+	sqlite3_schema "github.com/Jumpaku/gotaface/sqlite3/schema"
+	"github.com/samber/lo"
+)
+
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite3  Dialect = "sqlite3"
+	DialectSpanner  Dialect = "spanner"
+	DialectMySQL    Dialect = "mysql"
+)
+
+// ParseDDL parses DDL statements in the source dialect and returns the dialect-neutral schemas of the defined tables.
+// MySQL is not supported as the source dialect since DDL statements in MySQL cannot be parsed.
+func ParseDDL(ctx context.Context, source Dialect, ddl string) ([]schema.Table, error) {
+	switch source {
+	default:
+		return nil, fmt.Errorf(`fail to parse DDL: unsupported dialect %q`, source)
+	case DialectPostgres:
+		fetcher, err := postgres_schema.NewDDLFetcher(ddl)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+		tables, err := schema.FetchAll[postgres_schema.SchemaTable](ctx, fetcher, fetcher)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+		return lo.Map(tables, func(table postgres_schema.SchemaTable, _ int) schema.Table {
+			return postgres_schema.ConvertTable(table)
+		}), nil
+	case DialectSQLite3:
+		fetcher, err := sqlite3_schema.NewDDLFetcher(ddl)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+		tables, err := schema.FetchAll[sqlite3_schema.SchemaTable](ctx, fetcher, fetcher)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+		return lo.Map(tables, func(table sqlite3_schema.SchemaTable, _ int) schema.Table { return sqlite3_schema.ConvertTable(table) }), nil
+	case DialectSpanner:
+		fetcher, err := spanner_schema.NewDDLFetcher(ddl)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+		tables, err := schema.FetchAll[spanner_schema.SchemaTable](ctx, fetcher, fetcher)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to parse DDL: %w`, err)
+		}
+		return lo.Map(tables, func(table spanner_schema.SchemaTable, _ int) schema.Table { return spanner_schema.ConvertTable(table) }), nil
+	}
+}
+
+// Translate returns DDL statements creating the dialect-neutral tables in the target dialect.
+// Parts of the tables that cannot be translated into the target dialect are reported as warnings.
+func Translate(target Dialect, tables []schema.Table) ([]string, []schema.Warning, error) {
+	switch target {
+	default:
+		return nil, nil, fmt.Errorf(`fail to translate tables: unsupported dialect %q`, target)
+	case DialectPostgres:
+		converted, warnings := postgres_schema.ConvertFromTables(tables)
+		return postgres_schema.GenerateDDL(converted), warnings, nil
+	case DialectSQLite3:
+		converted, warnings := sqlite3_schema.ConvertFromTables(tables)
+		return sqlite3_schema.GenerateDDL(converted), warnings, nil
+	case DialectSpanner:
+		converted, warnings := spanner_schema.ConvertFromTables(tables)
+		return spanner_schema.GenerateDDL(converted), warnings, nil
+	case DialectMySQL:
+		converted, warnings := mysql_schema.ConvertFromTables(tables)
+		return mysql_schema.GenerateDDL(converted), warnings, nil
+	}
+}
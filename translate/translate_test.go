@@ -0,0 +1,107 @@
+package translate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Jumpaku/gotaface/schema"
+	"github.com/Jumpaku/gotaface/translate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	testcases := []struct {
+		name         string
+		source       translate.Dialect
+		target       translate.Dialect
+		ddl          string
+		want         []string
+		wantWarnings []schema.Warning
+	}{
+		{
+			name:   "sqlite3_to_spanner",
+			source: translate.DialectSQLite3,
+			target: translate.DialectSpanner,
+			ddl: `CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, email VARCHAR(100) UNIQUE, score DECIMAL(10, 2) DEFAULT 0);
+CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE, body BLOB, created_at DATETIME NOT NULL, CHECK (id > 0));
+CREATE INDEX posts_created_at_idx ON posts (created_at DESC) WHERE user_id > 0;
+CREATE INDEX users_lower_name_idx ON users (lower(name));
+CREATE TABLE logs (message TEXT)`,
+			want: []string{
+				"CREATE TABLE `logs` (\n    `message` STRING(MAX)\n) PRIMARY KEY ()",
+				"CREATE TABLE `posts` (\n    `id` INT64,\n    `user_id` INT64 NOT NULL,\n    `body` BYTES(MAX),\n    `created_at` TIMESTAMP NOT NULL,\n    CHECK (id > 0)\n) PRIMARY KEY (`id`)",
+				"CREATE TABLE `users` (\n    `id` INT64,\n    `name` STRING(MAX) NOT NULL,\n    `email` STRING(100),\n    `score` NUMERIC DEFAULT (0)\n) PRIMARY KEY (`id`)",
+				"CREATE INDEX `posts_created_at_idx` ON `posts` (`created_at` DESC)",
+				"CREATE UNIQUE INDEX `users_email_key` ON `users` (`email`)",
+				"ALTER TABLE `posts` ADD FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)",
+			},
+			wantWarnings: []schema.Warning{
+				{Table: "logs", Message: "table without primary key is converted into a table with the empty primary key, which has at most one row"},
+				{Table: "posts", Message: "ON DELETE SET NULL of foreign key (user_id) is ignored"},
+				{Table: "posts", Message: "ON UPDATE CASCADE of foreign key (user_id) is ignored"},
+				{Table: "posts", Message: "predicate of partial index posts_created_at_idx is ignored"},
+				{Table: "users", Message: "auto-increment of column id is ignored"},
+				{Table: "users", Message: "index users_lower_name_idx on expressions is ignored"},
+			},
+		},
+		{
+			name:   "spanner_to_postgres",
+			source: translate.DialectSpanner,
+			target: translate.DialectPostgres,
+			ddl: `CREATE TABLE B_1 (PK_11 INT64 NOT NULL, Tags ARRAY<STRING(10)>) PRIMARY KEY (PK_11);
+CREATE TABLE B_2 (PK_11 INT64 NOT NULL, PK_21 INT64 NOT NULL, Body STRING(MAX)) PRIMARY KEY (PK_11, PK_21), INTERLEAVE IN PARENT B_1 ON DELETE CASCADE`,
+			want: []string{
+				"CREATE TABLE \"B_1\" (\n    \"PK_11\" bigint NOT NULL,\n    \"Tags\" character varying(10)[],\n    PRIMARY KEY (\"PK_11\")\n)",
+				"CREATE TABLE \"B_2\" (\n    \"PK_11\" bigint NOT NULL,\n    \"PK_21\" bigint NOT NULL,\n    \"Body\" text,\n    PRIMARY KEY (\"PK_11\", \"PK_21\")\n)",
+				"ALTER TABLE \"B_2\" ADD FOREIGN KEY (\"PK_11\") REFERENCES \"B_1\" (\"PK_11\")",
+			},
+			wantWarnings: []schema.Warning{
+				{Table: "B_2", Message: "interleaving in B_1 is converted into a foreign key"},
+			},
+		},
+		{
+			name:   "postgres_to_sqlite3",
+			source: translate.DialectPostgres,
+			target: translate.DialectSQLite3,
+			ddl: `CREATE TABLE items (id bigserial PRIMARY KEY, code text NOT NULL, price numeric, total integer GENERATED ALWAYS AS (price * 2) STORED);
+CREATE INDEX items_code_idx ON items (code) WHERE price > 0;
+CREATE INDEX ON items (lower(code) DESC);`,
+			want: []string{
+				"CREATE TABLE \"items\" (\n    \"id\" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,\n    \"code\" TEXT NOT NULL,\n    \"price\" NUMERIC,\n    \"total\" INTEGER GENERATED ALWAYS AS (price * 2) STORED\n)",
+				"CREATE INDEX \"items_code_idx\" ON \"items\" (\"code\") WHERE price > 0",
+				"CREATE INDEX \"items_lower_idx\" ON \"items\" (lower(code) DESC)",
+			},
+		},
+		{
+			name:   "postgres_to_mysql",
+			source: translate.DialectPostgres,
+			target: translate.DialectMySQL,
+			ddl:    `CREATE TABLE app.items (id bigserial PRIMARY KEY, code text NOT NULL, tags text[], data jsonb, ok boolean DEFAULT true);`,
+			want: []string{
+				"CREATE TABLE `items` (\n    `id` bigint NOT NULL AUTO_INCREMENT,\n    `code` longtext NOT NULL,\n    `tags` json NULL,\n    `data` json NULL,\n    `ok` tinyint(1) NULL DEFAULT (true),\n    PRIMARY KEY (`id`)\n)",
+			},
+			wantWarnings: []schema.Warning{
+				{Table: "items", Message: "schema app is ignored"},
+				{Table: "items", Message: "type text[] of column tags is converted into json"},
+			},
+		},
+	}
+
+	for number, testcase := range testcases {
+		t.Run(fmt.Sprintf("%03d:%s", number, testcase.name), func(t *testing.T) {
+			tables, err := translate.ParseDDL(context.Background(), testcase.source, testcase.ddl)
+			assert.Nil(t, err)
+
+			got, gotWarnings, err := translate.Translate(testcase.target, tables)
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.want, got)
+			assert.Equal(t, testcase.wantWarnings, gotWarnings)
+		})
+	}
+}
+
+func TestParseDDL_Unsupported(t *testing.T) {
+	_, err := translate.ParseDDL(context.Background(), translate.DialectMySQL, `CREATE TABLE t (id int PRIMARY KEY)`)
+	assert.NotNil(t, err)
+}